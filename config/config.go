@@ -10,12 +10,14 @@ import (
 
 type (
 	Config struct {
-		Server Server `mapstructure:"server"`
-		DB     DB     `mapstructure:"db"`
-		Token  Token  `mapstructure:"Token"`
-		Tripay Tripay `mapstructure:"tripay"`
-		SMTP   SMTP   `mapstructure:"smtp"`
-		Brevo  BREVO  `mapstructure:"brevo"`
+		Server   Server   `mapstructure:"server"`
+		DB       DB       `mapstructure:"db"`
+		Token    Token    `mapstructure:"Token"`
+		Tripay   Tripay   `mapstructure:"tripay"`
+		SMTP     SMTP     `mapstructure:"smtp"`
+		Brevo    BREVO    `mapstructure:"brevo"`
+		SMS      SMS      `mapstructure:"sms"`
+		Frontend Frontend `mapstructure:"frontend"`
 	}
 
 	Server struct {
@@ -55,6 +57,17 @@ type (
 		Name   string `mapstructure:"name"`
 		From   string `mapstructure:"from"`
 	}
+
+	SMS struct {
+		Enabled bool   `mapstructure:"enabled"`
+		Sender  string `mapstructure:"sender"`
+	}
+
+	// Frontend is the customer web app: an allowed CORS origin and the base of
+	// the links sent in emails.
+	Frontend struct {
+		URL string `mapstructure:"url"`
+	}
 )
 
 func NewConfig() (*Config, error) {
@@ -91,7 +104,13 @@ func NewConfig() (*Config, error) {
 		"brevo.api_key": "BREVO_API_KEY",
 		"brevo.name":    "BREVO_NAME",
 		"brevo.from":    "BREVO_FROM",
+
+		"sms.enabled": "SMS_ENABLED",
+		"sms.sender":  "SMS_SENDER",
+
+		"frontend.url": "FRONTEND_URL",
 	}
+	v.SetDefault("frontend.url", "https://www.tikethebat.live")
 
 	for key, env := range bindEnvs {
 		if err := v.BindEnv(key, env); err != nil {
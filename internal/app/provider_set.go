@@ -7,6 +7,7 @@ import (
 	"eticket-api/internal/common/httpclient"
 	"eticket-api/internal/common/logger"
 	"eticket-api/internal/common/mailer"
+	"eticket-api/internal/common/sms"
 	"eticket-api/internal/common/token"
 	"eticket-api/internal/common/transact"
 	"eticket-api/internal/common/validator"
@@ -41,6 +42,8 @@ var CommonSet = wire.NewSet(
 	mailer.NewBrevo,
 	wire.Bind(new(mailer.Mailer), new(*mailer.Brevo)), // ✅ add this
 
+	sms.NewSender, // returns nil when SMS is disabled
+
 	// ✅ HTTP Client
 	httpclient.NewHTTPClient, // <-- You need this to get *httpclient.HTTP
 
@@ -58,8 +61,30 @@ var RepositorySet = wire.NewSet(
 	repository.NewScheduleRepository,
 	repository.NewTicketRepository,
 	repository.NewBookingRepository,
+	repository.NewBookingChangeRepository,
 	repository.NewClaimItemRepository,
 	repository.NewClaimSessionRepository,
+	repository.NewSeatLayoutRepository,
+	repository.NewScheduleSeatRepository,
+	repository.NewWaitlistRepository,
+	repository.NewCancellationPolicyRepository,
+	repository.NewRefundRepository,
+	repository.NewOutboxRepository,
+	repository.NewIdempotencyKeyRepository,
+	repository.NewBookingAccessCodeRepository,
+	repository.NewCustomerRepository,
+	repository.NewPromotionRepository,
+	repository.NewPromotionRedemptionRepository,
+	repository.NewVehicleCategoryRepository,
+	repository.NewFeeComponentRepository,
+	repository.NewAddonRepository,
+	repository.NewAddonStockRepository,
+	repository.NewBookingAddonRepository,
+	repository.NewManifestRepository,
+	repository.NewBoardingEventRepository,
+	repository.NewNotificationLogRepository,
+	repository.NewScheduleCancellationRepository,
+	repository.NewScheduleDelayRepository,
 
 	// --- Interface bindings ---
 	wire.Bind(new(domain.RoleRepository), new(*repository.RoleRepository)),
@@ -72,8 +97,30 @@ var RepositorySet = wire.NewSet(
 	wire.Bind(new(domain.ScheduleRepository), new(*repository.ScheduleRepository)),
 	wire.Bind(new(domain.TicketRepository), new(*repository.TicketRepository)),
 	wire.Bind(new(domain.BookingRepository), new(*repository.BookingRepository)),
+	wire.Bind(new(domain.BookingChangeRepository), new(*repository.BookingChangeRepository)),
 	wire.Bind(new(domain.ClaimItemRepository), new(*repository.ClaimItemRepository)),
 	wire.Bind(new(domain.ClaimSessionRepository), new(*repository.ClaimSessionRepository)),
+	wire.Bind(new(domain.SeatLayoutRepository), new(*repository.SeatLayoutRepository)),
+	wire.Bind(new(domain.ScheduleSeatRepository), new(*repository.ScheduleSeatRepository)),
+	wire.Bind(new(domain.WaitlistRepository), new(*repository.WaitlistRepository)),
+	wire.Bind(new(domain.CancellationPolicyRepository), new(*repository.CancellationPolicyRepository)),
+	wire.Bind(new(domain.RefundRepository), new(*repository.RefundRepository)),
+	wire.Bind(new(domain.OutboxRepository), new(*repository.OutboxRepository)),
+	wire.Bind(new(domain.IdempotencyKeyRepository), new(*repository.IdempotencyKeyRepository)),
+	wire.Bind(new(domain.BookingAccessCodeRepository), new(*repository.BookingAccessCodeRepository)),
+	wire.Bind(new(domain.CustomerRepository), new(*repository.CustomerRepository)),
+	wire.Bind(new(domain.PromotionRepository), new(*repository.PromotionRepository)),
+	wire.Bind(new(domain.PromotionRedemptionRepository), new(*repository.PromotionRedemptionRepository)),
+	wire.Bind(new(domain.VehicleCategoryRepository), new(*repository.VehicleCategoryRepository)),
+	wire.Bind(new(domain.FeeComponentRepository), new(*repository.FeeComponentRepository)),
+	wire.Bind(new(domain.AddonRepository), new(*repository.AddonRepository)),
+	wire.Bind(new(domain.AddonStockRepository), new(*repository.AddonStockRepository)),
+	wire.Bind(new(domain.BookingAddonRepository), new(*repository.BookingAddonRepository)),
+	wire.Bind(new(domain.ManifestRepository), new(*repository.ManifestRepository)),
+	wire.Bind(new(domain.BoardingEventRepository), new(*repository.BoardingEventRepository)),
+	wire.Bind(new(domain.NotificationLogRepository), new(*repository.NotificationLogRepository)),
+	wire.Bind(new(domain.ScheduleCancellationRepository), new(*repository.ScheduleCancellationRepository)),
+	wire.Bind(new(domain.ScheduleDelayRepository), new(*repository.ScheduleDelayRepository)),
 )
 
 var ClientSet = wire.NewSet(
@@ -97,11 +144,34 @@ var UsecaseSet = wire.NewSet(
 	usecase.NewClaimItemUsecase,
 	usecase.NewClaimSessionUsecase,
 	usecase.NewPaymentUsecase,
+	usecase.NewSeatLayoutUsecase,
+	usecase.NewWaitlistUsecase,
+	usecase.NewCancellationPolicyUsecase,
+	usecase.NewOutboxUsecase,
+	usecase.NewIdempotencyUsecase,
+	usecase.NewManageBookingUsecase,
+	usecase.NewCustomerUsecase,
+	usecase.NewPromotionUsecase,
+	usecase.NewVehicleCategoryUsecase,
+	usecase.NewFeeComponentUsecase,
+	usecase.NewAddonUsecase,
+	usecase.NewManifestUsecase,
+	usecase.NewBoardingUsecase,
+	usecase.NewReminderUsecase,
+	usecase.NewScheduleCancellationUsecase,
+	usecase.NewScheduleDelayUsecase,
 	// ...dst
 )
 
 var JobSet = wire.NewSet(
 	job.NewClaimSessionJob,
+	job.NewWaitlistJob,
+	job.NewOutboxJob,
+	job.NewIdempotencyJob,
+	job.NewBookingExpiryJob,
+	job.NewManifestJob,
+	job.NewReminderJob,
+	job.NewScheduleCancellationJob,
 	// job.NewEmailJobQueue, // <--- tambahkan ini
 )
 
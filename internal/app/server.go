@@ -36,9 +36,17 @@ func New(cfg *config.Config) (*Server, error) {
 
 // NewServer menerima semua dependency yang dibutuhkan, Wire akan mengisi otomatis
 func NewServer(
+	cfg *config.Config,
 	db *gorm.DB,
 	router *http.Router,
 	claimSessionJob *job.ClaimSessionJob,
+	waitlistJob *job.WaitlistJob,
+	outboxJob *job.OutboxJob,
+	idempotencyJob *job.IdempotencyJob,
+	bookingExpiryJob *job.BookingExpiryJob,
+	manifestJob *job.ManifestJob,
+	reminderJob *job.ReminderJob,
+	scheduleCancellationJob *job.ScheduleCancellationJob,
 ) (*Server, error) {
 	gin.SetMode(gin.DebugMode)
 	app := gin.Default()
@@ -49,15 +57,43 @@ func NewServer(
 		&domain.User{},
 		&domain.Ship{},
 		&domain.Harbor{},
+		&domain.VehicleCategory{},
 		&domain.Class{},
 		&domain.Schedule{},
 		&domain.Booking{},
+		&domain.BookingChange{},
 		&domain.Quota{},
 		&domain.ClaimSession{},
 		&domain.ClaimItem{},
 		&domain.Ticket{},
 		&domain.RefreshToken{},
 		&domain.PasswordReset{},
+		&domain.SeatLayout{},
+		&domain.LayoutSeat{},
+		&domain.ScheduleSeat{},
+		&domain.Waitlist{},
+		&domain.CancellationPolicy{},
+		&domain.CancellationRule{},
+		&domain.Refund{},
+		&domain.Outbox{},
+		&domain.IdempotencyKey{},
+		&domain.BookingAccessCode{},
+		&domain.Customer{},
+		&domain.Promotion{},
+		&domain.PromotionRedemption{},
+		&domain.Fare{},
+		&domain.FeeComponent{},
+		&domain.TicketFee{},
+		&domain.Addon{},
+		&domain.AddonStock{},
+		&domain.ClaimAddon{},
+		&domain.BookingAddon{},
+		&domain.Manifest{},
+		&domain.BoardingEvent{},
+		&domain.NotificationLog{},
+		&domain.ScheduleCancellation{},
+		&domain.ScheduleCancellationItem{},
+		&domain.ScheduleDelay{},
 	); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
@@ -68,13 +104,14 @@ func NewServer(
 			allowed := map[string]bool{
 				"http://localhost:3000":          true,
 				"https://tiket-hebat.vercel.app": true,
-				"https://www.tikethebat.live":    true,
+				cfg.Frontend.URL:                 true,
 				"https://tripay.co.id/":          true,
 			}
 			return allowed[origin]
 		},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Idempotency-Key"},
+		ExposeHeaders:    []string{"Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -85,7 +122,14 @@ func NewServer(
 	router.RegisterMetrics(api)
 	router.RegisterV1(api.Group("/v1"))
 	router.RegisterV2(api.Group("/v2"))
-	go claimSessionJob.CleanExpiredClaimSession()
+	go claimSessionJob.ExpireClaimSessions()
+	go waitlistJob.OfferFreedQuota()
+	go outboxJob.DispatchPending()
+	go idempotencyJob.DeleteExpiredKeys()
+	go bookingExpiryJob.ExpireUnpaidBookings()
+	go manifestJob.FreezeDepartedManifests()
+	go reminderJob.SendDepartureReminders()
+	go scheduleCancellationJob.ProcessCancellations()
 
 	return &Server{app: app}, nil
 }
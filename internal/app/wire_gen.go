@@ -13,6 +13,7 @@ import (
 	"eticket-api/internal/common/httpclient"
 	"eticket-api/internal/common/logger"
 	"eticket-api/internal/common/mailer"
+	"eticket-api/internal/common/sms"
 	"eticket-api/internal/common/token"
 	"eticket-api/internal/common/transact"
 	"eticket-api/internal/common/validator"
@@ -48,29 +49,75 @@ func New(cfg *config.Config) (*Server, error) {
 	brevo := mailer.NewBrevo(cfg)
 	authUsecase := usecase.NewAuthUsecase(gotann, refreshTokenRepository, userRepository, brevo, jwt)
 	bookingRepository := repository.NewBookingRepository(gormDB)
-	bookingUsecase := usecase.NewBookingUsecase(gotann, bookingRepository, quotaRepository)
+	scheduleSeatRepository := repository.NewScheduleSeatRepository(gormDB)
+	ticketRepository := repository.NewTicketRepository(gormDB)
+	scheduleRepository := repository.NewScheduleRepository(gormDB)
+	seatLayoutRepository := repository.NewSeatLayoutRepository(gormDB)
+	bookingChangeRepository := repository.NewBookingChangeRepository(gormDB)
+	cancellationPolicyRepository := repository.NewCancellationPolicyRepository(gormDB)
+	refundRepository := repository.NewRefundRepository(gormDB)
+	feeComponentRepository := repository.NewFeeComponentRepository(gormDB)
+	addonStockRepository := repository.NewAddonStockRepository(gormDB)
+	bookingAddonRepository := repository.NewBookingAddonRepository(gormDB)
+	outboxRepository := repository.NewOutboxRepository(gormDB)
+	httpclientHTTP := httpclient.NewHTTPClient(cfg)
+	tripayClient := client.NewTripayClient(httpclientHTTP, cfg)
+	sender := sms.NewSender(cfg)
+	outboxUsecase := usecase.NewOutboxUsecase(gotann, outboxRepository, bookingRepository, bookingChangeRepository, tripayClient, brevo, sender)
+	bookingUsecase := usecase.NewBookingUsecase(gotann, bookingRepository, quotaRepository, scheduleSeatRepository, ticketRepository, scheduleRepository, seatLayoutRepository, bookingChangeRepository, cancellationPolicyRepository, refundRepository, feeComponentRepository, addonStockRepository, bookingAddonRepository, outboxRepository, outboxUsecase, jwt)
 	classRepository := repository.NewClassRepository(gormDB)
-	classUsecase := usecase.NewClassUsecase(gotann, classRepository)
+	vehicleCategoryRepository := repository.NewVehicleCategoryRepository(gormDB)
+	classUsecase := usecase.NewClassUsecase(gotann, classRepository, vehicleCategoryRepository)
 	harborRepository := repository.NewHarborRepository(gormDB)
 	harborUsecase := usecase.NewHarborUsecase(gotann, harborRepository)
 	roleRepository := repository.NewRoleRepository(gormDB)
 	roleUsecase := usecase.NewRoleUsecase(gotann, roleRepository)
-	claimSessionRepository := repository.NewClaimSessionRepository(gormDB)
 	shipRepository := repository.NewShipRepository(gormDB)
-	scheduleRepository := repository.NewScheduleRepository(gormDB)
-	ticketRepository := repository.NewTicketRepository(gormDB)
-	scheduleUsecase := usecase.NewScheduleUsecase(gotann, claimSessionRepository, classRepository, shipRepository, scheduleRepository, ticketRepository)
+	scheduleUsecase := usecase.NewScheduleUsecase(gotann, classRepository, shipRepository, scheduleRepository, ticketRepository)
 	shipUsecase := usecase.NewShipUsecase(gotann, shipRepository)
-	ticketUsecase := usecase.NewTicketUsecase(gotann, ticketRepository, bookingRepository, scheduleRepository, quotaRepository)
+	boardingEventRepository := repository.NewBoardingEventRepository(gormDB)
+	ticketUsecase := usecase.NewTicketUsecase(gotann, ticketRepository, bookingRepository, scheduleRepository, quotaRepository, seatLayoutRepository, scheduleSeatRepository, jwt, boardingEventRepository)
 	userUsecase := usecase.NewUserUsecase(gotann, userRepository)
-	httpclientHTTP := httpclient.NewHTTPClient(cfg)
-	tripayClient := client.NewTripayClient(httpclientHTTP, cfg)
-	paymentUsecase := usecase.NewPaymentUsecase(gotann, tripayClient, bookingRepository, ticketRepository, quotaRepository, brevo)
+	promotionRepository := repository.NewPromotionRepository(gormDB)
+	promotionRedemptionRepository := repository.NewPromotionRedemptionRepository(gormDB)
+	paymentUsecase := usecase.NewPaymentUsecase(gotann, tripayClient, bookingRepository, ticketRepository, quotaRepository, scheduleSeatRepository, bookingChangeRepository, addonStockRepository, bookingAddonRepository, refundRepository, promotionRepository, promotionRedemptionRepository, outboxRepository, outboxUsecase, jwt)
+	claimSessionRepository := repository.NewClaimSessionRepository(gormDB)
 	claimItemRepository := repository.NewClaimItemRepository(gormDB)
-	claimSessionUsecase := usecase.NewClaimSessionUsecase(gotann, claimSessionRepository, claimItemRepository, ticketRepository, scheduleRepository, bookingRepository, quotaRepository, tripayClient, brevo)
-	router := http.NewRouter(jwt, loggerLogger, validatorValidator, quotaUsecase, authUsecase, bookingUsecase, classUsecase, harborUsecase, roleUsecase, scheduleUsecase, shipUsecase, ticketUsecase, userUsecase, paymentUsecase, claimSessionUsecase)
+	claimSessionUsecase := usecase.NewClaimSessionUsecase(gotann, claimSessionRepository, claimItemRepository, ticketRepository, scheduleRepository, bookingRepository, quotaRepository, seatLayoutRepository, scheduleSeatRepository, promotionRepository, promotionRedemptionRepository, feeComponentRepository, addonStockRepository, bookingAddonRepository, outboxRepository, outboxUsecase)
+	seatLayoutUsecase := usecase.NewSeatLayoutUsecase(gotann, seatLayoutRepository, scheduleSeatRepository, scheduleRepository, shipRepository, classRepository)
+	waitlistRepository := repository.NewWaitlistRepository(gormDB)
+	waitlistUsecase := usecase.NewWaitlistUsecase(gotann, waitlistRepository, claimSessionRepository, scheduleRepository, quotaRepository, seatLayoutRepository, scheduleSeatRepository, outboxRepository, outboxUsecase, cfg)
+	cancellationPolicyUsecase := usecase.NewCancellationPolicyUsecase(gotann, cancellationPolicyRepository, harborRepository, classRepository)
+	idempotencyKeyRepository := repository.NewIdempotencyKeyRepository(gormDB)
+	idempotencyUsecase := usecase.NewIdempotencyUsecase(gotann, idempotencyKeyRepository)
+	bookingAccessCodeRepository := repository.NewBookingAccessCodeRepository(gormDB)
+	manageBookingUsecase := usecase.NewManageBookingUsecase(gotann, bookingRepository, ticketRepository, bookingAccessCodeRepository, outboxRepository, outboxUsecase, bookingUsecase, jwt)
+	customerRepository := repository.NewCustomerRepository(gormDB)
+	customerUsecase := usecase.NewCustomerUsecase(gotann, customerRepository, bookingRepository, outboxRepository, outboxUsecase, jwt)
+	promotionUsecase := usecase.NewPromotionUsecase(gotann, promotionRepository, harborRepository, classRepository, scheduleRepository)
+	vehicleCategoryUsecase := usecase.NewVehicleCategoryUsecase(gotann, vehicleCategoryRepository)
+	feeComponentUsecase := usecase.NewFeeComponentUsecase(gotann, feeComponentRepository, harborRepository, classRepository)
+	addonRepository := repository.NewAddonRepository(gormDB)
+	addonUsecase := usecase.NewAddonUsecase(gotann, addonRepository, addonStockRepository, bookingAddonRepository, scheduleRepository)
+	manifestRepository := repository.NewManifestRepository(gormDB)
+	manifestUsecase := usecase.NewManifestUsecase(gotann, manifestRepository, scheduleRepository, ticketRepository, bookingAddonRepository)
+	boardingUsecase := usecase.NewBoardingUsecase(gotann, ticketRepository, boardingEventRepository, scheduleRepository, jwt)
+	scheduleCancellationRepository := repository.NewScheduleCancellationRepository(gormDB)
+	scheduleCancellationUsecase := usecase.NewScheduleCancellationUsecase(gotann, scheduleCancellationRepository, scheduleRepository, bookingRepository, ticketRepository, quotaRepository, scheduleSeatRepository, seatLayoutRepository, bookingChangeRepository, refundRepository, addonStockRepository, bookingAddonRepository, boardingEventRepository, promotionRepository, promotionRedemptionRepository, outboxRepository, outboxUsecase, jwt)
+	scheduleDelayRepository := repository.NewScheduleDelayRepository(gormDB)
+	scheduleDelayUsecase := usecase.NewScheduleDelayUsecase(gotann, scheduleDelayRepository, scheduleRepository, outboxRepository, outboxUsecase)
+	router := http.NewRouter(jwt, loggerLogger, validatorValidator, quotaUsecase, authUsecase, bookingUsecase, classUsecase, harborUsecase, roleUsecase, scheduleUsecase, shipUsecase, ticketUsecase, userUsecase, paymentUsecase, claimSessionUsecase, seatLayoutUsecase, waitlistUsecase, cancellationPolicyUsecase, idempotencyUsecase, manageBookingUsecase, customerUsecase, promotionUsecase, vehicleCategoryUsecase, feeComponentUsecase, addonUsecase, manifestUsecase, boardingUsecase, scheduleCancellationUsecase, scheduleDelayUsecase)
 	claimSessionJob := job.NewClaimSessionJob(loggerLogger, claimSessionUsecase)
-	server, err := NewServer(gormDB, router, claimSessionJob)
+	waitlistJob := job.NewWaitlistJob(loggerLogger, waitlistUsecase)
+	outboxJob := job.NewOutboxJob(loggerLogger, outboxUsecase)
+	idempotencyJob := job.NewIdempotencyJob(loggerLogger, idempotencyUsecase)
+	bookingExpiryJob := job.NewBookingExpiryJob(loggerLogger, paymentUsecase)
+	manifestJob := job.NewManifestJob(loggerLogger, manifestUsecase)
+	notificationLogRepository := repository.NewNotificationLogRepository(gormDB)
+	reminderUsecase := usecase.NewReminderUsecase(gotann, notificationLogRepository, bookingRepository, ticketRepository, outboxRepository, outboxUsecase, jwt, cfg)
+	reminderJob := job.NewReminderJob(loggerLogger, reminderUsecase)
+	scheduleCancellationJob := job.NewScheduleCancellationJob(loggerLogger, scheduleCancellationUsecase)
+	server, err := NewServer(cfg, gormDB, router, claimSessionJob, waitlistJob, outboxJob, idempotencyJob, bookingExpiryJob, manifestJob, reminderJob, scheduleCancellationJob)
 	if err != nil {
 		return nil, err
 	}
@@ -88,9 +135,17 @@ type Server struct {
 }
 
 // NewServer menerima semua dependency yang dibutuhkan, Wire akan mengisi otomatis
-func NewServer(db2 *gorm.DB,
+func NewServer(
+	cfg *config.Config, db2 *gorm.DB,
 	router *http.Router,
 	claimSessionJob *job.ClaimSessionJob,
+	waitlistJob *job.WaitlistJob,
+	outboxJob *job.OutboxJob,
+	idempotencyJob *job.IdempotencyJob,
+	bookingExpiryJob *job.BookingExpiryJob,
+	manifestJob *job.ManifestJob,
+	reminderJob *job.ReminderJob,
+	scheduleCancellationJob *job.ScheduleCancellationJob,
 ) (*Server, error) {
 	gin.SetMode(gin.DebugMode)
 	app := gin.Default()
@@ -100,15 +155,43 @@ func NewServer(db2 *gorm.DB,
 		&domain.User{},
 		&domain.Ship{},
 		&domain.Harbor{},
+		&domain.VehicleCategory{},
 		&domain.Class{},
 		&domain.Schedule{},
 		&domain.Booking{},
+		&domain.BookingChange{},
 		&domain.Quota{},
 		&domain.ClaimSession{},
 		&domain.ClaimItem{},
 		&domain.Ticket{},
 		&domain.RefreshToken{},
 		&domain.PasswordReset{},
+		&domain.SeatLayout{},
+		&domain.LayoutSeat{},
+		&domain.ScheduleSeat{},
+		&domain.Waitlist{},
+		&domain.CancellationPolicy{},
+		&domain.CancellationRule{},
+		&domain.Refund{},
+		&domain.Outbox{},
+		&domain.IdempotencyKey{},
+		&domain.BookingAccessCode{},
+		&domain.Customer{},
+		&domain.Promotion{},
+		&domain.PromotionRedemption{},
+		&domain.Fare{},
+		&domain.FeeComponent{},
+		&domain.TicketFee{},
+		&domain.Addon{},
+		&domain.AddonStock{},
+		&domain.ClaimAddon{},
+		&domain.BookingAddon{},
+		&domain.Manifest{},
+		&domain.BoardingEvent{},
+		&domain.NotificationLog{},
+		&domain.ScheduleCancellation{},
+		&domain.ScheduleCancellationItem{},
+		&domain.ScheduleDelay{},
 	); err != nil {
 		log.Fatalf("Failed to migrate database: %v", err)
 	}
@@ -118,13 +201,14 @@ func NewServer(db2 *gorm.DB,
 			allowed := map[string]bool{
 				"http://localhost:3000":          true,
 				"https://tiket-hebat.vercel.app": true,
-				"https://www.tikethebat.live":    true,
+				cfg.Frontend.URL:                 true,
 				"https://tripay.co.id/":          true,
 			}
 			return allowed[origin]
 		},
 		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Idempotency-Key"},
+		ExposeHeaders:    []string{"Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -134,7 +218,14 @@ func NewServer(db2 *gorm.DB,
 	router.RegisterMetrics(api)
 	router.RegisterV1(api.Group("/v1"))
 	router.RegisterV2(api.Group("/v2"))
-	go claimSessionJob.CleanExpiredClaimSession()
+	go claimSessionJob.ExpireClaimSessions()
+	go waitlistJob.OfferFreedQuota()
+	go outboxJob.DispatchPending()
+	go idempotencyJob.DeleteExpiredKeys()
+	go bookingExpiryJob.ExpireUnpaidBookings()
+	go manifestJob.FreezeDepartedManifests()
+	go reminderJob.SendDepartureReminders()
+	go scheduleCancellationJob.ProcessCancellations()
 
 	return &Server{app: app}, nil
 }
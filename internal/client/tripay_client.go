@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"errors"
 	"eticket-api/config"
+	enum "eticket-api/internal/common/enums"
 	"eticket-api/internal/common/httpclient"
 	"eticket-api/internal/domain"
 	"eticket-api/internal/model"
@@ -51,10 +52,20 @@ func GenerateTransactionSignature(merchantCode, merchantRef string, amount int,
 	return hex.EncodeToString(h.Sum(nil))
 }
 
+// fareLabels names the passenger fares on order items.
+var fareLabels = map[string]string{
+	enum.FareChild.String():  "Anak",
+	enum.FareInfant.String(): "Bayi",
+	enum.FareSenior.String(): "Lansia",
+}
+
 func TicketToItem(ticket *domain.Ticket) domain.OrderItem {
 	name := "Tiket " + ticket.Type
 	if ticket.Type == "passenger" && ticket.PassengerName != "" {
 		name = fmt.Sprintf("Tiket Penumpang - %s", ticket.PassengerName)
+		if label, ok := fareLabels[ticket.FareCategory]; ok {
+			name = fmt.Sprintf("Tiket Penumpang %s - %s", label, ticket.PassengerName)
+		}
 	}
 	if ticket.Type == "vehicle" && ticket.LicensePlate != nil {
 		name = fmt.Sprintf("Tiket Kendaraan - %s", *ticket.LicensePlate)
@@ -211,3 +222,36 @@ func (c *TripayClient) GetTransactionDetail(reference string) (*domain.Transacti
 // 	fmt.Println("Callback payload verified:", payload)
 // 	return nil
 // }
+
+// DiscountToItem turns a promo discount into a negative order item so the
+// items still add up to the charged amount.
+func DiscountToItem(code string, discount float64) domain.OrderItem {
+	return domain.OrderItem{
+		SKU:      code,
+		Name:     "Diskon " + code,
+		Price:    -int(discount),
+		Quantity: 1,
+	}
+}
+
+// FeeToItem turns the fees of one component, added up over the booking, into
+// an order item.
+func FeeToItem(fee domain.TicketFee) domain.OrderItem {
+	return domain.OrderItem{
+		SKU:      fee.Kind,
+		Name:     fee.Name,
+		Price:    int(fee.Amount),
+		Quantity: 1,
+	}
+}
+
+// AddonToItem turns add-ons of one product on one leg, added up over the
+// booking, into an order item.
+func AddonToItem(addon domain.BookingAddon) domain.OrderItem {
+	return domain.OrderItem{
+		SKU:      addon.Kind,
+		Name:     addon.Name,
+		Price:    int(addon.Price),
+		Quantity: addon.Quantity,
+	}
+}
@@ -3,6 +3,8 @@ package constant
 import "time"
 
 const (
-	AccessTokenExpiry  = 7 * 24 * time.Minute
-	RefreshTokenExpiry = 7 * 24 * time.Hour // 7 days
+	AccessTokenExpiry   = 7 * 24 * time.Minute
+	RefreshTokenExpiry  = 7 * 24 * time.Hour // 7 days
+	BookingTokenExpiry  = 30 * time.Minute
+	CustomerTokenExpiry = 24 * time.Hour
 )
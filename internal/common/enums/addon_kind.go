@@ -0,0 +1,23 @@
+package enum
+
+// AddonKind represents what an add-on product sold with a booking is
+type AddonKind int
+
+const (
+	AddonMeal AddonKind = iota
+	AddonCabin
+	AddonLuggage
+)
+
+func (ak AddonKind) String() string {
+	switch ak {
+	case AddonMeal:
+		return "MEAL"
+	case AddonCabin:
+		return "CABIN"
+	case AddonLuggage:
+		return "LUGGAGE"
+	default:
+		return "UNKNOWN"
+	}
+}
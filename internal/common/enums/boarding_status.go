@@ -0,0 +1,29 @@
+package enum
+
+// BoardingStatus represents where a ticket is on its way onto the ship
+type BoardingStatus int
+
+const (
+	BoardingIssued BoardingStatus = iota
+	BoardingCheckedIn
+	BoardingBoarded
+	BoardingNoShow
+	BoardingCancelled
+)
+
+func (bs BoardingStatus) String() string {
+	switch bs {
+	case BoardingIssued:
+		return "ISSUED"
+	case BoardingCheckedIn:
+		return "CHECKED_IN"
+	case BoardingBoarded:
+		return "BOARDED"
+	case BoardingNoShow:
+		return "NO_SHOW"
+	case BoardingCancelled:
+		return "CANCELLED"
+	default:
+		return "UNKNOWN"
+	}
+}
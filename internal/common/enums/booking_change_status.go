@@ -0,0 +1,23 @@
+package enum
+
+// BookingChangeStatus represents the settlement state of a booking change
+type BookingChangeStatus int
+
+const (
+	BookingChangeCompleted BookingChangeStatus = iota
+	BookingChangeAwaitingPayment
+	BookingChangePaymentFailed
+)
+
+func (bcs BookingChangeStatus) String() string {
+	switch bcs {
+	case BookingChangeCompleted:
+		return "COMPLETED"
+	case BookingChangeAwaitingPayment:
+		return "AWAITING_PAYMENT"
+	case BookingChangePaymentFailed:
+		return "PAYMENT_FAILED"
+	default:
+		return "UNKNOWN"
+	}
+}
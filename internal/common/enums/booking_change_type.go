@@ -0,0 +1,20 @@
+package enum
+
+// BookingChangeType represents what a booking change did
+type BookingChangeType int
+
+const (
+	BookingChangeReschedule BookingChangeType = iota
+	BookingChangeOperatorRebook
+)
+
+func (bct BookingChangeType) String() string {
+	switch bct {
+	case BookingChangeReschedule:
+		return "RESCHEDULE"
+	case BookingChangeOperatorRebook:
+		return "OPERATOR_REBOOK"
+	default:
+		return "UNKNOWN"
+	}
+}
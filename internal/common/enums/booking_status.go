@@ -8,6 +8,7 @@ const (
 	BookingUnpaid
 	BookingExpired
 	BookingRefund
+	BookingCancelledByOperator
 )
 
 func (css BookingStatus) String() string {
@@ -20,6 +21,8 @@ func (css BookingStatus) String() string {
 		return "EXPIRED"
 	case BookingRefund:
 		return "REFUND"
+	case BookingCancelledByOperator:
+		return "CANCELLED_BY_OPERATOR"
 	default:
 		return "FAILED"
 	}
@@ -52,5 +55,6 @@ func GetInvalidBookingStatuses() []string {
 		BookingExpired.String(),
 		BookingUnpaid.String(),
 		BookingRefund.String(),
+		BookingCancelledByOperator.String(),
 	}
 }
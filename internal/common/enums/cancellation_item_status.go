@@ -0,0 +1,30 @@
+package enum
+
+// CancellationItemStatus represents what a schedule cancellation did to one
+// booking
+type CancellationItemStatus int
+
+const (
+	CancellationItemPending CancellationItemStatus = iota
+	CancellationItemRefunded
+	CancellationItemRebooked
+	CancellationItemCancelled
+	CancellationItemFailed
+)
+
+func (cis CancellationItemStatus) String() string {
+	switch cis {
+	case CancellationItemPending:
+		return "PENDING"
+	case CancellationItemRefunded:
+		return "REFUNDED"
+	case CancellationItemRebooked:
+		return "REBOOKED"
+	case CancellationItemCancelled:
+		return "CANCELLED"
+	case CancellationItemFailed:
+		return "FAILED"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,21 @@
+package enum
+
+// CancellationResolution represents what paid bookings of a cancelled
+// schedule are offered
+type CancellationResolution int
+
+const (
+	CancellationRefund CancellationResolution = iota
+	CancellationRebook
+)
+
+func (cr CancellationResolution) String() string {
+	switch cr {
+	case CancellationRefund:
+		return "REFUND"
+	case CancellationRebook:
+		return "REBOOK"
+	default:
+		return "UNKNOWN"
+	}
+}
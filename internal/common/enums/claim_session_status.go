@@ -8,6 +8,7 @@ const (
 	ClaimSessionPending
 	ClaimSessionSuccess
 	ClaimSessionCancelled
+	ClaimSessionExpired
 )
 
 func (css ClaimSessionStatus) String() string {
@@ -20,6 +21,8 @@ func (css ClaimSessionStatus) String() string {
 		return "RESERVED"
 	case ClaimSessionCancelled:
 		return "CANCELLED"
+	case ClaimSessionExpired:
+		return "EXPIRED"
 	default:
 		return "UNKNOWN"
 	}
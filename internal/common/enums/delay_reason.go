@@ -0,0 +1,29 @@
+package enum
+
+// DelayReason represents why a departure was delayed
+type DelayReason int
+
+const (
+	DelayWeather DelayReason = iota
+	DelayTechnical
+	DelayOperational
+	DelayPortCongestion
+	DelayOther
+)
+
+func (dr DelayReason) String() string {
+	switch dr {
+	case DelayWeather:
+		return "WEATHER"
+	case DelayTechnical:
+		return "TECHNICAL"
+	case DelayOperational:
+		return "OPERATIONAL"
+	case DelayPortCongestion:
+		return "PORT_CONGESTION"
+	case DelayOther:
+		return "OTHER"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,20 @@
+package enum
+
+// DiscountType represents how a promotion reduces the price of a claim
+type DiscountType int
+
+const (
+	DiscountPercentage DiscountType = iota
+	DiscountFixed
+)
+
+func (dt DiscountType) String() string {
+	switch dt {
+	case DiscountPercentage:
+		return "PERCENTAGE"
+	case DiscountFixed:
+		return "FIXED"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,26 @@
+package enum
+
+// FareCategory represents the passenger fare a ticket is sold at
+type FareCategory int
+
+const (
+	FareAdult FareCategory = iota
+	FareChild
+	FareInfant
+	FareSenior
+)
+
+func (fc FareCategory) String() string {
+	switch fc {
+	case FareAdult:
+		return "ADULT"
+	case FareChild:
+		return "CHILD"
+	case FareInfant:
+		return "INFANT"
+	case FareSenior:
+		return "SENIOR"
+	default:
+		return "UNKNOWN"
+	}
+}
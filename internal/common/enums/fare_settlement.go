@@ -0,0 +1,23 @@
+package enum
+
+// FareSettlement represents how a fare difference was settled
+type FareSettlement int
+
+const (
+	FareSettlementNone FareSettlement = iota
+	FareSettlementCharge
+	FareSettlementCredit
+)
+
+func (fs FareSettlement) String() string {
+	switch fs {
+	case FareSettlementNone:
+		return "NONE"
+	case FareSettlementCharge:
+		return "CHARGE"
+	case FareSettlementCredit:
+		return "CREDIT"
+	default:
+		return "UNKNOWN"
+	}
+}
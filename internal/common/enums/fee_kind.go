@@ -0,0 +1,23 @@
+package enum
+
+// FeeKind represents what a fee component charged on top of a fare is for
+type FeeKind int
+
+const (
+	FeePortService FeeKind = iota
+	FeeInsurance
+	FeeTax
+)
+
+func (fk FeeKind) String() string {
+	switch fk {
+	case FeePortService:
+		return "PORT_SERVICE"
+	case FeeInsurance:
+		return "INSURANCE"
+	case FeeTax:
+		return "TAX"
+	default:
+		return "UNKNOWN"
+	}
+}
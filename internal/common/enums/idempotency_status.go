@@ -0,0 +1,20 @@
+package enum
+
+// IdempotencyStatus represents the state of a request stored under an idempotency key
+type IdempotencyStatus int
+
+const (
+	IdempotencyProcessing IdempotencyStatus = iota
+	IdempotencyCompleted
+)
+
+func (is IdempotencyStatus) String() string {
+	switch is {
+	case IdempotencyProcessing:
+		return "PROCESSING"
+	case IdempotencyCompleted:
+		return "COMPLETED"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,20 @@
+package enum
+
+// NotificationKind represents a time-driven message sent to a customer
+type NotificationKind int
+
+const (
+	NotificationReminder24h NotificationKind = iota
+	NotificationReminder3h
+)
+
+func (nk NotificationKind) String() string {
+	switch nk {
+	case NotificationReminder24h:
+		return "DEPARTURE_REMINDER_24H"
+	case NotificationReminder3h:
+		return "DEPARTURE_REMINDER_3H"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,23 @@
+package enum
+
+// OutboxEventType represents the side effect an outbox event runs
+type OutboxEventType int
+
+const (
+	OutboxCreatePayment OutboxEventType = iota
+	OutboxSendEmail
+	OutboxSendSMS
+)
+
+func (oet OutboxEventType) String() string {
+	switch oet {
+	case OutboxCreatePayment:
+		return "CREATE_PAYMENT"
+	case OutboxSendEmail:
+		return "SEND_EMAIL"
+	case OutboxSendSMS:
+		return "SEND_SMS"
+	default:
+		return "UNKNOWN"
+	}
+}
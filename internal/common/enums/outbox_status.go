@@ -0,0 +1,26 @@
+package enum
+
+// OutboxStatus represents the delivery state of an outbox event
+type OutboxStatus int
+
+const (
+	OutboxPending OutboxStatus = iota
+	OutboxDone
+	OutboxFailed
+	OutboxCancelled
+)
+
+func (os OutboxStatus) String() string {
+	switch os {
+	case OutboxPending:
+		return "PENDING"
+	case OutboxDone:
+		return "DONE"
+	case OutboxFailed:
+		return "FAILED"
+	case OutboxCancelled:
+		return "CANCELLED"
+	default:
+		return "UNKNOWN"
+	}
+}
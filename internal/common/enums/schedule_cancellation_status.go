@@ -0,0 +1,20 @@
+package enum
+
+// ScheduleCancellationStatus represents the progress of a schedule cancellation
+type ScheduleCancellationStatus int
+
+const (
+	ScheduleCancellationRunning ScheduleCancellationStatus = iota
+	ScheduleCancellationCompleted
+)
+
+func (scs ScheduleCancellationStatus) String() string {
+	switch scs {
+	case ScheduleCancellationRunning:
+		return "RUNNING"
+	case ScheduleCancellationCompleted:
+		return "COMPLETED"
+	default:
+		return "UNKNOWN"
+	}
+}
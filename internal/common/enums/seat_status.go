@@ -0,0 +1,26 @@
+package enum
+
+// SeatStatus represents the state of a seat in a schedule's seat inventory
+type SeatStatus int
+
+const (
+	SeatAvailable SeatStatus = iota
+	SeatHeld
+	SeatSold
+	SeatBlocked
+)
+
+func (ss SeatStatus) String() string {
+	switch ss {
+	case SeatAvailable:
+		return "AVAILABLE"
+	case SeatHeld:
+		return "HELD"
+	case SeatSold:
+		return "SOLD"
+	case SeatBlocked:
+		return "BLOCKED"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,29 @@
+package enum
+
+// VehicleType represents the kind of vehicle a vehicle ticket carries
+type VehicleType int
+
+const (
+	VehicleBicycle VehicleType = iota
+	VehicleMotorbike
+	VehicleCar
+	VehicleBus
+	VehicleTruck
+)
+
+func (vt VehicleType) String() string {
+	switch vt {
+	case VehicleBicycle:
+		return "BICYCLE"
+	case VehicleMotorbike:
+		return "MOTORBIKE"
+	case VehicleCar:
+		return "CAR"
+	case VehicleBus:
+		return "BUS"
+	case VehicleTruck:
+		return "TRUCK"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,29 @@
+package enum
+
+// WaitlistStatus represents the state of a waitlist entry
+type WaitlistStatus int
+
+const (
+	WaitlistWaiting WaitlistStatus = iota
+	WaitlistOffered
+	WaitlistFulfilled
+	WaitlistLapsed
+	WaitlistCancelled
+)
+
+func (ws WaitlistStatus) String() string {
+	switch ws {
+	case WaitlistWaiting:
+		return "WAITING"
+	case WaitlistOffered:
+		return "OFFERED"
+	case WaitlistFulfilled:
+		return "FULFILLED"
+	case WaitlistLapsed:
+		return "LAPSED"
+	case WaitlistCancelled:
+		return "CANCELLED"
+	default:
+		return "UNKNOWN"
+	}
+}
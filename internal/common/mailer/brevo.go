@@ -2,6 +2,7 @@ package mailer
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"eticket-api/config"
 	"fmt"
@@ -24,16 +25,16 @@ func NewBrevo(cfg *config.Config) *Brevo {
 	}
 }
 
-func (b *Brevo) SendAsync(to, subject, body string) {
+func (b *Brevo) SendAsync(to, subject, body string, attachments ...Attachment) {
 	go func() {
-		err := b.Send(to, subject, body)
+		err := b.Send(to, subject, body, attachments...)
 		if err != nil {
 			fmt.Printf("[Brevo] failed to send email to %s: %v\n", to, err)
 		}
 	}()
 }
 
-func (b *Brevo) Send(to, subject, body string) error {
+func (b *Brevo) Send(to, subject, body string, attachments ...Attachment) error {
 	payload := map[string]interface{}{
 		"sender": map[string]string{
 			"name":  b.FromName,
@@ -45,6 +46,16 @@ func (b *Brevo) Send(to, subject, body string) error {
 		"subject":     subject,
 		"htmlContent": body,
 	}
+	if len(attachments) > 0 {
+		files := make([]map[string]string, len(attachments))
+		for i, attachment := range attachments {
+			files[i] = map[string]string{
+				"name":    attachment.Filename,
+				"content": base64.StdEncoding.EncodeToString(attachment.Content),
+			}
+		}
+		payload["attachment"] = files
+	}
 
 	jsonBody, err := json.Marshal(payload)
 	if err != nil {
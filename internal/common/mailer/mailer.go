@@ -1,6 +1,13 @@
 package mailer
 
+// Attachment is a file sent along with an email.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
 type Mailer interface {
-	SendAsync(to, subject, body string)
-	Send(to, subject, body string) error
+	SendAsync(to, subject, body string, attachments ...Attachment)
+	Send(to, subject, body string, attachments ...Attachment) error
 }
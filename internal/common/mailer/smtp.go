@@ -1,6 +1,10 @@
 package mailer
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
 	"eticket-api/config"
 	"fmt"
 	"mime"
@@ -26,9 +30,9 @@ func NewSMTP(cfg *config.Config) *SMTP {
 	}
 }
 
-func (m *SMTP) SendAsync(toEmail, subject, body string) {
+func (m *SMTP) SendAsync(toEmail, subject, body string, attachments ...Attachment) {
 	go func() {
-		if err := m.Send(toEmail, subject, body); err != nil {
+		if err := m.Send(toEmail, subject, body, attachments...); err != nil {
 			// Log error here if you have a logger
 			fmt.Printf("failed to send email: %v\n", err)
 		}
@@ -36,27 +40,75 @@ func (m *SMTP) SendAsync(toEmail, subject, body string) {
 }
 
 // send is the actual synchronous email sender (private)
-func (m *SMTP) Send(toEmail, subject, body string) error {
+func (m *SMTP) Send(toEmail, subject, body string, attachments ...Attachment) error {
 	addr := fmt.Sprintf("%s:%d", m.Host, m.Port)
 
 	encodedSubject := EncodeRFC2047(subject)
 	encodedFrom := EncodeRFC2047(m.From)
 
-	msg := strings.Join([]string{
+	headers := []string{
 		"MIME-Version: 1.0",
-		"Content-Type: text/html; charset=\"UTF-8\"",
 		fmt.Sprintf("From: %s", encodedFrom),
 		fmt.Sprintf("To: %s", toEmail),
 		fmt.Sprintf("Subject: %s", encodedSubject),
-		"", // Blank line between headers and body
-		body,
-	}, "\r\n")
+	}
+
+	var msg string
+	if len(attachments) == 0 {
+		msg = strings.Join(append(headers,
+			"Content-Type: text/html; charset=\"UTF-8\"",
+			"", // Blank line between headers and body
+			body,
+		), "\r\n")
+	} else {
+		multipart, err := multipartBody(headers, body, attachments)
+		if err != nil {
+			return err
+		}
+		msg = multipart
+	}
 
 	auth := smtp.PlainAuth("", m.Username, m.Password, m.Host)
 
 	return smtp.SendMail(addr, auth, m.From, []string{toEmail}, []byte(msg))
 }
 
+// multipartBody writes a multipart/mixed message: the HTML body first, then
+// each attachment base64 encoded.
+func multipartBody(headers []string, body string, attachments []Attachment) (string, error) {
+	random := make([]byte, 12)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate MIME boundary: %w", err)
+	}
+	boundary := "eticket-" + hex.EncodeToString(random)
+
+	var msg bytes.Buffer
+	msg.WriteString(strings.Join(append(headers,
+		fmt.Sprintf("Content-Type: multipart/mixed; boundary=\"%s\"", boundary),
+		"",
+		"",
+	), "\r\n"))
+
+	fmt.Fprintf(&msg, "--%s\r\nContent-Type: text/html; charset=\"UTF-8\"\r\n\r\n%s\r\n", boundary, body)
+	for _, attachment := range attachments {
+		filename := mime.QEncoding.Encode("UTF-8", attachment.Filename)
+		fmt.Fprintf(&msg, "--%s\r\n", boundary)
+		fmt.Fprintf(&msg, "Content-Type: %s; name=\"%s\"\r\n", attachment.ContentType, filename)
+		fmt.Fprintf(&msg, "Content-Disposition: attachment; filename=\"%s\"\r\n", filename)
+		msg.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+		// RFC 2045 limits encoded lines to 76 characters
+		encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+		for len(encoded) > 76 {
+			msg.WriteString(encoded[:76] + "\r\n")
+			encoded = encoded[76:]
+		}
+		msg.WriteString(encoded + "\r\n")
+	}
+	fmt.Fprintf(&msg, "--%s--\r\n", boundary)
+	return msg.String(), nil
+}
+
 // encodeRFC2047 safely encodes a string for use in email headers (Subject, From, etc.)
 func EncodeRFC2047(s string) string {
 	if IsASCII(s) {
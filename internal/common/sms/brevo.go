@@ -0,0 +1,78 @@
+package sms
+
+import (
+	"bytes"
+	"encoding/json"
+	"eticket-api/config"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Brevo sends transactional SMS through the same Brevo account as the emails.
+type Brevo struct {
+	APIKey string
+	Sender string
+}
+
+// NewSender returns the Brevo SMS channel, or nil when SMS is disabled.
+func NewSender(cfg *config.Config) Sender {
+	if !cfg.SMS.Enabled {
+		return nil
+	}
+	return &Brevo{
+		APIKey: cfg.Brevo.APIKey,
+		Sender: cfg.SMS.Sender,
+	}
+}
+
+func (b *Brevo) Send(to, message string) error {
+	payload := map[string]interface{}{
+		"sender":    b.Sender,
+		"recipient": internationalNumber(to),
+		"content":   message,
+		"type":      "transactional",
+	}
+
+	jsonBody, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", "https://api.brevo.com/v3/transactionalSMS/sms", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("api-key", b.APIKey)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms send error: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("sms send failed, status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// internationalNumber turns a local Indonesian number such as 0812... into
+// the 62812... form Brevo expects.
+func internationalNumber(phone string) string {
+	phone = strings.TrimPrefix(strings.TrimSpace(phone), "+")
+	if strings.HasPrefix(phone, "0") {
+		return "62" + phone[1:]
+	}
+	return phone
+}
+
+// Ensure Brevo implements Sender interface
+var _ Sender = (*Brevo)(nil)
@@ -0,0 +1,7 @@
+package sms
+
+// Sender delivers a text message to a phone number. It is optional: when no
+// SMS channel is configured the application runs without one.
+type Sender interface {
+	Send(to, message string) error
+}
@@ -0,0 +1,198 @@
+package templates
+
+import (
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/pdf"
+	"eticket-api/pkg/qrcode"
+	"fmt"
+	"strconv"
+)
+
+const (
+	documentMargin = 40.0
+	qrSize         = 180.0
+)
+
+// BoardingPassPDF lays out one boarding pass per page, each with the QR code
+// of its signed payload in passes, in the order of tickets.
+func BoardingPassPDF(booking *domain.Booking, tickets []*domain.Ticket, passes []string) ([]byte, error) {
+	doc := pdf.New(pdf.A4Width, pdf.A4Height)
+	for i, ticket := range tickets {
+		code, err := qrcode.Encode([]byte(passes[i]))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode QR of ticket %s: %w", ticket.TicketCode, err)
+		}
+		doc.AddPage()
+		schedule := ticket.Schedule
+
+		y := documentMargin + 16
+		doc.Text(documentMargin, y, 18, true, "BOARDING PASS")
+		doc.Text(doc.Width()-documentMargin-150, y, 10, false, fmt.Sprintf("Tiket %d dari %d", i+1, len(tickets)))
+		y += 10
+		doc.Line(documentMargin, y, doc.Width()-documentMargin, y)
+		y += 26
+
+		doc.Text(documentMargin, y, 16, true, fmt.Sprintf("%s  >  %s", schedule.DepartureHarbor.HarborName, schedule.ArrivalHarbor.HarborName))
+		y += 20
+		doc.Text(documentMargin, y, 11, false, fmt.Sprintf("Kapal %s", schedule.Ship.ShipName))
+		y += 16
+		doc.Text(documentMargin, y, 11, false, fmt.Sprintf("Berangkat %s", schedule.DepartureDatetime.Format("Monday, 2 January 2006 15:04")))
+		y += 16
+		doc.Text(documentMargin, y, 11, false, fmt.Sprintf("Tiba %s", schedule.ArrivalDatetime.Format("Monday, 2 January 2006 15:04")))
+		y += 30
+
+		top := y
+		var rows [][2]string
+		switch ticket.Type {
+		case "vehicle":
+			length := "-"
+			if ticket.VehicleLength != nil {
+				length = strconv.FormatFloat(*ticket.VehicleLength, 'f', -1, 64) + " m"
+			}
+			rows = [][2]string{
+				{"Plat Nomor", deref(ticket.LicensePlate)},
+				{"Kendaraan", fmt.Sprintf("%s %s", deref(ticket.VehicleType), deref(ticket.VehicleBrand))},
+				{"Panjang", length},
+			}
+			for _, driver := range tickets {
+				if ticket.DriverTicketID != nil && driver.ID == *ticket.DriverTicketID {
+					rows = append(rows, [2]string{"Pengemudi", driver.PassengerName})
+				}
+			}
+		default:
+			seat := deref(ticket.SeatNumber)
+			if seat == "" {
+				seat = "Bebas"
+			}
+			rows = [][2]string{
+				{"Nama", ticket.PassengerName},
+				{"Identitas", fmt.Sprintf("%s %s", deref(ticket.IDType), deref(ticket.IDNumber))},
+				{"Kursi", seat},
+			}
+		}
+		rows = append(rows,
+			[2]string{"Kelas", ticket.Class.ClassName},
+			[2]string{"Kode Tiket", ticket.TicketCode},
+			[2]string{"Order ID", booking.OrderID},
+		)
+		for _, row := range rows {
+			doc.Text(documentMargin, y, 9, false, row[0])
+			doc.Text(documentMargin, y+14, 12, true, row[1])
+			y += 34
+		}
+
+		drawQR(doc, code, doc.Width()-documentMargin-qrSize, top-12, qrSize)
+		doc.Text(doc.Width()-documentMargin-qrSize, top+qrSize, 8, false, "Tunjukkan kode ini di gerbang keberangkatan")
+
+		y += 10
+		doc.Line(documentMargin, y, doc.Width()-documentMargin, y)
+		y += 18
+		doc.Text(documentMargin, y, 9, false, "Check-in ditutup saat kapal berangkat.")
+		y += 12
+		doc.Text(documentMargin, y, 9, false, "Bawa identitas yang sama dengan yang tertera pada tiket.")
+	}
+	return doc.Bytes(), nil
+}
+
+// InvoicePDF lists what the booking was charged for, the same lines as the
+// confirmation email, as a receipt once it is paid.
+func InvoicePDF(booking *domain.Booking, tickets []*domain.Ticket) []byte {
+	doc := pdf.New(pdf.A4Width, pdf.A4Height)
+	doc.AddPage()
+	right := doc.Width() - documentMargin
+
+	title := "INVOICE"
+	if booking.Status == "PAID" {
+		title = "BUKTI PEMBAYARAN"
+	}
+	y := documentMargin + 16
+	doc.Text(documentMargin, y, 18, true, title)
+	y += 10
+	doc.Line(documentMargin, y, right, y)
+	y += 22
+
+	info := [][2]string{
+		{"Order ID", booking.OrderID},
+		{"Status", booking.Status},
+		{"Dipesan", booking.CreatedAt.Format("2 January 2006 15:04")},
+		{"Pemesan", booking.CustomerName},
+		{"Email", booking.Email},
+		{"Telepon", booking.PhoneNumber},
+		{"Rute", fmt.Sprintf("%s - %s", booking.Schedule.DepartureHarbor.HarborName, booking.Schedule.ArrivalHarbor.HarborName)},
+		{"Keberangkatan", booking.Schedule.DepartureDatetime.Format("2 January 2006 15:04")},
+	}
+	if booking.ReferenceNumber != nil {
+		info = append(info, [2]string{"Referensi", *booking.ReferenceNumber})
+	}
+	for _, row := range info {
+		doc.Text(documentMargin, y, 10, false, row[0])
+		doc.Text(documentMargin+100, y, 10, true, row[1])
+		y += 15
+	}
+	y += 15
+
+	line := func(label, amount string, bold bool) {
+		if y > doc.Height()-documentMargin {
+			doc.AddPage()
+			y = documentMargin + 16
+		}
+		doc.Text(documentMargin, y, 10, bold, label)
+		doc.Text(right-90, y, 10, bold, amount)
+		y += 16
+	}
+	line("Keterangan", "Jumlah", true)
+	doc.Line(documentMargin, y-12, right, y-12)
+
+	total := 0.0
+	var fees []domain.TicketFee
+	for _, ticket := range tickets {
+		label := fmt.Sprintf("%s - %s", ticket.Class.ClassName, ticket.PassengerName)
+		if ticket.Type == "vehicle" {
+			label = fmt.Sprintf("%s - %s", ticket.Class.ClassName, deref(ticket.LicensePlate))
+		}
+		line(label, "Rp "+formatPrice(ticket.Price), false)
+		total += ticket.Price
+		for _, fee := range ticket.Fees {
+			total += fee.Amount
+		}
+		fees = append(fees, ticket.Fees...)
+	}
+	for _, fee := range feeLines(fees) {
+		line(fee.Name, "Rp "+formatPrice(fee.Amount), false)
+	}
+	for _, addon := range booking.Addons {
+		amount := addon.Price * float64(addon.Quantity)
+		total += amount
+		line(fmt.Sprintf("%s x%d", addon.Name, addon.Quantity), "Rp "+formatPrice(amount), false)
+	}
+	if booking.Discount > 0 && booking.PromoCode != nil {
+		total -= booking.Discount
+		line(fmt.Sprintf("Diskon (%s)", *booking.PromoCode), "-Rp "+formatPrice(booking.Discount), false)
+	}
+
+	doc.Line(documentMargin, y-12, right, y-12)
+	line("Total", "Rp "+formatPrice(total), true)
+	return doc.Bytes()
+}
+
+// drawQR fills the dark modules of code in a size by size square, quiet zone
+// included. Dark modules next to each other in a row are drawn as one
+// rectangle to keep the page small.
+func drawQR(doc *pdf.Document, code *qrcode.Code, x, y, size float64) {
+	module := size / float64(code.Size()+8)
+	x += 4 * module
+	y += 4 * module
+	for row := 0; row < code.Size(); row++ {
+		for col := 0; col < code.Size(); {
+			if !code.Dark(col, row) {
+				col++
+				continue
+			}
+			start := col
+			for col < code.Size() && code.Dark(col, row) {
+				col++
+			}
+			doc.Rect(x+float64(start)*module, y+float64(row)*module, float64(col-start)*module, module)
+		}
+	}
+}
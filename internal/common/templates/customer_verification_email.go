@@ -0,0 +1,103 @@
+package templates
+
+import (
+	"eticket-api/internal/domain"
+	"fmt"
+	"time"
+)
+
+// CustomerVerificationEmail sends the code that confirms a customer owns the
+// email of their account.
+func CustomerVerificationEmail(customer *domain.Customer, code string, expiresIn time.Duration) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="id">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Verifikasi Email - Tiket Hebat</title>
+    <style>
+        body {
+            font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif;
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            padding: 20px;
+            line-height: 1.6;
+        }
+
+        .email-container {
+            max-width: 650px;
+            margin: 0 auto;
+            background: #ffffff;
+            border-radius: 20px;
+            overflow: hidden;
+            box-shadow: 0 20px 40px rgba(0, 0, 0, 0.1);
+        }
+
+        .header {
+            background: linear-gradient(135deg, #007bff 0%%, #0056b3 100%%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+
+        .content {
+            padding: 40px 30px;
+        }
+
+        .verify-code {
+            background: #f1f7ff;
+            border-radius: 15px;
+            padding: 25px;
+            margin: 25px 0;
+            text-align: center;
+            font-size: 36px;
+            font-weight: bold;
+            letter-spacing: 10px;
+            color: #0056b3;
+        }
+
+        .footer {
+            background: #343a40;
+            color: #adb5bd;
+            padding: 30px;
+            text-align: center;
+            font-size: 12px;
+        }
+    </style>
+</head>
+<body>
+    <div class="email-container">
+        <div class="header">
+            <h1>Verifikasi Email</h1>
+            <p>%s</p>
+        </div>
+
+        <div class="content">
+            <div style="font-size: 20px; color: #333; margin-bottom: 20px; font-weight: 600;">
+                Halo %s! 👋
+            </div>
+
+            <p style="margin-bottom: 25px; font-size: 16px; color: #555;">
+                Gunakan kode berikut untuk memverifikasi email akun Anda. Setelah terverifikasi, pesanan yang pernah Anda buat dengan email ini dapat ditambahkan ke akun. Kode berlaku selama %d menit.
+            </p>
+
+            <div class="verify-code">%s</div>
+
+            <p style="color: #555; font-size: 14px;">
+                Jika Anda tidak mendaftar di Tiket Hebat, abaikan email ini. Jangan bagikan kode kepada siapa pun.
+            </p>
+        </div>
+
+        <div class="footer">
+            &copy; %d Tiket Hebat. Semua hak dilindungi.
+        </div>
+    </div>
+</body>
+</html>`,
+		customer.Email,
+		customer.FullName,
+		int(expiresIn.Minutes()),
+		code,
+		time.Now().Year(),
+	)
+}
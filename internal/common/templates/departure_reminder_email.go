@@ -0,0 +1,178 @@
+package templates
+
+import (
+	"eticket-api/internal/domain"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// DepartureReminderEmail reminds a customer of an upcoming departure, with
+// the tickets on it, what to do at the gate and a link to the e-tickets.
+func DepartureReminderEmail(booking *domain.Booking, schedule *domain.Schedule, tickets []*domain.Ticket, before time.Duration, checkInOpensAt time.Time, link string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="id">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Pengingat Keberangkatan - Tiket Hebat</title>
+    <style>
+        body {
+            font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif;
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            padding: 20px;
+            line-height: 1.6;
+        }
+
+        .email-container {
+            max-width: 650px;
+            margin: 0 auto;
+            background: #ffffff;
+            border-radius: 20px;
+            overflow: hidden;
+            box-shadow: 0 20px 40px rgba(0, 0, 0, 0.1);
+        }
+
+        .header {
+            background: linear-gradient(135deg, #007bff 0%%, #0056b3 100%%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+
+        .content {
+            padding: 40px 30px;
+        }
+
+        .trip-summary {
+            background: #f0f7ff;
+            border-radius: 15px;
+            padding: 25px;
+            margin: 25px 0;
+            border-left: 5px solid #007bff;
+        }
+
+        .ticket-table {
+            width: 100%%;
+            border-collapse: collapse;
+            margin: 20px 0;
+            font-size: 14px;
+        }
+
+        .ticket-table th, .ticket-table td {
+            padding: 10px;
+            border-bottom: 1px solid #e9ecef;
+            text-align: left;
+        }
+
+        .instructions {
+            background: #fff8e1;
+            border-radius: 15px;
+            padding: 20px 25px;
+            margin: 25px 0;
+            border-left: 5px solid #ffc107;
+        }
+
+        .ticket-button {
+            background: linear-gradient(135deg, #28a745 0%%, #20c997 100%%);
+            color: white;
+            padding: 15px 30px;
+            text-decoration: none;
+            border-radius: 25px;
+            font-weight: bold;
+            display: inline-block;
+        }
+
+        .footer {
+            background: #343a40;
+            color: #adb5bd;
+            padding: 30px;
+            text-align: center;
+            font-size: 12px;
+        }
+    </style>
+</head>
+<body>
+    <div class="email-container">
+        <div class="header">
+            <h1>Kapal Anda Berangkat dalam %d Jam</h1>
+            <p>Order ID %s</p>
+        </div>
+
+        <div class="content">
+            <div style="font-size: 20px; color: #333; margin-bottom: 20px; font-weight: 600;">
+                Halo %s! 👋
+            </div>
+
+            <p style="margin-bottom: 25px; font-size: 16px; color: #555;">
+                Perjalanan Anda sudah dekat. Berikut ringkasan keberangkatan dan tiket Anda.
+            </p>
+
+            <div class="trip-summary">
+                <p><strong>Rute:</strong> %s - %s</p>
+                <p><strong>Kapal:</strong> %s</p>
+                <p><strong>Keberangkatan:</strong> %s</p>
+            </div>
+
+            <table class="ticket-table">
+                <tr><th>Kode Tiket</th><th>Penumpang / Kendaraan</th><th>Kelas</th><th>Kursi</th></tr>
+                %s
+            </table>
+
+            <div class="instructions">
+                <p><strong>Petunjuk Check-in</strong></p>
+                <ul>
+                    <li>Check-in dibuka %s dan ditutup saat kapal berangkat.</li>
+                    <li>Tunjukkan kode QR pada e-tiket terlampir di gerbang keberangkatan.</li>
+                    <li>Bawa identitas yang sama dengan yang tertera pada tiket.</li>
+                    <li>Kendaraan diharap tiba lebih awal untuk proses muat.</li>
+                </ul>
+            </div>
+
+            <div style="text-align: center; margin: 30px 0;">
+                <a href="%s" class="ticket-button">Lihat E-Tiket</a>
+            </div>
+        </div>
+
+        <div class="footer">
+            &copy; %d Tiket Hebat. Semua hak dilindungi.
+        </div>
+    </div>
+</body>
+</html>`,
+		int(before.Hours()),
+		booking.OrderID,
+		booking.CustomerName,
+		schedule.DepartureHarbor.HarborName,
+		schedule.ArrivalHarbor.HarborName,
+		schedule.Ship.ShipName,
+		schedule.DepartureDatetime.Format("Monday, 02 Jan 2006 15:04"),
+		reminderTicketRows(tickets),
+		checkInOpensAt.Format("02 Jan 2006 15:04"),
+		link,
+		time.Now().Year(),
+	)
+}
+
+func reminderTicketRows(tickets []*domain.Ticket) string {
+	var rows strings.Builder
+	for _, ticket := range tickets {
+		holder := ticket.PassengerName
+		if ticket.Type == "vehicle" {
+			holder = deref(ticket.LicensePlate)
+		}
+		seat := deref(ticket.SeatNumber)
+		if seat == "" {
+			seat = "-"
+		}
+		fmt.Fprintf(&rows, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>",
+			html.EscapeString(ticket.TicketCode),
+			html.EscapeString(holder),
+			html.EscapeString(ticket.Class.ClassName),
+			html.EscapeString(seat),
+		)
+	}
+	return rows.String()
+}
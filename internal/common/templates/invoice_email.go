@@ -18,6 +18,28 @@ func BookingInvoiceEmail(booking *domain.Booking, payment *domain.Transaction) s
 		qrImgHTML = `<div style="color:#dc3545;">QR tidak tersedia</div>`
 	}
 
+	// Fees charged on top of the fares, one row per fee component
+	feesHTML := ""
+	for _, fee := range feeLines(booking.Fees) {
+		feesHTML += fmt.Sprintf(`
+                <div class="info-label" style="margin-top:15px;">%s</div>
+                <div class="info-value">Rp %s</div>`, fee.Name, formatPrice(fee.Amount))
+	}
+
+	// Add-ons bought with the booking, one row per product and ticket
+	for _, addon := range booking.Addons {
+		feesHTML += fmt.Sprintf(`
+                <div class="info-label" style="margin-top:15px;">%s x%d</div>
+                <div class="info-value">Rp %s</div>`, addon.Name, addon.Quantity, formatPrice(addon.Price*float64(addon.Quantity)))
+	}
+
+	discountHTML := ""
+	if booking.Discount > 0 && booking.PromoCode != nil {
+		discountHTML = fmt.Sprintf(`
+                <div class="info-label" style="margin-top:15px;">Diskon (%s)</div>
+                <div class="info-value">-Rp %s</div>`, *booking.PromoCode, formatPrice(booking.Discount))
+	}
+
 	return fmt.Sprintf(`
 <!DOCTYPE html>
 <html lang="id">
@@ -65,7 +87,7 @@ func BookingInvoiceEmail(booking *domain.Booking, payment *domain.Transaction) s
                 <div class="info-label">ID Pemesanan</div>
                 <div class="info-value">%s</div>
                 <div class="info-label" style="margin-top:15px;">Status</div>
-                <div class="info-value" style="color: #007bff;">Menunggu Pembayaran</div>
+                <div class="info-value" style="color: #007bff;">Menunggu Pembayaran</div>%s
                 <div class="info-label" style="margin-top:15px;">Total Tagihan</div>
                 <div class="info-value" style="color: #28a745;">Rp %s</div>
                 <div class="info-label" style="margin-top:15px;">Batas Waktu Pembayaran</div>
@@ -111,6 +133,7 @@ func BookingInvoiceEmail(booking *domain.Booking, payment *domain.Transaction) s
 `,
 		booking.CustomerName,
 		booking.OrderID,
+		feesHTML+discountHTML,
 		formatPrice(float64(payment.Amount)),
 		time.Unix(payment.ExpiredTime, 0).Format("2 January 2006 15:04"),
 		qrImgHTML,
@@ -128,6 +151,7 @@ func BookingSuccessEmail(booking *domain.Booking, tickets []*domain.Ticket) stri
 	passengerCount := 0
 	vehicleCount := 0
 	totalPrice := 0.0
+	var fees []domain.TicketFee
 
 	for _, ticket := range tickets {
 		switch ticket.Type {
@@ -137,6 +161,29 @@ func BookingSuccessEmail(booking *domain.Booking, tickets []*domain.Ticket) stri
 			vehicleCount++
 		}
 		totalPrice += ticket.Price
+		for _, fee := range ticket.Fees {
+			totalPrice += fee.Amount
+		}
+		fees = append(fees, ticket.Fees...)
+	}
+
+	feesHTML := ""
+	for _, fee := range feeLines(fees) {
+		feesHTML += fmt.Sprintf(`<br>%s: Rp %s`, fee.Name, formatPrice(fee.Amount))
+	}
+
+	// Add-ons for a ticket are listed on its card, the rest here
+	for _, addon := range booking.Addons {
+		totalPrice += addon.Price * float64(addon.Quantity)
+		if addon.TicketID == nil {
+			feesHTML += fmt.Sprintf(`<br>%s x%d: Rp %s`, addon.Name, addon.Quantity, formatPrice(addon.Price*float64(addon.Quantity)))
+		}
+	}
+
+	discountHTML := ""
+	if booking.Discount > 0 && booking.PromoCode != nil {
+		totalPrice -= booking.Discount
+		discountHTML = fmt.Sprintf(`<br>Diskon (%s): -Rp %s`, *booking.PromoCode, formatPrice(booking.Discount))
 	}
 
 	// Build ticket details HTML
@@ -607,7 +654,7 @@ func BookingSuccessEmail(booking *domain.Booking, tickets []*domain.Ticket) stri
             <div class="price-summary">
                 <div class="total-price">Rp %s</div>
                 <div class="price-breakdown">
-                    Total: %d Penumpang + %d Kendaraan
+                    Total: %d Penumpang + %d Kendaraan%s
                 </div>
             </div>
             
@@ -673,6 +720,7 @@ func BookingSuccessEmail(booking *domain.Booking, tickets []*domain.Ticket) stri
 		formatPrice(totalPrice),
 		passengerCount,
 		vehicleCount,
+		feesHTML+discountHTML,
 		time.Now().Year(),
 	)
 }
@@ -939,6 +987,30 @@ func buildTicketDetailsHTML(tickets []*domain.Ticket) string {
 			}
 		}
 
+		// Bookings spanning several legs show which crossing each ticket is for
+		if ticket.Schedule.ID != 0 {
+			html.WriteString(fmt.Sprintf(`
+                <div class="info-item">
+                    <div class="info-label">Perjalanan</div>
+                    <div class="info-value">%s → %s, %s</div>
+                </div>`,
+				ticket.Schedule.DepartureHarbor.HarborName,
+				ticket.Schedule.ArrivalHarbor.HarborName,
+				ticket.Schedule.DepartureDatetime.Format("2 Jan 2006 15:04"),
+			))
+		}
+
+		for _, addon := range ticket.Addons {
+			html.WriteString(fmt.Sprintf(`
+                <div class="info-item">
+                    <div class="info-label">Tambahan</div>
+                    <div class="info-value">%s x%d</div>
+                </div>`,
+				addon.Name,
+				addon.Quantity,
+			))
+		}
+
 		html.WriteString(fmt.Sprintf(`
                 <div class="info-item">
                     <div class="info-label">Kelas</div>
@@ -959,6 +1031,24 @@ func buildTicketDetailsHTML(tickets []*domain.Ticket) string {
 }
 
 // Helper functions
+
+// feeLines adds up fees of the same name, in the order the names first
+// appear.
+func feeLines(fees []domain.TicketFee) []domain.TicketFee {
+	var lines []domain.TicketFee
+	index := make(map[string]int)
+	for _, fee := range fees {
+		i, ok := index[fee.Name]
+		if !ok {
+			index[fee.Name] = len(lines)
+			lines = append(lines, domain.TicketFee{Name: fee.Name, Amount: fee.Amount})
+			continue
+		}
+		lines[i].Amount += fee.Amount
+	}
+	return lines
+}
+
 func formatPrice(price float64) string {
 	// Format price with thousand separators
 	return fmt.Sprintf("%.0f", price)
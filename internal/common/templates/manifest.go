@@ -0,0 +1,198 @@
+package templates
+
+import (
+	"bytes"
+	"encoding/csv"
+	"eticket-api/internal/model"
+	"eticket-api/pkg/pdf"
+	"fmt"
+	"strconv"
+)
+
+// ManifestCSV writes the manifest as one row per ticket, passengers first,
+// for the harbor authority's spreadsheets.
+func ManifestCSV(manifest *model.Manifest) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{
+		"no", "type", "ticket_code", "order_id", "name", "gender", "age", "id_type", "id_number", "address",
+		"class", "fare_category", "seat_number", "license_plate", "vehicle_type", "vehicle_brand", "vehicle_length",
+		"driver_name", "driver_ticket_code", "checked_in",
+	})
+	for _, p := range manifest.Passengers {
+		w.Write([]string{
+			strconv.Itoa(p.No), "passenger", p.TicketCode, p.OrderID, p.Name, deref(p.Gender), strconv.Itoa(p.Age), deref(p.IDType), deref(p.IDNumber), p.Address,
+			p.ClassName, p.FareCategory, deref(p.SeatNumber), "", "", "", "",
+			"", "", strconv.FormatBool(p.CheckedIn),
+		})
+	}
+	for _, v := range manifest.Vehicles {
+		length := ""
+		if v.VehicleLength != nil {
+			length = strconv.FormatFloat(*v.VehicleLength, 'f', -1, 64)
+		}
+		w.Write([]string{
+			strconv.Itoa(v.No), "vehicle", v.TicketCode, v.OrderID, "", "", "", "", "", "",
+			v.ClassName, "", "", deref(v.LicensePlate), deref(v.VehicleType), deref(v.VehicleBrand), length,
+			v.DriverName, v.DriverTicketCode, strconv.FormatBool(v.CheckedIn),
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type manifestColumn struct {
+	title string
+	width float64
+}
+
+const (
+	manifestMargin   = 30.0
+	manifestRowSize  = 14.0
+	manifestFontSize = 8.0
+)
+
+// ManifestPDF lays the manifest out on landscape A4 pages: the departure,
+// passengers, vehicles with their drivers, totals per class and add-ons to
+// serve on board.
+func ManifestPDF(manifest *model.Manifest) []byte {
+	doc := pdf.New(pdf.A4Height, pdf.A4Width)
+	doc.AddPage()
+
+	title := "MANIFES PENUMPANG"
+	if manifest.Final {
+		title += " (FINAL)"
+	}
+	y := manifestMargin + 12
+	doc.Text(manifestMargin, y, 14, true, title)
+	y += 18
+	doc.Text(manifestMargin, y, 9, false, fmt.Sprintf("Kapal: %s    Rute: %s - %s", manifest.ShipName, manifest.DepartureHarbor, manifest.ArrivalHarbor))
+	y += 12
+	doc.Text(manifestMargin, y, 9, false, fmt.Sprintf("Keberangkatan: %s    Kedatangan: %s    Dicetak: %s",
+		manifest.DepartureDatetime.Format("2 Jan 2006 15:04"),
+		manifest.ArrivalDatetime.Format("2 Jan 2006 15:04"),
+		manifest.GeneratedAt.Format("2 Jan 2006 15:04"),
+	))
+	y += 12
+	doc.Text(manifestMargin, y, 9, false, fmt.Sprintf("Penumpang: %d    Kendaraan: %d    Check-in: %d",
+		manifest.PassengerCount, manifest.VehicleCount, manifest.CheckedInCount))
+	y += 20
+
+	passengers := make([][]string, len(manifest.Passengers))
+	for i, p := range manifest.Passengers {
+		gender := ""
+		if p.Gender != nil {
+			gender = getGenderDisplay(*p.Gender)
+		}
+		passengers[i] = []string{
+			strconv.Itoa(p.No), p.Name, gender, strconv.Itoa(p.Age), deref(p.IDType) + " " + deref(p.IDNumber),
+			p.ClassName, p.FareCategory, deref(p.SeatNumber), p.TicketCode, checkedIn(p.CheckedIn),
+		}
+	}
+	y = manifestTable(doc, y, "Penumpang", []manifestColumn{
+		{"No", 28}, {"Nama", 150}, {"Jenis Kelamin", 70}, {"Umur", 35}, {"Identitas", 130},
+		{"Kelas", 90}, {"Tarif", 50}, {"Kursi", 45}, {"Kode Tiket", 120}, {"Check-in", 50},
+	}, passengers)
+
+	vehicles := make([][]string, len(manifest.Vehicles))
+	for i, v := range manifest.Vehicles {
+		length := ""
+		if v.VehicleLength != nil {
+			length = strconv.FormatFloat(*v.VehicleLength, 'f', -1, 64) + " m"
+		}
+		vehicles[i] = []string{
+			strconv.Itoa(v.No), deref(v.LicensePlate), deref(v.VehicleType), deref(v.VehicleBrand), length,
+			v.ClassName, v.DriverName, v.TicketCode, checkedIn(v.CheckedIn),
+		}
+	}
+	y = manifestTable(doc, y, "Kendaraan", []manifestColumn{
+		{"No", 28}, {"Plat Nomor", 80}, {"Jenis", 60}, {"Merek", 90}, {"Panjang", 50},
+		{"Kelas", 100}, {"Pengemudi", 150}, {"Kode Tiket", 150}, {"Check-in", 50},
+	}, vehicles)
+
+	totals := make([][]string, len(manifest.Totals))
+	for i, t := range manifest.Totals {
+		totals[i] = []string{t.ClassName, t.Type, strconv.Itoa(t.Tickets), strconv.Itoa(t.CheckedIn)}
+	}
+	y = manifestTable(doc, y, "Total per Kelas", []manifestColumn{
+		{"Kelas", 150}, {"Jenis", 80}, {"Tiket", 60}, {"Check-in", 60},
+	}, totals)
+
+	addons := make([][]string, len(manifest.Addons))
+	for i, a := range manifest.Addons {
+		addons[i] = []string{a.OrderID, a.PassengerName, a.SeatNumber, a.Name, strconv.Itoa(a.Quantity)}
+	}
+	manifestTable(doc, y, "Layanan Tambahan", []manifestColumn{
+		{"Order", 120}, {"Penumpang", 150}, {"Kursi", 50}, {"Layanan", 150}, {"Jumlah", 50},
+	}, addons)
+
+	return doc.Bytes()
+}
+
+// manifestTable draws a titled table from y and returns where the next one
+// starts, breaking onto new pages with the header repeated. Empty tables are
+// left out.
+func manifestTable(doc *pdf.Document, y float64, title string, columns []manifestColumn, rows [][]string) float64 {
+	if len(rows) == 0 {
+		return y
+	}
+	bottom := doc.Height() - manifestMargin
+	header := func() {
+		x := manifestMargin
+		for _, column := range columns {
+			doc.Text(x, y, manifestFontSize, true, column.title)
+			x += column.width
+		}
+		doc.Line(manifestMargin, y+4, x, y+4)
+		y += manifestRowSize
+	}
+
+	if y+3*manifestRowSize > bottom {
+		doc.AddPage()
+		y = manifestMargin + 12
+	}
+	doc.Text(manifestMargin, y, 10, true, title)
+	y += manifestRowSize
+	header()
+	for _, row := range rows {
+		if y > bottom {
+			doc.AddPage()
+			y = manifestMargin + 12
+			header()
+		}
+		x := manifestMargin
+		for i, column := range columns {
+			doc.Text(x, y, manifestFontSize, false, fitText(row[i], column.width))
+			x += column.width
+		}
+		y += manifestRowSize
+	}
+	return y + manifestRowSize
+}
+
+// fitText cuts s to roughly what fits in width points of manifest text.
+func fitText(s string, width float64) string {
+	limit := int(width/(manifestFontSize*0.5)) - 1
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "."
+}
+
+func checkedIn(ok bool) string {
+	if ok {
+		return "Ya"
+	}
+	return "-"
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
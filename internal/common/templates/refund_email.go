@@ -0,0 +1,161 @@
+package templates
+
+import (
+	"eticket-api/internal/domain"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RefundEmail confirms a cancelled booking and lists the refund of every
+// ticket with the cancellation policy rule that was applied.
+func RefundEmail(booking *domain.Booking, refunds []*domain.Refund) string {
+	var total float64
+	for _, refund := range refunds {
+		total += refund.Amount
+	}
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="id">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Pembatalan & Pengembalian Dana - Tiket Hebat</title>
+    <style>
+        body {
+            font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif;
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            padding: 20px;
+            line-height: 1.6;
+        }
+
+        .email-container {
+            max-width: 650px;
+            margin: 0 auto;
+            background: #ffffff;
+            border-radius: 20px;
+            overflow: hidden;
+            box-shadow: 0 20px 40px rgba(0, 0, 0, 0.1);
+        }
+
+        .header {
+            background: linear-gradient(135deg, #fd7e14 0%%, #ffc107 100%%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+
+        .content {
+            padding: 40px 30px;
+        }
+
+        .refund-table {
+            width: 100%%;
+            border-collapse: collapse;
+            margin: 25px 0;
+            font-size: 14px;
+        }
+
+        .refund-table th, .refund-table td {
+            padding: 10px;
+            border-bottom: 1px solid #e9ecef;
+            text-align: left;
+        }
+
+        .refund-table th {
+            background: #f8f9fa;
+            color: #333;
+        }
+
+        .refund-total {
+            background: #fff8e1;
+            border-radius: 15px;
+            padding: 20px 25px;
+            border-left: 5px solid #fd7e14;
+            font-size: 18px;
+        }
+
+        .footer {
+            background: #343a40;
+            color: #adb5bd;
+            padding: 30px;
+            text-align: center;
+            font-size: 12px;
+        }
+    </style>
+</head>
+<body>
+    <div class="email-container">
+        <div class="header">
+            <h1>Pemesanan Dibatalkan</h1>
+            <p>Pengembalian dana Anda sedang diproses</p>
+        </div>
+
+        <div class="content">
+            <div style="font-size: 20px; color: #333; margin-bottom: 20px; font-weight: 600;">
+                Halo %s! 👋
+            </div>
+
+            <p style="margin-bottom: 25px; font-size: 16px; color: #555;">
+                Pemesanan <strong>%s</strong> telah dibatalkan. Besaran pengembalian dana dihitung per tiket sesuai kebijakan pembatalan yang berlaku.
+            </p>
+
+            <table class="refund-table">
+                <tr>
+                    <th>Tiket</th>
+                    <th>Penumpang</th>
+                    <th>Kebijakan</th>
+                    <th>Harga</th>
+                    <th>Refund</th>
+                </tr>
+                %s
+            </table>
+
+            <div class="refund-total">
+                <strong>Total Pengembalian Dana:</strong> Rp %s
+            </div>
+
+            <p style="margin-top: 25px; color: #555; font-size: 14px;">
+                Dana akan dikembalikan ke metode pembayaran yang Anda gunakan. Hubungi support@tikethebat.live jika ada pertanyaan.
+            </p>
+        </div>
+
+        <div class="footer">
+            &copy; %d Tiket Hebat. Semua hak dilindungi.
+        </div>
+    </div>
+</body>
+</html>`,
+		booking.CustomerName,
+		booking.OrderID,
+		buildRefundRowsHTML(refunds),
+		formatPrice(total),
+		time.Now().Year(),
+	)
+}
+
+// Helper function to build one table row per refunded ticket
+func buildRefundRowsHTML(refunds []*domain.Refund) string {
+	var html strings.Builder
+
+	for _, refund := range refunds {
+		html.WriteString(fmt.Sprintf(`
+                <tr>
+                    <td>%s</td>
+                    <td>%s</td>
+                    <td>%s (%.0f%%)</td>
+                    <td>Rp %s</td>
+                    <td>Rp %s</td>
+                </tr>`,
+			refund.Ticket.TicketCode,
+			refund.Ticket.PassengerName,
+			refund.PolicyName,
+			refund.RefundPercent,
+			formatPrice(refund.TicketPrice),
+			formatPrice(refund.Amount),
+		))
+	}
+
+	return html.String()
+}
@@ -0,0 +1,184 @@
+package templates
+
+import (
+	"eticket-api/internal/domain"
+	"fmt"
+	"html"
+	"time"
+)
+
+// ScheduleCancellationEmail tells a customer that the operator cancelled
+// their departure and what became of the booking: moved to rebookedTo, or
+// refunded in full as listed in refunds, or, for an unpaid booking, simply
+// cancelled.
+func ScheduleCancellationEmail(booking *domain.Booking, schedule *domain.Schedule, reason string, refunds []*domain.Refund, rebookedTo *domain.Schedule) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="id">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Keberangkatan Dibatalkan - Tiket Hebat</title>
+    <style>
+        body {
+            font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif;
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            padding: 20px;
+            line-height: 1.6;
+        }
+
+        .email-container {
+            max-width: 650px;
+            margin: 0 auto;
+            background: #ffffff;
+            border-radius: 20px;
+            overflow: hidden;
+            box-shadow: 0 20px 40px rgba(0, 0, 0, 0.1);
+        }
+
+        .header {
+            background: linear-gradient(135deg, #dc3545 0%%, #c82333 100%%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+
+        .content {
+            padding: 40px 30px;
+        }
+
+        .trip-summary {
+            background: #fdf2f2;
+            border-radius: 15px;
+            padding: 25px;
+            margin: 25px 0;
+            border-left: 5px solid #dc3545;
+        }
+
+        .outcome {
+            background: #f0f7ff;
+            border-radius: 15px;
+            padding: 20px 25px;
+            margin: 25px 0;
+            border-left: 5px solid #007bff;
+        }
+
+        .refund-table {
+            width: 100%%;
+            border-collapse: collapse;
+            margin: 25px 0;
+            font-size: 14px;
+        }
+
+        .refund-table th, .refund-table td {
+            padding: 10px;
+            border-bottom: 1px solid #e9ecef;
+            text-align: left;
+        }
+
+        .refund-table th {
+            background: #f8f9fa;
+            color: #333;
+        }
+
+        .footer {
+            background: #343a40;
+            color: #adb5bd;
+            padding: 30px;
+            text-align: center;
+            font-size: 12px;
+        }
+    </style>
+</head>
+<body>
+    <div class="email-container">
+        <div class="header">
+            <h1>Keberangkatan Dibatalkan</h1>
+            <p>Order ID %s</p>
+        </div>
+
+        <div class="content">
+            <div style="font-size: 20px; color: #333; margin-bottom: 20px; font-weight: 600;">
+                Halo %s! 👋
+            </div>
+
+            <p style="margin-bottom: 25px; font-size: 16px; color: #555;">
+                Mohon maaf, operator membatalkan keberangkatan berikut.
+            </p>
+
+            <div class="trip-summary">
+                <p><strong>Rute:</strong> %s - %s</p>
+                <p><strong>Kapal:</strong> %s</p>
+                <p><strong>Keberangkatan:</strong> %s</p>
+                <p><strong>Alasan:</strong> %s</p>
+            </div>
+
+            %s
+
+            <p style="margin-top: 25px; color: #555; font-size: 14px;">
+                Hubungi support@tikethebat.live jika ada pertanyaan.
+            </p>
+        </div>
+
+        <div class="footer">
+            &copy; %d Tiket Hebat. Semua hak dilindungi.
+        </div>
+    </div>
+</body>
+</html>`,
+		booking.OrderID,
+		booking.CustomerName,
+		schedule.DepartureHarbor.HarborName,
+		schedule.ArrivalHarbor.HarborName,
+		schedule.Ship.ShipName,
+		schedule.DepartureDatetime.Format("Monday, 02 Jan 2006 15:04"),
+		html.EscapeString(reason),
+		cancellationOutcome(refunds, rebookedTo),
+		time.Now().Year(),
+	)
+}
+
+func cancellationOutcome(refunds []*domain.Refund, rebookedTo *domain.Schedule) string {
+	if rebookedTo != nil {
+		return fmt.Sprintf(`<div class="outcome">
+                <p><strong>Tiket Anda sudah dipindahkan tanpa biaya tambahan ke:</strong></p>
+                <p><strong>Kapal:</strong> %s</p>
+                <p><strong>Keberangkatan:</strong> %s</p>
+                <p>E-tiket baru terlampir, kode QR pada e-tiket lama tidak berlaku lagi.</p>
+            </div>`,
+			rebookedTo.Ship.ShipName,
+			rebookedTo.DepartureDatetime.Format("Monday, 02 Jan 2006 15:04"),
+		)
+	}
+	if len(refunds) == 0 {
+		return `<div class="outcome">
+                <p>Pemesanan Anda belum dibayar dan telah dibatalkan. Tidak ada pembayaran yang perlu dilakukan.</p>
+            </div>`
+	}
+
+	var total float64
+	for _, refund := range refunds {
+		total += refund.Amount
+	}
+	return fmt.Sprintf(`<div class="outcome">
+                <p>Seluruh harga tiket dan biayanya dikembalikan ke metode pembayaran yang Anda gunakan.</p>
+            </div>
+
+            <table class="refund-table">
+                <tr>
+                    <th>Tiket</th>
+                    <th>Penumpang</th>
+                    <th>Kebijakan</th>
+                    <th>Harga</th>
+                    <th>Refund</th>
+                </tr>
+                %s
+            </table>
+
+            <div class="outcome">
+                <strong>Total Pengembalian Dana:</strong> Rp %s
+            </div>`,
+		buildRefundRowsHTML(refunds),
+		formatPrice(total),
+	)
+}
@@ -0,0 +1,161 @@
+package templates
+
+import (
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"fmt"
+	"html"
+	"time"
+)
+
+// DelayReasonText is how a delay reason code reads to a passenger.
+func DelayReasonText(reason string) string {
+	switch reason {
+	case enum.DelayWeather.String():
+		return "Cuaca buruk"
+	case enum.DelayTechnical.String():
+		return "Kendala teknis kapal"
+	case enum.DelayOperational.String():
+		return "Kendala operasional"
+	case enum.DelayPortCongestion.String():
+		return "Kepadatan pelabuhan"
+	default:
+		return "Alasan lain"
+	}
+}
+
+// ScheduleDelaySMS is the text message version of ScheduleDelayEmail, short
+// enough for a single SMS on most routes.
+func ScheduleDelaySMS(booking *domain.Booking, schedule *domain.Schedule, delay *domain.ScheduleDelay) string {
+	return fmt.Sprintf("Tiket Hebat: Keberangkatan %s-%s order %s diundur dari %s ke %s. Alasan: %s.",
+		schedule.DepartureHarbor.HarborName,
+		schedule.ArrivalHarbor.HarborName,
+		booking.OrderID,
+		delay.PreviousDeparture.Format("02 Jan 15:04"),
+		delay.NewDeparture.Format("02 Jan 15:04"),
+		DelayReasonText(delay.Reason),
+	)
+}
+
+// ScheduleDelayEmail tells a passenger their departure now leaves later,
+// with the previous and new times and why.
+func ScheduleDelayEmail(booking *domain.Booking, schedule *domain.Schedule, delay *domain.ScheduleDelay) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="id">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Keberangkatan Diundur - Tiket Hebat</title>
+    <style>
+        body {
+            font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif;
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            padding: 20px;
+            line-height: 1.6;
+        }
+
+        .email-container {
+            max-width: 650px;
+            margin: 0 auto;
+            background: #ffffff;
+            border-radius: 20px;
+            overflow: hidden;
+            box-shadow: 0 20px 40px rgba(0, 0, 0, 0.1);
+        }
+
+        .header {
+            background: linear-gradient(135deg, #fd7e14 0%%, #e8590c 100%%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+
+        .content {
+            padding: 40px 30px;
+        }
+
+        .trip-summary {
+            background: #fff8f0;
+            border-radius: 15px;
+            padding: 25px;
+            margin: 25px 0;
+            border-left: 5px solid #fd7e14;
+        }
+
+        .old-time {
+            color: #868e96;
+            text-decoration: line-through;
+        }
+
+        .new-time {
+            color: #e8590c;
+            font-weight: 600;
+        }
+
+        .footer {
+            background: #343a40;
+            color: #adb5bd;
+            padding: 30px;
+            text-align: center;
+            font-size: 12px;
+        }
+    </style>
+</head>
+<body>
+    <div class="email-container">
+        <div class="header">
+            <h1>Keberangkatan Diundur</h1>
+            <p>Order ID %s</p>
+        </div>
+
+        <div class="content">
+            <div style="font-size: 20px; color: #333; margin-bottom: 20px; font-weight: 600;">
+                Halo %s! 👋
+            </div>
+
+            <p style="margin-bottom: 25px; font-size: 16px; color: #555;">
+                Mohon maaf, keberangkatan Anda diundur. Tiket Anda tetap berlaku untuk jadwal baru.
+            </p>
+
+            <div class="trip-summary">
+                <p><strong>Rute:</strong> %s - %s</p>
+                <p><strong>Kapal:</strong> %s</p>
+                <p><strong>Jadwal sebelumnya:</strong> <span class="old-time">%s</span></p>
+                <p><strong>Jadwal baru:</strong> <span class="new-time">%s</span></p>
+                <p><strong>Perkiraan tiba:</strong> %s</p>
+                <p><strong>Alasan:</strong> %s</p>
+                %s
+            </div>
+
+            <p style="margin-top: 25px; color: #555; font-size: 14px;">
+                Hubungi support@tikethebat.live jika ada pertanyaan.
+            </p>
+        </div>
+
+        <div class="footer">
+            &copy; %d Tiket Hebat. Semua hak dilindungi.
+        </div>
+    </div>
+</body>
+</html>`,
+		booking.OrderID,
+		booking.CustomerName,
+		schedule.DepartureHarbor.HarborName,
+		schedule.ArrivalHarbor.HarborName,
+		schedule.Ship.ShipName,
+		delay.PreviousDeparture.Format("Monday, 02 Jan 2006 15:04"),
+		delay.NewDeparture.Format("Monday, 02 Jan 2006 15:04"),
+		delay.NewArrival.Format("Monday, 02 Jan 2006 15:04"),
+		DelayReasonText(delay.Reason),
+		delayNote(delay.Note),
+		time.Now().Year(),
+	)
+}
+
+func delayNote(note string) string {
+	if note == "" {
+		return ""
+	}
+	return fmt.Sprintf(`<p><strong>Keterangan:</strong> %s</p>`, html.EscapeString(note))
+}
@@ -0,0 +1,123 @@
+package templates
+
+import (
+	"eticket-api/internal/domain"
+	"fmt"
+	"time"
+)
+
+// WaitlistOfferEmail tells a waitlisted customer their seats are held and links
+// to the claim session created for them.
+func WaitlistOfferEmail(entry *domain.Waitlist, schedule *domain.Schedule, class *domain.Class, link string, expiresAt time.Time) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="id">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Kursi Tersedia - Tiket Hebat</title>
+    <style>
+        body {
+            font-family: 'Segoe UI', Tahoma, Geneva, Verdana, sans-serif;
+            background: linear-gradient(135deg, #667eea 0%%, #764ba2 100%%);
+            padding: 20px;
+            line-height: 1.6;
+        }
+
+        .email-container {
+            max-width: 650px;
+            margin: 0 auto;
+            background: #ffffff;
+            border-radius: 20px;
+            overflow: hidden;
+            box-shadow: 0 20px 40px rgba(0, 0, 0, 0.1);
+        }
+
+        .header {
+            background: linear-gradient(135deg, #28a745 0%%, #20c997 100%%);
+            color: white;
+            padding: 40px 30px;
+            text-align: center;
+        }
+
+        .content {
+            padding: 40px 30px;
+        }
+
+        .offer-summary {
+            background: #f0fff4;
+            border-radius: 15px;
+            padding: 25px;
+            margin: 25px 0;
+            border-left: 5px solid #28a745;
+        }
+
+        .claim-button {
+            background: linear-gradient(135deg, #007bff 0%%, #0056b3 100%%);
+            color: white;
+            padding: 15px 30px;
+            text-decoration: none;
+            border-radius: 25px;
+            font-weight: bold;
+            display: inline-block;
+        }
+
+        .footer {
+            background: #343a40;
+            color: #adb5bd;
+            padding: 30px;
+            text-align: center;
+            font-size: 12px;
+        }
+    </style>
+</head>
+<body>
+    <div class="email-container">
+        <div class="header">
+            <h1>Kursi Anda Tersedia!</h1>
+            <p>Tiket yang Anda tunggu kini bisa dipesan</p>
+        </div>
+
+        <div class="content">
+            <div style="font-size: 20px; color: #333; margin-bottom: 20px; font-weight: 600;">
+                Halo %s! 👋
+            </div>
+
+            <p style="margin-bottom: 25px; font-size: 16px; color: #555;">
+                Ada kursi yang kembali tersedia dan sudah kami tahan untuk Anda. Selesaikan pemesanan sebelum batas waktu berakhir.
+            </p>
+
+            <div class="offer-summary">
+                <p><strong>Rute:</strong> %s - %s</p>
+                <p><strong>Keberangkatan:</strong> %s</p>
+                <p><strong>Kelas:</strong> %s</p>
+                <p><strong>Jumlah:</strong> %d tiket</p>
+                <p><strong>Berlaku hingga:</strong> %s</p>
+            </div>
+
+            <div style="text-align: center; margin: 30px 0;">
+                <a href="%s" class="claim-button">Lanjutkan Pemesanan</a>
+            </div>
+
+            <p style="color: #555; font-size: 14px;">
+                Jika batas waktu terlewat, kursi akan ditawarkan ke pelanggan berikutnya dalam daftar tunggu.
+            </p>
+        </div>
+
+        <div class="footer">
+            &copy; %d Tiket Hebat. Semua hak dilindungi.
+        </div>
+    </div>
+</body>
+</html>`,
+		entry.CustomerName,
+		schedule.DepartureHarbor.HarborName,
+		schedule.ArrivalHarbor.HarborName,
+		schedule.DepartureDatetime.Format("02 Jan 2006 15:04"),
+		class.ClassName,
+		entry.Quantity,
+		expiresAt.Format("02 Jan 2006 15:04"),
+		link,
+		time.Now().Year(),
+	)
+}
@@ -22,6 +22,43 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
+// BookingClaims lets a customer manage one booking without a user account
+type BookingClaims struct {
+	BookingID uint   `json:"booking_id"`
+	OrderID   string `json:"order_id"`
+	jwt.RegisteredClaims
+}
+
+// CustomerClaims identifies a signed in customer account
+type CustomerClaims struct {
+	CustomerID uint   `json:"customer_id"`
+	Email      string `json:"email"`
+	jwt.RegisteredClaims
+}
+
+// TicketClaims is what the QR code on a ticket carries, signed so gates can
+// trust it without a lookup
+type TicketClaims struct {
+	TicketCode string `json:"ticket_code"`
+	ScheduleID uint   `json:"schedule_id"`
+	Passenger  string `json:"passenger"`
+	jwt.RegisteredClaims
+}
+
+// SnapshotClaims identifies the offline ticket list a gate device downloaded,
+// so its uploads can be checked against what it knew
+type SnapshotClaims struct {
+	ScheduleID uint `json:"schedule_id"`
+	jwt.RegisteredClaims
+}
+
+const (
+	bookingAudience  = "booking"
+	customerAudience = "customer"
+	ticketAudience   = "ticket"
+	snapshotAudience = "snapshot"
+)
+
 // Constructor (call this in Run() or main)
 func NewJWT(cfg *config.Config) *JWT {
 	return &JWT{
@@ -95,5 +132,198 @@ func (tm *JWT) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, errors.New("token expired")
 	}
 
+	// Booking tokens carry no user and must not pass as a staff session
+	if claims.User == nil {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+func (tm *JWT) GenerateBookingToken(booking *domain.Booking) (string, error) {
+	expirationTime := time.Now().Add(constant.BookingTokenExpiry)
+
+	claims := &BookingClaims{
+		BookingID: booking.ID,
+		OrderID:   booking.OrderID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    "eticket-api",
+			Subject:   booking.OrderID,
+			Audience:  jwt.ClaimStrings{bookingAudience},
+			ID:        uuid.New().String(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(tm.secretKey)
+}
+
+func (tm *JWT) ValidateBookingToken(tokenString string) (*BookingClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &BookingClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return tm.secretKey, nil
+	}, jwt.WithAudience(bookingAudience))
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrSignatureInvalid) {
+			return nil, errors.New("invalid token signature")
+		}
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	claims, ok := token.Claims.(*BookingClaims)
+	if !ok || claims.BookingID == 0 {
+		return nil, errors.New("failed to extract claims")
+	}
+
+	return claims, nil
+}
+
+func (tm *JWT) GenerateCustomerToken(customer *domain.Customer) (string, error) {
+	expirationTime := time.Now().Add(constant.CustomerTokenExpiry)
+
+	claims := &CustomerClaims{
+		CustomerID: customer.ID,
+		Email:      customer.Email,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    "eticket-api",
+			Subject:   fmt.Sprintf("%d", customer.ID),
+			Audience:  jwt.ClaimStrings{customerAudience},
+			ID:        uuid.New().String(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(tm.secretKey)
+}
+
+func (tm *JWT) ValidateCustomerToken(tokenString string) (*CustomerClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &CustomerClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return tm.secretKey, nil
+	}, jwt.WithAudience(customerAudience))
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrSignatureInvalid) {
+			return nil, errors.New("invalid token signature")
+		}
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	claims, ok := token.Claims.(*CustomerClaims)
+	if !ok || claims.CustomerID == 0 {
+		return nil, errors.New("failed to extract claims")
+	}
+
+	return claims, nil
+}
+
+// GenerateTicketToken signs the QR payload of a ticket. It is kept short to
+// keep the QR code small, so it has no ID and no issue time.
+func (tm *JWT) GenerateTicketToken(ticket *domain.Ticket, expiresAt time.Time) (string, error) {
+	claims := &TicketClaims{
+		TicketCode: ticket.TicketCode,
+		ScheduleID: ticket.ScheduleID,
+		Passenger:  ticket.PassengerName,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			Audience:  jwt.ClaimStrings{ticketAudience},
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(tm.secretKey)
+}
+
+func (tm *JWT) ValidateTicketToken(tokenString string) (*TicketClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &TicketClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return tm.secretKey, nil
+	}, jwt.WithAudience(ticketAudience), jwt.WithExpirationRequired())
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrSignatureInvalid) {
+			return nil, errors.New("invalid token signature")
+		}
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, errors.New("token expired")
+		}
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	claims, ok := token.Claims.(*TicketClaims)
+	if !ok || claims.TicketCode == "" {
+		return nil, errors.New("failed to extract claims")
+	}
+
+	return claims, nil
+}
+
+// GenerateSnapshotToken signs the departure of an offline ticket list, with
+// the time it was taken.
+func (tm *JWT) GenerateSnapshotToken(scheduleID uint, expiresAt time.Time) (string, error) {
+	claims := &SnapshotClaims{
+		ScheduleID: scheduleID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Audience:  jwt.ClaimStrings{snapshotAudience},
+			ID:        uuid.New().String(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(tm.secretKey)
+}
+
+func (tm *JWT) ValidateSnapshotToken(tokenString string) (*SnapshotClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &SnapshotClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return tm.secretKey, nil
+	}, jwt.WithAudience(snapshotAudience), jwt.WithIssuedAt())
+
+	if err != nil {
+		if errors.Is(err, jwt.ErrSignatureInvalid) {
+			return nil, errors.New("invalid token signature")
+		}
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, errors.New("token expired")
+		}
+		return nil, fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	claims, ok := token.Claims.(*SnapshotClaims)
+	if !ok || claims.ScheduleID == 0 || claims.IssuedAt == nil {
+		return nil, errors.New("failed to extract claims")
+	}
+
 	return claims, nil
 }
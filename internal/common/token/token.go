@@ -1,9 +1,20 @@
 package token
 
-import "eticket-api/internal/domain"
+import (
+	"eticket-api/internal/domain"
+	"time"
+)
 
 type TokenUtil interface {
 	GenerateAccessToken(user *domain.User) (string, error)
 	GenerateRefreshToken(user *domain.User) (string, error)
 	ValidateToken(token string) (*Claims, error)
+	GenerateBookingToken(booking *domain.Booking) (string, error)
+	ValidateBookingToken(token string) (*BookingClaims, error)
+	GenerateCustomerToken(customer *domain.Customer) (string, error)
+	ValidateCustomerToken(token string) (*CustomerClaims, error)
+	GenerateTicketToken(ticket *domain.Ticket, expiresAt time.Time) (string, error)
+	ValidateTicketToken(token string) (*TicketClaims, error)
+	GenerateSnapshotToken(scheduleID uint, expiresAt time.Time) (string, error)
+	ValidateSnapshotToken(token string) (*SnapshotClaims, error)
 }
@@ -2,8 +2,10 @@ package utils
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"math/big"
 
 	"golang.org/x/crypto/bcrypt"
 )
@@ -29,3 +31,23 @@ func GenerateSecureToken(n int) (string, error) {
 	}
 	return hex.EncodeToString(bytes), nil
 }
+
+// GenerateNumericCode returns a secure random code of n digits.
+func GenerateNumericCode(n int) (string, error) {
+	code := make([]byte, n)
+	for i := range code {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + digit.Int64())
+	}
+	return string(code), nil
+}
+
+// HashToken returns the SHA-256 hex digest of a short-lived secret, for
+// storing codes that only need to be compared.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -25,6 +25,7 @@ func Authenticate(token_util token.TokenUtil) gin.HandlerFunc {
 			return
 		}
 
+		c.Set("user_id", claims.User.ID)
 		c.Set("rolename", claims.User.Role.RoleName)
 		c.Set("token", tokenStr)
 		c.Next()
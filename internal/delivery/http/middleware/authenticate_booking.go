@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"errors"
+	"eticket-api/internal/common/token"
+	"eticket-api/internal/delivery/http/response"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware method to authenticate a customer's booking token sent as a
+// bearer token
+func AuthenticateBooking(token_util token.TokenUtil) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		tokenStr, found := strings.CutPrefix(header, "Bearer ")
+		if !found || tokenStr == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.NewErrorResponse("Missing token", errors.New("missing bearer token").Error()))
+			return
+		}
+
+		claims, err := token_util.ValidateBookingToken(tokenStr)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.NewErrorResponse("Invalid or expired token", err.Error()))
+			return
+		}
+
+		c.Set("booking_id", claims.BookingID)
+		c.Set("order_id", claims.OrderID)
+		c.Next()
+	}
+}
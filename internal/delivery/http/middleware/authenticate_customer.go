@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"errors"
+	"eticket-api/internal/common/token"
+	"eticket-api/internal/delivery/http/response"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware method to authenticate a customer account token sent as a
+// bearer token
+func AuthenticateCustomer(token_util token.TokenUtil) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		tokenStr, found := strings.CutPrefix(header, "Bearer ")
+		if !found || tokenStr == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.NewErrorResponse("Missing token", errors.New("missing bearer token").Error()))
+			return
+		}
+
+		claims, err := token_util.ValidateCustomerToken(tokenStr)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response.NewErrorResponse("Invalid or expired token", err.Error()))
+			return
+		}
+
+		c.Set("customer_id", claims.CustomerID)
+		c.Next()
+	}
+}
+
+// Middleware method to recognise a signed in customer on routes that guests
+// may use too. Requests without a valid customer token pass as guests.
+func IdentifyCustomer(token_util token.TokenUtil) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		tokenStr, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if found && tokenStr != "" {
+			if claims, err := token_util.ValidateCustomerToken(tokenStr); err == nil {
+				c.Set("customer_id", claims.CustomerID)
+			}
+		}
+		c.Next()
+	}
+}
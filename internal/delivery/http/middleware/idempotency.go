@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/token"
+	"eticket-api/internal/delivery/http/response"
+	"eticket-api/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxIdempotencyKeyLength = 255
+
+// idempotencyRecorder keeps a copy of the response body while it is written
+type idempotencyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency makes POST requests sent with an Idempotency-Key header safe to
+// retry. Keys belong to one caller and one method and path, guests counting
+// as one caller: the first request under a key runs and its response is
+// stored, a repeat of it gets the stored response back and the same key with a
+// different body is rejected. The same key from another caller or on another
+// path is a request of its own.
+func Idempotency(idempotency *usecase.IdempotencyUsecase, token_util token.TokenUtil, log logger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		if key == "" || c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			c.AbortWithStatusJSON(http.StatusBadRequest, response.NewErrorResponse("Idempotency-Key is too long", nil))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, response.NewErrorResponse("Failed to read request body", err.Error()))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		scope := c.Request.Method + " " + c.Request.URL.Path + " " + idempotencyCaller(c, token_util)
+		sum := sha256.Sum256(append([]byte(scope+"\n"), body...))
+		// A client that timed out is the reason for the key, so its response is
+		// stored even when the client is gone
+		ctx := context.WithoutCancel(c.Request.Context())
+
+		record, replay, err := idempotency.Begin(ctx, key, scope, hex.EncodeToString(sum[:]))
+		if err != nil {
+			if errors.Is(err, errs.ErrKeyReused) {
+				c.AbortWithStatusJSON(http.StatusUnprocessableEntity, response.NewErrorResponse("Idempotency-Key was already used for a different request", nil))
+				return
+			}
+			if errors.Is(err, errs.ErrInProgress) {
+				c.AbortWithStatusJSON(http.StatusConflict, response.NewErrorResponse("A request with this Idempotency-Key is still being processed", nil))
+				return
+			}
+			log.WithError(err).Error("failed to check idempotency key")
+			c.AbortWithStatusJSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to check idempotency key", nil))
+			return
+		}
+		if replay {
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(record.ResponseStatus, "application/json; charset=utf-8", record.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		recorder := &idempotencyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		status := http.StatusInternalServerError
+		defer func() {
+			// A panic leaves status at 500, which releases the key for a retry
+			if err := idempotency.Complete(ctx, record, status, recorder.body.Bytes()); err != nil {
+				log.WithError(err).Error("failed to store idempotent response")
+			}
+		}()
+
+		c.Next()
+		status = recorder.Status()
+	}
+}
+
+// idempotencyCaller names who sent a request: the signed in staff user,
+// customer or booking. Guests share one name, so their keys are scoped by the
+// method and path alone; their address changes between retries on mobile
+// networks and behind proxies. It runs before the route's own
+// authentication, so a token that does not validate counts as a guest here
+// and is refused later.
+func idempotencyCaller(c *gin.Context, token_util token.TokenUtil) string {
+	if tokenStr, err := c.Cookie("access_token"); err == nil {
+		if claims, err := token_util.ValidateToken(tokenStr); err == nil {
+			return fmt.Sprintf("user:%d", claims.User.ID)
+		}
+	}
+	if tokenStr, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); found && tokenStr != "" {
+		if claims, err := token_util.ValidateCustomerToken(tokenStr); err == nil {
+			return fmt.Sprintf("customer:%d", claims.CustomerID)
+		}
+		if claims, err := token_util.ValidateBookingToken(tokenStr); err == nil {
+			return fmt.Sprintf("booking:%d", claims.BookingID)
+		}
+	}
+	return "guest"
+}
@@ -23,21 +23,40 @@ func (r *Router) RegisterMetrics(group *gin.RouterGroup) {
 func (r *Router) RegisterV1(group *gin.RouterGroup) {
 	group.Use(middleware.Logger(r.Logger))
 	group.Use(middleware.Recovery(r.Logger))
+	group.Use(middleware.Idempotency(r.Idempotency, r.TokenUtil, r.Logger))
+	group.Use(middleware.IdentifyCustomer(r.TokenUtil))
 	protected := group.Group("")
 	protected.Use(middleware.Authenticate(r.TokenUtil))
+	customer := group.Group("")
+	customer.Use(middleware.AuthenticateBooking(r.TokenUtil))
+	account := group.Group("")
+	account.Use(middleware.AuthenticateCustomer(r.TokenUtil))
 
 	v1.NewQuotaController(group, protected, r.Logger, r.Validator, r.Quota)
+	v1.NewAddonController(group, protected, r.Logger, r.Validator, r.Addon)
 	v1.NewAuthController(group, protected, r.Logger, r.Validator, r.Auth)
+	v1.NewBoardingController(group, protected, r.Logger, r.Validator, r.Boarding)
 	v1.NewBookingController(group, protected, r.Logger, r.Validator, r.Booking)
+	v1.NewCancellationPolicyController(group, protected, r.Logger, r.Validator, r.Cancellation)
 	v1.NewClassController(group, protected, r.Logger, r.Validator, r.Class)
 	v1.NewClaimSessionController(group, protected, r.Logger, r.Validator, r.ClaimSession)
+	v1.NewCustomerController(group, account, r.Logger, r.Validator, r.Customer)
+	v1.NewFeeComponentController(group, protected, r.Logger, r.Validator, r.Fee)
 	v1.NewHarborController(group, protected, r.Logger, r.Validator, r.Harbor)
+	v1.NewManageBookingController(group, customer, r.Logger, r.Validator, r.Manage)
+	v1.NewManifestController(group, protected, r.Logger, r.Validator, r.Manifest)
 	v1.NewPaymentController(group, protected, r.Logger, r.Validator, r.Payment)
+	v1.NewPromotionController(group, protected, r.Logger, r.Validator, r.Promotion)
 	v1.NewRoleController(group, protected, r.Logger, r.Validator, r.Role)
 	v1.NewScheduleController(group, protected, r.Logger, r.Validator, r.Schedule)
+	v1.NewScheduleCancellationController(group, protected, r.Logger, r.Validator, r.ScheduleCancellation)
+	v1.NewScheduleDelayController(group, protected, r.Logger, r.Validator, r.ScheduleDelay)
+	v1.NewSeatLayoutController(group, protected, r.Logger, r.Validator, r.SeatLayout)
 	v1.NewShipController(group, protected, r.Logger, r.Validator, r.Ship)
 	v1.NewTicketController(group, protected, r.Logger, r.Validator, r.Ticket)
 	v1.NewUserController(group, protected, r.Logger, r.Validator, r.User)
+	v1.NewVehicleCategoryController(group, protected, r.Logger, r.Validator, r.Vehicle)
+	v1.NewWaitlistController(group, protected, r.Logger, r.Validator, r.Waitlist)
 }
 
 // Register untuk /v2 (future)
@@ -12,18 +12,32 @@ type Router struct {
 	Logger    logger.Logger
 	Validator validator.Validator
 
-	Quota        *usecase.QuotaUsecase
-	Auth         *usecase.AuthUsecase
-	Booking      *usecase.BookingUsecase
-	Class        *usecase.ClassUsecase
-	Harbor       *usecase.HarborUsecase
-	Role         *usecase.RoleUsecase
-	Schedule     *usecase.ScheduleUsecase
-	Ship         *usecase.ShipUsecase
-	Ticket       *usecase.TicketUsecase
-	User         *usecase.UserUsecase
-	Payment      *usecase.PaymentUsecase
-	ClaimSession *usecase.ClaimSessionUsecase
+	Quota                *usecase.QuotaUsecase
+	Auth                 *usecase.AuthUsecase
+	Booking              *usecase.BookingUsecase
+	Class                *usecase.ClassUsecase
+	Harbor               *usecase.HarborUsecase
+	Role                 *usecase.RoleUsecase
+	Schedule             *usecase.ScheduleUsecase
+	Ship                 *usecase.ShipUsecase
+	Ticket               *usecase.TicketUsecase
+	User                 *usecase.UserUsecase
+	Payment              *usecase.PaymentUsecase
+	ClaimSession         *usecase.ClaimSessionUsecase
+	SeatLayout           *usecase.SeatLayoutUsecase
+	Waitlist             *usecase.WaitlistUsecase
+	Cancellation         *usecase.CancellationPolicyUsecase
+	Idempotency          *usecase.IdempotencyUsecase
+	Manage               *usecase.ManageBookingUsecase
+	Customer             *usecase.CustomerUsecase
+	Promotion            *usecase.PromotionUsecase
+	Vehicle              *usecase.VehicleCategoryUsecase
+	Fee                  *usecase.FeeComponentUsecase
+	Addon                *usecase.AddonUsecase
+	Manifest             *usecase.ManifestUsecase
+	Boarding             *usecase.BoardingUsecase
+	ScheduleCancellation *usecase.ScheduleCancellationUsecase
+	ScheduleDelay        *usecase.ScheduleDelayUsecase
 }
 
 // NewRouter is Wire-compatible constructor
@@ -43,22 +57,50 @@ func NewRouter(
 	user *usecase.UserUsecase,
 	payment *usecase.PaymentUsecase,
 	claimSession *usecase.ClaimSessionUsecase,
+	seatLayout *usecase.SeatLayoutUsecase,
+	waitlist *usecase.WaitlistUsecase,
+	cancellation *usecase.CancellationPolicyUsecase,
+	idempotency *usecase.IdempotencyUsecase,
+	manage *usecase.ManageBookingUsecase,
+	customer *usecase.CustomerUsecase,
+	promotion *usecase.PromotionUsecase,
+	vehicle *usecase.VehicleCategoryUsecase,
+	fee *usecase.FeeComponentUsecase,
+	addon *usecase.AddonUsecase,
+	manifest *usecase.ManifestUsecase,
+	boarding *usecase.BoardingUsecase,
+	scheduleCancellation *usecase.ScheduleCancellationUsecase,
+	scheduleDelay *usecase.ScheduleDelayUsecase,
 ) *Router {
 	return &Router{
-		TokenUtil:    tokenUtil,
-		Logger:       log,
-		Validator:    validate,
-		Quota:        quota,
-		Auth:         auth,
-		Booking:      booking,
-		Class:        class,
-		Harbor:       harbor,
-		Role:         role,
-		Schedule:     schedule,
-		Ship:         ship,
-		Ticket:       ticket,
-		User:         user,
-		Payment:      payment,
-		ClaimSession: claimSession,
+		TokenUtil:            tokenUtil,
+		Logger:               log,
+		Validator:            validate,
+		Quota:                quota,
+		Auth:                 auth,
+		Booking:              booking,
+		Class:                class,
+		Harbor:               harbor,
+		Role:                 role,
+		Schedule:             schedule,
+		Ship:                 ship,
+		Ticket:               ticket,
+		User:                 user,
+		Payment:              payment,
+		ClaimSession:         claimSession,
+		SeatLayout:           seatLayout,
+		Waitlist:             waitlist,
+		Cancellation:         cancellation,
+		Idempotency:          idempotency,
+		Manage:               manage,
+		Customer:             customer,
+		Promotion:            promotion,
+		Vehicle:              vehicle,
+		Fee:                  fee,
+		Addon:                addon,
+		Manifest:             manifest,
+		Boarding:             boarding,
+		ScheduleCancellation: scheduleCancellation,
+		ScheduleDelay:        scheduleDelay,
 	}
 }
@@ -0,0 +1,365 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/usecase"
+
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AddonController struct {
+	Validate     validator.Validator
+	Log          logger.Logger
+	AddonUsecase *usecase.AddonUsecase
+}
+
+func NewAddonController(
+	router *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	addon_usecase *usecase.AddonUsecase,
+
+) {
+	c := &AddonController{
+		Log:          log,
+		Validate:     validate,
+		AddonUsecase: addon_usecase,
+	}
+
+	protected.GET("/addons", c.GetAllAddons)
+	protected.GET("/addon/:id", c.GetAddonByID)
+	protected.POST("/addon/create", c.CreateAddon)
+	protected.PUT("/addon/update/:id", c.UpdateAddon)
+	protected.DELETE("/addon/:id", c.DeleteAddon)
+	protected.GET("/addons/schedule/:id", c.GetScheduleAddons)
+
+	router.GET("/addon-stocks/schedule/:id", c.GetAddonStocks)
+	protected.POST("/addon-stock/create", c.CreateAddonStock)
+	protected.PUT("/addon-stock/update/:id", c.UpdateAddonStock)
+	protected.DELETE("/addon-stock/:id", c.DeleteAddonStock)
+}
+
+func (c *AddonController) CreateAddon(ctx *gin.Context) {
+	request := new(requests.CreateAddonRequest)
+
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.AddonUsecase.CreateAddon(ctx, requests.AddonFromCreate(request)); err != nil {
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid add-on")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid add-on", err.Error()))
+			return
+		}
+		c.Log.WithError(err).Error("failed to create add-on")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create add-on", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.NewSuccessResponse(nil, "Add-on created successfully", nil))
+}
+
+func (c *AddonController) GetAllAddons(ctx *gin.Context) {
+
+	params := response.GetParams(ctx)
+	datas, total, err := c.AddonUsecase.ListAddons(ctx, params.Limit, params.Offset, params.Sort, params.Search)
+
+	if err != nil {
+		c.Log.WithError(err).Error("failed to retrieve add-ons")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve add-ons", err.Error()))
+		return
+	}
+
+	responses := make([]*requests.AddonResponse, len(datas))
+	for i, data := range datas {
+		responses[i] = requests.AddonToResponse(data)
+	}
+
+	ctx.JSON(http.StatusOK, response.NewMetaResponse(
+		responses,
+		"Add-ons retrieved successfully",
+		total,
+		params.Limit,
+		params.Page,
+		params.Sort,
+		params.Search,
+		params.Path,
+	))
+}
+
+func (c *AddonController) GetAddonByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse add-on ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid add-on ID", err.Error()))
+		return
+	}
+
+	data, err := c.AddonUsecase.GetAddonByID(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("add-on not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Add-on not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve add-on")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve add-on", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.AddonToResponse(data), "Add-on retrieved successfully", nil))
+}
+
+func (c *AddonController) UpdateAddon(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse add-on ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid or missing add-on ID", nil))
+		return
+	}
+
+	request := new(requests.UpdateAddonRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	request.ID = uint(id)
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.AddonUsecase.UpdateAddon(ctx, requests.AddonFromUpdate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).WithField("id", id).Warn("add-on not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Add-on not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("id", id).Warn("invalid add-on")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid add-on", err.Error()))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to update add-on")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to update add-on", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Add-on updated successfully", nil))
+}
+
+func (c *AddonController) DeleteAddon(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse add-on ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid add-on ID", err.Error()))
+		return
+	}
+
+	if err := c.AddonUsecase.DeleteAddon(ctx, uint(id)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("add-on not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Add-on not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to delete add-on")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to delete add-on", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Add-on deleted successfully", nil))
+}
+
+func (c *AddonController) GetScheduleAddons(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	datas, err := c.AddonUsecase.ListScheduleAddons(ctx, uint(id))
+	if err != nil {
+		c.Log.WithError(err).WithField("schedule_id", id).Error("failed to retrieve schedule add-ons")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve schedule add-ons", err.Error()))
+		return
+	}
+
+	responses := make([]*requests.ScheduleAddonResponse, len(datas))
+	for i, data := range datas {
+		responses[i] = requests.ScheduleAddonToResponse(data)
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(responses, "Schedule add-ons retrieved successfully", nil))
+}
+
+func (c *AddonController) GetAddonStocks(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	datas, err := c.AddonUsecase.ListAddonStocks(ctx, uint(id))
+	if err != nil {
+		c.Log.WithError(err).WithField("schedule_id", id).Error("failed to retrieve add-on stock")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve add-on stock", err.Error()))
+		return
+	}
+
+	responses := make([]*requests.AddonStockResponse, 0, len(datas))
+	for _, data := range datas {
+		if !data.Addon.IsActive {
+			continue
+		}
+		responses = append(responses, requests.AddonStockToResponse(data))
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(responses, "Add-on stock retrieved successfully", nil))
+}
+
+func (c *AddonController) CreateAddonStock(ctx *gin.Context) {
+	request := new(requests.CreateAddonStockRequest)
+
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.AddonUsecase.CreateAddonStock(ctx, requests.AddonStockFromCreate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).Warn("schedule or add-on not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Schedule or add-on not found", nil))
+			return
+		}
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid add-on stock")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid add-on stock", err.Error()))
+			return
+		}
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Warn("add-on already on sale on this schedule")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Add-on already on sale on this schedule", nil))
+			return
+		}
+		c.Log.WithError(err).Error("failed to create add-on stock")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create add-on stock", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.NewSuccessResponse(nil, "Add-on stock created successfully", nil))
+}
+
+func (c *AddonController) UpdateAddonStock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse add-on stock ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid or missing add-on stock ID", nil))
+		return
+	}
+
+	request := new(requests.UpdateAddonStockRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	request.ID = uint(id)
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.AddonUsecase.UpdateAddonStock(ctx, requests.AddonStockFromUpdate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).WithField("id", id).Warn("add-on stock not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Add-on stock not found", nil))
+			return
+		}
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("id", id).Warn("invalid add-on stock")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid add-on stock", err.Error()))
+			return
+		}
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("id", id).Warn("capacity below units sold or held")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Capacity is below the units already sold or held", nil))
+			return
+		}
+		c.Log.WithError(err).WithField("id", id).Error("failed to update add-on stock")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to update add-on stock", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Add-on stock updated successfully", nil))
+}
+
+func (c *AddonController) DeleteAddonStock(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse add-on stock ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid add-on stock ID", err.Error()))
+		return
+	}
+
+	if err := c.AddonUsecase.DeleteAddonStock(ctx, uint(id)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("add-on stock not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Add-on stock not found", nil))
+			return
+		}
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("id", id).Warn("add-on already sold or held")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Add-on already sold or held on this schedule", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to delete add-on stock")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to delete add-on stock", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Add-on stock deleted successfully", nil))
+}
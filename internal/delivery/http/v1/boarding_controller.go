@@ -0,0 +1,290 @@
+package v1
+
+import (
+	"errors"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"eticket-api/internal/usecase"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BoardingController struct {
+	Validate        validator.Validator
+	Log             logger.Logger
+	BoardingUsecase *usecase.BoardingUsecase
+}
+
+func NewBoardingController(
+	router *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	boarding_usecase *usecase.BoardingUsecase,
+) {
+	c := &BoardingController{
+		Log:             log,
+		Validate:        validate,
+		BoardingUsecase: boarding_usecase,
+	}
+
+	protected.POST("/boarding/ticket/:id/check-in", c.Move(enum.BoardingCheckedIn.String()))
+	protected.POST("/boarding/ticket/:id/board", c.Move(enum.BoardingBoarded.String()))
+	protected.POST("/boarding/ticket/:id/no-show", c.Move(enum.BoardingNoShow.String()))
+	protected.POST("/boarding/ticket/:id/cancel", c.Move(enum.BoardingCancelled.String()))
+	protected.POST("/boarding/ticket/:id/undo", c.Undo)
+	protected.GET("/boarding/ticket/:id/history", c.GetHistory)
+	protected.GET("/boarding/schedule/:id", c.GetCounts)
+	protected.POST("/boarding/schedule/:id/close", c.CloseGate)
+	protected.GET("/boarding/schedule/:id/snapshot", c.GetSnapshot)
+	protected.POST("/boarding/schedule/:id/sync", c.Sync)
+}
+
+// Move returns the handler that moves a ticket to the boarding status to.
+func (c *BoardingController) Move(to string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id, err := strconv.Atoi(ctx.Param("id"))
+		if err != nil {
+			c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse ticket ID")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid ticket ID", err.Error()))
+			return
+		}
+
+		request := new(requests.BoardingRequest)
+		if err := bindBoardingRequest(ctx, c.Validate, request); err != nil {
+			c.Log.WithError(err).Error("failed to bind JSON request body")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+			return
+		}
+
+		data, err := c.BoardingUsecase.Move(ctx, uint(id), to, boardingActor(ctx, request))
+		if err != nil {
+			c.writeBoardingError(ctx, err, id)
+			return
+		}
+
+		ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.BoardingTicketToResponse(data), "Ticket moved to "+to, nil))
+	}
+}
+
+func (c *BoardingController) Undo(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse ticket ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid ticket ID", err.Error()))
+		return
+	}
+
+	request := new(requests.BoardingRequest)
+	if err := bindBoardingRequest(ctx, c.Validate, request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	data, err := c.BoardingUsecase.Undo(ctx, uint(id), boardingActor(ctx, request))
+	if err != nil {
+		c.writeBoardingError(ctx, err, id)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.BoardingTicketToResponse(data), "Boarding move undone", nil))
+}
+
+func (c *BoardingController) GetHistory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse ticket ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid ticket ID", err.Error()))
+		return
+	}
+
+	datas, err := c.BoardingUsecase.History(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("ticket not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("ticket not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve boarding history")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve boarding history", err.Error()))
+		return
+	}
+
+	responses := make([]*requests.BoardingEventResponse, len(datas))
+	for i, data := range datas {
+		responses[i] = requests.BoardingEventToResponse(data)
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(responses, "Boarding history retrieved successfully", nil))
+}
+
+func (c *BoardingController) GetCounts(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	data, err := c.BoardingUsecase.Counts(ctx, uint(id))
+	if err != nil {
+		c.Log.WithError(err).WithField("schedule_id", id).Error("failed to count boarding")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to count boarding", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(data, "Boarding counts retrieved successfully", nil))
+}
+
+// CloseGate marks everyone on a departure who did not board as a no-show.
+func (c *BoardingController) CloseGate(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	request := new(requests.BoardingRequest)
+	if err := bindBoardingRequest(ctx, c.Validate, request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	marked, err := c.BoardingUsecase.CloseGate(ctx, uint(id), boardingActor(ctx, request))
+	if err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("schedule_id", id).Warn("boarding changed while closing the gate")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Boarding changed while closing the gate, try again", err.Error()))
+			return
+		}
+
+		c.Log.WithError(err).WithField("schedule_id", id).Error("failed to close gate")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to close gate", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(&requests.CloseGateResponse{ScheduleID: uint(id), NoShow: marked}, "Gate closed successfully", nil))
+}
+
+// GetSnapshot downloads the tickets of a departure for a gate device to scan
+// while it is offline.
+func (c *BoardingController) GetSnapshot(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	data, err := c.BoardingUsecase.Snapshot(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("schedule_id", id).Warn("schedule not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("schedule not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("schedule_id", id).Error("failed to get boarding snapshot")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to get boarding snapshot", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(data, "Boarding snapshot retrieved successfully", nil))
+}
+
+// Sync uploads the scans a gate device collected offline. Scans that could
+// not be recorded are listed in the response rather than failing the upload.
+func (c *BoardingController) Sync(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	request := new(requests.BoardingSyncRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	actor := boardingActor(ctx, &requests.BoardingRequest{Gate: request.Gate, HarborID: request.HarborID})
+	actor.Device = request.Device
+	data, err := c.BoardingUsecase.Sync(ctx, uint(id), request.SnapshotToken, requests.BoardingScansFromRequest(request), actor)
+	if err != nil {
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("schedule_id", id).Warn("boarding snapshot rejected")
+			ctx.JSON(http.StatusUnprocessableEntity, response.NewErrorResponse("Boarding snapshot rejected", err.Error()))
+			return
+		}
+
+		c.Log.WithError(err).WithField("schedule_id", id).Error("failed to sync boarding scans")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to sync boarding scans", err.Error()))
+		return
+	}
+
+	c.Log.WithField("schedule_id", id).WithField("device", request.Device).
+		WithField("conflicts", data.Conflicts).Info("boarding scans synced")
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(data, "Boarding scans synced", nil))
+}
+
+func (c *BoardingController) writeBoardingError(ctx *gin.Context, err error, id int) {
+	if errors.Is(err, errs.ErrNotFound) {
+		c.Log.WithField("id", id).Warn("ticket not found")
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse("ticket not found", nil))
+		return
+	}
+
+	if errors.Is(err, errs.ErrBadRequest) {
+		c.Log.WithError(err).WithField("id", id).Warn("ticket cannot board")
+		ctx.JSON(http.StatusUnprocessableEntity, response.NewErrorResponse("Ticket cannot board", err.Error()))
+		return
+	}
+
+	// The error names the previous scan of a double check-in
+	if errors.Is(err, errs.ErrConflict) {
+		c.Log.WithError(err).WithField("id", id).Warn("boarding move rejected")
+		ctx.JSON(http.StatusConflict, response.NewErrorResponse("Boarding move rejected", err.Error()))
+		return
+	}
+
+	c.Log.WithError(err).WithField("id", id).Error("failed to move ticket")
+	ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to move ticket", err.Error()))
+}
+
+// bindBoardingRequest reads the optional gate of a boarding move. An empty
+// body is a move without a gate.
+func bindBoardingRequest(ctx *gin.Context, validate validator.Validator, request *requests.BoardingRequest) error {
+	if err := ctx.ShouldBindJSON(request); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return validate.Struct(request)
+}
+
+// boardingActor is the signed in staff user making a boarding move at a gate.
+func boardingActor(ctx *gin.Context, request *requests.BoardingRequest) *usecase.BoardingActor {
+	return &usecase.BoardingActor{
+		UserID:   ctx.GetUint("user_id"),
+		Gate:     request.Gate,
+		HarborID: request.HarborID,
+	}
+}
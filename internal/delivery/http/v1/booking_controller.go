@@ -8,6 +8,7 @@ import (
 	"eticket-api/internal/delivery/http/response"
 	requests "eticket-api/internal/delivery/http/v1/request"
 	"eticket-api/internal/usecase"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -35,15 +36,17 @@ func NewBookingController(
 		BookingUsecase: booking_usecase,
 	}
 
-	router.GET("/bookings", c.GetAllBookings)
-	router.GET("/booking/:id", c.GetBookingByID)
 	router.GET("/booking/order/:id", c.GetBookingByOrderID)
 	router.GET("/booking/payment/callback", c.GetBookingByID)
 	router.POST("/booking/refund", c.RefundBooking)
 
+	protected.GET("/bookings", c.GetAllBookings)
+	protected.GET("/booking/:id", c.GetBookingByID)
 	protected.POST("/booking/create", c.CreateBooking)
 	protected.PUT("/booking/update/:id", c.UpdateBooking)
+	protected.POST("/booking/reschedule/:id", c.RescheduleBooking)
 	protected.DELETE("/booking/:id", c.DeleteBooking)
+	protected.GET("/booking/:id/document/:document", c.GetDocument)
 }
 
 func (c *BookingController) CreateBooking(ctx *gin.Context) {
@@ -129,6 +132,43 @@ func (c *BookingController) GetBookingByID(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.BookingToResponse(data), "Booking retrieved successfully", nil))
 }
 
+// GetDocument downloads the e-ticket or invoice PDF of a booking.
+func (c *BookingController) GetDocument(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("invalid booking ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid booking ID", err.Error()))
+		return
+	}
+
+	data, err := c.BookingUsecase.GetDocument(ctx, uint(id), ctx.Param("document"))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("booking not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("booking not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid document, use e-ticket or invoice", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("id", id).Warn("booking has no e-ticket yet")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("E-tickets are issued once the booking is paid", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to render booking document")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to render booking document", err.Error()))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, data.Filename))
+	ctx.Data(http.StatusOK, data.ContentType, data.Content)
+}
+
 // GetBookingByOrderID retrieves a booking by its Order ID
 func (c *BookingController) GetBookingByOrderID(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -153,7 +193,8 @@ func (c *BookingController) GetBookingByOrderID(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.BookingToResponse(data), "Booking retrieved successfully", nil))
+	// Customer and passenger data are served by the manage booking API
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.BookingToPublicResponse(data), "Booking retrieved successfully", nil))
 }
 
 func (c *BookingController) UpdateBooking(ctx *gin.Context) {
@@ -200,6 +241,72 @@ func (c *BookingController) UpdateBooking(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Booking updated successfully", nil))
 }
 
+func (c *BookingController) RescheduleBooking(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id == 0 {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("invalid or missing booking ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid or missing booking ID", nil))
+		return
+	}
+
+	request := new(requests.RescheduleBookingRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	request.ID = uint(id)
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	change, payment, err := c.BookingUsecase.RescheduleBooking(ctx, request.ID, request.FromScheduleID, request.ToScheduleID, request.PaymentMethod)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("booking or schedule not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("booking or schedule not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("id", id).Warn("invalid reschedule request")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid reschedule request", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrQuotaExceeded) || errors.Is(err, errs.ErrSeatUnavailable) {
+			c.Log.WithError(err).WithField("id", id).Warn("target schedule sold out")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("target schedule sold out", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("id", id).Warn("booking cannot be rescheduled")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("booking cannot be rescheduled", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrExternalTimeout) || errors.Is(err, errs.ErrExternalDown) {
+			c.Log.WithError(err).Warn("external system unavailable")
+			ctx.JSON(http.StatusServiceUnavailable, response.NewErrorResponse("external system unavailable", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to reschedule booking")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to reschedule booking", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.RescheduleBookingResponse{
+		Change:  requests.BookingChangeToResponse(change),
+		Payment: payment,
+	}, "Booking rescheduled successfully", nil))
+}
+
 func (c *BookingController) RefundBooking(ctx *gin.Context) {
 	request := new(requests.RefundBookingRequest)
 	if err := ctx.ShouldBindJSON(request); err != nil {
@@ -215,19 +322,26 @@ func (c *BookingController) RefundBooking(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.BookingUsecase.RefundBooking(ctx, request.OrderID, request.Email, request.IDNumber); err != nil {
+	refunds, err := c.BookingUsecase.RefundBooking(ctx, request.OrderID, request.Email, request.IDNumber)
+	if err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
 			c.Log.WithField("orderId", request.OrderID).Warn("booking not found")
 			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("booking not found", nil))
 			return
 		}
 
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("orderId", request.OrderID).Warn("booking not eligible for refund")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("booking is not eligible for refund", nil))
+			return
+		}
+
 		c.Log.WithError(err).WithField("orderId", request.OrderID).Error("failed to refund booking")
 		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to refund booking", err.Error()))
 		return
 	}
 
-	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Booking refunded successfully", nil))
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.RefundToResponse(request.OrderID, refunds), "Booking refunded successfully", nil))
 }
 
 func (c *BookingController) DeleteBooking(ctx *gin.Context) {
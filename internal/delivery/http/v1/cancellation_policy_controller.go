@@ -0,0 +1,210 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/usecase"
+
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CancellationPolicyController struct {
+	Validate                  validator.Validator
+	Log                       logger.Logger
+	CancellationPolicyUsecase *usecase.CancellationPolicyUsecase
+}
+
+func NewCancellationPolicyController(
+	router *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	cancellation_policy_usecase *usecase.CancellationPolicyUsecase,
+
+) {
+	c := &CancellationPolicyController{
+		Log:                       log,
+		Validate:                  validate,
+		CancellationPolicyUsecase: cancellation_policy_usecase,
+	}
+
+	protected.GET("/cancellation-policies", c.GetAllCancellationPolicies)
+	protected.GET("/cancellation-policy/:id", c.GetCancellationPolicyByID)
+	protected.POST("/cancellation-policy/create", c.CreateCancellationPolicy)
+	protected.PUT("/cancellation-policy/update/:id", c.UpdateCancellationPolicy)
+	protected.DELETE("/cancellation-policy/:id", c.DeleteCancellationPolicy)
+}
+
+func (c *CancellationPolicyController) CreateCancellationPolicy(ctx *gin.Context) {
+	request := new(requests.CreateCancellationPolicyRequest)
+
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.CancellationPolicyUsecase.CreateCancellationPolicy(ctx, requests.CancellationPolicyFromCreate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).Warn("harbor or class not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Harbor or class not found", nil))
+			return
+		}
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid cancellation policy")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid cancellation policy", err.Error()))
+			return
+		}
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Error("cancellation policy already exists")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("cancellation policy already exists", nil))
+			return
+		}
+		c.Log.WithError(err).Error("failed to create cancellation policy")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create cancellation policy", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.NewSuccessResponse(nil, "Cancellation policy created successfully", nil))
+}
+
+func (c *CancellationPolicyController) GetAllCancellationPolicies(ctx *gin.Context) {
+
+	params := response.GetParams(ctx)
+	datas, total, err := c.CancellationPolicyUsecase.ListCancellationPolicies(ctx, params.Limit, params.Offset, params.Sort, params.Search)
+
+	if err != nil {
+		c.Log.WithError(err).Error("failed to retrieve cancellation policies")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve cancellation policies", err.Error()))
+		return
+	}
+
+	responses := make([]*requests.CancellationPolicyResponse, len(datas))
+	for i, data := range datas {
+		responses[i] = requests.CancellationPolicyToResponse(data)
+	}
+
+	ctx.JSON(http.StatusOK, response.NewMetaResponse(
+		responses,
+		"Cancellation policies retrieved successfully",
+		total,
+		params.Limit,
+		params.Page,
+		params.Sort,
+		params.Search,
+		params.Path,
+	))
+}
+
+func (c *CancellationPolicyController) GetCancellationPolicyByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse cancellation policy ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid cancellation policy ID", err.Error()))
+		return
+	}
+
+	data, err := c.CancellationPolicyUsecase.GetCancellationPolicyByID(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("cancellation policy not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Cancellation policy not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve cancellation policy")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve cancellation policy", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.CancellationPolicyToResponse(data), "Cancellation policy retrieved successfully", nil))
+}
+
+func (c *CancellationPolicyController) UpdateCancellationPolicy(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse cancellation policy ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid or missing cancellation policy ID", nil))
+		return
+	}
+
+	request := new(requests.UpdateCancellationPolicyRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	request.ID = uint(id)
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.CancellationPolicyUsecase.UpdateCancellationPolicy(ctx, requests.CancellationPolicyFromUpdate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).WithField("id", id).Warn("cancellation policy, harbor or class not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Cancellation policy, harbor or class not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("id", id).Warn("invalid cancellation policy")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid cancellation policy", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Error("cancellation policy already exists")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Cancellation policy already exists", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to update cancellation policy")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to update cancellation policy", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Cancellation policy updated successfully", nil))
+}
+
+func (c *CancellationPolicyController) DeleteCancellationPolicy(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse cancellation policy ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid cancellation policy ID", err.Error()))
+		return
+	}
+
+	if err := c.CancellationPolicyUsecase.DeleteCancellationPolicy(ctx, uint(id)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("cancellation policy not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Cancellation policy not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to delete cancellation policy")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to delete cancellation policy", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Cancellation policy deleted successfully", nil))
+}
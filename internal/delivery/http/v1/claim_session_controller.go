@@ -37,6 +37,8 @@ func NewClaimSessionController(
 	router.POST("/claim/lock", c.LockClaimSession)
 	router.POST("/claim/entry/:sessionid", c.EntryClaimSession)
 	router.POST("/claim/create", c.CreateClaimSession)
+	router.POST("/claim/cancel/:sessionid", c.CancelClaimSession)
+	router.POST("/claim/extend/:sessionid", c.ExtendClaimSession)
 	router.GET("/claims", c.GetAllClaimSessions)
 	router.GET("/claim/:sessionid", c.GetClaimSessionByUUID)
 	router.PUT("/claim/update/:id", c.UpdateClaimSession)
@@ -60,9 +62,44 @@ func (c *ClaimSessionController) LockClaimSession(ctx *gin.Context) {
 		return
 	}
 
+	// Promo codes with a per customer limit need the signed in customer
+	if customerID := ctx.GetUint("customer_id"); customerID != 0 {
+		request.CustomerID = &customerID
+	}
+
 	datas, err := c.ClaimSessionUsecase.LockClaimSession(ctx, request)
 
 	if err != nil {
+		if errors.Is(err, errs.ErrPromoUnavailable) {
+			c.Log.WithError(err).Warn("promo code unavailable")
+			ctx.JSON(http.StatusUnprocessableEntity, response.NewErrorResponse("promo code cannot be used", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrQuotaExceeded) {
+			c.Log.WithError(err).Warn("quota exceeded")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("quota exceeded", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrSeatUnavailable) {
+			c.Log.WithError(err).Warn("seat unavailable")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("seat unavailable", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid claim request")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("invalid claim request", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).Warn("schedule not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("schedule not found", nil))
+			return
+		}
+
 		c.Log.WithError(err).Error("failed to create claim session")
 		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create claim session", err.Error()))
 		return
@@ -104,6 +141,10 @@ func (c *ClaimSessionController) EntryClaimSession(ctx *gin.Context) {
 		return
 	}
 
+	if customerID := ctx.GetUint("customer_id"); customerID != 0 {
+		request.CustomerID = &customerID
+	}
+
 	datas, err := c.ClaimSessionUsecase.EntryClaimSession(ctx, request, sessionID)
 	if err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
@@ -118,6 +159,18 @@ func (c *ClaimSessionController) EntryClaimSession(ctx *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, errs.ErrQuotaExceeded) {
+			c.Log.WithError(err).Warn("quota exceeded")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("quota exceeded", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid ticket data")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("invalid ticket data", err.Error()))
+			return
+		}
+
 		if errors.Is(err, errs.ErrExternalTimeout) || errors.Is(err, errs.ErrExternalDown) {
 			c.Log.WithError(err).Warn("external system unavailable")
 			ctx.JSON(http.StatusServiceUnavailable, response.NewErrorResponse("external system unavailable", nil))
@@ -168,6 +221,80 @@ func (c *ClaimSessionController) CreateClaimSession(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, response.NewSuccessResponse(nil, "Claim session created successfully", nil))
 }
 
+func (c *ClaimSessionController) CancelClaimSession(ctx *gin.Context) {
+	sessionID := ctx.Param("sessionid")
+	if sessionID == "" {
+		c.Log.WithField("sessionid", sessionID).Error("empty session UUID provided")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid claim session ID", "sessionid is empty"))
+		return
+	}
+
+	if err := c.ClaimSessionUsecase.CancelClaimSession(ctx, sessionID); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("sessionid", sessionID).Warn("claim session not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("claim session not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrExpired) {
+			c.Log.WithField("sessionid", sessionID).Warn("claim session expired")
+			ctx.JSON(http.StatusGone, response.NewErrorResponse("claim session expired", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Warn("claim session is no longer pending")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("claim session is no longer pending", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("sessionid", sessionID).Error("failed to cancel claim session")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to cancel claim session", err.Error()))
+		return
+	}
+
+	// Clear cookies
+	ctx.SetSameSite(http.SameSiteNoneMode)
+	ctx.SetCookie("session_id", "", -1, "/", "", true, true)
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Claim session cancelled successfully", nil))
+}
+
+func (c *ClaimSessionController) ExtendClaimSession(ctx *gin.Context) {
+	sessionID := ctx.Param("sessionid")
+	if sessionID == "" {
+		c.Log.WithField("sessionid", sessionID).Error("empty session UUID provided")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid claim session ID", "sessionid is empty"))
+		return
+	}
+
+	data, err := c.ClaimSessionUsecase.ExtendClaimSession(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("sessionid", sessionID).Warn("claim session not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("claim session not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrExpired) {
+			c.Log.WithField("sessionid", sessionID).Warn("claim session expired")
+			ctx.JSON(http.StatusGone, response.NewErrorResponse("claim session expired", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Warn("claim session cannot be extended")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("claim session cannot be extended", err.Error()))
+			return
+		}
+
+		c.Log.WithError(err).WithField("sessionid", sessionID).Error("failed to extend claim session")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to extend claim session", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(data, "Claim session extended successfully", nil))
+}
+
 func (c *ClaimSessionController) GetAllClaimSessions(ctx *gin.Context) {
 	params := response.GetParams(ctx)
 	datas, total, err := c.ClaimSessionUsecase.ListClaimSessions(ctx, params.Limit, params.Offset, params.Sort, params.Search)
@@ -65,6 +65,16 @@ func (c *ClassController) CreateClass(ctx *gin.Context) {
 			ctx.JSON(http.StatusConflict, response.NewErrorResponse("user already exists", nil))
 			return
 		}
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid vehicle category")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid vehicle category", err.Error()))
+			return
+		}
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).Warn("vehicle category not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Vehicle category not found", nil))
+			return
+		}
 		c.Log.WithError(err).Error("failed to create class")
 		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create class", err.Error()))
 		return
@@ -162,6 +172,12 @@ func (c *ClassController) UpdateClass(ctx *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("id", id).Warn("invalid vehicle category")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid vehicle category", err.Error()))
+			return
+		}
+
 		c.Log.WithError(err).WithField("id", id).Error("failed to update class")
 		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to update class", err.Error()))
 		return
@@ -0,0 +1,252 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"eticket-api/internal/usecase"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CustomerController struct {
+	Validate        validator.Validator
+	Log             logger.Logger
+	CustomerUsecase *usecase.CustomerUsecase
+}
+
+// NewCustomerController creates a new CustomerController instance.
+// Routes on account need a customer token from register or login.
+func NewCustomerController(
+	router *gin.RouterGroup,
+	account *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	customer_usecase *usecase.CustomerUsecase,
+
+) {
+	c := &CustomerController{
+		Log:             log,
+		Validate:        validate,
+		CustomerUsecase: customer_usecase,
+	}
+
+	router.POST("/customer/register", c.Register)
+	router.POST("/customer/login", c.Login)
+
+	account.GET("/customer/me", c.Me)
+	account.POST("/customer/verify", c.VerifyEmail)
+	account.POST("/customer/verify/resend", c.ResendVerification)
+	account.GET("/customer/trips/upcoming", c.GetUpcomingTrips)
+	account.GET("/customer/trips/past", c.GetPastTrips)
+	account.POST("/customer/bookings/claim", c.ClaimGuestBookings)
+}
+
+func (c *CustomerController) Register(ctx *gin.Context) {
+	request := new(requests.RegisterCustomerRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	customer, accessToken, err := c.CustomerUsecase.Register(ctx, requests.CustomerFromRegister(request))
+	if err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Warn("customer already exists")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("An account with this email already exists", nil))
+			return
+		}
+
+		c.Log.WithError(err).Error("failed to register customer")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to register customer", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.NewSuccessResponse(requests.CustomerTokenToResponse(customer, accessToken), "Account created, check your email for the verification code", nil))
+}
+
+func (c *CustomerController) Login(ctx *gin.Context) {
+	request := new(requests.CustomerLoginRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	customer, accessToken, err := c.CustomerUsecase.Login(ctx, request.Email, request.Password)
+	if err != nil {
+		if errors.Is(err, errs.ErrUnauthorized) {
+			c.Log.Warn("customer login rejected")
+			ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse("Invalid credentials", nil))
+			return
+		}
+
+		c.Log.WithError(err).Error("failed to log in customer")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to log in", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.CustomerTokenToResponse(customer, accessToken), "Login successful", nil))
+}
+
+func (c *CustomerController) Me(ctx *gin.Context) {
+	id := ctx.GetUint("customer_id")
+
+	customer, err := c.CustomerUsecase.GetCustomer(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("customer not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("customer not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve customer")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve customer", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.CustomerToResponse(customer), "Customer retrieved successfully", nil))
+}
+
+func (c *CustomerController) VerifyEmail(ctx *gin.Context) {
+	id := ctx.GetUint("customer_id")
+
+	request := new(requests.VerifyCustomerEmailRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	customer, err := c.CustomerUsecase.VerifyEmail(ctx, id, request.Code)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("customer not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("customer not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrUnauthorized) {
+			c.Log.WithField("id", id).Warn("invalid verification code")
+			ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse("Invalid or expired verification code", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to verify email")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to verify email", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.CustomerToResponse(customer), "Email verified successfully", nil))
+}
+
+func (c *CustomerController) ResendVerification(ctx *gin.Context) {
+	id := ctx.GetUint("customer_id")
+
+	if err := c.CustomerUsecase.ResendVerification(ctx, id); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("customer not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("customer not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithField("id", id).Warn("verification code requested too often")
+			ctx.JSON(http.StatusTooManyRequests, response.NewErrorResponse("Please wait a minute before requesting a new code", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to resend verification code")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to resend verification code", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.NewSuccessResponse(nil, "Verification code is on its way", nil))
+}
+
+func (c *CustomerController) GetUpcomingTrips(ctx *gin.Context) {
+	c.listTrips(ctx, true)
+}
+
+func (c *CustomerController) GetPastTrips(ctx *gin.Context) {
+	c.listTrips(ctx, false)
+}
+
+func (c *CustomerController) listTrips(ctx *gin.Context, upcoming bool) {
+	id := ctx.GetUint("customer_id")
+	params := response.GetParams(ctx)
+
+	datas, total, err := c.CustomerUsecase.ListTrips(ctx, id, upcoming, params.Limit, params.Offset)
+	if err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve trips")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve trips", err.Error()))
+		return
+	}
+
+	responses := make([]*requests.BookingResponse, len(datas))
+	for i, data := range datas {
+		responses[i] = requests.BookingToResponse(data)
+	}
+
+	ctx.JSON(http.StatusOK, response.NewMetaResponse(
+		responses,
+		"Trips retrieved successfully",
+		total,
+		params.Limit,
+		params.Page,
+		params.Sort,
+		params.Search,
+		params.Path,
+	))
+}
+
+func (c *CustomerController) ClaimGuestBookings(ctx *gin.Context) {
+	id := ctx.GetUint("customer_id")
+
+	claimed, err := c.CustomerUsecase.ClaimGuestBookings(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("customer not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("customer not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrForbidden) {
+			c.Log.WithField("id", id).Warn("email not verified")
+			ctx.JSON(http.StatusForbidden, response.NewErrorResponse("Verify your email before claiming bookings", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to claim guest bookings")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to claim guest bookings", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(&requests.ClaimGuestBookingsResponse{Claimed: claimed}, "Guest bookings claimed successfully", nil))
+}
@@ -0,0 +1,199 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/usecase"
+
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FeeComponentController struct {
+	Validate            validator.Validator
+	Log                 logger.Logger
+	FeeComponentUsecase *usecase.FeeComponentUsecase
+}
+
+func NewFeeComponentController(
+	router *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	fee_component_usecase *usecase.FeeComponentUsecase,
+
+) {
+	c := &FeeComponentController{
+		Log:                 log,
+		Validate:            validate,
+		FeeComponentUsecase: fee_component_usecase,
+	}
+
+	protected.GET("/fee-components", c.GetAllFeeComponents)
+	protected.GET("/fee-component/:id", c.GetFeeComponentByID)
+	protected.POST("/fee-component/create", c.CreateFeeComponent)
+	protected.PUT("/fee-component/update/:id", c.UpdateFeeComponent)
+	protected.DELETE("/fee-component/:id", c.DeleteFeeComponent)
+}
+
+func (c *FeeComponentController) CreateFeeComponent(ctx *gin.Context) {
+	request := new(requests.CreateFeeComponentRequest)
+
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.FeeComponentUsecase.CreateFeeComponent(ctx, requests.FeeComponentFromCreate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).Warn("harbor or class not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Harbor or class not found", nil))
+			return
+		}
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid fee component")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid fee component", err.Error()))
+			return
+		}
+		c.Log.WithError(err).Error("failed to create fee component")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create fee component", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.NewSuccessResponse(nil, "Fee component created successfully", nil))
+}
+
+func (c *FeeComponentController) GetAllFeeComponents(ctx *gin.Context) {
+
+	params := response.GetParams(ctx)
+	datas, total, err := c.FeeComponentUsecase.ListFeeComponents(ctx, params.Limit, params.Offset, params.Sort, params.Search)
+
+	if err != nil {
+		c.Log.WithError(err).Error("failed to retrieve fee components")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve fee components", err.Error()))
+		return
+	}
+
+	responses := make([]*requests.FeeComponentResponse, len(datas))
+	for i, data := range datas {
+		responses[i] = requests.FeeComponentToResponse(data)
+	}
+
+	ctx.JSON(http.StatusOK, response.NewMetaResponse(
+		responses,
+		"Fee components retrieved successfully",
+		total,
+		params.Limit,
+		params.Page,
+		params.Sort,
+		params.Search,
+		params.Path,
+	))
+}
+
+func (c *FeeComponentController) GetFeeComponentByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse fee component ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid fee component ID", err.Error()))
+		return
+	}
+
+	data, err := c.FeeComponentUsecase.GetFeeComponentByID(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("fee component not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Fee component not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve fee component")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve fee component", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.FeeComponentToResponse(data), "Fee component retrieved successfully", nil))
+}
+
+func (c *FeeComponentController) UpdateFeeComponent(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse fee component ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid or missing fee component ID", nil))
+		return
+	}
+
+	request := new(requests.UpdateFeeComponentRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	request.ID = uint(id)
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.FeeComponentUsecase.UpdateFeeComponent(ctx, requests.FeeComponentFromUpdate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).WithField("id", id).Warn("fee component, harbor or class not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Fee component, harbor or class not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("id", id).Warn("invalid fee component")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid fee component", err.Error()))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to update fee component")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to update fee component", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Fee component updated successfully", nil))
+}
+
+func (c *FeeComponentController) DeleteFeeComponent(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse fee component ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid fee component ID", err.Error()))
+		return
+	}
+
+	if err := c.FeeComponentUsecase.DeleteFeeComponent(ctx, uint(id)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("fee component not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Fee component not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to delete fee component")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to delete fee component", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Fee component deleted successfully", nil))
+}
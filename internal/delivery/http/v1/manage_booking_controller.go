@@ -0,0 +1,306 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"eticket-api/internal/usecase"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ManageBookingController struct {
+	Validate             validator.Validator
+	Log                  logger.Logger
+	ManageBookingUsecase *usecase.ManageBookingUsecase
+}
+
+// NewManageBookingController creates a new ManageBookingController instance.
+// Routes on customer need a booking token from login or a verified code.
+func NewManageBookingController(
+	router *gin.RouterGroup,
+	customer *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	manage_booking_usecase *usecase.ManageBookingUsecase,
+
+) {
+	c := &ManageBookingController{
+		Log:                  log,
+		Validate:             validate,
+		ManageBookingUsecase: manage_booking_usecase,
+	}
+
+	router.POST("/manage/login", c.Login)
+	router.POST("/manage/code", c.RequestAccessCode)
+	router.POST("/manage/code/verify", c.VerifyAccessCode)
+
+	customer.GET("/manage/booking", c.GetBooking)
+	customer.POST("/manage/booking/resend-ticket", c.ResendTicket)
+	customer.GET("/manage/booking/ticket/:id/qr", c.GetTicketPass)
+	customer.GET("/manage/booking/document/:document", c.GetDocument)
+	customer.PUT("/manage/booking/contact", c.UpdateContact)
+	customer.POST("/manage/booking/cancel", c.CancelBooking)
+}
+
+func (c *ManageBookingController) Login(ctx *gin.Context) {
+	request := new(requests.ManageBookingLoginRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	booking, accessToken, err := c.ManageBookingUsecase.Login(ctx, request.OrderID, request.Email, request.IDNumber)
+	if err != nil {
+		if errors.Is(err, errs.ErrUnauthorized) {
+			c.Log.WithField("orderId", request.OrderID).Warn("booking login rejected")
+			ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse("Booking details do not match", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("orderId", request.OrderID).Error("failed to log in to booking")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to log in to booking", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.ManageBookingTokenToResponse(booking, accessToken), "Booking login successful", nil))
+}
+
+func (c *ManageBookingController) RequestAccessCode(ctx *gin.Context) {
+	request := new(requests.BookingAccessCodeRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.ManageBookingUsecase.RequestAccessCode(ctx, request.OrderID, request.Email); err != nil {
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithField("orderId", request.OrderID).Warn("access code requested too often")
+			ctx.JSON(http.StatusTooManyRequests, response.NewErrorResponse("Please wait a minute before requesting a new code", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("orderId", request.OrderID).Error("failed to request access code")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to request access code", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.NewSuccessResponse(nil, "If the booking exists, an access code was sent to its email", nil))
+}
+
+func (c *ManageBookingController) VerifyAccessCode(ctx *gin.Context) {
+	request := new(requests.VerifyBookingAccessCodeRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	booking, accessToken, err := c.ManageBookingUsecase.VerifyAccessCode(ctx, request.OrderID, request.Code)
+	if err != nil {
+		if errors.Is(err, errs.ErrUnauthorized) {
+			c.Log.WithField("orderId", request.OrderID).Warn("invalid access code")
+			ctx.JSON(http.StatusUnauthorized, response.NewErrorResponse("Invalid or expired access code", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("orderId", request.OrderID).Error("failed to verify access code")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to verify access code", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.ManageBookingTokenToResponse(booking, accessToken), "Access code verified successfully", nil))
+}
+
+func (c *ManageBookingController) GetBooking(ctx *gin.Context) {
+	id := ctx.GetUint("booking_id")
+
+	data, err := c.ManageBookingUsecase.GetBooking(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("booking not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("booking not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve booking")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve booking", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.BookingToResponse(data), "Booking retrieved successfully", nil))
+}
+
+func (c *ManageBookingController) ResendTicket(ctx *gin.Context) {
+	id := ctx.GetUint("booking_id")
+
+	if err := c.ManageBookingUsecase.ResendTicket(ctx, id); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("booking not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("booking not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("id", id).Warn("booking has no e-ticket yet")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("E-tickets are sent once the booking is paid", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to resend ticket")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to resend ticket", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.NewSuccessResponse(nil, "E-ticket email is on its way", nil))
+}
+
+func (c *ManageBookingController) GetTicketPass(ctx *gin.Context) {
+	bookingID := ctx.GetUint("booking_id")
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse ticket ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid ticket ID", err.Error()))
+		return
+	}
+
+	ticket, payload, err := c.ManageBookingUsecase.GetTicketPass(ctx, bookingID, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("ticket not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("ticket not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("id", id).Warn("ticket has no QR yet")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("QR codes are issued once the booking is paid", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve ticket QR")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve ticket QR", err.Error()))
+		return
+	}
+
+	writeTicketPass(ctx, c.Log, ticket, payload)
+}
+
+func (c *ManageBookingController) GetDocument(ctx *gin.Context) {
+	id := ctx.GetUint("booking_id")
+
+	data, err := c.ManageBookingUsecase.GetDocument(ctx, id, ctx.Param("document"))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("booking not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("booking not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid document, use e-ticket or invoice", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("id", id).Warn("booking has no e-ticket yet")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("E-tickets are issued once the booking is paid", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to render booking document")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to render booking document", err.Error()))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, data.Filename))
+	ctx.Data(http.StatusOK, data.ContentType, data.Content)
+}
+
+func (c *ManageBookingController) UpdateContact(ctx *gin.Context) {
+	id := ctx.GetUint("booking_id")
+
+	request := new(requests.UpdateBookingContactRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	data, err := c.ManageBookingUsecase.UpdateContact(ctx, id, request.Email, request.PhoneNumber)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("booking not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("booking not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to update contact details")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to update contact details", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.BookingToResponse(data), "Contact details updated successfully", nil))
+}
+
+func (c *ManageBookingController) CancelBooking(ctx *gin.Context) {
+	id := ctx.GetUint("booking_id")
+
+	booking, refunds, err := c.ManageBookingUsecase.CancelBooking(ctx, id)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("booking not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("booking not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("id", id).Warn("booking not eligible for cancellation")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("booking is not eligible for cancellation", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to cancel booking")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to cancel booking", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.RefundToResponse(booking.OrderID, refunds), "Booking cancelled successfully", nil))
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/templates"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/delivery/http/response"
+	"eticket-api/internal/usecase"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ManifestController struct {
+	Validate        validator.Validator
+	Log             logger.Logger
+	ManifestUsecase *usecase.ManifestUsecase
+}
+
+func NewManifestController(
+	router *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	manifest_usecase *usecase.ManifestUsecase,
+) {
+	c := &ManifestController{
+		Log:             log,
+		Validate:        validate,
+		ManifestUsecase: manifest_usecase,
+	}
+
+	protected.GET("/manifest/schedule/:id", c.GetManifest)
+}
+
+// GetManifest returns the manifest of a departure as JSON, or as a file with
+// ?format=csv or ?format=pdf.
+func (c *ManifestController) GetManifest(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	format := ctx.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" && format != "pdf" {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid format, use json, csv or pdf", nil))
+		return
+	}
+
+	data, err := c.ManifestUsecase.GetManifest(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("schedule_id", id).Warn("schedule not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Schedule not found", nil))
+			return
+		}
+		c.Log.WithError(err).WithField("schedule_id", id).Error("failed to retrieve manifest")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve manifest", err.Error()))
+		return
+	}
+
+	filename := fmt.Sprintf("manifest-%d", id)
+	if data.Final {
+		filename += "-final"
+	}
+	switch format {
+	case "csv":
+		body, err := templates.ManifestCSV(data)
+		if err != nil {
+			c.Log.WithError(err).WithField("schedule_id", id).Error("failed to write manifest CSV")
+			ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to write manifest", err.Error()))
+			return
+		}
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+		ctx.Data(http.StatusOK, "text/csv; charset=utf-8", body)
+	case "pdf":
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, filename))
+		ctx.Data(http.StatusOK, "application/pdf", templates.ManifestPDF(data))
+	default:
+		ctx.JSON(http.StatusOK, response.NewSuccessResponse(data, "Manifest retrieved successfully", nil))
+	}
+}
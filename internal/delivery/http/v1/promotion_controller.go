@@ -0,0 +1,210 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/usecase"
+
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionController struct {
+	Validate         validator.Validator
+	Log              logger.Logger
+	PromotionUsecase *usecase.PromotionUsecase
+}
+
+func NewPromotionController(
+	router *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	promotion_usecase *usecase.PromotionUsecase,
+
+) {
+	c := &PromotionController{
+		Log:              log,
+		Validate:         validate,
+		PromotionUsecase: promotion_usecase,
+	}
+
+	protected.GET("/promotions", c.GetAllPromotions)
+	protected.GET("/promotion/:id", c.GetPromotionByID)
+	protected.POST("/promotion/create", c.CreatePromotion)
+	protected.PUT("/promotion/update/:id", c.UpdatePromotion)
+	protected.DELETE("/promotion/:id", c.DeletePromotion)
+}
+
+func (c *PromotionController) CreatePromotion(ctx *gin.Context) {
+	request := new(requests.CreatePromotionRequest)
+
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.PromotionUsecase.CreatePromotion(ctx, requests.PromotionFromCreate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).Warn("harbor, class or schedule not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Harbor, class or schedule not found", nil))
+			return
+		}
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid promotion")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid promotion", err.Error()))
+			return
+		}
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Error("promo code already exists")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Promo code already exists", nil))
+			return
+		}
+		c.Log.WithError(err).Error("failed to create promotion")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create promotion", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.NewSuccessResponse(nil, "Promotion created successfully", nil))
+}
+
+func (c *PromotionController) GetAllPromotions(ctx *gin.Context) {
+
+	params := response.GetParams(ctx)
+	datas, total, err := c.PromotionUsecase.ListPromotions(ctx, params.Limit, params.Offset, params.Sort, params.Search)
+
+	if err != nil {
+		c.Log.WithError(err).Error("failed to retrieve promotions")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve promotions", err.Error()))
+		return
+	}
+
+	responses := make([]*requests.PromotionResponse, len(datas))
+	for i, data := range datas {
+		responses[i] = requests.PromotionToResponse(data)
+	}
+
+	ctx.JSON(http.StatusOK, response.NewMetaResponse(
+		responses,
+		"Promotions retrieved successfully",
+		total,
+		params.Limit,
+		params.Page,
+		params.Sort,
+		params.Search,
+		params.Path,
+	))
+}
+
+func (c *PromotionController) GetPromotionByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse promotion ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid promotion ID", err.Error()))
+		return
+	}
+
+	data, err := c.PromotionUsecase.GetPromotionByID(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("promotion not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Promotion not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve promotion")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve promotion", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.PromotionToResponse(data), "Promotion retrieved successfully", nil))
+}
+
+func (c *PromotionController) UpdatePromotion(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse promotion ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid or missing promotion ID", nil))
+		return
+	}
+
+	request := new(requests.UpdatePromotionRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	request.ID = uint(id)
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.PromotionUsecase.UpdatePromotion(ctx, requests.PromotionFromUpdate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).WithField("id", id).Warn("promotion, harbor, class or schedule not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Promotion, harbor, class or schedule not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("id", id).Warn("invalid promotion")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid promotion", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Error("promo code already exists")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Promo code already exists", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to update promotion")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to update promotion", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Promotion updated successfully", nil))
+}
+
+func (c *PromotionController) DeletePromotion(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse promotion ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid promotion ID", err.Error()))
+		return
+	}
+
+	if err := c.PromotionUsecase.DeletePromotion(ctx, uint(id)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("promotion not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Promotion not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to delete promotion")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to delete promotion", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Promotion deleted successfully", nil))
+}
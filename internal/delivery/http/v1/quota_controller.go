@@ -62,6 +62,11 @@ func (c *QuotaController) CreateQuota(ctx *gin.Context) {
 	}
 
 	if err := c.QuotaUsecase.CreateQuota(ctx, requests.QuotaFromCreate(request)); err != nil {
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid fares")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid fares", err.Error()))
+			return
+		}
 		if errors.Is(err, errs.ErrConflict) {
 			c.Log.WithError(err).Error("user already exists")
 			ctx.JSON(http.StatusConflict, response.NewErrorResponse("user already exists", nil))
@@ -102,6 +107,11 @@ func (c *QuotaController) CreateQuotaBulk(ctx *gin.Context) {
 	}
 
 	if err := c.QuotaUsecase.CreateQuotaBulk(ctx, quotas); err != nil {
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid fares")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid fares", err.Error()))
+			return
+		}
 		c.Log.WithError(err).Error("failed to create Quotas in bulk")
 		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create Quotas in bulk", err.Error()))
 		return
@@ -194,6 +204,12 @@ func (c *QuotaController) UpdateQuota(ctx *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("id", id).Warn("invalid quota")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid quota", err.Error()))
+			return
+		}
+
 		if errors.Is(err, errs.ErrConflict) {
 			c.Log.WithError(err).Error("Quota already exists")
 			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Quota already exists", nil))
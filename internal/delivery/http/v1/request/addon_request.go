@@ -0,0 +1,182 @@
+package requests
+
+import (
+	"eticket-api/internal/domain"
+	"time"
+)
+
+type CreateAddonRequest struct {
+	Name        string  `json:"name" validate:"required,max=64"`
+	Kind        string  `json:"kind" validate:"required,oneof=MEAL CABIN LUGGAGE"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price" validate:"min=0"`
+	IsActive    bool    `json:"is_active"`
+}
+
+type UpdateAddonRequest struct {
+	ID          uint    `json:"id" validate:"required"`
+	Name        string  `json:"name" validate:"required,max=64"`
+	Kind        string  `json:"kind" validate:"required,oneof=MEAL CABIN LUGGAGE"`
+	Description string  `json:"description"`
+	Price       float64 `json:"price" validate:"min=0"`
+	IsActive    bool    `json:"is_active"`
+}
+
+type AddonResponse struct {
+	ID          uint      `json:"id"`
+	Name        string    `json:"name"`
+	Kind        string    `json:"kind"`
+	Description string    `json:"description"`
+	Price       float64   `json:"price"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Leave Price empty to sell at the add-on's own price
+type CreateAddonStockRequest struct {
+	ScheduleID uint     `json:"schedule_id" validate:"required"`
+	AddonID    uint     `json:"addon_id" validate:"required"`
+	Capacity   int      `json:"capacity" validate:"required,min=1"`
+	Price      *float64 `json:"price" validate:"omitempty,min=0"`
+}
+
+type UpdateAddonStockRequest struct {
+	ID       uint     `json:"id" validate:"required"`
+	Capacity int      `json:"capacity" validate:"required,min=1"`
+	Price    *float64 `json:"price" validate:"omitempty,min=0"`
+}
+
+// AddonStockResponse is an add-on on sale on a schedule; Available is what
+// can still be claimed
+type AddonStockResponse struct {
+	ID         uint    `json:"id"`
+	ScheduleID uint    `json:"schedule_id"`
+	AddonID    uint    `json:"addon_id"`
+	Name       string  `json:"name"`
+	Kind       string  `json:"kind"`
+	Price      float64 `json:"price"`
+	Capacity   int     `json:"capacity"`
+	Available  int     `json:"available"`
+}
+
+// BookingAddonResponse is an add-on sold with a booking; TicketID is empty
+// for add-ons of the booking as a whole
+type BookingAddonResponse struct {
+	ID         uint    `json:"id"`
+	TicketID   *uint   `json:"ticket_id"`
+	ScheduleID uint    `json:"schedule_id"`
+	AddonID    uint    `json:"addon_id"`
+	Name       string  `json:"name"`
+	Kind       string  `json:"kind"`
+	Price      float64 `json:"price"`
+	Quantity   int     `json:"quantity"`
+}
+
+// ScheduleAddonResponse is an add-on the crew has to serve on a departure
+type ScheduleAddonResponse struct {
+	BookingAddonResponse
+	BookingID     uint    `json:"booking_id"`
+	TicketCode    *string `json:"ticket_code"`
+	PassengerName *string `json:"passenger_name"`
+	SeatNumber    *string `json:"seat_number"`
+}
+
+// Map Addon domain to AddonResponse model
+func AddonToResponse(addon *domain.Addon) *AddonResponse {
+	return &AddonResponse{
+		ID:          addon.ID,
+		Name:        addon.Name,
+		Kind:        addon.Kind,
+		Description: addon.Description,
+		Price:       addon.Price,
+		IsActive:    addon.IsActive,
+		CreatedAt:   addon.CreatedAt,
+		UpdatedAt:   addon.UpdatedAt,
+	}
+}
+
+func AddonFromCreate(request *CreateAddonRequest) *domain.Addon {
+	return &domain.Addon{
+		Name:        request.Name,
+		Kind:        request.Kind,
+		Description: request.Description,
+		Price:       request.Price,
+		IsActive:    request.IsActive,
+	}
+}
+
+func AddonFromUpdate(request *UpdateAddonRequest) *domain.Addon {
+	return &domain.Addon{
+		ID:          request.ID,
+		Name:        request.Name,
+		Kind:        request.Kind,
+		Description: request.Description,
+		Price:       request.Price,
+		IsActive:    request.IsActive,
+	}
+}
+
+func AddonStockToResponse(stock *domain.AddonStock) *AddonStockResponse {
+	return &AddonStockResponse{
+		ID:         stock.ID,
+		ScheduleID: stock.ScheduleID,
+		AddonID:    stock.AddonID,
+		Name:       stock.Addon.Name,
+		Kind:       stock.Addon.Kind,
+		Price:      stock.UnitPrice(),
+		Capacity:   stock.Capacity,
+		Available:  stock.Stock - stock.Held,
+	}
+}
+
+func AddonStockFromCreate(request *CreateAddonStockRequest) *domain.AddonStock {
+	return &domain.AddonStock{
+		ScheduleID: request.ScheduleID,
+		AddonID:    request.AddonID,
+		Capacity:   request.Capacity,
+		Price:      request.Price,
+	}
+}
+
+func AddonStockFromUpdate(request *UpdateAddonStockRequest) *domain.AddonStock {
+	return &domain.AddonStock{
+		ID:       request.ID,
+		Capacity: request.Capacity,
+		Price:    request.Price,
+	}
+}
+
+func BookingAddonsToResponse(addons []domain.BookingAddon) []BookingAddonResponse {
+	responses := make([]BookingAddonResponse, len(addons))
+	for i, addon := range addons {
+		responses[i] = bookingAddonToResponse(&addon)
+	}
+	return responses
+}
+
+func ScheduleAddonToResponse(addon *domain.BookingAddon) *ScheduleAddonResponse {
+	response := &ScheduleAddonResponse{
+		BookingAddonResponse: bookingAddonToResponse(addon),
+		BookingID:            addon.BookingID,
+	}
+	if addon.Ticket != nil {
+		response.TicketCode = &addon.Ticket.TicketCode
+		response.PassengerName = &addon.Ticket.PassengerName
+		response.SeatNumber = addon.Ticket.SeatNumber
+	}
+	return response
+}
+
+func bookingAddonToResponse(addon *domain.BookingAddon) BookingAddonResponse {
+	return BookingAddonResponse{
+		ID:         addon.ID,
+		TicketID:   addon.TicketID,
+		ScheduleID: addon.ScheduleID,
+		AddonID:    addon.AddonID,
+		Name:       addon.Name,
+		Kind:       addon.Kind,
+		Price:      addon.Price,
+		Quantity:   addon.Quantity,
+	}
+}
@@ -0,0 +1,97 @@
+package requests
+
+import (
+	"eticket-api/internal/domain"
+	"eticket-api/internal/model"
+	"time"
+)
+
+// BoardingRequest says which gate made a boarding move. Both fields are
+// optional for moves made from the back office.
+type BoardingRequest struct {
+	Gate     string `json:"gate" validate:"max=32"`
+	HarborID *uint  `json:"harbor_id"`
+}
+
+// BoardingSyncRequest uploads the scans a gate device collected offline
+// against the snapshot it downloaded.
+type BoardingSyncRequest struct {
+	SnapshotToken string                `json:"snapshot_token" validate:"required"`
+	Device        string                `json:"device" validate:"required,max=64"`
+	Gate          string                `json:"gate" validate:"max=32"`
+	HarborID      *uint                 `json:"harbor_id"`
+	Scans         []BoardingScanRequest `json:"scans" validate:"required,min=1,max=2000,dive"`
+}
+
+type BoardingScanRequest struct {
+	TicketCode string    `json:"ticket_code" validate:"required,max=64"`
+	Status     string    `json:"status" validate:"required,oneof=CHECKED_IN BOARDED"`
+	ScannedAt  time.Time `json:"scanned_at" validate:"required"`
+}
+
+type BoardingTicketResponse struct {
+	ID             uint       `json:"id"`
+	TicketCode     string     `json:"ticket_code"`
+	ScheduleID     uint       `json:"schedule_id"`
+	BoardingStatus string     `json:"boarding_status"`
+	CheckedInAt    *time.Time `json:"checked_in_at"`
+	BoardedAt      *time.Time `json:"boarded_at"`
+}
+
+type BoardingEventResponse struct {
+	ID         uint       `json:"id"`
+	TicketID   uint       `json:"ticket_id"`
+	FromStatus string     `json:"from_status"`
+	ToStatus   string     `json:"to_status"`
+	UserID     *uint      `json:"user_id"`
+	Gate       string     `json:"gate"`
+	Device     string     `json:"device,omitempty"`
+	HarborID   *uint      `json:"harbor_id"`
+	OccurredAt time.Time  `json:"occurred_at"`
+	UndoneAt   *time.Time `json:"undone_at,omitempty"`
+	UndoneBy   *uint      `json:"undone_by,omitempty"`
+}
+
+type CloseGateResponse struct {
+	ScheduleID uint `json:"schedule_id"`
+	NoShow     int  `json:"no_show"`
+}
+
+func BoardingTicketToResponse(ticket *domain.Ticket) *BoardingTicketResponse {
+	return &BoardingTicketResponse{
+		ID:             ticket.ID,
+		TicketCode:     ticket.TicketCode,
+		ScheduleID:     ticket.ScheduleID,
+		BoardingStatus: ticket.BoardingStatus,
+		CheckedInAt:    ticket.CheckedInAt,
+		BoardedAt:      ticket.BoardedAt,
+	}
+}
+
+func BoardingEventToResponse(event *domain.BoardingEvent) *BoardingEventResponse {
+	return &BoardingEventResponse{
+		ID:         event.ID,
+		TicketID:   event.TicketID,
+		FromStatus: event.FromStatus,
+		ToStatus:   event.ToStatus,
+		UserID:     event.UserID,
+		Gate:       event.Gate,
+		Device:     event.Device,
+		HarborID:   event.HarborID,
+		OccurredAt: event.OccurredAt,
+		UndoneAt:   event.UndoneAt,
+		UndoneBy:   event.UndoneBy,
+	}
+}
+
+func BoardingScansFromRequest(request *BoardingSyncRequest) []model.BoardingScan {
+	scans := make([]model.BoardingScan, len(request.Scans))
+	for i, scan := range request.Scans {
+		scans[i] = model.BoardingScan{
+			TicketCode: scan.TicketCode,
+			Status:     scan.Status,
+			ScannedAt:  scan.ScannedAt,
+		}
+	}
+	return scans
+}
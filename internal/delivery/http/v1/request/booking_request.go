@@ -36,20 +36,86 @@ type RefundBookingRequest struct {
 	Email    string `json:"email" validate:"required,email"`
 }
 
+// FromScheduleID picks the leg to move and defaults to the booking's schedule.
+// PaymentMethod is required when the new departure costs more.
+type RescheduleBookingRequest struct {
+	ID             uint   `json:"id" validate:"required"`
+	FromScheduleID uint   `json:"from_schedule_id"`
+	ToScheduleID   uint   `json:"to_schedule_id" validate:"required"`
+	PaymentMethod  string `json:"payment_method"`
+}
+
 type BookingResponse struct {
-	ID              uint            `json:"id"`
-	OrderID         string          `json:"order_id"`
-	Schedule        BookingSchedule `json:"schedule"`
-	CustomerName    string          `json:"customer_name"`
-	IDType          string          `json:"id_type"`
-	IDNumber        string          `json:"id_number"`
-	PhoneNumber     string          `json:"phone_number"`
-	Email           string          `json:"email"`
-	Status          string          `json:"status"`
-	ReferenceNumber *string         `json:"reference_number"`
-	CreatedAt       time.Time       `json:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at"`
-	Tickets         []BookingTicket `json:"tickets"`
+	ID              uint                   `json:"id"`
+	OrderID         string                 `json:"order_id"`
+	Schedule        BookingSchedule        `json:"schedule"`
+	CustomerName    string                 `json:"customer_name"`
+	IDType          string                 `json:"id_type"`
+	IDNumber        string                 `json:"id_number"`
+	PhoneNumber     string                 `json:"phone_number"`
+	Email           string                 `json:"email"`
+	Status          string                 `json:"status"`
+	ReferenceNumber *string                `json:"reference_number"`
+	Fees            []TicketFeeResponse    `json:"fees"`
+	Addons          []BookingAddonResponse `json:"addons"`
+	PromoCode       *string                `json:"promo_code"`
+	Discount        float64                `json:"discount"`
+	Credit          float64                `json:"credit"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+	Tickets         []BookingTicket        `json:"tickets"`
+	Changes         []BookingChange        `json:"changes"`
+}
+
+// BookingPublicResponse is what anyone holding an order ID may see; customer
+// and passenger data need a booking token.
+type BookingPublicResponse struct {
+	OrderID     string          `json:"order_id"`
+	Schedule    BookingSchedule `json:"schedule"`
+	Status      string          `json:"status"`
+	TicketCount int             `json:"ticket_count"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+type BookingChangeSchedule struct {
+	ID                uint      `json:"id"`
+	DepartureDatetime time.Time `json:"departure_datetime"`
+	ArrivalDatetime   time.Time `json:"arrival_datetime"`
+}
+
+type BookingChange struct {
+	ID              uint                  `json:"id"`
+	Type            string                `json:"type"`
+	FromSchedule    BookingChangeSchedule `json:"from_schedule"`
+	ToSchedule      BookingChangeSchedule `json:"to_schedule"`
+	FareDifference  float64               `json:"fare_difference"`
+	Settlement      string                `json:"settlement"`
+	Status          string                `json:"status"`
+	ReferenceNumber *string               `json:"reference_number"`
+	Detail          string                `json:"detail"`
+	CreatedAt       time.Time             `json:"created_at"`
+}
+
+type RescheduleBookingResponse struct {
+	Change  BookingChange       `json:"change"`
+	Payment *domain.Transaction `json:"payment"`
+}
+
+type RefundItem struct {
+	TicketID             uint    `json:"ticket_id"`
+	TicketCode           string  `json:"ticket_code"`
+	PassengerName        string  `json:"passenger_name"`
+	PolicyName           string  `json:"policy_name"`
+	HoursBeforeDeparture float64 `json:"hours_before_departure"`
+	TicketPrice          float64 `json:"ticket_price"`
+	RefundPercent        float64 `json:"refund_percent"`
+	Amount               float64 `json:"amount"`
+}
+
+type RefundBookingResponse struct {
+	OrderID     string       `json:"order_id"`
+	TotalAmount float64      `json:"total_amount"`
+	Refunds     []RefundItem `json:"refunds"`
 }
 
 type BookingTicketClass struct {
@@ -61,6 +127,7 @@ type BookingTicketClass struct {
 type BookingTicket struct {
 	ID              uint               `json:"id"`
 	TicketCode      string             `json:"ticket_code"`
+	ScheduleID      uint               `json:"schedule_id"` // Leg of the ticket, may differ from the booking's first leg
 	Class           BookingTicketClass `json:"class"`
 	Type            string             `json:"type" binding:"required,oneof=passenger vehicle"`
 	PassengerName   string             `json:"passenger_name"`
@@ -72,6 +139,11 @@ type BookingTicket struct {
 	SeatNumber      *string            `json:"seat_number"`
 	LicensePlate    *string            `json:"license_plate"`
 	Price           float64            `json:"price"`
+	FareCategory    string             `json:"fare_category"`
+	VehicleType     *string            `json:"vehicle_type,omitempty"`
+	VehicleBrand    *string            `json:"vehicle_brand,omitempty"`
+	VehicleLength   *float64           `json:"vehicle_length,omitempty"`
+	DriverTicketID  *uint              `json:"driver_ticket_id,omitempty"` // Passenger ticket of the driver
 }
 
 type BookingHarbor struct {
@@ -93,6 +165,17 @@ type BookingSchedule struct {
 	ArrivalDatetime   time.Time           `json:"arrival_datetime"`
 }
 
+// Map Booking domain to BookingPublicResponse model
+func BookingToPublicResponse(booking *domain.Booking) *BookingPublicResponse {
+	return &BookingPublicResponse{
+		OrderID:     booking.OrderID,
+		Schedule:    bookingScheduleToResponse(&booking.Schedule),
+		Status:      booking.Status,
+		TicketCount: len(booking.Tickets),
+		CreatedAt:   booking.CreatedAt,
+	}
+}
+
 // Map Booking domain to ReadBookingResponse model
 func BookingToResponse(booking *domain.Booking) *BookingResponse {
 	// Map tickets
@@ -107,6 +190,7 @@ func BookingToResponse(booking *domain.Booking) *BookingResponse {
 				Type:      ticket.Class.Type,
 			},
 			TicketCode:      ticket.TicketCode, // Unique ticket code
+			ScheduleID:      ticket.ScheduleID,
 			PassengerName:   ticket.PassengerName,
 			PassengerAge:    ticket.PassengerAge,
 			PassengerGender: ticket.PassengerGender,
@@ -116,29 +200,23 @@ func BookingToResponse(booking *domain.Booking) *BookingResponse {
 			SeatNumber:      ticket.SeatNumber,
 			LicensePlate:    ticket.LicensePlate,
 			Price:           ticket.Price,
+			FareCategory:    ticket.FareCategory,
+			VehicleType:     ticket.VehicleType,
+			VehicleBrand:    ticket.VehicleBrand,
+			VehicleLength:   ticket.VehicleLength,
+			DriverTicketID:  ticket.DriverTicketID,
 		}
 	}
 
+	changes := make([]BookingChange, len(booking.Changes))
+	for i := range booking.Changes {
+		changes[i] = BookingChangeToResponse(&booking.Changes[i])
+	}
+
 	return &BookingResponse{
-		ID:      booking.ID,
-		OrderID: booking.OrderID,
-		Schedule: BookingSchedule{
-			ID: booking.Schedule.ID,
-			Ship: BookingScheduleShip{
-				ID:       booking.Schedule.Ship.ID,
-				ShipName: booking.Schedule.Ship.ShipName,
-			},
-			DepartureHarbor: BookingHarbor{
-				ID:         booking.Schedule.DepartureHarbor.ID,
-				HarborName: booking.Schedule.DepartureHarbor.HarborName,
-			},
-			ArrivalHarbor: BookingHarbor{
-				ID:         booking.Schedule.ArrivalHarbor.ID,
-				HarborName: booking.Schedule.ArrivalHarbor.HarborName,
-			},
-			DepartureDatetime: booking.Schedule.DepartureDatetime,
-			ArrivalDatetime:   booking.Schedule.ArrivalDatetime,
-		},
+		ID:              booking.ID,
+		OrderID:         booking.OrderID,
+		Schedule:        bookingScheduleToResponse(&booking.Schedule),
 		CustomerName:    booking.CustomerName,
 		IDType:          booking.IDType,
 		IDNumber:        booking.IDNumber,
@@ -146,10 +224,80 @@ func BookingToResponse(booking *domain.Booking) *BookingResponse {
 		Email:           booking.Email,
 		Status:          booking.Status,
 		ReferenceNumber: booking.ReferenceNumber,
+		Fees:            TicketFeesToResponse(booking.Fees),
+		Addons:          BookingAddonsToResponse(booking.Addons),
+		PromoCode:       booking.PromoCode,
+		Discount:        booking.Discount,
+		Credit:          booking.Credit,
 		CreatedAt:       booking.CreatedAt,
 		UpdatedAt:       booking.UpdatedAt,
 		Tickets:         tickets,
+		Changes:         changes,
+	}
+}
+
+// Helper to map a booking's schedule
+func bookingScheduleToResponse(schedule *domain.Schedule) BookingSchedule {
+	return BookingSchedule{
+		ID: schedule.ID,
+		Ship: BookingScheduleShip{
+			ID:       schedule.Ship.ID,
+			ShipName: schedule.Ship.ShipName,
+		},
+		DepartureHarbor: BookingHarbor{
+			ID:         schedule.DepartureHarbor.ID,
+			HarborName: schedule.DepartureHarbor.HarborName,
+		},
+		ArrivalHarbor: BookingHarbor{
+			ID:         schedule.ArrivalHarbor.ID,
+			HarborName: schedule.ArrivalHarbor.HarborName,
+		},
+		DepartureDatetime: schedule.DepartureDatetime,
+		ArrivalDatetime:   schedule.ArrivalDatetime,
+	}
+}
+
+// Map BookingChange domain to its place in the booking history
+func BookingChangeToResponse(change *domain.BookingChange) BookingChange {
+	return BookingChange{
+		ID:   change.ID,
+		Type: change.Type,
+		FromSchedule: BookingChangeSchedule{
+			ID:                change.FromScheduleID,
+			DepartureDatetime: change.FromSchedule.DepartureDatetime,
+			ArrivalDatetime:   change.FromSchedule.ArrivalDatetime,
+		},
+		ToSchedule: BookingChangeSchedule{
+			ID:                change.ToScheduleID,
+			DepartureDatetime: change.ToSchedule.DepartureDatetime,
+			ArrivalDatetime:   change.ToSchedule.ArrivalDatetime,
+		},
+		FareDifference:  change.FareDifference,
+		Settlement:      change.Settlement,
+		Status:          change.Status,
+		ReferenceNumber: change.ReferenceNumber,
+		Detail:          change.Detail,
+		CreatedAt:       change.CreatedAt,
+	}
+}
+
+// Map refund ledger entries to the per ticket breakdown of a cancellation
+func RefundToResponse(orderID string, refunds []*domain.Refund) *RefundBookingResponse {
+	response := &RefundBookingResponse{OrderID: orderID, Refunds: make([]RefundItem, len(refunds))}
+	for i, refund := range refunds {
+		response.Refunds[i] = RefundItem{
+			TicketID:             refund.TicketID,
+			TicketCode:           refund.Ticket.TicketCode,
+			PassengerName:        refund.Ticket.PassengerName,
+			PolicyName:           refund.PolicyName,
+			HoursBeforeDeparture: refund.HoursBeforeDeparture,
+			TicketPrice:          refund.TicketPrice,
+			RefundPercent:        refund.RefundPercent,
+			Amount:               refund.Amount,
+		}
+		response.TotalAmount += refund.Amount
 	}
+	return response
 }
 
 func BookingFromCreate(request *CreateBookingRequest) *domain.Booking {
@@ -0,0 +1,119 @@
+package requests
+
+import (
+	"eticket-api/internal/domain"
+	"time"
+)
+
+type CancellationRuleRequest struct {
+	MinHoursBefore int     `json:"min_hours_before" validate:"min=0"`
+	RefundPercent  float64 `json:"refund_percent" validate:"min=0,max=100"`
+}
+
+// Leave both harbors empty for a policy on every route and ClassID empty for
+// every class. Rules are matched from the longest notice down.
+type CreateCancellationPolicyRequest struct {
+	Name              string                    `json:"name" validate:"required,max=64"`
+	DepartureHarborID *uint                     `json:"departure_harbor_id" validate:"required_with=ArrivalHarborID"`
+	ArrivalHarborID   *uint                     `json:"arrival_harbor_id" validate:"required_with=DepartureHarborID"`
+	ClassID           *uint                     `json:"class_id"`
+	Rules             []CancellationRuleRequest `json:"rules" validate:"required,min=1,dive"`
+}
+
+type UpdateCancellationPolicyRequest struct {
+	ID                uint                      `json:"id" validate:"required"`
+	Name              string                    `json:"name" validate:"required,max=64"`
+	DepartureHarborID *uint                     `json:"departure_harbor_id" validate:"required_with=ArrivalHarborID"`
+	ArrivalHarborID   *uint                     `json:"arrival_harbor_id" validate:"required_with=DepartureHarborID"`
+	ClassID           *uint                     `json:"class_id"`
+	Rules             []CancellationRuleRequest `json:"rules" validate:"required,min=1,dive"`
+}
+
+type CancellationPolicyHarbor struct {
+	ID         uint   `json:"id"`
+	HarborName string `json:"harbor_name"`
+}
+
+type CancellationPolicyClass struct {
+	ID        uint   `json:"id"`
+	ClassName string `json:"class_name"`
+	Type      string `json:"type"`
+}
+
+type CancellationRuleResponse struct {
+	MinHoursBefore int     `json:"min_hours_before"`
+	RefundPercent  float64 `json:"refund_percent"`
+}
+
+type CancellationPolicyResponse struct {
+	ID              uint                       `json:"id"`
+	Name            string                     `json:"name"`
+	DepartureHarbor *CancellationPolicyHarbor  `json:"departure_harbor"`
+	ArrivalHarbor   *CancellationPolicyHarbor  `json:"arrival_harbor"`
+	Class           *CancellationPolicyClass   `json:"class"`
+	Rules           []CancellationRuleResponse `json:"rules"`
+	CreatedAt       time.Time                  `json:"created_at"`
+	UpdatedAt       time.Time                  `json:"updated_at"`
+}
+
+// Map CancellationPolicy domain to CancellationPolicyResponse model
+func CancellationPolicyToResponse(policy *domain.CancellationPolicy) *CancellationPolicyResponse {
+	rules := make([]CancellationRuleResponse, len(policy.Rules))
+	for i, rule := range policy.Rules {
+		rules[i] = CancellationRuleResponse{
+			MinHoursBefore: rule.MinHoursBefore,
+			RefundPercent:  rule.RefundPercent,
+		}
+	}
+
+	response := &CancellationPolicyResponse{
+		ID:        policy.ID,
+		Name:      policy.Name,
+		Rules:     rules,
+		CreatedAt: policy.CreatedAt,
+		UpdatedAt: policy.UpdatedAt,
+	}
+	if policy.DepartureHarbor != nil {
+		response.DepartureHarbor = &CancellationPolicyHarbor{ID: policy.DepartureHarbor.ID, HarborName: policy.DepartureHarbor.HarborName}
+	}
+	if policy.ArrivalHarbor != nil {
+		response.ArrivalHarbor = &CancellationPolicyHarbor{ID: policy.ArrivalHarbor.ID, HarborName: policy.ArrivalHarbor.HarborName}
+	}
+	if policy.Class != nil {
+		response.Class = &CancellationPolicyClass{ID: policy.Class.ID, ClassName: policy.Class.ClassName, Type: policy.Class.Type}
+	}
+	return response
+}
+
+func CancellationPolicyFromCreate(request *CreateCancellationPolicyRequest) *domain.CancellationPolicy {
+	return &domain.CancellationPolicy{
+		Name:              request.Name,
+		DepartureHarborID: request.DepartureHarborID,
+		ArrivalHarborID:   request.ArrivalHarborID,
+		ClassID:           request.ClassID,
+		Rules:             buildCancellationRules(request.Rules),
+	}
+}
+
+func CancellationPolicyFromUpdate(request *UpdateCancellationPolicyRequest) *domain.CancellationPolicy {
+	return &domain.CancellationPolicy{
+		ID:                request.ID,
+		Name:              request.Name,
+		DepartureHarborID: request.DepartureHarborID,
+		ArrivalHarborID:   request.ArrivalHarborID,
+		ClassID:           request.ClassID,
+		Rules:             buildCancellationRules(request.Rules),
+	}
+}
+
+// Helper to build policy rules from the request
+func buildCancellationRules(requests []CancellationRuleRequest) []domain.CancellationRule {
+	rules := make([]domain.CancellationRule, len(requests))
+	for i, rule := range requests {
+		rules[i] = domain.CancellationRule{
+			MinHoursBefore: rule.MinHoursBefore,
+			RefundPercent:  rule.RefundPercent,
+		}
+	}
+	return rules
+}
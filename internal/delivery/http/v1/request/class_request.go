@@ -9,6 +9,8 @@ type CreateClassRequest struct {
 	ClassName  string `json:"class_name" validate:"required"`
 	Type       string `json:"type" validate:"required"`
 	ClassAlias string `json:"class_alias"  validate:"required"`
+
+	VehicleCategoryID *uint `json:"vehicle_category_id"`
 }
 
 type UpdateClassRequest struct {
@@ -16,6 +18,8 @@ type UpdateClassRequest struct {
 	ClassName  string `json:"class_name" validate:"required"`
 	Type       string `json:"type" validate:"required"`
 	ClassAlias string `json:"class_alias"  validate:"required"`
+
+	VehicleCategoryID *uint `json:"vehicle_category_id"`
 }
 
 type ClassResponse struct {
@@ -25,11 +29,13 @@ type ClassResponse struct {
 	Type       string    `json:"type"`
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
+
+	VehicleCategory *VehicleCategoryResponse `json:"vehicle_category,omitempty"`
 }
 
 // Map Class domain to ReadClassResponse model
 func ClassToResponse(class *domain.Class) *ClassResponse {
-	return &ClassResponse{
+	response := &ClassResponse{
 		ID:         class.ID,
 		ClassName:  class.ClassName,
 		ClassAlias: class.ClassAlias,
@@ -37,6 +43,10 @@ func ClassToResponse(class *domain.Class) *ClassResponse {
 		CreatedAt:  class.CreatedAt,
 		UpdatedAt:  class.UpdatedAt,
 	}
+	if class.VehicleCategory != nil {
+		response.VehicleCategory = VehicleCategoryToResponse(class.VehicleCategory)
+	}
+	return response
 }
 
 func ClassFromCreate(request *CreateClassRequest) *domain.Class {
@@ -44,6 +54,8 @@ func ClassFromCreate(request *CreateClassRequest) *domain.Class {
 		ClassName:  request.ClassName,
 		ClassAlias: request.ClassAlias,
 		Type:       request.Type,
+
+		VehicleCategoryID: request.VehicleCategoryID,
 	}
 }
 
@@ -53,5 +65,7 @@ func ClassFromUpdate(request *UpdateClassRequest) *domain.Class {
 		ClassName:  request.ClassName,
 		ClassAlias: request.ClassAlias,
 		Type:       request.Type,
+
+		VehicleCategoryID: request.VehicleCategoryID,
 	}
 }
@@ -0,0 +1,77 @@
+package requests
+
+import (
+	constant "eticket-api/internal/common/constants"
+	"eticket-api/internal/domain"
+	"time"
+)
+
+type RegisterCustomerRequest struct {
+	Email       string `json:"email" validate:"required,email"`
+	Password    string `json:"password" validate:"required,min=8,max=72"` // bcrypt reads at most 72 bytes
+	FullName    string `json:"full_name" validate:"required,max=32"`
+	PhoneNumber string `json:"phone_number" validate:"required,max=14"`
+}
+
+type CustomerLoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+type VerifyCustomerEmailRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type CustomerResponse struct {
+	ID              uint       `json:"id"`
+	Email           string     `json:"email"`
+	FullName        string     `json:"full_name"`
+	PhoneNumber     string     `json:"phone_number"`
+	EmailVerified   bool       `json:"email_verified"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type CustomerTokenResponse struct {
+	AccessToken string            `json:"access_token"`
+	TokenType   string            `json:"token_type"`
+	ExpiresIn   int               `json:"expires_in"` // Seconds
+	Customer    *CustomerResponse `json:"customer"`
+}
+
+type ClaimGuestBookingsResponse struct {
+	Claimed int64 `json:"claimed"`
+}
+
+// Map a RegisterCustomerRequest to Customer domain
+func CustomerFromRegister(request *RegisterCustomerRequest) *domain.Customer {
+	return &domain.Customer{
+		Email:       request.Email,
+		Password:    request.Password,
+		FullName:    request.FullName,
+		PhoneNumber: request.PhoneNumber,
+	}
+}
+
+// Map a Customer domain to CustomerResponse model
+func CustomerToResponse(customer *domain.Customer) *CustomerResponse {
+	return &CustomerResponse{
+		ID:              customer.ID,
+		Email:           customer.Email,
+		FullName:        customer.FullName,
+		PhoneNumber:     customer.PhoneNumber,
+		EmailVerified:   customer.EmailVerifiedAt != nil,
+		EmailVerifiedAt: customer.EmailVerifiedAt,
+		CreatedAt:       customer.CreatedAt,
+	}
+}
+
+// Map a customer token to CustomerTokenResponse model
+func CustomerTokenToResponse(customer *domain.Customer, accessToken string) *CustomerTokenResponse {
+	return &CustomerTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(constant.CustomerTokenExpiry.Seconds()),
+		Customer:    CustomerToResponse(customer),
+	}
+}
@@ -0,0 +1,120 @@
+package requests
+
+import (
+	"eticket-api/internal/domain"
+	"time"
+)
+
+// Set either Amount or Percent. Leave a restriction empty to charge the fee
+// everywhere; HarborID matches the departure harbor.
+type CreateFeeComponentRequest struct {
+	Name              string  `json:"name" validate:"required,max=64"`
+	Kind              string  `json:"kind" validate:"required,oneof=PORT_SERVICE INSURANCE TAX"`
+	Amount            float64 `json:"amount" validate:"min=0"`
+	Percent           float64 `json:"percent" validate:"min=0,max=100"`
+	HarborID          *uint   `json:"harbor_id"`
+	DepartureHarborID *uint   `json:"departure_harbor_id" validate:"required_with=ArrivalHarborID"`
+	ArrivalHarborID   *uint   `json:"arrival_harbor_id" validate:"required_with=DepartureHarborID"`
+	ClassID           *uint   `json:"class_id"`
+	IsActive          bool    `json:"is_active"`
+}
+
+type UpdateFeeComponentRequest struct {
+	ID                uint    `json:"id" validate:"required"`
+	Name              string  `json:"name" validate:"required,max=64"`
+	Kind              string  `json:"kind" validate:"required,oneof=PORT_SERVICE INSURANCE TAX"`
+	Amount            float64 `json:"amount" validate:"min=0"`
+	Percent           float64 `json:"percent" validate:"min=0,max=100"`
+	HarborID          *uint   `json:"harbor_id"`
+	DepartureHarborID *uint   `json:"departure_harbor_id" validate:"required_with=ArrivalHarborID"`
+	ArrivalHarborID   *uint   `json:"arrival_harbor_id" validate:"required_with=DepartureHarborID"`
+	ClassID           *uint   `json:"class_id"`
+	IsActive          bool    `json:"is_active"`
+}
+
+type FeeComponentResponse struct {
+	ID                uint      `json:"id"`
+	Name              string    `json:"name"`
+	Kind              string    `json:"kind"`
+	Amount            float64   `json:"amount"`
+	Percent           float64   `json:"percent"`
+	HarborID          *uint     `json:"harbor_id"`
+	DepartureHarborID *uint     `json:"departure_harbor_id"`
+	ArrivalHarborID   *uint     `json:"arrival_harbor_id"`
+	ClassID           *uint     `json:"class_id"`
+	IsActive          bool      `json:"is_active"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// TicketFeeResponse is a fee charged on a ticket, or the total of one fee
+// across a booking
+type TicketFeeResponse struct {
+	Name   string  `json:"name"`
+	Kind   string  `json:"kind"`
+	Amount float64 `json:"amount"`
+}
+
+// Map FeeComponent domain to FeeComponentResponse model
+func FeeComponentToResponse(component *domain.FeeComponent) *FeeComponentResponse {
+	return &FeeComponentResponse{
+		ID:                component.ID,
+		Name:              component.Name,
+		Kind:              component.Kind,
+		Amount:            component.Amount,
+		Percent:           component.Percent,
+		HarborID:          component.HarborID,
+		DepartureHarborID: component.DepartureHarborID,
+		ArrivalHarborID:   component.ArrivalHarborID,
+		ClassID:           component.ClassID,
+		IsActive:          component.IsActive,
+		CreatedAt:         component.CreatedAt,
+		UpdatedAt:         component.UpdatedAt,
+	}
+}
+
+func FeeComponentFromCreate(request *CreateFeeComponentRequest) *domain.FeeComponent {
+	return &domain.FeeComponent{
+		Name:              request.Name,
+		Kind:              request.Kind,
+		Amount:            request.Amount,
+		Percent:           request.Percent,
+		HarborID:          request.HarborID,
+		DepartureHarborID: request.DepartureHarborID,
+		ArrivalHarborID:   request.ArrivalHarborID,
+		ClassID:           request.ClassID,
+		IsActive:          request.IsActive,
+	}
+}
+
+func FeeComponentFromUpdate(request *UpdateFeeComponentRequest) *domain.FeeComponent {
+	return &domain.FeeComponent{
+		ID:                request.ID,
+		Name:              request.Name,
+		Kind:              request.Kind,
+		Amount:            request.Amount,
+		Percent:           request.Percent,
+		HarborID:          request.HarborID,
+		DepartureHarborID: request.DepartureHarborID,
+		ArrivalHarborID:   request.ArrivalHarborID,
+		ClassID:           request.ClassID,
+		IsActive:          request.IsActive,
+	}
+}
+
+// TicketFeesToResponse adds up fees of the same name, in the order the names
+// first appear
+func TicketFeesToResponse(fees []domain.TicketFee) []TicketFeeResponse {
+	var responses []TicketFeeResponse
+	index := make(map[string]int)
+	for _, fee := range fees {
+		i, ok := index[fee.Name]
+		if !ok {
+			index[fee.Name] = len(responses)
+			responses = append(responses, TicketFeeResponse{Name: fee.Name, Kind: fee.Kind, Amount: fee.Amount})
+			continue
+		}
+		responses[i].Amount += fee.Amount
+	}
+	return responses
+}
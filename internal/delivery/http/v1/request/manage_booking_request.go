@@ -0,0 +1,44 @@
+package requests
+
+import (
+	constant "eticket-api/internal/common/constants"
+	"eticket-api/internal/domain"
+)
+
+type ManageBookingLoginRequest struct {
+	OrderID  string `json:"order_id" validate:"required"`
+	Email    string `json:"email" validate:"required,email"`
+	IDNumber string `json:"id_number" validate:"required"`
+}
+
+type BookingAccessCodeRequest struct {
+	OrderID string `json:"order_id" validate:"required"`
+	Email   string `json:"email" validate:"required,email"`
+}
+
+type VerifyBookingAccessCodeRequest struct {
+	OrderID string `json:"order_id" validate:"required"`
+	Code    string `json:"code" validate:"required,len=6,numeric"`
+}
+
+type UpdateBookingContactRequest struct {
+	Email       string `json:"email" validate:"required,email"`
+	PhoneNumber string `json:"phone_number" validate:"required,max=14"`
+}
+
+type ManageBookingTokenResponse struct {
+	AccessToken string           `json:"access_token"`
+	TokenType   string           `json:"token_type"`
+	ExpiresIn   int              `json:"expires_in"` // Seconds
+	Booking     *BookingResponse `json:"booking"`
+}
+
+// Map a booking token to ManageBookingTokenResponse model
+func ManageBookingTokenToResponse(booking *domain.Booking, accessToken string) *ManageBookingTokenResponse {
+	return &ManageBookingTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(constant.BookingTokenExpiry.Seconds()),
+		Booking:     BookingToResponse(booking),
+	}
+}
@@ -0,0 +1,133 @@
+package requests
+
+import (
+	"eticket-api/internal/domain"
+	"time"
+)
+
+// Leave a restriction empty to let the code apply to any route, class or
+// schedule. DiscountValue is a percent for PERCENTAGE and an amount for FIXED.
+type CreatePromotionRequest struct {
+	Code              string    `json:"code" validate:"required,max=32"`
+	Name              string    `json:"name" validate:"required,max=64"`
+	DiscountType      string    `json:"discount_type" validate:"required,oneof=PERCENTAGE FIXED"`
+	DiscountValue     float64   `json:"discount_value" validate:"required,gt=0"`
+	MaxDiscount       *float64  `json:"max_discount" validate:"omitempty,gt=0"`
+	MinSpend          float64   `json:"min_spend" validate:"min=0"`
+	ValidFrom         time.Time `json:"valid_from" validate:"required"`
+	ValidUntil        time.Time `json:"valid_until" validate:"required,gtfield=ValidFrom"`
+	UsageLimit        *int      `json:"usage_limit" validate:"omitempty,min=1"`
+	PerCustomerLimit  *int      `json:"per_customer_limit" validate:"omitempty,min=1"`
+	DepartureHarborID *uint     `json:"departure_harbor_id" validate:"required_with=ArrivalHarborID"`
+	ArrivalHarborID   *uint     `json:"arrival_harbor_id" validate:"required_with=DepartureHarborID"`
+	ClassID           *uint     `json:"class_id"`
+	ScheduleID        *uint     `json:"schedule_id"`
+	IsActive          bool      `json:"is_active"`
+}
+
+type UpdatePromotionRequest struct {
+	ID                uint      `json:"id" validate:"required"`
+	Code              string    `json:"code" validate:"required,max=32"`
+	Name              string    `json:"name" validate:"required,max=64"`
+	DiscountType      string    `json:"discount_type" validate:"required,oneof=PERCENTAGE FIXED"`
+	DiscountValue     float64   `json:"discount_value" validate:"required,gt=0"`
+	MaxDiscount       *float64  `json:"max_discount" validate:"omitempty,gt=0"`
+	MinSpend          float64   `json:"min_spend" validate:"min=0"`
+	ValidFrom         time.Time `json:"valid_from" validate:"required"`
+	ValidUntil        time.Time `json:"valid_until" validate:"required,gtfield=ValidFrom"`
+	UsageLimit        *int      `json:"usage_limit" validate:"omitempty,min=1"`
+	PerCustomerLimit  *int      `json:"per_customer_limit" validate:"omitempty,min=1"`
+	DepartureHarborID *uint     `json:"departure_harbor_id" validate:"required_with=ArrivalHarborID"`
+	ArrivalHarborID   *uint     `json:"arrival_harbor_id" validate:"required_with=DepartureHarborID"`
+	ClassID           *uint     `json:"class_id"`
+	ScheduleID        *uint     `json:"schedule_id"`
+	IsActive          bool      `json:"is_active"`
+}
+
+type PromotionResponse struct {
+	ID                uint      `json:"id"`
+	Code              string    `json:"code"`
+	Name              string    `json:"name"`
+	DiscountType      string    `json:"discount_type"`
+	DiscountValue     float64   `json:"discount_value"`
+	MaxDiscount       *float64  `json:"max_discount"`
+	MinSpend          float64   `json:"min_spend"`
+	ValidFrom         time.Time `json:"valid_from"`
+	ValidUntil        time.Time `json:"valid_until"`
+	UsageLimit        *int      `json:"usage_limit"`
+	PerCustomerLimit  *int      `json:"per_customer_limit"`
+	UsedCount         int       `json:"used_count"`
+	DepartureHarborID *uint     `json:"departure_harbor_id"`
+	ArrivalHarborID   *uint     `json:"arrival_harbor_id"`
+	ClassID           *uint     `json:"class_id"`
+	ScheduleID        *uint     `json:"schedule_id"`
+	IsActive          bool      `json:"is_active"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// Map Promotion domain to PromotionResponse model
+func PromotionToResponse(promotion *domain.Promotion) *PromotionResponse {
+	return &PromotionResponse{
+		ID:                promotion.ID,
+		Code:              promotion.Code,
+		Name:              promotion.Name,
+		DiscountType:      promotion.DiscountType,
+		DiscountValue:     promotion.DiscountValue,
+		MaxDiscount:       promotion.MaxDiscount,
+		MinSpend:          promotion.MinSpend,
+		ValidFrom:         promotion.ValidFrom,
+		ValidUntil:        promotion.ValidUntil,
+		UsageLimit:        promotion.UsageLimit,
+		PerCustomerLimit:  promotion.PerCustomerLimit,
+		UsedCount:         promotion.UsedCount,
+		DepartureHarborID: promotion.DepartureHarborID,
+		ArrivalHarborID:   promotion.ArrivalHarborID,
+		ClassID:           promotion.ClassID,
+		ScheduleID:        promotion.ScheduleID,
+		IsActive:          promotion.IsActive,
+		CreatedAt:         promotion.CreatedAt,
+		UpdatedAt:         promotion.UpdatedAt,
+	}
+}
+
+func PromotionFromCreate(request *CreatePromotionRequest) *domain.Promotion {
+	return &domain.Promotion{
+		Code:              request.Code,
+		Name:              request.Name,
+		DiscountType:      request.DiscountType,
+		DiscountValue:     request.DiscountValue,
+		MaxDiscount:       request.MaxDiscount,
+		MinSpend:          request.MinSpend,
+		ValidFrom:         request.ValidFrom,
+		ValidUntil:        request.ValidUntil,
+		UsageLimit:        request.UsageLimit,
+		PerCustomerLimit:  request.PerCustomerLimit,
+		DepartureHarborID: request.DepartureHarborID,
+		ArrivalHarborID:   request.ArrivalHarborID,
+		ClassID:           request.ClassID,
+		ScheduleID:        request.ScheduleID,
+		IsActive:          request.IsActive,
+	}
+}
+
+func PromotionFromUpdate(request *UpdatePromotionRequest) *domain.Promotion {
+	return &domain.Promotion{
+		ID:                request.ID,
+		Code:              request.Code,
+		Name:              request.Name,
+		DiscountType:      request.DiscountType,
+		DiscountValue:     request.DiscountValue,
+		MaxDiscount:       request.MaxDiscount,
+		MinSpend:          request.MinSpend,
+		ValidFrom:         request.ValidFrom,
+		ValidUntil:        request.ValidUntil,
+		UsageLimit:        request.UsageLimit,
+		PerCustomerLimit:  request.PerCustomerLimit,
+		DepartureHarborID: request.DepartureHarborID,
+		ArrivalHarborID:   request.ArrivalHarborID,
+		ClassID:           request.ClassID,
+		ScheduleID:        request.ScheduleID,
+		IsActive:          request.IsActive,
+	}
+}
@@ -5,31 +5,51 @@ import (
 	"time"
 )
 
+// Leave MaxAge empty for a fare with no upper age. Categories are ADULT,
+// CHILD, INFANT and SENIOR; infants ride on a lap without a seat.
+type FareRequest struct {
+	Category string  `json:"category" validate:"required,oneof=ADULT CHILD INFANT SENIOR"`
+	MinAge   int     `json:"min_age" validate:"min=0"`
+	MaxAge   *int    `json:"max_age" validate:"omitempty,min=0"`
+	Price    float64 `json:"price" validate:"min=0"`
+}
+
+// Price is charged to everyone when Fares is empty.
 type CreateQuotaRequest struct {
-	ScheduleID uint    `json:"schedule_id" validate:"required,gt=0"`
-	ClassID    uint    `json:"class_id" validate:"required,gt=0"`
-	Capacity   int     `json:"capacity" validate:"required,gte=0"`
-	Price      float64 `json:"price"`
+	ScheduleID uint          `json:"schedule_id" validate:"required,gt=0"`
+	ClassID    uint          `json:"class_id" validate:"required,gt=0"`
+	Capacity   int           `json:"capacity" validate:"required,gte=0"`
+	Price      float64       `json:"price"`
+	Fares      []FareRequest `json:"fares" validate:"omitempty,dive"`
 }
 
 type UpdateQuotaRequest struct {
-	ID         uint    `json:"id" validate:"required,gt=0"`
-	ScheduleID uint    `json:"schedule_id" validate:"required,gt=0"`
-	ClassID    uint    `json:"class_id" validate:"required,gt=0"`
-	Price      float64 `json:"price"`
-	Capacity   int     `json:"capacity" validate:"required,gte=0"`
+	ID         uint          `json:"id" validate:"required,gt=0"`
+	ScheduleID uint          `json:"schedule_id" validate:"required,gt=0"`
+	ClassID    uint          `json:"class_id" validate:"required,gt=0"`
+	Price      float64       `json:"price"`
+	Capacity   int           `json:"capacity" validate:"required,gte=0"`
+	Fares      []FareRequest `json:"fares" validate:"omitempty,dive"`
 }
 
 type QuotaResponse struct {
-	ID         uint          `json:"id"`
-	ScheduleID uint          `json:"schedule_id"`
-	Class      QuotaClass    `json:"class"`
-	Schedule   QuotaSchedule `json:"schedule"`
-	Price      float64       `json:"price"`
-	Quota      int           `json:"quota"`
-	Capacity   int           `json:"Capacity"`
-	CreatedAt  time.Time     `json:"created_at"`
-	UpdatedAt  time.Time     `json:"updated_at"`
+	ID         uint           `json:"id"`
+	ScheduleID uint           `json:"schedule_id"`
+	Class      QuotaClass     `json:"class"`
+	Schedule   QuotaSchedule  `json:"schedule"`
+	Price      float64        `json:"price"`
+	Fares      []FareResponse `json:"fares"`
+	Quota      int            `json:"quota"`
+	Capacity   int            `json:"Capacity"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+type FareResponse struct {
+	Category string  `json:"category"`
+	MinAge   int     `json:"min_age"`
+	MaxAge   *int    `json:"max_age"`
+	Price    float64 `json:"price"`
 }
 
 type QuotaClass struct {
@@ -48,6 +68,16 @@ type QuotaSchedule struct {
 }
 
 func QuotaToResponse(quota *domain.Quota) *QuotaResponse {
+	fares := make([]FareResponse, len(quota.Fares))
+	for i, fare := range quota.Fares {
+		fares[i] = FareResponse{
+			Category: fare.Category,
+			MinAge:   fare.MinAge,
+			MaxAge:   fare.MaxAge,
+			Price:    fare.Price,
+		}
+	}
+
 	return &QuotaResponse{
 		ID:         quota.ID,
 		ScheduleID: quota.ScheduleID,
@@ -65,6 +95,7 @@ func QuotaToResponse(quota *domain.Quota) *QuotaResponse {
 			ArrivalDatetime:   quota.Schedule.ArrivalDatetime,
 		},
 		Price:     quota.Price,
+		Fares:     fares,
 		Quota:     quota.Quota,
 		Capacity:  quota.Capacity, // Remaining quota after reservations
 		CreatedAt: quota.CreatedAt,
@@ -78,6 +109,7 @@ func QuotaFromCreate(request *CreateQuotaRequest) *domain.Quota {
 		ClassID:    request.ClassID,
 		Price:      request.Price,
 		Capacity:   request.Capacity,
+		Fares:      buildFares(request.Fares),
 	}
 }
 
@@ -88,5 +120,20 @@ func QuotaFromUpdate(request *UpdateQuotaRequest) *domain.Quota {
 		ClassID:    request.ClassID,
 		Price:      request.Price,
 		Capacity:   request.Capacity,
+		Fares:      buildFares(request.Fares),
+	}
+}
+
+// Helper to build quota fares from the request
+func buildFares(requests []FareRequest) []domain.Fare {
+	fares := make([]domain.Fare, len(requests))
+	for i, fare := range requests {
+		fares[i] = domain.Fare{
+			Category: fare.Category,
+			MinAge:   fare.MinAge,
+			MaxAge:   fare.MaxAge,
+			Price:    fare.Price,
+		}
 	}
+	return fares
 }
@@ -0,0 +1,10 @@
+package requests
+
+// CancelScheduleRequest cancels a departure. Paid bookings get a full refund,
+// or with REBOOK a seat on ToScheduleID, or on the next departure of the
+// route with room when it is left out.
+type CancelScheduleRequest struct {
+	Reason       string `json:"reason" validate:"required,max=500"`
+	Resolution   string `json:"resolution" validate:"required,oneof=REFUND REBOOK"`
+	ToScheduleID *uint  `json:"to_schedule_id" validate:"omitempty,gt=0"`
+}
@@ -0,0 +1,55 @@
+package requests
+
+import (
+	"eticket-api/internal/domain"
+	"time"
+)
+
+// DelayScheduleRequest moves a departure later. The arrival moves by the
+// same amount when ArrivalDatetime is left out.
+type DelayScheduleRequest struct {
+	DepartureDatetime time.Time  `json:"departure_datetime" validate:"required"`
+	ArrivalDatetime   *time.Time `json:"arrival_datetime" validate:"omitempty,gtfield=DepartureDatetime"`
+	Reason            string     `json:"reason" validate:"required,oneof=WEATHER TECHNICAL OPERATIONAL PORT_CONGESTION OTHER"`
+	Note              string     `json:"note" validate:"max=500"`
+	NotifySMS         bool       `json:"notify_sms"`
+}
+
+// OnTimePerformanceRequest is the period of an on-time performance report,
+// from its first day up to and including its last.
+type OnTimePerformanceRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02" validate:"required"`
+	To   time.Time `form:"to" time_format:"2006-01-02" validate:"required,gtefield=From"`
+}
+
+type ScheduleDelayResponse struct {
+	ID                uint      `json:"id"`
+	ScheduleID        uint      `json:"schedule_id"`
+	Reason            string    `json:"reason"`
+	Note              string    `json:"note"`
+	PreviousDeparture time.Time `json:"previous_departure"`
+	NewDeparture      time.Time `json:"new_departure"`
+	PreviousArrival   time.Time `json:"previous_arrival"`
+	NewArrival        time.Time `json:"new_arrival"`
+	DelayMinutes      int       `json:"delay_minutes"`
+	Notified          int       `json:"notified"`
+	UserID            *uint     `json:"user_id"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+func ScheduleDelayToResponse(delay *domain.ScheduleDelay) *ScheduleDelayResponse {
+	return &ScheduleDelayResponse{
+		ID:                delay.ID,
+		ScheduleID:        delay.ScheduleID,
+		Reason:            delay.Reason,
+		Note:              delay.Note,
+		PreviousDeparture: delay.PreviousDeparture,
+		NewDeparture:      delay.NewDeparture,
+		PreviousArrival:   delay.PreviousArrival,
+		NewArrival:        delay.NewArrival,
+		DelayMinutes:      delay.Minutes(),
+		Notified:          delay.Notified,
+		UserID:            delay.UserID,
+		CreatedAt:         delay.CreatedAt,
+	}
+}
@@ -32,11 +32,22 @@ type ScheduleResponse struct {
 	DepartureDatetime time.Time       `json:"departure_datetime"`
 	ArrivalDatetime   time.Time       `json:"arrival_datetime"`
 	Status            string          `json:"status"`
+	Delay             *ScheduleDelay  `json:"delay"` // Null while the departure keeps its original time
 	Quotas            []ScheduleQuota `json:"quotas"`
 	CreatedAt         time.Time       `json:"created_at"`
 	UpdatedAt         time.Time       `json:"updated_at"`
 }
 
+// ScheduleDelay is how late a departure now leaves compared to its original
+// time, and the reason of its latest delay.
+type ScheduleDelay struct {
+	OriginalDepartureDatetime time.Time `json:"original_departure_datetime"`
+	OriginalArrivalDatetime   time.Time `json:"original_arrival_datetime"`
+	DelayMinutes              int       `json:"delay_minutes"`
+	Reason                    string    `json:"reason"`
+	Note                      string    `json:"note"`
+}
+
 type ScheduleHarbor struct {
 	ID         uint   `json:"id"`
 	HarborName string `json:"harbor_name"`
@@ -78,6 +89,21 @@ func buildScheduleQuotas(quotasDomain []*domain.Quota) []ScheduleQuota {
 	return quotas
 }
 
+func buildScheduleDelay(schedule *domain.Schedule) *ScheduleDelay {
+	if len(schedule.Delays) == 0 {
+		return nil
+	}
+	first := schedule.Delays[0]
+	latest := schedule.Delays[len(schedule.Delays)-1]
+	return &ScheduleDelay{
+		OriginalDepartureDatetime: first.PreviousDeparture,
+		OriginalArrivalDatetime:   first.PreviousArrival,
+		DelayMinutes:              int(schedule.DepartureDatetime.Sub(first.PreviousDeparture).Minutes()),
+		Reason:                    latest.Reason,
+		Note:                      latest.Note,
+	}
+}
+
 // Map Schedule domain to ReadScheduleResponse model
 func ScheduleToResponse(schedule *domain.Schedule) *ScheduleResponse {
 	return &ScheduleResponse{
@@ -98,6 +124,7 @@ func ScheduleToResponse(schedule *domain.Schedule) *ScheduleResponse {
 		DepartureDatetime: schedule.DepartureDatetime,
 		ArrivalDatetime:   schedule.ArrivalDatetime,
 		Status:            schedule.Status,
+		Delay:             buildScheduleDelay(schedule),
 		CreatedAt:         schedule.CreatedAt,
 		UpdatedAt:         schedule.UpdatedAt,
 	}
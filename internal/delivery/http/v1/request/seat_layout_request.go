@@ -0,0 +1,204 @@
+package requests
+
+import (
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"fmt"
+	"time"
+)
+
+type LayoutSeatRequest struct {
+	SeatNumber   string `json:"seat_number" validate:"required,max=24"`
+	RowNumber    int    `json:"row_number" validate:"required,min=1"`
+	ColumnNumber int    `json:"column_number" validate:"required,min=1"`
+	IsBlocked    bool   `json:"is_blocked"`
+}
+
+// Seats is optional; when empty a rows x columns grid labelled 1A, 1B, ... is
+// generated and BlockedSeats marks seats of that grid as not for sale.
+type CreateSeatLayoutRequest struct {
+	ShipID       uint                `json:"ship_id" validate:"required"`
+	ClassID      uint                `json:"class_id" validate:"required"`
+	Rows         int                 `json:"rows" validate:"required,min=1,max=200"`
+	Columns      int                 `json:"columns" validate:"required,min=1,max=26"`
+	BlockedSeats []string            `json:"blocked_seats"`
+	Seats        []LayoutSeatRequest `json:"seats" validate:"dive"`
+}
+
+type UpdateSeatLayoutRequest struct {
+	ID           uint                `json:"id" validate:"required"`
+	ShipID       uint                `json:"ship_id" validate:"required"`
+	ClassID      uint                `json:"class_id" validate:"required"`
+	Rows         int                 `json:"rows" validate:"required,min=1,max=200"`
+	Columns      int                 `json:"columns" validate:"required,min=1,max=26"`
+	BlockedSeats []string            `json:"blocked_seats"`
+	Seats        []LayoutSeatRequest `json:"seats" validate:"dive"`
+}
+
+type SeatLayoutShip struct {
+	ID       uint   `json:"id"`
+	ShipName string `json:"ship_name"`
+}
+
+type SeatLayoutClass struct {
+	ID        uint   `json:"id"`
+	ClassName string `json:"class_name"`
+	Type      string `json:"type"`
+}
+
+type LayoutSeatResponse struct {
+	SeatNumber   string `json:"seat_number"`
+	RowNumber    int    `json:"row_number"`
+	ColumnNumber int    `json:"column_number"`
+	IsBlocked    bool   `json:"is_blocked"`
+}
+
+type SeatLayoutResponse struct {
+	ID        uint                 `json:"id"`
+	Ship      SeatLayoutShip       `json:"ship"`
+	Class     SeatLayoutClass      `json:"class"`
+	Rows      int                  `json:"rows"`
+	Columns   int                  `json:"columns"`
+	Seats     []LayoutSeatResponse `json:"seats"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+type SeatMapSeat struct {
+	SeatNumber   string `json:"seat_number"`
+	ColumnNumber int    `json:"column_number"`
+	Status       string `json:"status"`
+}
+
+type SeatMapRow struct {
+	RowNumber int           `json:"row_number"`
+	Seats     []SeatMapSeat `json:"seats"`
+}
+
+type SeatMapClass struct {
+	Class     SeatLayoutClass `json:"class"`
+	Available int             `json:"available"`
+	Rows      []SeatMapRow    `json:"rows"`
+}
+
+type SeatMapResponse struct {
+	ScheduleID uint           `json:"schedule_id"`
+	Classes    []SeatMapClass `json:"classes"`
+}
+
+// Map SeatLayout domain to SeatLayoutResponse model
+func SeatLayoutToResponse(layout *domain.SeatLayout) *SeatLayoutResponse {
+	seats := make([]LayoutSeatResponse, len(layout.Seats))
+	for i, seat := range layout.Seats {
+		seats[i] = LayoutSeatResponse{
+			SeatNumber:   seat.SeatNumber,
+			RowNumber:    seat.RowNumber,
+			ColumnNumber: seat.ColumnNumber,
+			IsBlocked:    seat.IsBlocked,
+		}
+	}
+	return &SeatLayoutResponse{
+		ID: layout.ID,
+		Ship: SeatLayoutShip{
+			ID:       layout.Ship.ID,
+			ShipName: layout.Ship.ShipName,
+		},
+		Class: SeatLayoutClass{
+			ID:        layout.Class.ID,
+			ClassName: layout.Class.ClassName,
+			Type:      layout.Class.Type,
+		},
+		Rows:      layout.Rows,
+		Columns:   layout.Columns,
+		Seats:     seats,
+		CreatedAt: layout.CreatedAt,
+		UpdatedAt: layout.UpdatedAt,
+	}
+}
+
+func SeatLayoutFromCreate(request *CreateSeatLayoutRequest) *domain.SeatLayout {
+	return &domain.SeatLayout{
+		ShipID:  request.ShipID,
+		ClassID: request.ClassID,
+		Rows:    request.Rows,
+		Columns: request.Columns,
+		Seats:   buildLayoutSeats(request.Rows, request.Columns, request.BlockedSeats, request.Seats),
+	}
+}
+
+func SeatLayoutFromUpdate(request *UpdateSeatLayoutRequest) *domain.SeatLayout {
+	return &domain.SeatLayout{
+		ID:      request.ID,
+		ShipID:  request.ShipID,
+		ClassID: request.ClassID,
+		Rows:    request.Rows,
+		Columns: request.Columns,
+		Seats:   buildLayoutSeats(request.Rows, request.Columns, request.BlockedSeats, request.Seats),
+	}
+}
+
+// Helper to build layout seats from explicit seats or a generated grid
+func buildLayoutSeats(rows, columns int, blocked []string, explicit []LayoutSeatRequest) []domain.LayoutSeat {
+	if len(explicit) > 0 {
+		seats := make([]domain.LayoutSeat, len(explicit))
+		for i, seat := range explicit {
+			seats[i] = domain.LayoutSeat{
+				SeatNumber:   seat.SeatNumber,
+				RowNumber:    seat.RowNumber,
+				ColumnNumber: seat.ColumnNumber,
+				IsBlocked:    seat.IsBlocked,
+			}
+		}
+		return seats
+	}
+
+	isBlocked := make(map[string]bool, len(blocked))
+	for _, number := range blocked {
+		isBlocked[number] = true
+	}
+	seats := make([]domain.LayoutSeat, 0, rows*columns)
+	for row := 1; row <= rows; row++ {
+		for column := 1; column <= columns; column++ {
+			number := fmt.Sprintf("%d%c", row, 'A'+column-1)
+			seats = append(seats, domain.LayoutSeat{
+				SeatNumber:   number,
+				RowNumber:    row,
+				ColumnNumber: column,
+				IsBlocked:    isBlocked[number],
+			})
+		}
+	}
+	return seats
+}
+
+// Map schedule seat inventory to SeatMapResponse, grouped by class and row.
+// Seats are expected ordered by class, row and column.
+func SeatMapToResponse(scheduleID uint, seats []*domain.ScheduleSeat) *SeatMapResponse {
+	response := &SeatMapResponse{ScheduleID: scheduleID, Classes: []SeatMapClass{}}
+	for _, seat := range seats {
+		classes := response.Classes
+		if len(classes) == 0 || classes[len(classes)-1].Class.ID != seat.ClassID {
+			response.Classes = append(response.Classes, SeatMapClass{
+				Class: SeatLayoutClass{
+					ID:        seat.Class.ID,
+					ClassName: seat.Class.ClassName,
+					Type:      seat.Class.Type,
+				},
+			})
+		}
+		class := &response.Classes[len(response.Classes)-1]
+		if len(class.Rows) == 0 || class.Rows[len(class.Rows)-1].RowNumber != seat.RowNumber {
+			class.Rows = append(class.Rows, SeatMapRow{RowNumber: seat.RowNumber})
+		}
+		row := &class.Rows[len(class.Rows)-1]
+		row.Seats = append(row.Seats, SeatMapSeat{
+			SeatNumber:   seat.SeatNumber,
+			ColumnNumber: seat.ColumnNumber,
+			Status:       seat.Status,
+		})
+		if seat.Status == enum.SeatAvailable.String() {
+			class.Available++
+		}
+	}
+	return response
+}
@@ -18,8 +18,13 @@ type CreateTicketRequest struct {
 	SeatNumber      *string `json:"seat_number"`
 	LicensePlate    *string `json:"license_plate"`
 	Type            string  `json:"type" validate:"required"`
-	Price           float64 `json:"price" validate:"required,gte=0"`
-	IsCheckedIn     bool    `json:"is_checked_in"`
+
+	VehicleType    *string  `json:"vehicle_type" validate:"omitempty,oneof=BICYCLE MOTORBIKE CAR BUS TRUCK"`
+	VehicleBrand   *string  `json:"vehicle_brand" validate:"omitempty,max=32"`
+	VehicleLength  *float64 `json:"vehicle_length" validate:"omitempty,gt=0"`
+	DriverTicketID *uint    `json:"driver_ticket_id"`
+	Price          float64  `json:"price" validate:"required,gte=0"`
+	IsCheckedIn    bool     `json:"is_checked_in"`
 }
 
 type UpdateTicketRequest struct {
@@ -36,29 +41,66 @@ type UpdateTicketRequest struct {
 	SeatNumber      *string `json:"seat_number"`
 	LicensePlate    *string `json:"license_plate"`
 	Type            string  `json:"type" validate:"required"`
-	Price           float64 `json:"price" validate:"required,gte=0"`
-	IsCheckedIn     bool    `json:"is_checked_in"`
+
+	VehicleType    *string  `json:"vehicle_type" validate:"omitempty,oneof=BICYCLE MOTORBIKE CAR BUS TRUCK"`
+	VehicleBrand   *string  `json:"vehicle_brand" validate:"omitempty,max=32"`
+	VehicleLength  *float64 `json:"vehicle_length" validate:"omitempty,gt=0"`
+	DriverTicketID *uint    `json:"driver_ticket_id"`
+	Price          float64  `json:"price" validate:"required,gte=0"`
+	IsCheckedIn    bool     `json:"is_checked_in"`
+}
+
+type VerifyTicketRequest struct {
+	Payload    string `json:"payload" validate:"required"`
+	ScheduleID *uint  `json:"schedule_id"`
+	Gate       string `json:"gate" validate:"max=32"`
+	HarborID   *uint  `json:"harbor_id"`
+}
+
+type TicketPassResponse struct {
+	TicketID   uint   `json:"ticket_id"`
+	TicketCode string `json:"ticket_code"`
+	Payload    string `json:"payload"`
 }
 
 type TicketResponse struct {
-	ID              uint           `json:"id"`
-	Schedule        TicketSchedule `json:"schedule"`
-	Class           TicketClass    `json:"class"`
-	TicketCode      string         `json:"ticket_code"`
-	Booking         *TicketBooking `json:"booking,omitempty"`
-	PassengerName   string         `json:"passenger_name"`
-	PassengerAge    int            `json:"passenger_age"`
-	Address         string         `json:"address"`
-	PassengerGender *string        `json:"passenger"`
-	IDType          *string        `json:"id_type"`
-	IDNumber        *string        `json:"id_number"`
-	SeatNumber      *string        `json:"seat_number"`
-	LicensePlate    *string        `json:"license_plate"`
-	Type            string         `json:"type" binding:"required,oneof=passenger vehicle"`
-	Price           float64        `json:"price"`
-	IsCheckedIn     bool           `json:"is_checked_in"`
-	CreatedAt       time.Time      `json:"created_at"`
-	UpdatedAt       time.Time      `json:"updated_at"`
+	ID              uint                   `json:"id"`
+	Schedule        TicketSchedule         `json:"schedule"`
+	Class           TicketClass            `json:"class"`
+	TicketCode      string                 `json:"ticket_code"`
+	Booking         *TicketBooking         `json:"booking,omitempty"`
+	PassengerName   string                 `json:"passenger_name"`
+	PassengerAge    int                    `json:"passenger_age"`
+	Address         string                 `json:"address"`
+	PassengerGender *string                `json:"passenger"`
+	IDType          *string                `json:"id_type"`
+	IDNumber        *string                `json:"id_number"`
+	SeatNumber      *string                `json:"seat_number"`
+	LicensePlate    *string                `json:"license_plate"`
+	Type            string                 `json:"type" binding:"required,oneof=passenger vehicle"`
+	Price           float64                `json:"price"`
+	FareCategory    string                 `json:"fare_category"`
+	Fees            []TicketFeeResponse    `json:"fees"`
+	Addons          []BookingAddonResponse `json:"addons"`
+	VehicleType     *string                `json:"vehicle_type,omitempty"`
+	VehicleBrand    *string                `json:"vehicle_brand,omitempty"`
+	VehicleLength   *float64               `json:"vehicle_length,omitempty"`
+	DriverTicketID  *uint                  `json:"driver_ticket_id,omitempty"`
+	Driver          *TicketDriver          `json:"driver,omitempty"`
+	IsCheckedIn     bool                   `json:"is_checked_in"`
+	BoardingStatus  string                 `json:"boarding_status"`
+	CheckedInAt     *time.Time             `json:"checked_in_at"`
+	BoardedAt       *time.Time             `json:"boarded_at"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+// TicketDriver is the passenger driving a vehicle ticket
+type TicketDriver struct {
+	ID            uint    `json:"id"`
+	TicketCode    string  `json:"ticket_code"`
+	PassengerName string  `json:"passenger_name"`
+	IDNumber      *string `json:"id_number"`
 }
 
 type TicketScheduleHarbor struct {
@@ -100,7 +142,7 @@ type TicketClass struct {
 
 // Map Ticket domain to ReadTicketResponse model
 func TicketToResponse(ticket *domain.Ticket) *TicketResponse {
-	return &TicketResponse{
+	response := &TicketResponse{
 		ID: ticket.ID,
 		Schedule: TicketSchedule{
 			ID: ticket.Schedule.ID,
@@ -134,8 +176,18 @@ func TicketToResponse(ticket *domain.Ticket) *TicketResponse {
 		SeatNumber:      ticket.SeatNumber,
 		LicensePlate:    ticket.LicensePlate,
 		IsCheckedIn:     ticket.IsCheckedIn,
+		BoardingStatus:  ticket.BoardingStatus,
+		CheckedInAt:     ticket.CheckedInAt,
+		BoardedAt:       ticket.BoardedAt,
 		Type:            ticket.Type,
 		Price:           ticket.Price,
+		FareCategory:    ticket.FareCategory,
+		Fees:            TicketFeesToResponse(ticket.Fees),
+		Addons:          BookingAddonsToResponse(ticket.Addons),
+		VehicleType:     ticket.VehicleType,
+		VehicleBrand:    ticket.VehicleBrand,
+		VehicleLength:   ticket.VehicleLength,
+		DriverTicketID:  ticket.DriverTicketID,
 		Booking: &TicketBooking{
 			ID:           ticket.Booking.ID,
 			OrderID:      ticket.Booking.OrderID, // Unique identifier for the booking
@@ -148,7 +200,15 @@ func TicketToResponse(ticket *domain.Ticket) *TicketResponse {
 		CreatedAt: ticket.CreatedAt,
 		UpdatedAt: ticket.UpdatedAt,
 	}
-
+	if ticket.Driver != nil {
+		response.Driver = &TicketDriver{
+			ID:            ticket.Driver.ID,
+			TicketCode:    ticket.Driver.TicketCode,
+			PassengerName: ticket.Driver.PassengerName,
+			IDNumber:      ticket.Driver.IDNumber,
+		}
+	}
+	return response
 }
 
 func TicketFromCreate(request *CreateTicketRequest) *domain.Ticket {
@@ -164,6 +224,10 @@ func TicketFromCreate(request *CreateTicketRequest) *domain.Ticket {
 		IDNumber:        request.IDNumber,
 		SeatNumber:      request.SeatNumber,
 		LicensePlate:    request.LicensePlate,
+		VehicleType:     request.VehicleType,
+		VehicleBrand:    request.VehicleBrand,
+		VehicleLength:   request.VehicleLength,
+		DriverTicketID:  request.DriverTicketID,
 		Type:            request.Type,
 		Price:           request.Price,
 	}
@@ -182,6 +246,10 @@ func TicketFromUpdate(request *UpdateTicketRequest) *domain.Ticket {
 		IDNumber:        request.IDNumber,
 		SeatNumber:      request.SeatNumber,
 		LicensePlate:    request.LicensePlate,
+		VehicleType:     request.VehicleType,
+		VehicleBrand:    request.VehicleBrand,
+		VehicleLength:   request.VehicleLength,
+		DriverTicketID:  request.DriverTicketID,
 		Type:            request.Type,
 		Price:           request.Price,
 	}
@@ -0,0 +1,77 @@
+package requests
+
+import (
+	"eticket-api/internal/domain"
+	"time"
+)
+
+// Lengths are in meters. LaneMeters is the deck length one vehicle takes when
+// its quota is counted in lane meters; leave it zero to count vehicles.
+type CreateVehicleCategoryRequest struct {
+	Golongan    string   `json:"golongan" validate:"required,max=8"`
+	Name        string   `json:"name" validate:"required,max=64"`
+	VehicleType string   `json:"vehicle_type" validate:"required,oneof=BICYCLE MOTORBIKE CAR BUS TRUCK"`
+	MinLength   float64  `json:"min_length" validate:"min=0"`
+	MaxLength   *float64 `json:"max_length" validate:"omitempty,gtfield=MinLength"`
+	LaneMeters  int      `json:"lane_meters" validate:"min=0"`
+}
+
+type UpdateVehicleCategoryRequest struct {
+	ID          uint     `json:"id" validate:"required"`
+	Golongan    string   `json:"golongan" validate:"required,max=8"`
+	Name        string   `json:"name" validate:"required,max=64"`
+	VehicleType string   `json:"vehicle_type" validate:"required,oneof=BICYCLE MOTORBIKE CAR BUS TRUCK"`
+	MinLength   float64  `json:"min_length" validate:"min=0"`
+	MaxLength   *float64 `json:"max_length" validate:"omitempty,gtfield=MinLength"`
+	LaneMeters  int      `json:"lane_meters" validate:"min=0"`
+}
+
+type VehicleCategoryResponse struct {
+	ID          uint      `json:"id"`
+	Golongan    string    `json:"golongan"`
+	Name        string    `json:"name"`
+	VehicleType string    `json:"vehicle_type"`
+	MinLength   float64   `json:"min_length"`
+	MaxLength   *float64  `json:"max_length"`
+	LaneMeters  int       `json:"lane_meters"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Map VehicleCategory domain to VehicleCategoryResponse model
+func VehicleCategoryToResponse(category *domain.VehicleCategory) *VehicleCategoryResponse {
+	return &VehicleCategoryResponse{
+		ID:          category.ID,
+		Golongan:    category.Golongan,
+		Name:        category.Name,
+		VehicleType: category.VehicleType,
+		MinLength:   category.MinLength,
+		MaxLength:   category.MaxLength,
+		LaneMeters:  category.LaneMeters,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
+
+func VehicleCategoryFromCreate(request *CreateVehicleCategoryRequest) *domain.VehicleCategory {
+	return &domain.VehicleCategory{
+		Golongan:    request.Golongan,
+		Name:        request.Name,
+		VehicleType: request.VehicleType,
+		MinLength:   request.MinLength,
+		MaxLength:   request.MaxLength,
+		LaneMeters:  request.LaneMeters,
+	}
+}
+
+func VehicleCategoryFromUpdate(request *UpdateVehicleCategoryRequest) *domain.VehicleCategory {
+	return &domain.VehicleCategory{
+		ID:          request.ID,
+		Golongan:    request.Golongan,
+		Name:        request.Name,
+		VehicleType: request.VehicleType,
+		MinLength:   request.MinLength,
+		MaxLength:   request.MaxLength,
+		LaneMeters:  request.LaneMeters,
+	}
+}
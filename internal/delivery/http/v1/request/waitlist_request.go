@@ -0,0 +1,101 @@
+package requests
+
+import (
+	"eticket-api/internal/domain"
+	"time"
+)
+
+type JoinWaitlistRequest struct {
+	ScheduleID   uint   `json:"schedule_id" validate:"required"`
+	ClassID      uint   `json:"class_id" validate:"required"`
+	CustomerName string `json:"customer_name" validate:"required"`
+	Email        string `json:"email" validate:"required,email"`
+	PhoneNumber  string `json:"phone_number" validate:"required,max=24"`
+	Quantity     int    `json:"quantity" validate:"required,min=1,max=20"`
+}
+
+type WaitlistHarbor struct {
+	ID         uint   `json:"id"`
+	HarborName string `json:"harbor_name"`
+}
+
+type WaitlistSchedule struct {
+	ID                uint           `json:"id"`
+	DepartureHarbor   WaitlistHarbor `json:"departure_harbor"`
+	ArrivalHarbor     WaitlistHarbor `json:"arrival_harbor"`
+	DepartureDatetime time.Time      `json:"departure_datetime"`
+}
+
+type WaitlistClass struct {
+	ID        uint   `json:"id"`
+	ClassName string `json:"class_name"`
+	Type      string `json:"type"`
+}
+
+type WaitlistResponse struct {
+	ID           uint             `json:"id"`
+	Schedule     WaitlistSchedule `json:"schedule"`
+	Class        WaitlistClass    `json:"class"`
+	CustomerName string           `json:"customer_name"`
+	Email        string           `json:"email"`
+	PhoneNumber  string           `json:"phone_number"`
+	Quantity     int              `json:"quantity"`
+	Status       string           `json:"status"`
+	SessionID    *string          `json:"session_id"`
+	OfferedAt    *time.Time       `json:"offered_at"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+type JoinWaitlistResponse struct {
+	ID     uint   `json:"id"`
+	Status string `json:"status"`
+}
+
+// Map Waitlist domain to WaitlistResponse model
+func WaitlistToResponse(waitlist *domain.Waitlist) *WaitlistResponse {
+	var sessionID *string
+	if waitlist.ClaimSession != nil {
+		sessionID = &waitlist.ClaimSession.SessionID
+	}
+	return &WaitlistResponse{
+		ID: waitlist.ID,
+		Schedule: WaitlistSchedule{
+			ID: waitlist.Schedule.ID,
+			DepartureHarbor: WaitlistHarbor{
+				ID:         waitlist.Schedule.DepartureHarbor.ID,
+				HarborName: waitlist.Schedule.DepartureHarbor.HarborName,
+			},
+			ArrivalHarbor: WaitlistHarbor{
+				ID:         waitlist.Schedule.ArrivalHarbor.ID,
+				HarborName: waitlist.Schedule.ArrivalHarbor.HarborName,
+			},
+			DepartureDatetime: waitlist.Schedule.DepartureDatetime,
+		},
+		Class: WaitlistClass{
+			ID:        waitlist.Class.ID,
+			ClassName: waitlist.Class.ClassName,
+			Type:      waitlist.Class.Type,
+		},
+		CustomerName: waitlist.CustomerName,
+		Email:        waitlist.Email,
+		PhoneNumber:  waitlist.PhoneNumber,
+		Quantity:     waitlist.Quantity,
+		Status:       waitlist.Status,
+		SessionID:    sessionID,
+		OfferedAt:    waitlist.OfferedAt,
+		CreatedAt:    waitlist.CreatedAt,
+		UpdatedAt:    waitlist.UpdatedAt,
+	}
+}
+
+func WaitlistFromJoin(request *JoinWaitlistRequest) *domain.Waitlist {
+	return &domain.Waitlist{
+		ScheduleID:   request.ScheduleID,
+		ClassID:      request.ClassID,
+		CustomerName: request.CustomerName,
+		Email:        request.Email,
+		PhoneNumber:  request.PhoneNumber,
+		Quantity:     request.Quantity,
+	}
+}
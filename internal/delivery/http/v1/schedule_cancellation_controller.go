@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"eticket-api/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleCancellationController struct {
+	Validate                    validator.Validator
+	Log                         logger.Logger
+	ScheduleCancellationUsecase *usecase.ScheduleCancellationUsecase
+}
+
+func NewScheduleCancellationController(
+	router *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	schedule_cancellation_usecase *usecase.ScheduleCancellationUsecase,
+) {
+	c := &ScheduleCancellationController{
+		Log:                         log,
+		Validate:                    validate,
+		ScheduleCancellationUsecase: schedule_cancellation_usecase,
+	}
+
+	protected.POST("/schedule/:id/cancel", c.CancelSchedule)
+	protected.GET("/schedule/:id/cancellation", c.GetCancellation)
+	protected.POST("/schedule/:id/cancellation/retry", c.RetryFailed)
+}
+
+// CancelSchedule cancels a departure. The bookings on it are settled by a
+// background job, the response is the progress report right after queueing.
+func (c *ScheduleCancellationController) CancelSchedule(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	request := new(requests.CancelScheduleRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	data, err := c.ScheduleCancellationUsecase.CancelSchedule(ctx, uint(id), request.Reason, request.Resolution, request.ToScheduleID, ctx.GetUint("user_id"))
+	if err != nil {
+		c.writeCancellationError(ctx, err, id)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.NewSuccessResponse(data, "Schedule cancelled, bookings are being settled", nil))
+}
+
+// GetCancellation reports how many bookings of a cancelled departure were
+// refunded, rebooked or cancelled, and which ones failed.
+func (c *ScheduleCancellationController) GetCancellation(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	data, err := c.ScheduleCancellationUsecase.GetCancellation(ctx, uint(id))
+	if err != nil {
+		c.writeCancellationError(ctx, err, id)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(data, "Cancellation retrieved successfully", nil))
+}
+
+// RetryFailed queues the bookings a cancellation gave up on again.
+func (c *ScheduleCancellationController) RetryFailed(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	data, err := c.ScheduleCancellationUsecase.RetryFailed(ctx, uint(id))
+	if err != nil {
+		c.writeCancellationError(ctx, err, id)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, response.NewSuccessResponse(data, "Failed bookings queued again", nil))
+}
+
+func (c *ScheduleCancellationController) writeCancellationError(ctx *gin.Context, err error, id int) {
+	if errors.Is(err, errs.ErrNotFound) {
+		c.Log.WithField("schedule_id", id).Warn("schedule or cancellation not found")
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Schedule or cancellation not found", nil))
+		return
+	}
+
+	if errors.Is(err, errs.ErrBadRequest) {
+		c.Log.WithError(err).WithField("schedule_id", id).Warn("invalid cancellation")
+		ctx.JSON(http.StatusUnprocessableEntity, response.NewErrorResponse("Invalid cancellation", err.Error()))
+		return
+	}
+
+	if errors.Is(err, errs.ErrConflict) {
+		c.Log.WithError(err).WithField("schedule_id", id).Warn("schedule cannot be cancelled")
+		ctx.JSON(http.StatusConflict, response.NewErrorResponse("Schedule cannot be cancelled", err.Error()))
+		return
+	}
+
+	c.Log.WithError(err).WithField("schedule_id", id).Error("failed to cancel schedule")
+	ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to cancel schedule", err.Error()))
+}
@@ -178,6 +178,12 @@ func (c *ScheduleController) UpdateSchedule(ctx *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("id", id).Warn("invalid schedule update")
+			ctx.JSON(http.StatusUnprocessableEntity, response.NewErrorResponse("Invalid schedule update", err.Error()))
+			return
+		}
+
 		if errors.Is(err, errs.ErrConflict) {
 			c.Log.WithError(err).Error("schedule already exists")
 			ctx.JSON(http.StatusConflict, response.NewErrorResponse("schedule already exists", nil))
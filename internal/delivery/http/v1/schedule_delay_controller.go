@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"eticket-api/internal/usecase"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ScheduleDelayController struct {
+	Validate             validator.Validator
+	Log                  logger.Logger
+	ScheduleDelayUsecase *usecase.ScheduleDelayUsecase
+}
+
+func NewScheduleDelayController(
+	router *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	schedule_delay_usecase *usecase.ScheduleDelayUsecase,
+) {
+	c := &ScheduleDelayController{
+		Log:                  log,
+		Validate:             validate,
+		ScheduleDelayUsecase: schedule_delay_usecase,
+	}
+
+	protected.POST("/schedule/:id/delay", c.DelaySchedule)
+	protected.GET("/schedule/:id/delays", c.ListDelays)
+	protected.GET("/schedules/on-time-performance", c.GetOnTimePerformance)
+}
+
+// DelaySchedule moves a departure later and tells its passengers.
+func (c *ScheduleDelayController) DelaySchedule(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	request := new(requests.DelayScheduleRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	data, err := c.ScheduleDelayUsecase.DelaySchedule(ctx, uint(id), request.DepartureDatetime, request.ArrivalDatetime,
+		request.Reason, request.Note, request.NotifySMS, ctx.GetUint("user_id"))
+	if err != nil {
+		c.writeDelayError(ctx, err, id)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.NewSuccessResponse(requests.ScheduleDelayToResponse(data), "Schedule delayed, passengers are being notified", nil))
+}
+
+// ListDelays returns the delay history of a departure, oldest first.
+func (c *ScheduleDelayController) ListDelays(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	datas, err := c.ScheduleDelayUsecase.ListDelays(ctx, uint(id))
+	if err != nil {
+		c.writeDelayError(ctx, err, id)
+		return
+	}
+
+	responses := make([]*requests.ScheduleDelayResponse, len(datas))
+	for i, data := range datas {
+		responses[i] = requests.ScheduleDelayToResponse(data)
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(responses, "Schedule delays retrieved successfully", nil))
+}
+
+// GetOnTimePerformance reports how the departures of a period kept to their
+// timetable, for from=YYYY-MM-DD up to and including to=YYYY-MM-DD.
+func (c *ScheduleDelayController) GetOnTimePerformance(ctx *gin.Context) {
+	request := new(requests.OnTimePerformanceRequest)
+	if err := ctx.ShouldBindQuery(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind query parameters")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid query parameters", err.Error()))
+		return
+	}
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate query parameters")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	data, err := c.ScheduleDelayUsecase.OnTimePerformance(ctx, request.From, request.To.AddDate(0, 0, 1))
+	if err != nil {
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid report period")
+			ctx.JSON(http.StatusUnprocessableEntity, response.NewErrorResponse("Invalid report period", err.Error()))
+			return
+		}
+
+		c.Log.WithError(err).Error("failed to report on-time performance")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to report on-time performance", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(data, "On-time performance retrieved successfully", nil))
+}
+
+func (c *ScheduleDelayController) writeDelayError(ctx *gin.Context, err error, id int) {
+	if errors.Is(err, errs.ErrNotFound) {
+		c.Log.WithField("schedule_id", id).Warn("schedule not found")
+		ctx.JSON(http.StatusNotFound, response.NewErrorResponse("schedule not found", nil))
+		return
+	}
+
+	if errors.Is(err, errs.ErrBadRequest) {
+		c.Log.WithError(err).WithField("schedule_id", id).Warn("invalid delay")
+		ctx.JSON(http.StatusUnprocessableEntity, response.NewErrorResponse("Invalid delay", err.Error()))
+		return
+	}
+
+	if errors.Is(err, errs.ErrConflict) {
+		c.Log.WithError(err).WithField("schedule_id", id).Warn("schedule cannot be delayed")
+		ctx.JSON(http.StatusConflict, response.NewErrorResponse("Schedule cannot be delayed", err.Error()))
+		return
+	}
+
+	c.Log.WithError(err).WithField("schedule_id", id).Error("failed to delay schedule")
+	ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to delay schedule", err.Error()))
+}
@@ -0,0 +1,225 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/usecase"
+
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SeatLayoutController struct {
+	Validate          validator.Validator
+	Log               logger.Logger
+	SeatLayoutUsecase *usecase.SeatLayoutUsecase
+}
+
+func NewSeatLayoutController(
+	router *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	seat_layout_usecase *usecase.SeatLayoutUsecase,
+
+) {
+	c := &SeatLayoutController{
+		Log:               log,
+		Validate:          validate,
+		SeatLayoutUsecase: seat_layout_usecase,
+	}
+
+	router.GET("/schedule/:id/seats", c.GetScheduleSeatMap)
+
+	protected.GET("/seat-layouts", c.GetAllSeatLayouts)
+	protected.GET("/seat-layout/:id", c.GetSeatLayoutByID)
+	protected.POST("/seat-layout/create", c.CreateSeatLayout)
+	protected.PUT("/seat-layout/update/:id", c.UpdateSeatLayout)
+	protected.DELETE("/seat-layout/:id", c.DeleteSeatLayout)
+}
+
+func (c *SeatLayoutController) CreateSeatLayout(ctx *gin.Context) {
+	request := new(requests.CreateSeatLayoutRequest)
+
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.SeatLayoutUsecase.CreateSeatLayout(ctx, requests.SeatLayoutFromCreate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).Warn("ship or class not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Ship or class not found", nil))
+			return
+		}
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Error("seat layout already exists")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("seat layout already exists", nil))
+			return
+		}
+		c.Log.WithError(err).Error("failed to create seat layout")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create seat layout", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.NewSuccessResponse(nil, "Seat layout created successfully", nil))
+}
+
+func (c *SeatLayoutController) GetAllSeatLayouts(ctx *gin.Context) {
+
+	params := response.GetParams(ctx)
+	datas, total, err := c.SeatLayoutUsecase.ListSeatLayouts(ctx, params.Limit, params.Offset, params.Sort, params.Search)
+
+	if err != nil {
+		c.Log.WithError(err).Error("failed to retrieve seat layouts")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve seat layouts", err.Error()))
+		return
+	}
+
+	responses := make([]*requests.SeatLayoutResponse, len(datas))
+	for i, data := range datas {
+		responses[i] = requests.SeatLayoutToResponse(data)
+	}
+
+	ctx.JSON(http.StatusOK, response.NewMetaResponse(
+		responses,
+		"Seat layouts retrieved successfully",
+		total,
+		params.Limit,
+		params.Page,
+		params.Sort,
+		params.Search,
+		params.Path,
+	))
+}
+
+func (c *SeatLayoutController) GetSeatLayoutByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse seat layout ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid seat layout ID", err.Error()))
+		return
+	}
+
+	data, err := c.SeatLayoutUsecase.GetSeatLayoutByID(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("seat layout not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Seat layout not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve seat layout")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve seat layout", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.SeatLayoutToResponse(data), "Seat layout retrieved successfully", nil))
+}
+
+func (c *SeatLayoutController) UpdateSeatLayout(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse seat layout ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid or missing seat layout ID", nil))
+		return
+	}
+
+	request := new(requests.UpdateSeatLayoutRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	request.ID = uint(id)
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.SeatLayoutUsecase.UpdateSeatLayout(ctx, requests.SeatLayoutFromUpdate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("seat layout not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Seat layout not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Error("Seat layout already exists")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Seat layout already exists", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to update seat layout")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to update seat layout", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Seat layout updated successfully", nil))
+}
+
+func (c *SeatLayoutController) DeleteSeatLayout(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse seat layout ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid seat layout ID", err.Error()))
+		return
+	}
+
+	if err := c.SeatLayoutUsecase.DeleteSeatLayout(ctx, uint(id)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("seat layout not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Seat layout not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to delete seat layout")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to delete seat layout", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Seat layout deleted successfully", nil))
+}
+
+func (c *SeatLayoutController) GetScheduleSeatMap(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse schedule ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid schedule ID", err.Error()))
+		return
+	}
+
+	datas, err := c.SeatLayoutUsecase.GetScheduleSeatMap(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("schedule not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Schedule not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve seat map")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve seat map", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.SeatMapToResponse(uint(id), datas), "Seat map retrieved successfully", nil))
+}
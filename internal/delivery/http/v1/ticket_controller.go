@@ -7,7 +7,10 @@ import (
 	"eticket-api/internal/common/validator"
 	"eticket-api/internal/delivery/http/response"
 	requests "eticket-api/internal/delivery/http/v1/request"
+	"eticket-api/internal/domain"
 	"eticket-api/internal/usecase"
+	"eticket-api/pkg/qrcode"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -33,11 +36,12 @@ func NewTicketController(
 		TicketUsecase: ticket_usecase,
 	}
 
-	router.GET("/tickets", c.GetAllTickets)
-	router.GET("/tickets/schedule/:id", c.GetAllTicketsByScheduleID)
-	router.GET("/ticket/:id", c.GetTicketByID)
-
+	protected.GET("/tickets", c.GetAllTickets)
+	protected.GET("/tickets/schedule/:id", c.GetAllTicketsByScheduleID)
+	protected.GET("/ticket/:id", c.GetTicketByID)
 	protected.PATCH("/ticket/check-in/:id", c.CheckIn)
+	protected.GET("/ticket/:id/qr", c.GetTicketPass)
+	protected.POST("/ticket/verify", c.VerifyTicket)
 	protected.POST("/ticket/create", c.CreateTicket)
 	protected.PUT("/ticket//update:id", c.UpdateTicket)
 	protected.DELETE("/ticket/:id", c.DeleteTicket)
@@ -81,6 +85,18 @@ func (c *TicketController) CreateTicket(ctx *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, errs.ErrSeatUnavailable) {
+			c.Log.WithError(err).Warn("seat unavailable")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("seat unavailable", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("no fare for passenger")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("no fare for passenger", err.Error()))
+			return
+		}
+
 		c.Log.WithError(err).Error("failed to create ticket")
 		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create ticket", err.Error()))
 		return
@@ -251,7 +267,32 @@ func (c *TicketController) CheckIn(ctx *gin.Context) {
 		return
 	}
 
-	if err := c.TicketUsecase.CheckIn(ctx, uint(id)); err != nil {
+	request := new(requests.BoardingRequest)
+	if err := bindBoardingRequest(ctx, c.Validate, request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.TicketUsecase.CheckIn(ctx, uint(id), boardingActor(ctx, request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("ticket not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("ticket not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("id", id).Warn("ticket cannot be checked in")
+			ctx.JSON(http.StatusUnprocessableEntity, response.NewErrorResponse("Ticket cannot be checked in", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("id", id).Warn("ticket already checked in")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Ticket is already checked in", err.Error()))
+			return
+		}
+
 		c.Log.WithError(err).WithField("id", id).Error("failed to check in ticket")
 		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to check in ticket", err.Error()))
 		return
@@ -259,3 +300,116 @@ func (c *TicketController) CheckIn(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Ticket checked in successfully", nil))
 }
+
+func (c *TicketController) GetTicketPass(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse ticket ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid ticket ID", err.Error()))
+		return
+	}
+
+	ticket, payload, err := c.TicketUsecase.GetTicketPass(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("ticket not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("ticket not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).WithField("id", id).Warn("ticket has no QR yet")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("QR codes are issued once the booking is paid", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve ticket QR")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve ticket QR", err.Error()))
+		return
+	}
+
+	writeTicketPass(ctx, c.Log, ticket, payload)
+}
+
+// VerifyTicket checks in the ticket of a QR code scanned at the gate.
+func (c *TicketController) VerifyTicket(ctx *gin.Context) {
+	request := new(requests.VerifyTicketRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	actor := boardingActor(ctx, &requests.BoardingRequest{Gate: request.Gate, HarborID: request.HarborID})
+	data, err := c.TicketUsecase.VerifyTicket(ctx, request.Payload, request.ScheduleID, actor)
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.Warn("scanned ticket not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("ticket not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("ticket rejected at the gate")
+			ctx.JSON(http.StatusUnprocessableEntity, response.NewErrorResponse("Ticket rejected", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Warn("ticket already checked in")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Ticket is already checked in", err.Error()))
+			return
+		}
+
+		c.Log.WithError(err).Error("failed to verify ticket")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to verify ticket", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.TicketToResponse(data), "Ticket checked in successfully", nil))
+}
+
+// writeTicketPass writes the QR code of a ticket as a PNG, or with
+// ?format=svg or ?format=json as an SVG or the bare payload for apps that
+// draw their own.
+func writeTicketPass(ctx *gin.Context, log logger.Logger, ticket *domain.Ticket, payload string) {
+	format := ctx.DefaultQuery("format", "png")
+	if format == "json" {
+		ctx.JSON(http.StatusOK, response.NewSuccessResponse(&requests.TicketPassResponse{
+			TicketID:   ticket.ID,
+			TicketCode: ticket.TicketCode,
+			Payload:    payload,
+		}, "Ticket QR retrieved successfully", nil))
+		return
+	}
+	if format != "png" && format != "svg" {
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid format, use png, svg or json", nil))
+		return
+	}
+
+	code, err := qrcode.Encode([]byte(payload))
+	if err != nil {
+		log.WithError(err).WithField("id", ticket.ID).Error("failed to encode ticket QR")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to encode ticket QR", err.Error()))
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf(`inline; filename="%s.%s"`, ticket.TicketCode, format))
+	if format == "svg" {
+		ctx.Data(http.StatusOK, "image/svg+xml", []byte(code.SVG()))
+		return
+	}
+	body, err := code.PNG(8)
+	if err != nil {
+		log.WithError(err).WithField("id", ticket.ID).Error("failed to render ticket QR")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to render ticket QR", err.Error()))
+		return
+	}
+	ctx.Data(http.StatusOK, "image/png", body)
+}
@@ -0,0 +1,206 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/usecase"
+
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type VehicleCategoryController struct {
+	Validate               validator.Validator
+	Log                    logger.Logger
+	VehicleCategoryUsecase *usecase.VehicleCategoryUsecase
+}
+
+func NewVehicleCategoryController(
+	router *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	vehicle_category_usecase *usecase.VehicleCategoryUsecase,
+
+) {
+	c := &VehicleCategoryController{
+		Log:                    log,
+		Validate:               validate,
+		VehicleCategoryUsecase: vehicle_category_usecase,
+	}
+
+	router.GET("/vehicle-categories", c.GetAllVehicleCategories)
+	router.GET("/vehicle-category/:id", c.GetVehicleCategoryByID)
+
+	protected.POST("/vehicle-category/create", c.CreateVehicleCategory)
+	protected.PUT("/vehicle-category/update/:id", c.UpdateVehicleCategory)
+	protected.DELETE("/vehicle-category/:id", c.DeleteVehicleCategory)
+}
+
+func (c *VehicleCategoryController) CreateVehicleCategory(ctx *gin.Context) {
+	request := new(requests.CreateVehicleCategoryRequest)
+
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.VehicleCategoryUsecase.CreateVehicleCategory(ctx, requests.VehicleCategoryFromCreate(request)); err != nil {
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid vehicle category")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid vehicle category", err.Error()))
+			return
+		}
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Error("golongan already exists")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Golongan already exists", nil))
+			return
+		}
+		c.Log.WithError(err).Error("failed to create vehicle category")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to create vehicle category", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response.NewSuccessResponse(nil, "Vehicle category created successfully", nil))
+}
+
+func (c *VehicleCategoryController) GetAllVehicleCategories(ctx *gin.Context) {
+
+	params := response.GetParams(ctx)
+	datas, total, err := c.VehicleCategoryUsecase.ListVehicleCategories(ctx, params.Limit, params.Offset, params.Sort, params.Search)
+
+	if err != nil {
+		c.Log.WithError(err).Error("failed to retrieve vehicle categories")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve vehicle categories", err.Error()))
+		return
+	}
+
+	responses := make([]*requests.VehicleCategoryResponse, len(datas))
+	for i, data := range datas {
+		responses[i] = requests.VehicleCategoryToResponse(data)
+	}
+
+	ctx.JSON(http.StatusOK, response.NewMetaResponse(
+		responses,
+		"Vehicle categories retrieved successfully",
+		total,
+		params.Limit,
+		params.Page,
+		params.Sort,
+		params.Search,
+		params.Path,
+	))
+}
+
+func (c *VehicleCategoryController) GetVehicleCategoryByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse vehicle category ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid vehicle category ID", err.Error()))
+		return
+	}
+
+	data, err := c.VehicleCategoryUsecase.GetVehicleCategoryByID(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("vehicle category not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Vehicle category not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve vehicle category")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve vehicle category", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.VehicleCategoryToResponse(data), "Vehicle category retrieved successfully", nil))
+}
+
+func (c *VehicleCategoryController) UpdateVehicleCategory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse vehicle category ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid or missing vehicle category ID", nil))
+		return
+	}
+
+	request := new(requests.UpdateVehicleCategoryRequest)
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	request.ID = uint(id)
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).WithField("id", id).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	if err := c.VehicleCategoryUsecase.UpdateVehicleCategory(ctx, requests.VehicleCategoryFromUpdate(request)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("vehicle category not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Vehicle category not found", nil))
+			return
+		}
+
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).WithField("id", id).Warn("invalid vehicle category")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid vehicle category", err.Error()))
+			return
+		}
+
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Error("golongan already exists")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Golongan already exists", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to update vehicle category")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to update vehicle category", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Vehicle category updated successfully", nil))
+}
+
+func (c *VehicleCategoryController) DeleteVehicleCategory(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse vehicle category ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid vehicle category ID", err.Error()))
+		return
+	}
+
+	if err := c.VehicleCategoryUsecase.DeleteVehicleCategory(ctx, uint(id)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("vehicle category not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Vehicle category not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to delete vehicle category")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to delete vehicle category", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Vehicle category deleted successfully", nil))
+}
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"errors"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/common/validator"
+	"eticket-api/internal/usecase"
+
+	"eticket-api/internal/delivery/http/response"
+	requests "eticket-api/internal/delivery/http/v1/request"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WaitlistController struct {
+	Validate        validator.Validator
+	Log             logger.Logger
+	WaitlistUsecase *usecase.WaitlistUsecase
+}
+
+func NewWaitlistController(
+	router *gin.RouterGroup,
+	protected *gin.RouterGroup,
+	log logger.Logger,
+	validate validator.Validator,
+	waitlist_usecase *usecase.WaitlistUsecase,
+
+) {
+	c := &WaitlistController{
+		Log:             log,
+		Validate:        validate,
+		WaitlistUsecase: waitlist_usecase,
+	}
+
+	router.POST("/waitlist/join", c.JoinWaitlist)
+
+	protected.GET("/waitlists", c.GetAllWaitlists)
+	protected.GET("/waitlist/:id", c.GetWaitlistByID)
+	protected.POST("/waitlist/cancel/:id", c.CancelWaitlist)
+}
+
+func (c *WaitlistController) JoinWaitlist(ctx *gin.Context) {
+	request := new(requests.JoinWaitlistRequest)
+
+	if err := ctx.ShouldBindJSON(request); err != nil {
+		c.Log.WithError(err).Error("failed to bind JSON request body")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid request body", err.Error()))
+		return
+	}
+
+	if err := c.Validate.Struct(request); err != nil {
+		c.Log.WithError(err).Error("failed to validate request body")
+		errors := validator.ParseErrors(err)
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Validation error", errors))
+		return
+	}
+
+	waitlist := requests.WaitlistFromJoin(request)
+	if err := c.WaitlistUsecase.JoinWaitlist(ctx, waitlist); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithError(err).Warn("schedule or class not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Schedule or class not found", nil))
+			return
+		}
+		if errors.Is(err, errs.ErrBadRequest) {
+			c.Log.WithError(err).Warn("invalid waitlist request")
+			ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("invalid waitlist request", err.Error()))
+			return
+		}
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithError(err).Warn("already on the waitlist")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("already on the waitlist", nil))
+			return
+		}
+		c.Log.WithError(err).Error("failed to join waitlist")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to join waitlist", err.Error()))
+		return
+	}
+
+	data := &requests.JoinWaitlistResponse{ID: waitlist.ID, Status: waitlist.Status}
+	ctx.JSON(http.StatusCreated, response.NewSuccessResponse(data, "Joined waitlist successfully", nil))
+}
+
+func (c *WaitlistController) GetAllWaitlists(ctx *gin.Context) {
+
+	params := response.GetParams(ctx)
+	datas, total, err := c.WaitlistUsecase.ListWaitlists(ctx, params.Limit, params.Offset, params.Sort, params.Search)
+
+	if err != nil {
+		c.Log.WithError(err).Error("failed to retrieve waitlist entries")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve waitlist entries", err.Error()))
+		return
+	}
+
+	responses := make([]*requests.WaitlistResponse, len(datas))
+	for i, data := range datas {
+		responses[i] = requests.WaitlistToResponse(data)
+	}
+
+	ctx.JSON(http.StatusOK, response.NewMetaResponse(
+		responses,
+		"Waitlist entries retrieved successfully",
+		total,
+		params.Limit,
+		params.Page,
+		params.Sort,
+		params.Search,
+		params.Path,
+	))
+}
+
+func (c *WaitlistController) GetWaitlistByID(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse waitlist ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid waitlist ID", err.Error()))
+		return
+	}
+
+	data, err := c.WaitlistUsecase.GetWaitlistByID(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("waitlist entry not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Waitlist entry not found", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to retrieve waitlist entry")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to retrieve waitlist entry", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(requests.WaitlistToResponse(data), "Waitlist entry retrieved successfully", nil))
+}
+
+func (c *WaitlistController) CancelWaitlist(ctx *gin.Context) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+
+	if err != nil {
+		c.Log.WithError(err).WithField("id", ctx.Param("id")).Error("failed to parse waitlist ID")
+		ctx.JSON(http.StatusBadRequest, response.NewErrorResponse("Invalid waitlist ID", err.Error()))
+		return
+	}
+
+	if err := c.WaitlistUsecase.CancelWaitlist(ctx, uint(id)); err != nil {
+		if errors.Is(err, errs.ErrNotFound) {
+			c.Log.WithField("id", id).Warn("waitlist entry not found")
+			ctx.JSON(http.StatusNotFound, response.NewErrorResponse("Waitlist entry not found", nil))
+			return
+		}
+		if errors.Is(err, errs.ErrConflict) {
+			c.Log.WithField("id", id).Warn("waitlist entry is no longer waiting")
+			ctx.JSON(http.StatusConflict, response.NewErrorResponse("Waitlist entry is no longer waiting", nil))
+			return
+		}
+
+		c.Log.WithError(err).WithField("id", id).Error("failed to cancel waitlist entry")
+		ctx.JSON(http.StatusInternalServerError, response.NewErrorResponse("Failed to cancel waitlist entry", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response.NewSuccessResponse(nil, "Waitlist entry cancelled successfully", nil))
+}
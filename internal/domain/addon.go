@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// Addon is an extra sold with a booking, such as a meal, a cabin upgrade or
+// extra luggage. It is only on sale on schedules that carry stock of it.
+type Addon struct {
+	ID          uint      `gorm:"column:id;primaryKey"`
+	Name        string    `gorm:"column:name;type:varchar(64);not null"`
+	Kind        string    `gorm:"column:kind;type:varchar(16);not null"`
+	Description string    `gorm:"column:description;type:text"`
+	Price       float64   `gorm:"column:price;not null"` // Default price, a schedule's stock may override it
+	IsActive    bool      `gorm:"column:is_active;not null;default:true"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (a *Addon) TableName() string {
+	return "addon"
+}
+
+// AddonStock is the inventory of an add-on on one schedule. Stock, Capacity
+// and Held work like the counters of a Quota.
+type AddonStock struct {
+	ID         uint      `gorm:"column:id;primaryKey"`
+	ScheduleID uint      `gorm:"column:schedule_id;not null;uniqueIndex:idx_schedule_addon"`
+	AddonID    uint      `gorm:"column:addon_id;not null;uniqueIndex:idx_schedule_addon"`
+	Stock      int       `gorm:"column:stock;not null"` // Units left for sale
+	Capacity   int       `gorm:"column:capacity;not null"`
+	Held       int       `gorm:"column:held;not null;default:0"` // Units held by pending claim sessions
+	Price      *float64  `gorm:"column:price"`                   // Overrides the add-on price on this schedule
+	CreatedAt  time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;not null"`
+
+	Addon Addon `gorm:"foreignKey:AddonID"`
+}
+
+func (as *AddonStock) TableName() string {
+	return "addon_stock"
+}
+
+// UnitPrice is what one unit of the add-on costs on the stock's schedule.
+func (as *AddonStock) UnitPrice() float64 {
+	if as.Price != nil {
+		return *as.Price
+	}
+	return as.Addon.Price
+}
+
+// ClaimAddon is an add-on held by a claim session on one of its legs.
+type ClaimAddon struct {
+	ID             uint      `gorm:"column:id;primaryKey"`
+	ClaimSessionID uint      `gorm:"column:claim_session_id;not null;index"`
+	ScheduleID     uint      `gorm:"column:schedule_id;not null;index"`
+	AddonID        uint      `gorm:"column:addon_id;not null;index"`
+	Quantity       int       `gorm:"column:quantity;not null"`
+	Subtotal       float64   `gorm:"column:subtotal;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;not null"`
+
+	Addon Addon `gorm:"foreignKey:AddonID"`
+}
+
+func (ca *ClaimAddon) TableName() string {
+	return "claim_addon"
+}
+
+// BookingAddon is an add-on sold with a booking, attached to one ticket or,
+// when TicketID is nil, to the booking as a whole. The name, kind and price
+// are copied so later changes to the add-on leave sold ones alone.
+type BookingAddon struct {
+	ID         uint      `gorm:"column:id;primaryKey"`
+	BookingID  uint      `gorm:"column:booking_id;not null;index"`
+	TicketID   *uint     `gorm:"column:ticket_id;index"`
+	ScheduleID uint      `gorm:"column:schedule_id;not null;index"`
+	AddonID    uint      `gorm:"column:addon_id;not null;index"`
+	Name       string    `gorm:"column:name;type:varchar(64);not null"`
+	Kind       string    `gorm:"column:kind;type:varchar(16);not null"`
+	Price      float64   `gorm:"column:price;not null"` // Unit price
+	Quantity   int       `gorm:"column:quantity;not null"`
+	CreatedAt  time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;not null"`
+
+	Ticket *Ticket `gorm:"foreignKey:TicketID"`
+}
+
+func (ba *BookingAddon) TableName() string {
+	return "booking_addon"
+}
+
+type AddonRepository interface {
+	Count(ctx context.Context, conn gotann.Connection) (int64, error)
+	Insert(ctx context.Context, conn gotann.Connection, entity *Addon) error
+	Update(ctx context.Context, conn gotann.Connection, entity *Addon) error
+	Delete(ctx context.Context, conn gotann.Connection, entity *Addon) error
+	FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*Addon, error)
+	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*Addon, error)
+}
+
+type AddonStockRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *AddonStock) error
+	Update(ctx context.Context, conn gotann.Connection, entity *AddonStock) error
+	Delete(ctx context.Context, conn gotann.Connection, entity *AddonStock) error
+	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*AddonStock, error)
+	FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*AddonStock, error)
+
+	// Reservation counters, the same conditional updates as QuotaRepository.
+	Reserve(ctx context.Context, conn gotann.Connection, scheduleID uint, addonID uint, quantity int) (bool, error)
+	Release(ctx context.Context, conn gotann.Connection, scheduleID uint, addonID uint, quantity int) error
+	Confirm(ctx context.Context, conn gotann.Connection, scheduleID uint, addonID uint, quantity int) (bool, error)
+	Restore(ctx context.Context, conn gotann.Connection, scheduleID uint, addonID uint, quantity int) error
+}
+
+type BookingAddonRepository interface {
+	InsertBulk(ctx context.Context, conn gotann.Connection, addons []*BookingAddon) error
+	UpdateBulk(ctx context.Context, conn gotann.Connection, addons []*BookingAddon) error
+	FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*BookingAddon, error)
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// BoardingEvent is one move of a ticket through boarding. Events are kept for
+// the audit trail, the last one that is not undone can be undone.
+type BoardingEvent struct {
+	ID         uint       `gorm:"column:id;primaryKey"`
+	TicketID   uint       `gorm:"column:ticket_id;not null;index"`
+	ScheduleID uint       `gorm:"column:schedule_id;not null;index"`
+	FromStatus string     `gorm:"column:from_status;type:varchar(16);not null"`
+	ToStatus   string     `gorm:"column:to_status;type:varchar(16);not null"`
+	UserID     *uint      `gorm:"column:user_id;index"` // Staff user who made the move
+	Gate       string     `gorm:"column:gate;type:varchar(32)"`
+	Device     string     `gorm:"column:device;type:varchar(64)"` // Gate device that scanned offline, empty when online
+	HarborID   *uint      `gorm:"column:harbor_id"`
+	OccurredAt time.Time  `gorm:"column:occurred_at;not null"` // When the ticket was scanned
+	UndoneAt   *time.Time `gorm:"column:undone_at"`
+	UndoneBy   *uint      `gorm:"column:undone_by"`
+	CreatedAt  time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt  time.Time  `gorm:"column:updated_at;not null"`
+}
+
+func (be *BoardingEvent) TableName() string {
+	return "boarding_event"
+}
+
+type BoardingEventRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *BoardingEvent) error
+	Update(ctx context.Context, conn gotann.Connection, entity *BoardingEvent) error
+	FindByTicketID(ctx context.Context, conn gotann.Connection, ticketID uint) ([]*BoardingEvent, error)
+	FindLastByTicketID(ctx context.Context, conn gotann.Connection, ticketID uint) (*BoardingEvent, error)
+}
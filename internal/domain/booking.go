@@ -7,21 +7,29 @@ import (
 )
 
 type Booking struct {
-	ID              uint      `gorm:"column:id;primaryKey"`
-	OrderID         string    `gorm:"column:order_id;type:varchar(64);not null;uniqueIndex"` // Business order ID
-	ReferenceNumber *string   `gorm:"column:reference_number;"`
-	ScheduleID      uint      `gorm:"column:schedule_id;not null;index;"`
-	IDType          string    `gorm:"column:id_type;type:varchar(24);not null"`
-	IDNumber        string    `gorm:"column:id_number;type:varchar(24);not null"`
-	CustomerName    string    `gorm:"column:customer_name;type:varchar(32);not null"`
-	PhoneNumber     string    `gorm:"column:phone_number;type:varchar(14);not null"`
-	Email           string    `gorm:"column:email;not null"`
-	Status          string    `gorm:"column:status;type:varchar(24);not null;index"`
-	CreatedAt       time.Time `gorm:"column:created_at;not null"`
-	UpdatedAt       time.Time `gorm:"column:updated_at;not null"`
+	ID              uint       `gorm:"column:id;primaryKey"`
+	OrderID         string     `gorm:"column:order_id;type:varchar(64);not null;uniqueIndex"` // Business order ID
+	ReferenceNumber *string    `gorm:"column:reference_number;"`
+	ScheduleID      uint       `gorm:"column:schedule_id;not null;index;"`
+	CustomerID      *uint      `gorm:"column:customer_id;index"` // Account the booking belongs to, nil for guest bookings
+	IDType          string     `gorm:"column:id_type;type:varchar(24);not null"`
+	IDNumber        string     `gorm:"column:id_number;type:varchar(24);not null"`
+	CustomerName    string     `gorm:"column:customer_name;type:varchar(32);not null"`
+	PhoneNumber     string     `gorm:"column:phone_number;type:varchar(14);not null"`
+	Email           string     `gorm:"column:email;not null"`
+	Status          string     `gorm:"column:status;type:varchar(24);not null;index"`
+	Credit          float64    `gorm:"column:credit;not null;default:0"` // Fare owed back to the customer after changes
+	PromoCode       *string    `gorm:"column:promo_code;type:varchar(32)"`
+	Discount        float64    `gorm:"column:discount;not null;default:0"` // Taken off the ticket prices by PromoCode
+	PaymentDeadline *time.Time `gorm:"column:payment_deadline;index"`      // When the Tripay payment of an UNPAID booking expires
+	CreatedAt       time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at;not null"`
 
-	Tickets  []Ticket `gorm:"foreignKey:BookingID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Schedule Schedule `gorm:"foreignKey:ScheduleID"`
+	Tickets  []Ticket        `gorm:"foreignKey:BookingID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Schedule Schedule        `gorm:"foreignKey:ScheduleID"`
+	Changes  []BookingChange `gorm:"foreignKey:BookingID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Fees     []TicketFee     `gorm:"foreignKey:BookingID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"` // Fees of every ticket in the booking
+	Addons   []BookingAddon  `gorm:"foreignKey:BookingID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"` // Add-ons of the booking and of its tickets
 }
 
 func (b *Booking) TableName() string {
@@ -38,4 +46,9 @@ type BookingRepository interface {
 	FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*Booking, error)
 	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*Booking, error)
 	FindByOrderID(ctx context.Context, conn gotann.Connection, id string) (*Booking, error)
+	FindExpiredUnpaid(ctx context.Context, conn gotann.Connection, deadline, createdBefore time.Time, limit int) ([]*Booking, error)
+	LockByOrderID(ctx context.Context, conn gotann.Connection, orderID string) error
+	CountByCustomerID(ctx context.Context, conn gotann.Connection, customerID uint, upcoming bool, now time.Time) (int64, error)
+	FindByCustomerID(ctx context.Context, conn gotann.Connection, customerID uint, upcoming bool, now time.Time, limit, offset int) ([]*Booking, error)
+	ClaimByEmail(ctx context.Context, conn gotann.Connection, customerID uint, email string) (int64, error)
 }
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// BookingAccessCode is a one-time code emailed to the customer of a booking
+// to prove ownership. Only the hash of the code is stored.
+type BookingAccessCode struct {
+	ID        uint       `gorm:"column:id;primaryKey"`
+	BookingID uint       `gorm:"column:booking_id;not null;index"`
+	CodeHash  string     `gorm:"column:code_hash;type:char(64);not null"`
+	Attempts  int        `gorm:"column:attempts;not null;default:0"`
+	ExpiresAt time.Time  `gorm:"column:expires_at;not null"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at;not null"`
+}
+
+func (c *BookingAccessCode) TableName() string {
+	return "booking_access_code"
+}
+
+type BookingAccessCodeRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *BookingAccessCode) error
+	Update(ctx context.Context, conn gotann.Connection, entity *BookingAccessCode) error
+	FindLatestByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) (*BookingAccessCode, error)
+}
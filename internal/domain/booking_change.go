@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// BookingChange records one change made to a paid booking, such as moving it
+// to another departure, and how the fare difference was settled.
+type BookingChange struct {
+	ID              uint      `gorm:"column:id;primaryKey"`
+	BookingID       uint      `gorm:"column:booking_id;not null;index"`
+	Type            string    `gorm:"column:type;type:varchar(24);not null"`
+	FromScheduleID  uint      `gorm:"column:from_schedule_id;not null"`
+	ToScheduleID    uint      `gorm:"column:to_schedule_id;not null"`
+	FareDifference  float64   `gorm:"column:fare_difference;not null"` // Positive is owed by the customer, negative is credited
+	Settlement      string    `gorm:"column:settlement;type:varchar(24);not null"`
+	Status          string    `gorm:"column:status;type:varchar(24);not null"`
+	MerchantRef     *string   `gorm:"column:merchant_ref;type:varchar(64);uniqueIndex"`
+	ReferenceNumber *string   `gorm:"column:reference_number"`
+	Detail          string    `gorm:"column:detail;type:text"`
+	Previous        *string   `gorm:"column:previous;type:jsonb"` // What the change moved, kept until its charge settles
+	CreatedAt       time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt       time.Time `gorm:"column:updated_at;not null"`
+
+	FromSchedule Schedule `gorm:"foreignKey:FromScheduleID"`
+	ToSchedule   Schedule `gorm:"foreignKey:ToScheduleID"`
+}
+
+func (bc *BookingChange) TableName() string {
+	return "booking_change"
+}
+
+type BookingChangeRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *BookingChange) error
+	Update(ctx context.Context, conn gotann.Connection, entity *BookingChange) error
+	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*BookingChange, error)
+	FindByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) ([]*BookingChange, error)
+	FindByMerchantRef(ctx context.Context, conn gotann.Connection, merchantRef string) (*BookingChange, error)
+}
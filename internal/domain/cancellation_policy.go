@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// CancellationPolicy decides how much of a ticket's price is refunded when a
+// booking is cancelled. A policy applies to a route (departure and arrival
+// harbor), a class, both, or everything when neither is set.
+type CancellationPolicy struct {
+	ID                uint      `gorm:"column:id;primaryKey"`
+	Name              string    `gorm:"column:name;type:varchar(64);not null"`
+	DepartureHarborID *uint     `gorm:"column:departure_harbor_id;index:idx_cancellation_policy_scope"`
+	ArrivalHarborID   *uint     `gorm:"column:arrival_harbor_id;index:idx_cancellation_policy_scope"`
+	ClassID           *uint     `gorm:"column:class_id;index:idx_cancellation_policy_scope"`
+	CreatedAt         time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt         time.Time `gorm:"column:updated_at;not null"`
+
+	DepartureHarbor *Harbor            `gorm:"foreignKey:DepartureHarborID"`
+	ArrivalHarbor   *Harbor            `gorm:"foreignKey:ArrivalHarborID"`
+	Class           *Class             `gorm:"foreignKey:ClassID"`
+	Rules           []CancellationRule `gorm:"foreignKey:PolicyID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (cp *CancellationPolicy) TableName() string {
+	return "cancellation_policy"
+}
+
+// CancellationRule refunds RefundPercent of the price when the booking is
+// cancelled at least MinHoursBefore hours before departure.
+type CancellationRule struct {
+	ID             uint      `gorm:"column:id;primaryKey"`
+	PolicyID       uint      `gorm:"column:policy_id;not null;uniqueIndex:idx_policy_rule_hours"`
+	MinHoursBefore int       `gorm:"column:min_hours_before;not null;uniqueIndex:idx_policy_rule_hours"`
+	RefundPercent  float64   `gorm:"column:refund_percent;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (cr *CancellationRule) TableName() string {
+	return "cancellation_rule"
+}
+
+type CancellationPolicyRepository interface {
+	Count(ctx context.Context, conn gotann.Connection) (int64, error)
+	Insert(ctx context.Context, conn gotann.Connection, entity *CancellationPolicy) error
+	Update(ctx context.Context, conn gotann.Connection, entity *CancellationPolicy) error
+	Delete(ctx context.Context, conn gotann.Connection, entity *CancellationPolicy) error
+	FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*CancellationPolicy, error)
+	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*CancellationPolicy, error)
+	FindApplicable(ctx context.Context, conn gotann.Connection, departureHarborID, arrivalHarborID, classID uint) (*CancellationPolicy, error)
+}
@@ -9,8 +9,10 @@ import (
 type ClaimItem struct {
 	ID             uint      `gorm:"column:id;primaryKey"`
 	ClaimSessionID uint      `gorm:"column:claim_session_id;not null;index"` // Foreign key to ClaimSession
+	ScheduleID     uint      `gorm:"column:schedule_id;index"`               // Leg this item books, zero means the session's schedule
 	ClassID        uint      `gorm:"column:class_id;not null;index"`         // Foreign key to Class
 	Quantity       int       `gorm:"column:quantity;not null"`               // Number of tickets requested for this class
+	QuotaUnits     int       `gorm:"column:quota_units;not null;default:0"`  // Quota held, in lane meters for some vehicle classes, zero means Quantity
 	Subtotal       float64   `gorm:"column:subtotal;not null"`               // Total price for this class (Quantity * Price)
 	CreatedAt      time.Time `gorm:"column:created_at;not null"`
 	UpdatedAt      time.Time `gorm:"column:updated_at;not null"`
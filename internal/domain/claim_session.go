@@ -7,16 +7,21 @@ import (
 )
 
 type ClaimSession struct {
-	ID         uint      `gorm:"column:id;primaryKey" json:"id"`
-	SessionID  string    `gorm:"column:session_id;type:uuid;unique;not null"`
-	ScheduleID uint      `gorm:"column:schedule_id;not null;index"`
-	Status     string    `gorm:"column:status;type:varchar(24);not null"` //
-	ExpiresAt  time.Time `gorm:"column:expires_at;not null"`
-	CreatedAt  time.Time `gorm:"column:created_at;not null"`
-	UpdatedAt  time.Time `gorm:"column:updated_at;not null"`
+	ID          uint       `gorm:"column:id;primaryKey" json:"id"`
+	SessionID   string     `gorm:"column:session_id;type:uuid;unique;not null"`
+	ScheduleID  uint       `gorm:"column:schedule_id;not null;index"`
+	Status      string     `gorm:"column:status;type:varchar(24);not null"` //
+	ExpiresAt   time.Time  `gorm:"column:expires_at;not null"`
+	ExtendedAt  *time.Time `gorm:"column:extended_at"` // Set once the customer used their one extension
+	PromotionID *uint      `gorm:"column:promotion_id"`
+	PromoCode   *string    `gorm:"column:promo_code;type:varchar(32)"`
+	Discount    float64    `gorm:"column:discount;not null;default:0"`
+	CreatedAt   time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;not null"`
 
-	Schedule   Schedule    `gorm:"foreignKey:ScheduleID" json:"schedule"` // Gorm will create the relationship
-	ClaimItems []ClaimItem `gorm:"foreignKey:ClaimSessionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Schedule   Schedule     `gorm:"foreignKey:ScheduleID" json:"schedule"` // Gorm will create the relationship
+	ClaimItems []ClaimItem  `gorm:"foreignKey:ClaimSessionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Addons     []ClaimAddon `gorm:"foreignKey:ClaimSessionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (cs *ClaimSession) TableName() string {
@@ -33,6 +38,8 @@ type ClaimSessionRepository interface {
 	DeleteBulk(ctx context.Context, conn gotann.Connection, entity []*ClaimSession) error
 	FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*ClaimSession, error)
 	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*ClaimSession, error)
+	UpdateStatus(ctx context.Context, conn gotann.Connection, id uint, from, to string) (int64, error)
+	Extend(ctx context.Context, conn gotann.Connection, id uint, expiresAt time.Time) (int64, error)
 	FindExpired(ctx context.Context, conn gotann.Connection, limit int) ([]*ClaimSession, error)
 	FindBySessionID(ctx context.Context, conn gotann.Connection, uuid string) (*ClaimSession, error)
 	FindActiveByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*ClaimSession, error)
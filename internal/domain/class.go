@@ -13,6 +13,9 @@ type Class struct {
 	ClassAlias string    `gorm:"column:class_alias;type:varchar(8);not null"`
 	CreatedAt  time.Time `gorm:"column:created_at;not null"`
 	UpdatedAt  time.Time `gorm:"column:updated_at;not null"`
+
+	VehicleCategoryID *uint            `gorm:"column:vehicle_category_id;index"` // Golongan sold by a vehicle class
+	VehicleCategory   *VehicleCategory `gorm:"foreignKey:VehicleCategoryID"`
 }
 
 func (c *Class) TableName() string {
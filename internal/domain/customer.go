@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// Customer is a passenger account. Customers have no role and cannot use the
+// staff API.
+type Customer struct {
+	ID                 uint       `gorm:"column:id;primaryKey"`
+	Email              string     `gorm:"column:email;not null;uniqueIndex"` // Stored lower case
+	Password           string     `gorm:"column:password;not null"`          // Store bcrypt hash
+	FullName           string     `gorm:"column:full_name;type:varchar(32);not null"`
+	PhoneNumber        string     `gorm:"column:phone_number;type:varchar(14);not null"`
+	EmailVerifiedAt    *time.Time `gorm:"column:email_verified_at"`
+	VerifyCodeHash     string     `gorm:"column:verify_code_hash;type:char(64)"`
+	VerifyCodeSentAt   *time.Time `gorm:"column:verify_code_sent_at"`
+	VerifyCodeAttempts int        `gorm:"column:verify_code_attempts;not null;default:0"`
+	CreatedAt          time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt          time.Time  `gorm:"column:updated_at;not null"`
+}
+
+func (c *Customer) TableName() string {
+	return "customer"
+}
+
+type CustomerRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *Customer) error
+	Update(ctx context.Context, conn gotann.Connection, entity *Customer) error
+	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*Customer, error)
+	FindByEmail(ctx context.Context, conn gotann.Connection, email string) (*Customer, error)
+}
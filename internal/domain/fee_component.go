@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// FeeComponent is a charge added to every ticket it applies to, such as the
+// port service fee, Jasa Raharja insurance or PPN. A component charges either
+// a fixed Amount or a Percent of the ticket price. Leave a restriction empty
+// to apply the component everywhere; HarborID matches the departure harbor.
+type FeeComponent struct {
+	ID                uint      `gorm:"column:id;primaryKey"`
+	Name              string    `gorm:"column:name;type:varchar(64);not null"` // Shown on order items and emails
+	Kind              string    `gorm:"column:kind;type:varchar(16);not null"`
+	Amount            float64   `gorm:"column:amount;not null;default:0"`
+	Percent           float64   `gorm:"column:percent;not null;default:0"`
+	HarborID          *uint     `gorm:"column:harbor_id;index"`
+	DepartureHarborID *uint     `gorm:"column:departure_harbor_id;index"`
+	ArrivalHarborID   *uint     `gorm:"column:arrival_harbor_id;index"`
+	ClassID           *uint     `gorm:"column:class_id;index"`
+	IsActive          bool      `gorm:"column:is_active;not null;default:true"`
+	CreatedAt         time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt         time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (fc *FeeComponent) TableName() string {
+	return "fee_component"
+}
+
+// TicketFee is one fee component as charged on a ticket. The name, kind and
+// amount are copied so later changes to the component leave sold tickets
+// alone. Fees are listed on both the ticket and its booking.
+type TicketFee struct {
+	ID             uint      `gorm:"column:id;primaryKey"`
+	TicketID       uint      `gorm:"column:ticket_id;not null;index"`
+	BookingID      *uint     `gorm:"column:booking_id;index"`
+	FeeComponentID *uint     `gorm:"column:fee_component_id;index"`
+	Name           string    `gorm:"column:name;type:varchar(64);not null"`
+	Kind           string    `gorm:"column:kind;type:varchar(16);not null"`
+	Amount         float64   `gorm:"column:amount;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (tf *TicketFee) TableName() string {
+	return "ticket_fee"
+}
+
+type FeeComponentRepository interface {
+	Count(ctx context.Context, conn gotann.Connection) (int64, error)
+	Insert(ctx context.Context, conn gotann.Connection, entity *FeeComponent) error
+	Update(ctx context.Context, conn gotann.Connection, entity *FeeComponent) error
+	Delete(ctx context.Context, conn gotann.Connection, entity *FeeComponent) error
+	FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*FeeComponent, error)
+	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*FeeComponent, error)
+	FindActive(ctx context.Context, conn gotann.Connection) ([]*FeeComponent, error)
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// IdempotencyKey stores the outcome of a request sent with an Idempotency-Key
+// header so a retry of the same request gets the same response.
+type IdempotencyKey struct {
+	ID             uint      `gorm:"column:id;primaryKey"`
+	Key            string    `gorm:"column:key;type:varchar(255);not null;uniqueIndex:idx_idempotency_key_scope"`
+	Scope          string    `gorm:"column:scope;type:varchar(255);not null;uniqueIndex:idx_idempotency_key_scope"`
+	Fingerprint    string    `gorm:"column:fingerprint;type:char(64);not null"`
+	Status         string    `gorm:"column:status;type:varchar(24);not null"`
+	ResponseStatus int       `gorm:"column:response_status"`
+	ResponseBody   []byte    `gorm:"column:response_body;type:bytea"`
+	ExpiresAt      time.Time `gorm:"column:expires_at;not null;index"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (k *IdempotencyKey) TableName() string {
+	return "idempotency_key"
+}
+
+type IdempotencyKeyRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *IdempotencyKey) error
+	Update(ctx context.Context, conn gotann.Connection, entity *IdempotencyKey) error
+	Delete(ctx context.Context, conn gotann.Connection, entity *IdempotencyKey) error
+	FindByKey(ctx context.Context, conn gotann.Connection, key, scope string) (*IdempotencyKey, error)
+	DeleteExpired(ctx context.Context, conn gotann.Connection, now time.Time) (int64, error)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// Manifest is the final passenger manifest of a departure, frozen when the
+// ship leaves and kept for audit. Snapshot holds the manifest as JSON exactly
+// as it was at FrozenAt.
+type Manifest struct {
+	ID             uint      `gorm:"column:id;primaryKey"`
+	ScheduleID     uint      `gorm:"column:schedule_id;not null;uniqueIndex"`
+	PassengerCount int       `gorm:"column:passenger_count;not null"`
+	VehicleCount   int       `gorm:"column:vehicle_count;not null"`
+	Snapshot       []byte    `gorm:"column:snapshot;type:bytea;not null"`
+	FrozenAt       time.Time `gorm:"column:frozen_at;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (m *Manifest) TableName() string {
+	return "manifest"
+}
+
+type ManifestRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *Manifest) error
+	FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) (*Manifest, error)
+	FindUnfrozenScheduleIDs(ctx context.Context, conn gotann.Connection, departedBefore time.Time, limit int) ([]uint, error)
+}
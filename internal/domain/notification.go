@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// NotificationLog records that a notification of a kind went out for the
+// leg of a booking. It is written in the transaction that queues the email,
+// and the unique index keeps it from going out twice, whatever the number of
+// instances sending.
+type NotificationLog struct {
+	ID         uint      `gorm:"column:id;primaryKey"`
+	BookingID  uint      `gorm:"column:booking_id;not null;uniqueIndex:idx_notification_booking_kind"`
+	ScheduleID uint      `gorm:"column:schedule_id;not null;uniqueIndex:idx_notification_booking_kind"`
+	Kind       string    `gorm:"column:kind;type:varchar(32);not null;uniqueIndex:idx_notification_booking_kind"`
+	Recipient  string    `gorm:"column:recipient;type:varchar(255);not null"`
+	SentAt     time.Time `gorm:"column:sent_at;not null"` // When the message was queued in the outbox
+	CreatedAt  time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt  time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (nl *NotificationLog) TableName() string {
+	return "notification_log"
+}
+
+// DueNotification is a leg of a booking that has a notification to send.
+type DueNotification struct {
+	BookingID  uint
+	ScheduleID uint
+}
+
+type NotificationLogRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *NotificationLog) (int64, error)
+	FindDueReminders(ctx context.Context, conn gotann.Connection, kind string, departsAfter, departsBefore time.Time, limit int) ([]*DueNotification, error)
+}
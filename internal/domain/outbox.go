@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// Outbox is a side effect, such as a Tripay payment or an email, written in
+// the same transaction as the change that causes it and run after commit.
+type Outbox struct {
+	ID            uint       `gorm:"column:id;primaryKey"`
+	EventType     string     `gorm:"column:event_type;type:varchar(32);not null"`
+	AggregateID   string     `gorm:"column:aggregate_id;type:varchar(64);not null;index"` // Order ID the event belongs to, for tracing
+	Payload       string     `gorm:"column:payload;type:jsonb;not null"`
+	Result        *string    `gorm:"column:result;type:jsonb"`
+	Status        string     `gorm:"column:status;type:varchar(16);not null;index:idx_outbox_due"`
+	Attempts      int        `gorm:"column:attempts;not null;default:0"`
+	MaxAttempts   int        `gorm:"column:max_attempts;not null"`
+	LastError     *string    `gorm:"column:last_error;type:text"`
+	NextAttemptAt time.Time  `gorm:"column:next_attempt_at;not null;index:idx_outbox_due"`
+	ProcessedAt   *time.Time `gorm:"column:processed_at"`
+	CreatedAt     time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt     time.Time  `gorm:"column:updated_at;not null"`
+}
+
+func (o *Outbox) TableName() string {
+	return "outbox"
+}
+
+// PaymentOutboxPayload creates a Tripay transaction for a booking, or for the
+// fare difference of a booking change when BookingChangeID is set.
+type PaymentOutboxPayload struct {
+	BookingID       uint               `json:"booking_id"`
+	BookingChangeID *uint              `json:"booking_change_id,omitempty"`
+	SendInvoice     bool               `json:"send_invoice"`
+	Request         TransactionRequest `json:"request"`
+}
+
+type EmailOutboxPayload struct {
+	To          string            `json:"to"`
+	Subject     string            `json:"subject"`
+	Body        string            `json:"body"`
+	Attachments []EmailAttachment `json:"attachments,omitempty"`
+}
+
+// EmailAttachment is a file rendered when the email was queued, such as the
+// e-ticket PDF. Content is base64 in the JSON payload.
+type EmailAttachment struct {
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Content     []byte `json:"content"`
+}
+
+type SMSOutboxPayload struct {
+	To      string `json:"to"`
+	Message string `json:"message"`
+}
+
+type OutboxRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *Outbox) error
+	Update(ctx context.Context, conn gotann.Connection, entity *Outbox) error
+	FindDue(ctx context.Context, conn gotann.Connection, limit int) ([]*Outbox, error)
+	Claim(ctx context.Context, conn gotann.Connection, id uint, leaseUntil time.Time) (int64, error)
+	CancelPending(ctx context.Context, conn gotann.Connection, eventType, aggregateID string) (int64, error)
+}
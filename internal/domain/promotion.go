@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// Promotion is a promo code that discounts a claim when it is locked. The
+// discount is a percentage, optionally capped by MaxDiscount, or a fixed
+// amount, and only counts the items of the route, class and schedule the
+// promotion is restricted to. Unset restrictions and limits mean any.
+type Promotion struct {
+	ID                uint      `gorm:"column:id;primaryKey"`
+	Code              string    `gorm:"column:code;type:varchar(32);not null;uniqueIndex"` // Stored upper case
+	Name              string    `gorm:"column:name;type:varchar(64);not null"`
+	DiscountType      string    `gorm:"column:discount_type;type:varchar(16);not null"`
+	DiscountValue     float64   `gorm:"column:discount_value;not null"` // Percent for PERCENTAGE, amount for FIXED
+	MaxDiscount       *float64  `gorm:"column:max_discount"`
+	MinSpend          float64   `gorm:"column:min_spend;not null;default:0"`
+	ValidFrom         time.Time `gorm:"column:valid_from;not null"`
+	ValidUntil        time.Time `gorm:"column:valid_until;not null"`
+	UsageLimit        *int      `gorm:"column:usage_limit"`
+	PerCustomerLimit  *int      `gorm:"column:per_customer_limit"` // Needs a signed in customer
+	UsedCount         int       `gorm:"column:used_count;not null;default:0"`
+	DepartureHarborID *uint     `gorm:"column:departure_harbor_id"`
+	ArrivalHarborID   *uint     `gorm:"column:arrival_harbor_id"`
+	ClassID           *uint     `gorm:"column:class_id"`
+	ScheduleID        *uint     `gorm:"column:schedule_id"`
+	IsActive          bool      `gorm:"column:is_active;not null;default:true"`
+	CreatedAt         time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt         time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (p *Promotion) TableName() string {
+	return "promotion"
+}
+
+// PromotionRedemption is one use of a promotion. It is taken when a claim
+// session is locked and given back when the session is cancelled or expires;
+// once the booking is made it stays used.
+type PromotionRedemption struct {
+	ID             uint      `gorm:"column:id;primaryKey"`
+	PromotionID    uint      `gorm:"column:promotion_id;not null;index"`
+	CustomerID     *uint     `gorm:"column:customer_id;index"`
+	ClaimSessionID uint      `gorm:"column:claim_session_id;not null;uniqueIndex"`
+	BookingID      *uint     `gorm:"column:booking_id;index"`
+	Discount       float64   `gorm:"column:discount;not null"`
+	CreatedAt      time.Time `gorm:"column:created_at;not null"`
+}
+
+func (pr *PromotionRedemption) TableName() string {
+	return "promotion_redemption"
+}
+
+type PromotionRepository interface {
+	Count(ctx context.Context, conn gotann.Connection) (int64, error)
+	Insert(ctx context.Context, conn gotann.Connection, entity *Promotion) error
+	Update(ctx context.Context, conn gotann.Connection, entity *Promotion) error
+	Delete(ctx context.Context, conn gotann.Connection, entity *Promotion) error
+	FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*Promotion, error)
+	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*Promotion, error)
+	FindByCode(ctx context.Context, conn gotann.Connection, code string) (*Promotion, error)
+	Redeem(ctx context.Context, conn gotann.Connection, id uint) (int64, error)
+	Release(ctx context.Context, conn gotann.Connection, id uint) error
+}
+
+type PromotionRedemptionRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *PromotionRedemption) error
+	Update(ctx context.Context, conn gotann.Connection, entity *PromotionRedemption) error
+	Delete(ctx context.Context, conn gotann.Connection, entity *PromotionRedemption) error
+	CountByCustomer(ctx context.Context, conn gotann.Connection, promotionID, customerID uint) (int64, error)
+	FindByClaimSessionID(ctx context.Context, conn gotann.Connection, claimSessionID uint) (*PromotionRedemption, error)
+	FindByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) (*PromotionRedemption, error)
+}
@@ -12,18 +12,38 @@ type Quota struct {
 	ClassID    uint      `gorm:"column:class_id;not null;uniqueIndex:idx_schedule_class"`
 	Quota      int       `gorm:"column:quota;not null"`
 	Capacity   int       `gorm:"column:capacity;not null"`
-	Price      float64   `gorm:"column:price;not null"`
+	Held       int       `gorm:"column:held;not null;default:0"` // Units held by pending claim sessions
+	Price      float64   `gorm:"column:price;not null"`          // Base price, used when the quota has no fares
 	CreatedAt  time.Time `gorm:"column:created_at;not null"`
 	UpdatedAt  time.Time `gorm:"column:updated_at;not null"`
 
 	Class    Class    `gorm:"foreignKey:ClassID"`
 	Schedule Schedule `gorm:"foreignKey:ScheduleID"`
+	Fares    []Fare   `gorm:"foreignKey:QuotaID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (q *Quota) TableName() string {
 	return "quota"
 }
 
+// Fare prices passengers of a category on a quota. A passenger gets the fare
+// whose age range holds their age; MaxAge nil means no upper bound. Infants
+// travel on a lap and take neither quota nor a seat.
+type Fare struct {
+	ID        uint      `gorm:"column:id;primaryKey"`
+	QuotaID   uint      `gorm:"column:quota_id;not null;uniqueIndex:idx_quota_fare_category"`
+	Category  string    `gorm:"column:category;type:varchar(16);not null;uniqueIndex:idx_quota_fare_category"`
+	MinAge    int       `gorm:"column:min_age;not null;default:0"`
+	MaxAge    *int      `gorm:"column:max_age"`
+	Price     float64   `gorm:"column:price;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (f *Fare) TableName() string {
+	return "fare"
+}
+
 type QuotaRepository interface {
 	Count(ctx context.Context, conn gotann.Connection) (int64, error)
 	Insert(ctx context.Context, conn gotann.Connection, entity *Quota) error
@@ -35,4 +55,12 @@ type QuotaRepository interface {
 	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*Quota, error)
 	FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*Quota, error)
 	FindByScheduleIDAndClassID(ctx context.Context, conn gotann.Connection, scheduleID uint, classID uint) (*Quota, error)
+	ReplaceFares(ctx context.Context, conn gotann.Connection, quotaID uint, fares []Fare) error
+
+	// Reservation counters. Each call is a single conditional UPDATE on the
+	// (schedule, class) row, so callers never need to lock quotas up front.
+	Reserve(ctx context.Context, conn gotann.Connection, scheduleID uint, classID uint, quantity int) (bool, error)
+	Release(ctx context.Context, conn gotann.Connection, scheduleID uint, classID uint, quantity int) error
+	Confirm(ctx context.Context, conn gotann.Connection, scheduleID uint, classID uint, quantity int) (bool, error)
+	Restore(ctx context.Context, conn gotann.Connection, scheduleID uint, classID uint, quantity int) error
 }
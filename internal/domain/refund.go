@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// Refund is a ledger entry of the amount given back for one ticket of a
+// cancelled booking, with the policy rule that produced it.
+type Refund struct {
+	ID                   uint      `gorm:"column:id;primaryKey"`
+	BookingID            uint      `gorm:"column:booking_id;not null;index"`
+	TicketID             uint      `gorm:"column:ticket_id;not null;uniqueIndex"`
+	PolicyID             *uint     `gorm:"column:policy_id;index"`
+	PolicyName           string    `gorm:"column:policy_name;type:varchar(64);not null"`
+	HoursBeforeDeparture float64   `gorm:"column:hours_before_departure;not null"`
+	TicketPrice          float64   `gorm:"column:ticket_price;not null"`
+	RefundPercent        float64   `gorm:"column:refund_percent;not null"`
+	Amount               float64   `gorm:"column:amount;not null"`
+	CreatedAt            time.Time `gorm:"column:created_at;not null"`
+
+	Ticket Ticket `gorm:"foreignKey:TicketID"`
+}
+
+func (r *Refund) TableName() string {
+	return "refund"
+}
+
+type RefundRepository interface {
+	InsertBulk(ctx context.Context, conn gotann.Connection, entities []*Refund) error
+	FindByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) ([]*Refund, error)
+}
@@ -17,11 +17,12 @@ type Schedule struct {
 	CreatedAt         time.Time `gorm:"column:created_at;not null"`
 	UpdatedAt         time.Time `gorm:"column:updated_at;not null"`
 
-	Ship            Ship           `gorm:"foreignKey:ShipID"` // Gorm will create the relationship
-	DepartureHarbor Harbor         `gorm:"foreignKey:DepartureHarborID"`
-	ArrivalHarbor   Harbor         `gorm:"foreignKey:ArrivalHarborID"`
-	Quotas          []*Quota       `gorm:"foreignKey:ScheduleID"`
-	ClaimSessions   []ClaimSession `gorm:"foreignKey:ScheduleID"`
+	Ship            Ship            `gorm:"foreignKey:ShipID"` // Gorm will create the relationship
+	DepartureHarbor Harbor          `gorm:"foreignKey:DepartureHarborID"`
+	ArrivalHarbor   Harbor          `gorm:"foreignKey:ArrivalHarborID"`
+	Quotas          []*Quota        `gorm:"foreignKey:ScheduleID"`
+	ClaimSessions   []ClaimSession  `gorm:"foreignKey:ScheduleID"`
+	Delays          []ScheduleDelay `gorm:"foreignKey:ScheduleID"` // Delay history, oldest first
 }
 
 func (sch *Schedule) TableName() string {
@@ -38,4 +39,7 @@ type ScheduleRepository interface {
 	FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*Schedule, error)
 	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*Schedule, error)
 	FindActiveSchedules(ctx context.Context, conn gotann.Connection) ([]*Schedule, error)
+	FindUpcomingOnRoute(ctx context.Context, conn gotann.Connection, departureHarborID, arrivalHarborID uint, after time.Time, limit int) ([]*Schedule, error)
+	Reschedule(ctx context.Context, conn gotann.Connection, entity *Schedule, previousDeparture time.Time) (int64, error)
+	CountDepartingBetween(ctx context.Context, conn gotann.Connection, from, to time.Time) (int64, error)
 }
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// ScheduleCancellation is an operator's cancellation of a departure. Every
+// booking on it becomes an item that a background job settles one by one, so
+// the work picks up where it stopped after a restart.
+type ScheduleCancellation struct {
+	ID           uint       `gorm:"column:id;primaryKey"`
+	ScheduleID   uint       `gorm:"column:schedule_id;not null;uniqueIndex"`
+	Reason       string     `gorm:"column:reason;type:text;not null"`
+	Resolution   string     `gorm:"column:resolution;type:varchar(16);not null"` // What paid bookings get, a refund or a seat on another departure
+	ToScheduleID *uint      `gorm:"column:to_schedule_id"`                       // Departure to rebook onto, nil for the next one on the route with room
+	Status       string     `gorm:"column:status;type:varchar(16);not null;index"`
+	UserID       *uint      `gorm:"column:user_id"` // Staff member who cancelled
+	Total        int        `gorm:"column:total;not null"`
+	CompletedAt  *time.Time `gorm:"column:completed_at"`
+	CreatedAt    time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt    time.Time  `gorm:"column:updated_at;not null"`
+
+	Schedule Schedule `gorm:"foreignKey:ScheduleID"`
+}
+
+func (sc *ScheduleCancellation) TableName() string {
+	return "schedule_cancellation"
+}
+
+// ScheduleCancellationItem is one booking of a cancelled departure and what
+// was done with it. Items that keep failing stop being retried and are left
+// for staff.
+type ScheduleCancellationItem struct {
+	ID             uint       `gorm:"column:id;primaryKey"`
+	CancellationID uint       `gorm:"column:cancellation_id;not null;uniqueIndex:idx_cancellation_booking"`
+	BookingID      uint       `gorm:"column:booking_id;not null;uniqueIndex:idx_cancellation_booking"`
+	Status         string     `gorm:"column:status;type:varchar(16);not null;index"`
+	ToScheduleID   *uint      `gorm:"column:to_schedule_id"` // Departure the booking was rebooked onto
+	RefundAmount   float64    `gorm:"column:refund_amount;not null;default:0"`
+	Attempts       int        `gorm:"column:attempts;not null;default:0"`
+	Error          *string    `gorm:"column:error;type:text"`
+	ProcessedAt    *time.Time `gorm:"column:processed_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;not null"`
+
+	Booking Booking `gorm:"foreignKey:BookingID"`
+}
+
+func (sci *ScheduleCancellationItem) TableName() string {
+	return "schedule_cancellation_item"
+}
+
+type ScheduleCancellationRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *ScheduleCancellation) error
+	Update(ctx context.Context, conn gotann.Connection, entity *ScheduleCancellation) error
+	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*ScheduleCancellation, error)
+	FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) (*ScheduleCancellation, error)
+	FindRunning(ctx context.Context, conn gotann.Connection) ([]*ScheduleCancellation, error)
+	FindBookingIDs(ctx context.Context, conn gotann.Connection, scheduleID uint, statuses []string) ([]uint, error)
+
+	InsertItems(ctx context.Context, conn gotann.Connection, items []*ScheduleCancellationItem) error
+	// UpdatePendingItem saves an item that is still pending and returns how
+	// many rows it wrote, 0 when another instance settled it first.
+	UpdatePendingItem(ctx context.Context, conn gotann.Connection, item *ScheduleCancellationItem) (int64, error)
+	FindPendingItems(ctx context.Context, conn gotann.Connection, cancellationID uint, limit int) ([]*ScheduleCancellationItem, error)
+	FindItemsByStatus(ctx context.Context, conn gotann.Connection, cancellationID uint, status string) ([]*ScheduleCancellationItem, error)
+	CountItemsByStatus(ctx context.Context, conn gotann.Connection, cancellationID uint) (map[string]int64, error)
+	SumRefunded(ctx context.Context, conn gotann.Connection, cancellationID uint) (float64, error)
+	RetryFailedItems(ctx context.Context, conn gotann.Connection, cancellationID uint) (int64, error)
+}
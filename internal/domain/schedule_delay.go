@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// ScheduleDelay is one postponement of a departure. The delays of a schedule,
+// oldest first, are its delay history: the first PreviousDeparture is when
+// it was meant to leave.
+type ScheduleDelay struct {
+	ID                uint      `gorm:"column:id;primaryKey"`
+	ScheduleID        uint      `gorm:"column:schedule_id;not null;index"`
+	Reason            string    `gorm:"column:reason;type:varchar(24);not null;index"`
+	Note              string    `gorm:"column:note;type:text"` // Shown to passengers along with the reason
+	PreviousDeparture time.Time `gorm:"column:previous_departure;not null"`
+	NewDeparture      time.Time `gorm:"column:new_departure;not null"`
+	PreviousArrival   time.Time `gorm:"column:previous_arrival;not null"`
+	NewArrival        time.Time `gorm:"column:new_arrival;not null"`
+	Notified          int       `gorm:"column:notified;not null;default:0"` // Bookings told about the delay
+	UserID            *uint     `gorm:"column:user_id"`                     // Staff member who announced it
+	CreatedAt         time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt         time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (sd *ScheduleDelay) TableName() string {
+	return "schedule_delay"
+}
+
+// Minutes is how much later the departure leaves because of this delay.
+func (sd *ScheduleDelay) Minutes() int {
+	return int(sd.NewDeparture.Sub(sd.PreviousDeparture).Minutes())
+}
+
+type ScheduleDelayRepository interface {
+	Insert(ctx context.Context, conn gotann.Connection, entity *ScheduleDelay) error
+	Update(ctx context.Context, conn gotann.Connection, entity *ScheduleDelay) error
+	FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*ScheduleDelay, error)
+	FindDepartingBetween(ctx context.Context, conn gotann.Connection, from, to time.Time) ([]*ScheduleDelay, error)
+	FindBookingsToNotify(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*Booking, error)
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// ScheduleSeat is one seat of a schedule's inventory, copied from the ship's
+// seat layout the first time the schedule is sold or its seat map is viewed.
+type ScheduleSeat struct {
+	ID             uint      `gorm:"column:id;primaryKey"`
+	ScheduleID     uint      `gorm:"column:schedule_id;not null;uniqueIndex:idx_schedule_seat"`
+	ClassID        uint      `gorm:"column:class_id;not null;uniqueIndex:idx_schedule_seat"`
+	SeatNumber     string    `gorm:"column:seat_number;type:varchar(24);not null;uniqueIndex:idx_schedule_seat"`
+	RowNumber      int       `gorm:"column:row_number;not null"`
+	ColumnNumber   int       `gorm:"column:column_number;not null"`
+	Status         string    `gorm:"column:status;type:varchar(24);not null;index"`
+	ClaimSessionID *uint     `gorm:"column:claim_session_id;index"` // Set while the seat is held
+	TicketID       *uint     `gorm:"column:ticket_id;index"`        // Set once the seat is sold
+	CreatedAt      time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt      time.Time `gorm:"column:updated_at;not null"`
+
+	Class Class `gorm:"foreignKey:ClassID"`
+}
+
+func (ss *ScheduleSeat) TableName() string {
+	return "schedule_seat"
+}
+
+type ScheduleSeatRepository interface {
+	InsertBulk(ctx context.Context, conn gotann.Connection, seats []*ScheduleSeat) error
+	Update(ctx context.Context, conn gotann.Connection, entity *ScheduleSeat) error
+	CountByScheduleIDGroupByClass(ctx context.Context, conn gotann.Connection, scheduleID uint) (map[uint]int64, error)
+	FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*ScheduleSeat, error)
+	FindByClaimSessionID(ctx context.Context, conn gotann.Connection, sessionID uint) ([]*ScheduleSeat, error)
+	FindAvailable(ctx context.Context, conn gotann.Connection, scheduleID, classID uint, seatNumbers []string, limit int) ([]*ScheduleSeat, error)
+	Hold(ctx context.Context, conn gotann.Connection, scheduleID, classID, sessionID uint, seatNumbers []string) (int64, error)
+	HoldAny(ctx context.Context, conn gotann.Connection, scheduleID, classID, sessionID uint, quantity int) (int64, error)
+	ReleaseByClaimSessionID(ctx context.Context, conn gotann.Connection, sessionID uint) error
+	ReleaseByTicketIDs(ctx context.Context, conn gotann.Connection, ticketIDs []uint) error
+	ReleaseByScheduleIDAndTicketIDs(ctx context.Context, conn gotann.Connection, scheduleID uint, ticketIDs []uint) error
+}
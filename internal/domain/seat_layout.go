@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+type SeatLayout struct {
+	ID        uint      `gorm:"column:id;primaryKey"`
+	ShipID    uint      `gorm:"column:ship_id;not null;uniqueIndex:idx_ship_class_layout"`
+	ClassID   uint      `gorm:"column:class_id;not null;uniqueIndex:idx_ship_class_layout"`
+	Rows      int       `gorm:"column:row_count;not null"`
+	Columns   int       `gorm:"column:column_count;not null"`
+	CreatedAt time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null"`
+
+	Ship  Ship         `gorm:"foreignKey:ShipID"`
+	Class Class        `gorm:"foreignKey:ClassID"`
+	Seats []LayoutSeat `gorm:"foreignKey:SeatLayoutID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (sl *SeatLayout) TableName() string {
+	return "seat_layout"
+}
+
+type LayoutSeat struct {
+	ID           uint      `gorm:"column:id;primaryKey"`
+	SeatLayoutID uint      `gorm:"column:seat_layout_id;not null;uniqueIndex:idx_layout_seat_number"`
+	SeatNumber   string    `gorm:"column:seat_number;type:varchar(24);not null;uniqueIndex:idx_layout_seat_number"`
+	RowNumber    int       `gorm:"column:row_number;not null"`
+	ColumnNumber int       `gorm:"column:column_number;not null"`
+	IsBlocked    bool      `gorm:"column:is_blocked;not null;default:false"` // Never sold, e.g. crew seats or broken seats
+	CreatedAt    time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt    time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (ls *LayoutSeat) TableName() string {
+	return "layout_seat"
+}
+
+type SeatLayoutRepository interface {
+	Count(ctx context.Context, conn gotann.Connection) (int64, error)
+	Insert(ctx context.Context, conn gotann.Connection, entity *SeatLayout) error
+	Update(ctx context.Context, conn gotann.Connection, entity *SeatLayout) error
+	Delete(ctx context.Context, conn gotann.Connection, entity *SeatLayout) error
+	FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*SeatLayout, error)
+	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*SeatLayout, error)
+	FindByShipID(ctx context.Context, conn gotann.Connection, shipID uint) ([]*SeatLayout, error)
+}
@@ -7,28 +7,40 @@ import (
 )
 
 type Ticket struct {
-	ID              uint      `gorm:"column:id;primaryKey"`
-	BookingID       *uint     `gorm:"column:booking_id;not null;index;"`
-	TicketCode      string    `gorm:"column:ticket_code;type:varchar(64);not null;uniqueIndex"` // Unique ticket code
-	ScheduleID      uint      `gorm:"column:schedule_id;not null;index;"`
-	ClassID         uint      `gorm:"column:class_id;not null;index;"`
-	PassengerName   string    `gorm:"column:passenger_name;type:varchar(32)"`
-	PassengerAge    int       `gorm:"column:passenger_age;"`
-	Address         string    `gorm:"column:address;"`
-	PassengerGender *string   `gorm:"column:passenger_gender;type:varchar(24);"`
-	IDType          *string   `gorm:"column:id_type;type:varchar(24)"`
-	IDNumber        *string   `gorm:"column:id_number;type:varchar(24)"`
-	SeatNumber      *string   `gorm:"column:seat_number;type:varchar(24)"`
-	LicensePlate    *string   `gorm:"column:license_plate;type:varchar(24)"`
-	Type            string    `gorm:"column:type;type:varchar(20);not null"` // "passenger" or "vehicle"
-	Price           float64   `gorm:"column:price;not null"`
-	IsCheckedIn     bool      `gorm:"column:is_checked_in;not null;default:false"`
-	CreatedAt       time.Time `gorm:"column:created_at;not null"`
-	UpdatedAt       time.Time `gorm:"column:updated_at;not null"`
+	ID              uint       `gorm:"column:id;primaryKey"`
+	BookingID       *uint      `gorm:"column:booking_id;not null;index;"`
+	TicketCode      string     `gorm:"column:ticket_code;type:varchar(64);not null;uniqueIndex"` // Unique ticket code
+	ScheduleID      uint       `gorm:"column:schedule_id;not null;index;"`
+	ClassID         uint       `gorm:"column:class_id;not null;index;"`
+	PassengerName   string     `gorm:"column:passenger_name;type:varchar(32)"`
+	PassengerAge    int        `gorm:"column:passenger_age;"`
+	Address         string     `gorm:"column:address;"`
+	PassengerGender *string    `gorm:"column:passenger_gender;type:varchar(24);"`
+	IDType          *string    `gorm:"column:id_type;type:varchar(24)"`
+	IDNumber        *string    `gorm:"column:id_number;type:varchar(24)"`
+	SeatNumber      *string    `gorm:"column:seat_number;type:varchar(24)"`
+	LicensePlate    *string    `gorm:"column:license_plate;type:varchar(24)"`
+	VehicleType     *string    `gorm:"column:vehicle_type;type:varchar(16)"`
+	VehicleBrand    *string    `gorm:"column:vehicle_brand;type:varchar(32)"`
+	VehicleLength   *float64   `gorm:"column:vehicle_length"`                 // Meters
+	DriverTicketID  *uint      `gorm:"column:driver_ticket_id;index"`         // Passenger ticket of the driver, in the same booking
+	QuotaUnits      int        `gorm:"column:quota_units;not null;default:0"` // Quota the ticket takes, zero means one
+	Type            string     `gorm:"column:type;type:varchar(20);not null"` // "passenger" or "vehicle"
+	Price           float64    `gorm:"column:price;not null"`
+	FareCategory    string     `gorm:"column:fare_category;type:varchar(16)"`                             // Empty for vehicles
+	IsCheckedIn     bool       `gorm:"column:is_checked_in;not null;default:false"`                       // Checked in or boarded, kept in step with BoardingStatus
+	BoardingStatus  string     `gorm:"column:boarding_status;type:varchar(16);not null;default:'ISSUED'"` // Empty until the row is read back means ISSUED
+	CheckedInAt     *time.Time `gorm:"column:checked_in_at"`
+	BoardedAt       *time.Time `gorm:"column:boarded_at"`
+	CreatedAt       time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt       time.Time  `gorm:"column:updated_at;not null"`
 
-	Class    Class    `gorm:"foreignKey:ClassID"`
-	Schedule Schedule `gorm:"foreignKey:ScheduleID"`
-	Booking  Booking  `gorm:"foreignKey:BookingID"`
+	Class    Class          `gorm:"foreignKey:ClassID"`
+	Schedule Schedule       `gorm:"foreignKey:ScheduleID"`
+	Booking  Booking        `gorm:"foreignKey:BookingID"`
+	Driver   *Ticket        `gorm:"foreignKey:DriverTicketID"`
+	Fees     []TicketFee    `gorm:"foreignKey:TicketID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Addons   []BookingAddon `gorm:"foreignKey:TicketID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
 }
 
 func (t *Ticket) TableName() string {
@@ -47,8 +59,11 @@ type TicketRepository interface {
 	FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*Ticket, error)
 	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*Ticket, error)
 	FindByIDs(ctx context.Context, conn gotann.Connection, ids []uint) ([]*Ticket, error)
+	FindByTicketCode(ctx context.Context, conn gotann.Connection, code string) (*Ticket, error)
 	FindByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) ([]*Ticket, error)
 	FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*Ticket, error)
 	FindByClaimSessionID(ctx context.Context, conn gotann.Connection, sessionID uint) ([]*Ticket, error)
-	CheckIn(ctx context.Context, conn gotann.Connection, id uint) error
+	UpdateBoarding(ctx context.Context, conn gotann.Connection, ticket *Ticket, from string) (int64, error)
+	CountBoardingByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) (map[string]int64, error)
+	ReplaceFees(ctx context.Context, conn gotann.Connection, ticketID uint, fees []TicketFee) error
 }
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// VehicleCategory is a vehicle golongan from the ferry tariff, e.g. golongan
+// II for motorbikes or VII for trucks of 10 to 12 meters. Vehicle classes
+// point at a category, so every category has its own quota and price on a
+// schedule.
+type VehicleCategory struct {
+	ID          uint      `gorm:"column:id;primaryKey"`
+	Golongan    string    `gorm:"column:golongan;type:varchar(8);unique;not null"`
+	Name        string    `gorm:"column:name;type:varchar(64);not null"`
+	VehicleType string    `gorm:"column:vehicle_type;type:varchar(16);not null"`
+	MinLength   float64   `gorm:"column:min_length;not null;default:0"`  // Meters, inclusive
+	MaxLength   *float64  `gorm:"column:max_length"`                     // Meters, inclusive, nil means no limit
+	LaneMeters  int       `gorm:"column:lane_meters;not null;default:0"` // Deck length a vehicle takes, zero counts quota in units
+	CreatedAt   time.Time `gorm:"column:created_at;not null"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null"`
+}
+
+func (vc *VehicleCategory) TableName() string {
+	return "vehicle_category"
+}
+
+type VehicleCategoryRepository interface {
+	Count(ctx context.Context, conn gotann.Connection) (int64, error)
+	Insert(ctx context.Context, conn gotann.Connection, entity *VehicleCategory) error
+	Update(ctx context.Context, conn gotann.Connection, entity *VehicleCategory) error
+	Delete(ctx context.Context, conn gotann.Connection, entity *VehicleCategory) error
+	FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*VehicleCategory, error)
+	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*VehicleCategory, error)
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"context"
+	"eticket-api/pkg/gotann"
+	"time"
+)
+
+// Waitlist is a customer queued for a sold-out class of a schedule. Once quota
+// frees up the entry is offered a claim session of its own.
+type Waitlist struct {
+	ID             uint       `gorm:"column:id;primaryKey"`
+	ScheduleID     uint       `gorm:"column:schedule_id;not null;index;uniqueIndex:idx_waitlist_waiting,where:status = 'WAITING'"`
+	ClassID        uint       `gorm:"column:class_id;not null;index;uniqueIndex:idx_waitlist_waiting,where:status = 'WAITING'"`
+	CustomerName   string     `gorm:"column:customer_name;type:varchar(255);not null"`
+	Email          string     `gorm:"column:email;type:varchar(255);not null;uniqueIndex:idx_waitlist_waiting,where:status = 'WAITING'"`
+	PhoneNumber    string     `gorm:"column:phone_number;type:varchar(24);not null"`
+	Quantity       int        `gorm:"column:quantity;not null"`
+	Status         string     `gorm:"column:status;type:varchar(24);not null;index"`
+	ClaimSessionID *uint      `gorm:"column:claim_session_id;index"`
+	OfferedAt      *time.Time `gorm:"column:offered_at"`
+	CreatedAt      time.Time  `gorm:"column:created_at;not null"`
+	UpdatedAt      time.Time  `gorm:"column:updated_at;not null"`
+
+	Schedule     Schedule      `gorm:"foreignKey:ScheduleID"`
+	Class        Class         `gorm:"foreignKey:ClassID"`
+	ClaimSession *ClaimSession `gorm:"foreignKey:ClaimSessionID"`
+}
+
+func (w *Waitlist) TableName() string {
+	return "waitlist"
+}
+
+type WaitlistRepository interface {
+	Count(ctx context.Context, conn gotann.Connection) (int64, error)
+	Insert(ctx context.Context, conn gotann.Connection, entity *Waitlist) error
+	Update(ctx context.Context, conn gotann.Connection, entity *Waitlist) error
+	FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*Waitlist, error)
+	FindByID(ctx context.Context, conn gotann.Connection, id uint) (*Waitlist, error)
+	FindWaiting(ctx context.Context, conn gotann.Connection, limit int) ([]*Waitlist, error)
+	Offer(ctx context.Context, conn gotann.Connection, id uint, claimSessionID uint) (int64, error)
+	SettleOffers(ctx context.Context, conn gotann.Connection) error
+}
@@ -0,0 +1,51 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/usecase"
+
+	"github.com/robfig/cron/v3"
+)
+
+type BookingExpiryJob struct {
+	Log     logger.Logger
+	Usecase *usecase.PaymentUsecase
+}
+
+func NewBookingExpiryJob(log logger.Logger, usecase *usecase.PaymentUsecase) *BookingExpiryJob {
+	return &BookingExpiryJob{Log: log, Usecase: usecase}
+}
+
+func (j *BookingExpiryJob) ExpireUnpaidBookings() {
+	j.Log.Info("[BookingExpiryJob] Scheduler starting...")
+
+	c := cron.New()
+	c.AddFunc("@every 1m", func() {
+		j.Log.Info("[BookingExpiryJob] Scheduled expiry sweep triggered")
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		expiries, err := j.Usecase.ExpireUnpaidBookings(ctx)
+		if err != nil {
+			j.Log.WithError(err).Error("[BookingExpiryJob] Expiry sweep failed")
+			return
+		}
+		for _, expiry := range expiries {
+			log := j.Log.WithFields(map[string]interface{}{
+				"order_id":      expiry.OrderID,
+				"reference":     expiry.Reference,
+				"tripay_status": expiry.TripayStatus,
+				"action":        expiry.Action,
+			})
+			if expiry.Err != nil {
+				log.WithError(expiry.Err).Warn("[BookingExpiryJob] Booking left unpaid")
+				continue
+			}
+			log.Info("[BookingExpiryJob] Booking settled")
+		}
+		j.Log.WithField("bookings", len(expiries)).Info("[BookingExpiryJob] Expiry sweep completed")
+	})
+	c.Start()
+}
@@ -19,31 +19,31 @@ func NewClaimSessionJob(log logger.Logger, usecase *usecase.ClaimSessionUsecase)
 	return &ClaimSessionJob{Log: log, Usecase: usecase}
 }
 
-func (j *ClaimSessionJob) CleanExpiredClaimSession() {
+func (j *ClaimSessionJob) ExpireClaimSessions() {
 	j.Log.Info("[ClaimSessionJob] Scheduler starting...")
 
 	c := cron.New()
-	c.AddFunc("@every 1h", func() {
-		j.Log.Info("[ClaimSessionJob] Scheduled cleanup triggered")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	c.AddFunc("@every 1m", func() {
+		j.Log.Info("[ClaimSessionJob] Scheduled expiry sweep triggered")
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 		defer cancel()
-		if err := j.Usecase.DeleteExpiredClaimSession(ctx); err != nil {
-			j.Log.WithError(err).Error("[ClaimSessionJob] Cleanup failed")
+		if err := j.Usecase.ExpireClaimSessions(ctx); err != nil {
+			j.Log.WithError(err).Error("[ClaimSessionJob] Expiry sweep failed")
 		} else {
-			j.Log.Info("[ClaimSessionJob] Cleanup completed successfully")
+			j.Log.Info("[ClaimSessionJob] Expiry sweep completed successfully")
 		}
 	})
 	c.Start()
 
 	// Optionally run once at startup
 	go func() {
-		j.Log.Info("[ClaimSessionJob] Initial cleanup triggered")
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		j.Log.Info("[ClaimSessionJob] Initial expiry sweep triggered")
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 		defer cancel()
-		if err := j.Usecase.DeleteExpiredClaimSession(ctx); err != nil {
-			j.Log.WithError(err).Error("[ClaimSessionJob] Initial cleanup failed")
+		if err := j.Usecase.ExpireClaimSessions(ctx); err != nil {
+			j.Log.WithError(err).Error("[ClaimSessionJob] Initial expiry sweep failed")
 		} else {
-			j.Log.Info("[ClaimSessionJob] Initial cleanup completed successfully")
+			j.Log.Info("[ClaimSessionJob] Initial expiry sweep completed successfully")
 		}
 	}()
 }
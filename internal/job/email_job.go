@@ -6,9 +6,10 @@ import (
 )
 
 type Email struct {
-	To      string
-	Subject string
-	Body    string
+	To          string
+	Subject     string
+	Body        string
+	Attachments []mailer.Attachment
 }
 
 type EmailJob struct {
@@ -34,16 +35,16 @@ func (q *EmailJob) worker() {
 	q.Log.Info("Worker started") // tambahkan ini
 	for job := range q.Queue {
 		q.Log.Info("Sending email", "to", job.To, "subject", job.Subject)
-		q.Mailer.SendAsync(job.To, job.Subject, job.Body)
+		q.Mailer.SendAsync(job.To, job.Subject, job.Body, job.Attachments...)
 	}
 }
 
 // Fungsi untuk push job (async)
-func (q *EmailJob) SendAsync(to, subject, body string) {
-	q.Queue <- Email{To: to, Subject: subject, Body: body}
+func (q *EmailJob) SendAsync(to, subject, body string, attachments ...mailer.Attachment) {
+	q.Queue <- Email{To: to, Subject: subject, Body: body, Attachments: attachments}
 }
 
-func (q *EmailJob) Send(to, subject, body string) error {
-	q.Queue <- Email{To: to, Subject: subject, Body: body}
+func (q *EmailJob) Send(to, subject, body string, attachments ...mailer.Attachment) error {
+	q.Queue <- Email{To: to, Subject: subject, Body: body, Attachments: attachments}
 	return nil
 }
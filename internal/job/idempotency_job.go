@@ -0,0 +1,37 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/usecase"
+
+	"github.com/robfig/cron/v3"
+)
+
+type IdempotencyJob struct {
+	Log     logger.Logger
+	Usecase *usecase.IdempotencyUsecase
+}
+
+func NewIdempotencyJob(log logger.Logger, usecase *usecase.IdempotencyUsecase) *IdempotencyJob {
+	return &IdempotencyJob{Log: log, Usecase: usecase}
+}
+
+func (j *IdempotencyJob) DeleteExpiredKeys() {
+	j.Log.Info("[IdempotencyJob] Scheduler starting...")
+
+	c := cron.New()
+	c.AddFunc("@every 1h", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		deleted, err := j.Usecase.DeleteExpired(ctx)
+		if err != nil {
+			j.Log.WithError(err).Error("[IdempotencyJob] Cleanup failed")
+			return
+		}
+		j.Log.WithField("deleted", deleted).Info("[IdempotencyJob] Cleanup completed")
+	})
+	c.Start()
+}
@@ -0,0 +1,47 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/usecase"
+
+	"github.com/robfig/cron/v3"
+)
+
+type ManifestJob struct {
+	Log     logger.Logger
+	Usecase *usecase.ManifestUsecase
+}
+
+func NewManifestJob(log logger.Logger, usecase *usecase.ManifestUsecase) *ManifestJob {
+	return &ManifestJob{Log: log, Usecase: usecase}
+}
+
+func (j *ManifestJob) FreezeDepartedManifests() {
+	j.Log.Info("[ManifestJob] Scheduler starting...")
+
+	c := cron.New()
+	c.AddFunc("@every 1m", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		freezes, err := j.Usecase.FreezeDepartedManifests(ctx)
+		if err != nil {
+			j.Log.WithError(err).Error("[ManifestJob] Freeze sweep failed")
+			return
+		}
+		for _, freeze := range freezes {
+			log := j.Log.WithField("schedule_id", freeze.ScheduleID)
+			if freeze.Err != nil {
+				log.WithError(freeze.Err).Warn("[ManifestJob] Manifest not frozen")
+				continue
+			}
+			log.WithFields(map[string]interface{}{
+				"passengers": freeze.Passengers,
+				"vehicles":   freeze.Vehicles,
+			}).Info("[ManifestJob] Final manifest frozen")
+		}
+	})
+	c.Start()
+}
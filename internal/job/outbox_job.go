@@ -0,0 +1,34 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/usecase"
+
+	"github.com/robfig/cron/v3"
+)
+
+type OutboxJob struct {
+	Log     logger.Logger
+	Usecase *usecase.OutboxUsecase
+}
+
+func NewOutboxJob(log logger.Logger, usecase *usecase.OutboxUsecase) *OutboxJob {
+	return &OutboxJob{Log: log, Usecase: usecase}
+}
+
+func (j *OutboxJob) DispatchPending() {
+	j.Log.Info("[OutboxJob] Scheduler starting...")
+
+	c := cron.New()
+	c.AddFunc("@every 30s", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := j.Usecase.DispatchPending(ctx); err != nil {
+			j.Log.WithError(err).Error("[OutboxJob] Dispatch run failed")
+		}
+	})
+	c.Start()
+}
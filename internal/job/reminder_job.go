@@ -0,0 +1,48 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/usecase"
+
+	"github.com/robfig/cron/v3"
+)
+
+type ReminderJob struct {
+	Log     logger.Logger
+	Usecase *usecase.ReminderUsecase
+}
+
+func NewReminderJob(log logger.Logger, usecase *usecase.ReminderUsecase) *ReminderJob {
+	return &ReminderJob{Log: log, Usecase: usecase}
+}
+
+func (j *ReminderJob) SendDepartureReminders() {
+	j.Log.Info("[ReminderJob] Scheduler starting...")
+
+	c := cron.New()
+	c.AddFunc("@every 1m", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		sends, err := j.Usecase.SendDepartureReminders(ctx)
+		if err != nil {
+			j.Log.WithError(err).Error("[ReminderJob] Reminder sweep failed")
+		}
+		for _, send := range sends {
+			log := j.Log.WithFields(map[string]interface{}{
+				"booking_id":  send.BookingID,
+				"schedule_id": send.ScheduleID,
+				"kind":        send.Kind,
+			})
+			switch {
+			case send.Err != nil:
+				log.WithError(send.Err).Warn("[ReminderJob] Reminder not sent")
+			case !send.Skipped:
+				log.Info("[ReminderJob] Departure reminder queued")
+			}
+		}
+	})
+	c.Start()
+}
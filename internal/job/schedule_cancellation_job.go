@@ -0,0 +1,48 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/usecase"
+
+	"github.com/robfig/cron/v3"
+)
+
+type ScheduleCancellationJob struct {
+	Log     logger.Logger
+	Usecase *usecase.ScheduleCancellationUsecase
+}
+
+func NewScheduleCancellationJob(log logger.Logger, usecase *usecase.ScheduleCancellationUsecase) *ScheduleCancellationJob {
+	return &ScheduleCancellationJob{Log: log, Usecase: usecase}
+}
+
+func (j *ScheduleCancellationJob) ProcessCancellations() {
+	j.Log.Info("[ScheduleCancellationJob] Scheduler starting...")
+
+	c := cron.New()
+	c.AddFunc("@every 1m", func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		settles, err := j.Usecase.ProcessCancellations(ctx)
+		if err != nil {
+			j.Log.WithError(err).Error("[ScheduleCancellationJob] Cancellation sweep failed")
+		}
+		for _, settle := range settles {
+			log := j.Log.WithFields(map[string]interface{}{
+				"cancellation_id": settle.CancellationID,
+				"booking_id":      settle.BookingID,
+				"status":          settle.Status,
+			})
+			switch {
+			case settle.Err != nil:
+				log.WithError(settle.Err).Warn("[ScheduleCancellationJob] Booking not settled")
+			case !settle.Skipped:
+				log.Info("[ScheduleCancellationJob] Booking settled")
+			}
+		}
+	})
+	c.Start()
+}
@@ -0,0 +1,37 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"eticket-api/internal/common/logger"
+	"eticket-api/internal/usecase"
+
+	"github.com/robfig/cron/v3"
+)
+
+type WaitlistJob struct {
+	Log     logger.Logger
+	Usecase *usecase.WaitlistUsecase
+}
+
+func NewWaitlistJob(log logger.Logger, usecase *usecase.WaitlistUsecase) *WaitlistJob {
+	return &WaitlistJob{Log: log, Usecase: usecase}
+}
+
+func (j *WaitlistJob) OfferFreedQuota() {
+	j.Log.Info("[WaitlistJob] Scheduler starting...")
+
+	c := cron.New()
+	c.AddFunc("@every 1m", func() {
+		j.Log.Info("[WaitlistJob] Scheduled offer run triggered")
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := j.Usecase.OfferFreedQuota(ctx); err != nil {
+			j.Log.WithError(err).Error("[WaitlistJob] Offer run failed")
+		} else {
+			j.Log.Info("[WaitlistJob] Offer run completed successfully")
+		}
+	})
+	c.Start()
+}
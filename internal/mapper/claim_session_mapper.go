@@ -9,8 +9,13 @@ import (
 func TESTClaimSessionToResponse(session *domain.ClaimSession) *model.TESTReadClaimSessionResponse {
 	claimItems := make([]model.ClaimSessionItem, len(session.ClaimItems))
 	for i, item := range session.ClaimItems {
+		scheduleID := item.ScheduleID
+		if scheduleID == 0 {
+			scheduleID = session.ScheduleID
+		}
 		claimItems[i] = model.ClaimSessionItem{
-			ClassID: item.Class.ID,
+			ScheduleID: scheduleID,
+			ClassID:    item.Class.ID,
 			Class: model.ClaimSessionItemClass{
 				ID:        item.Class.ID,
 				ClassName: item.Class.ClassName,
@@ -21,6 +26,17 @@ func TESTClaimSessionToResponse(session *domain.ClaimSession) *model.TESTReadCla
 		}
 	}
 
+	addons := make([]model.ClaimSessionAddon, len(session.Addons))
+	for i, addon := range session.Addons {
+		addons[i] = model.ClaimSessionAddon{
+			ScheduleID: addon.ScheduleID,
+			AddonID:    addon.AddonID,
+			Name:       addon.Addon.Name,
+			Quantity:   addon.Quantity,
+			Subtotal:   addon.Subtotal,
+		}
+	}
+
 	return &model.TESTReadClaimSessionResponse{
 		ID:        session.ID,
 		SessionID: session.SessionID,
@@ -43,19 +59,34 @@ func TESTClaimSessionToResponse(session *domain.ClaimSession) *model.TESTReadCla
 			ArrivalDatetime:   session.Schedule.ArrivalDatetime,
 		},
 		ExpiresAt:  session.ExpiresAt,
+		ExtendedAt: session.ExtendedAt,
 		ClaimItems: claimItems,
+		Addons:     addons,
 		CreatedAt:  session.CreatedAt,
 		UpdatedAt:  session.UpdatedAt,
 	}
 }
 
+func ScheduleSeatsToClaimSessionSeats(seats []*domain.ScheduleSeat) []model.ClaimSessionSeat {
+	responses := make([]model.ClaimSessionSeat, len(seats))
+	for i, seat := range seats {
+		responses[i] = model.ClaimSessionSeat{
+			ScheduleID: seat.ScheduleID,
+			ClassID:    seat.ClassID,
+			SeatNumber: seat.SeatNumber,
+		}
+	}
+	return responses
+}
+
 func ClaimSessionFromRequest(req *model.TESTWriteClaimSessionRequest) *domain.ClaimSession {
 	claimItems := make([]domain.ClaimItem, len(req.Items))
 	for i, item := range req.Items {
 		claimItems[i] = domain.ClaimItem{
-			ClassID:  item.ClassID,
-			Quantity: item.Quantity,
-			Subtotal: item.Subtotal,
+			ScheduleID: item.ScheduleID,
+			ClassID:    item.ClassID,
+			Quantity:   item.Quantity,
+			Subtotal:   item.Subtotal,
 		}
 	}
 	return &domain.ClaimSession{
@@ -0,0 +1,343 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/addon.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAddonRepository is a mock of AddonRepository interface.
+type MockAddonRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddonRepositoryMockRecorder
+}
+
+// MockAddonRepositoryMockRecorder is the mock recorder for MockAddonRepository.
+type MockAddonRepositoryMockRecorder struct {
+	mock *MockAddonRepository
+}
+
+// NewMockAddonRepository creates a new mock instance.
+func NewMockAddonRepository(ctrl *gomock.Controller) *MockAddonRepository {
+	mock := &MockAddonRepository{ctrl: ctrl}
+	mock.recorder = &MockAddonRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddonRepository) EXPECT() *MockAddonRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockAddonRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conn)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockAddonRepositoryMockRecorder) Count(ctx, conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockAddonRepository)(nil).Count), ctx, conn)
+}
+
+// Delete mocks base method.
+func (m *MockAddonRepository) Delete(ctx context.Context, conn gotann.Connection, entity *domain.Addon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAddonRepositoryMockRecorder) Delete(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAddonRepository)(nil).Delete), ctx, conn, entity)
+}
+
+// FindAll mocks base method.
+func (m *MockAddonRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.Addon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, conn, limit, offset, sort, search)
+	ret0, _ := ret[0].([]*domain.Addon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAddonRepositoryMockRecorder) FindAll(ctx, conn, limit, offset, sort, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAddonRepository)(nil).FindAll), ctx, conn, limit, offset, sort, search)
+}
+
+// FindByID mocks base method.
+func (m *MockAddonRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.Addon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, conn, id)
+	ret0, _ := ret[0].(*domain.Addon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockAddonRepositoryMockRecorder) FindByID(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAddonRepository)(nil).FindByID), ctx, conn, id)
+}
+
+// Insert mocks base method.
+func (m *MockAddonRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.Addon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAddonRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAddonRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Update mocks base method.
+func (m *MockAddonRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.Addon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAddonRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAddonRepository)(nil).Update), ctx, conn, entity)
+}
+
+// MockAddonStockRepository is a mock of AddonStockRepository interface.
+type MockAddonStockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAddonStockRepositoryMockRecorder
+}
+
+// MockAddonStockRepositoryMockRecorder is the mock recorder for MockAddonStockRepository.
+type MockAddonStockRepositoryMockRecorder struct {
+	mock *MockAddonStockRepository
+}
+
+// NewMockAddonStockRepository creates a new mock instance.
+func NewMockAddonStockRepository(ctrl *gomock.Controller) *MockAddonStockRepository {
+	mock := &MockAddonStockRepository{ctrl: ctrl}
+	mock.recorder = &MockAddonStockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAddonStockRepository) EXPECT() *MockAddonStockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockAddonStockRepository) Confirm(ctx context.Context, conn gotann.Connection, scheduleID, addonID uint, quantity int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, conn, scheduleID, addonID, quantity)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockAddonStockRepositoryMockRecorder) Confirm(ctx, conn, scheduleID, addonID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockAddonStockRepository)(nil).Confirm), ctx, conn, scheduleID, addonID, quantity)
+}
+
+// Delete mocks base method.
+func (m *MockAddonStockRepository) Delete(ctx context.Context, conn gotann.Connection, entity *domain.AddonStock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAddonStockRepositoryMockRecorder) Delete(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAddonStockRepository)(nil).Delete), ctx, conn, entity)
+}
+
+// FindByID mocks base method.
+func (m *MockAddonStockRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.AddonStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, conn, id)
+	ret0, _ := ret[0].(*domain.AddonStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockAddonStockRepositoryMockRecorder) FindByID(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockAddonStockRepository)(nil).FindByID), ctx, conn, id)
+}
+
+// FindByScheduleID mocks base method.
+func (m *MockAddonStockRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.AddonStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByScheduleID", ctx, conn, scheduleID)
+	ret0, _ := ret[0].([]*domain.AddonStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByScheduleID indicates an expected call of FindByScheduleID.
+func (mr *MockAddonStockRepositoryMockRecorder) FindByScheduleID(ctx, conn, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByScheduleID", reflect.TypeOf((*MockAddonStockRepository)(nil).FindByScheduleID), ctx, conn, scheduleID)
+}
+
+// Insert mocks base method.
+func (m *MockAddonStockRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.AddonStock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockAddonStockRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockAddonStockRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Release mocks base method.
+func (m *MockAddonStockRepository) Release(ctx context.Context, conn gotann.Connection, scheduleID, addonID uint, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, conn, scheduleID, addonID, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockAddonStockRepositoryMockRecorder) Release(ctx, conn, scheduleID, addonID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockAddonStockRepository)(nil).Release), ctx, conn, scheduleID, addonID, quantity)
+}
+
+// Reserve mocks base method.
+func (m *MockAddonStockRepository) Reserve(ctx context.Context, conn gotann.Connection, scheduleID, addonID uint, quantity int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, conn, scheduleID, addonID, quantity)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockAddonStockRepositoryMockRecorder) Reserve(ctx, conn, scheduleID, addonID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockAddonStockRepository)(nil).Reserve), ctx, conn, scheduleID, addonID, quantity)
+}
+
+// Restore mocks base method.
+func (m *MockAddonStockRepository) Restore(ctx context.Context, conn gotann.Connection, scheduleID, addonID uint, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, conn, scheduleID, addonID, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockAddonStockRepositoryMockRecorder) Restore(ctx, conn, scheduleID, addonID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAddonStockRepository)(nil).Restore), ctx, conn, scheduleID, addonID, quantity)
+}
+
+// Update mocks base method.
+func (m *MockAddonStockRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.AddonStock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAddonStockRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAddonStockRepository)(nil).Update), ctx, conn, entity)
+}
+
+// MockBookingAddonRepository is a mock of BookingAddonRepository interface.
+type MockBookingAddonRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingAddonRepositoryMockRecorder
+}
+
+// MockBookingAddonRepositoryMockRecorder is the mock recorder for MockBookingAddonRepository.
+type MockBookingAddonRepositoryMockRecorder struct {
+	mock *MockBookingAddonRepository
+}
+
+// NewMockBookingAddonRepository creates a new mock instance.
+func NewMockBookingAddonRepository(ctrl *gomock.Controller) *MockBookingAddonRepository {
+	mock := &MockBookingAddonRepository{ctrl: ctrl}
+	mock.recorder = &MockBookingAddonRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingAddonRepository) EXPECT() *MockBookingAddonRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByScheduleID mocks base method.
+func (m *MockBookingAddonRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.BookingAddon, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByScheduleID", ctx, conn, scheduleID)
+	ret0, _ := ret[0].([]*domain.BookingAddon)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByScheduleID indicates an expected call of FindByScheduleID.
+func (mr *MockBookingAddonRepositoryMockRecorder) FindByScheduleID(ctx, conn, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByScheduleID", reflect.TypeOf((*MockBookingAddonRepository)(nil).FindByScheduleID), ctx, conn, scheduleID)
+}
+
+// InsertBulk mocks base method.
+func (m *MockBookingAddonRepository) InsertBulk(ctx context.Context, conn gotann.Connection, addons []*domain.BookingAddon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBulk", ctx, conn, addons)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertBulk indicates an expected call of InsertBulk.
+func (mr *MockBookingAddonRepositoryMockRecorder) InsertBulk(ctx, conn, addons interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBulk", reflect.TypeOf((*MockBookingAddonRepository)(nil).InsertBulk), ctx, conn, addons)
+}
+
+// UpdateBulk mocks base method.
+func (m *MockBookingAddonRepository) UpdateBulk(ctx context.Context, conn gotann.Connection, addons []*domain.BookingAddon) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBulk", ctx, conn, addons)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBulk indicates an expected call of UpdateBulk.
+func (mr *MockBookingAddonRepositoryMockRecorder) UpdateBulk(ctx, conn, addons interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBulk", reflect.TypeOf((*MockBookingAddonRepository)(nil).UpdateBulk), ctx, conn, addons)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/boarding.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBoardingEventRepository is a mock of BoardingEventRepository interface.
+type MockBoardingEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBoardingEventRepositoryMockRecorder
+}
+
+// MockBoardingEventRepositoryMockRecorder is the mock recorder for MockBoardingEventRepository.
+type MockBoardingEventRepositoryMockRecorder struct {
+	mock *MockBoardingEventRepository
+}
+
+// NewMockBoardingEventRepository creates a new mock instance.
+func NewMockBoardingEventRepository(ctrl *gomock.Controller) *MockBoardingEventRepository {
+	mock := &MockBoardingEventRepository{ctrl: ctrl}
+	mock.recorder = &MockBoardingEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBoardingEventRepository) EXPECT() *MockBoardingEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByTicketID mocks base method.
+func (m *MockBoardingEventRepository) FindByTicketID(ctx context.Context, conn gotann.Connection, ticketID uint) ([]*domain.BoardingEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTicketID", ctx, conn, ticketID)
+	ret0, _ := ret[0].([]*domain.BoardingEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTicketID indicates an expected call of FindByTicketID.
+func (mr *MockBoardingEventRepositoryMockRecorder) FindByTicketID(ctx, conn, ticketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTicketID", reflect.TypeOf((*MockBoardingEventRepository)(nil).FindByTicketID), ctx, conn, ticketID)
+}
+
+// FindLastByTicketID mocks base method.
+func (m *MockBoardingEventRepository) FindLastByTicketID(ctx context.Context, conn gotann.Connection, ticketID uint) (*domain.BoardingEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastByTicketID", ctx, conn, ticketID)
+	ret0, _ := ret[0].(*domain.BoardingEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLastByTicketID indicates an expected call of FindLastByTicketID.
+func (mr *MockBoardingEventRepositoryMockRecorder) FindLastByTicketID(ctx, conn, ticketID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastByTicketID", reflect.TypeOf((*MockBoardingEventRepository)(nil).FindLastByTicketID), ctx, conn, ticketID)
+}
+
+// Insert mocks base method.
+func (m *MockBoardingEventRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.BoardingEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockBoardingEventRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockBoardingEventRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Update mocks base method.
+func (m *MockBoardingEventRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.BoardingEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBoardingEventRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBoardingEventRepository)(nil).Update), ctx, conn, entity)
+}
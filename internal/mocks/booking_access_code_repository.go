@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/booking_access_code.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBookingAccessCodeRepository is a mock of BookingAccessCodeRepository interface.
+type MockBookingAccessCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingAccessCodeRepositoryMockRecorder
+}
+
+// MockBookingAccessCodeRepositoryMockRecorder is the mock recorder for MockBookingAccessCodeRepository.
+type MockBookingAccessCodeRepositoryMockRecorder struct {
+	mock *MockBookingAccessCodeRepository
+}
+
+// NewMockBookingAccessCodeRepository creates a new mock instance.
+func NewMockBookingAccessCodeRepository(ctrl *gomock.Controller) *MockBookingAccessCodeRepository {
+	mock := &MockBookingAccessCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockBookingAccessCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingAccessCodeRepository) EXPECT() *MockBookingAccessCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindLatestByBookingID mocks base method.
+func (m *MockBookingAccessCodeRepository) FindLatestByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) (*domain.BookingAccessCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLatestByBookingID", ctx, conn, bookingID)
+	ret0, _ := ret[0].(*domain.BookingAccessCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLatestByBookingID indicates an expected call of FindLatestByBookingID.
+func (mr *MockBookingAccessCodeRepositoryMockRecorder) FindLatestByBookingID(ctx, conn, bookingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLatestByBookingID", reflect.TypeOf((*MockBookingAccessCodeRepository)(nil).FindLatestByBookingID), ctx, conn, bookingID)
+}
+
+// Insert mocks base method.
+func (m *MockBookingAccessCodeRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.BookingAccessCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockBookingAccessCodeRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockBookingAccessCodeRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Update mocks base method.
+func (m *MockBookingAccessCodeRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.BookingAccessCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBookingAccessCodeRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookingAccessCodeRepository)(nil).Update), ctx, conn, entity)
+}
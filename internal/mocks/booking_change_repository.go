@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/booking_change.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBookingChangeRepository is a mock of BookingChangeRepository interface.
+type MockBookingChangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookingChangeRepositoryMockRecorder
+}
+
+// MockBookingChangeRepositoryMockRecorder is the mock recorder for MockBookingChangeRepository.
+type MockBookingChangeRepositoryMockRecorder struct {
+	mock *MockBookingChangeRepository
+}
+
+// NewMockBookingChangeRepository creates a new mock instance.
+func NewMockBookingChangeRepository(ctrl *gomock.Controller) *MockBookingChangeRepository {
+	mock := &MockBookingChangeRepository{ctrl: ctrl}
+	mock.recorder = &MockBookingChangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookingChangeRepository) EXPECT() *MockBookingChangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByBookingID mocks base method.
+func (m *MockBookingChangeRepository) FindByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) ([]*domain.BookingChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBookingID", ctx, conn, bookingID)
+	ret0, _ := ret[0].([]*domain.BookingChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBookingID indicates an expected call of FindByBookingID.
+func (mr *MockBookingChangeRepositoryMockRecorder) FindByBookingID(ctx, conn, bookingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBookingID", reflect.TypeOf((*MockBookingChangeRepository)(nil).FindByBookingID), ctx, conn, bookingID)
+}
+
+// FindByID mocks base method.
+func (m *MockBookingChangeRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.BookingChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, conn, id)
+	ret0, _ := ret[0].(*domain.BookingChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockBookingChangeRepositoryMockRecorder) FindByID(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockBookingChangeRepository)(nil).FindByID), ctx, conn, id)
+}
+
+// FindByMerchantRef mocks base method.
+func (m *MockBookingChangeRepository) FindByMerchantRef(ctx context.Context, conn gotann.Connection, merchantRef string) (*domain.BookingChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByMerchantRef", ctx, conn, merchantRef)
+	ret0, _ := ret[0].(*domain.BookingChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByMerchantRef indicates an expected call of FindByMerchantRef.
+func (mr *MockBookingChangeRepositoryMockRecorder) FindByMerchantRef(ctx, conn, merchantRef interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByMerchantRef", reflect.TypeOf((*MockBookingChangeRepository)(nil).FindByMerchantRef), ctx, conn, merchantRef)
+}
+
+// Insert mocks base method.
+func (m *MockBookingChangeRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.BookingChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockBookingChangeRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockBookingChangeRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Update mocks base method.
+func (m *MockBookingChangeRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.BookingChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockBookingChangeRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookingChangeRepository)(nil).Update), ctx, conn, entity)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/cancellation_policy.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCancellationPolicyRepository is a mock of CancellationPolicyRepository interface.
+type MockCancellationPolicyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCancellationPolicyRepositoryMockRecorder
+}
+
+// MockCancellationPolicyRepositoryMockRecorder is the mock recorder for MockCancellationPolicyRepository.
+type MockCancellationPolicyRepositoryMockRecorder struct {
+	mock *MockCancellationPolicyRepository
+}
+
+// NewMockCancellationPolicyRepository creates a new mock instance.
+func NewMockCancellationPolicyRepository(ctrl *gomock.Controller) *MockCancellationPolicyRepository {
+	mock := &MockCancellationPolicyRepository{ctrl: ctrl}
+	mock.recorder = &MockCancellationPolicyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCancellationPolicyRepository) EXPECT() *MockCancellationPolicyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockCancellationPolicyRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conn)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockCancellationPolicyRepositoryMockRecorder) Count(ctx, conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockCancellationPolicyRepository)(nil).Count), ctx, conn)
+}
+
+// Delete mocks base method.
+func (m *MockCancellationPolicyRepository) Delete(ctx context.Context, conn gotann.Connection, entity *domain.CancellationPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCancellationPolicyRepositoryMockRecorder) Delete(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCancellationPolicyRepository)(nil).Delete), ctx, conn, entity)
+}
+
+// FindAll mocks base method.
+func (m *MockCancellationPolicyRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.CancellationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, conn, limit, offset, sort, search)
+	ret0, _ := ret[0].([]*domain.CancellationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockCancellationPolicyRepositoryMockRecorder) FindAll(ctx, conn, limit, offset, sort, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockCancellationPolicyRepository)(nil).FindAll), ctx, conn, limit, offset, sort, search)
+}
+
+// FindApplicable mocks base method.
+func (m *MockCancellationPolicyRepository) FindApplicable(ctx context.Context, conn gotann.Connection, departureHarborID, arrivalHarborID, classID uint) (*domain.CancellationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindApplicable", ctx, conn, departureHarborID, arrivalHarborID, classID)
+	ret0, _ := ret[0].(*domain.CancellationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindApplicable indicates an expected call of FindApplicable.
+func (mr *MockCancellationPolicyRepositoryMockRecorder) FindApplicable(ctx, conn, departureHarborID, arrivalHarborID, classID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindApplicable", reflect.TypeOf((*MockCancellationPolicyRepository)(nil).FindApplicable), ctx, conn, departureHarborID, arrivalHarborID, classID)
+}
+
+// FindByID mocks base method.
+func (m *MockCancellationPolicyRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.CancellationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, conn, id)
+	ret0, _ := ret[0].(*domain.CancellationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockCancellationPolicyRepositoryMockRecorder) FindByID(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockCancellationPolicyRepository)(nil).FindByID), ctx, conn, id)
+}
+
+// Insert mocks base method.
+func (m *MockCancellationPolicyRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.CancellationPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockCancellationPolicyRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockCancellationPolicyRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Update mocks base method.
+func (m *MockCancellationPolicyRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.CancellationPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCancellationPolicyRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCancellationPolicyRepository)(nil).Update), ctx, conn, entity)
+}
@@ -9,6 +9,7 @@ import (
 	domain "eticket-api/internal/domain"
 	gotann "eticket-api/pkg/gotann"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -79,6 +80,21 @@ func (mr *MockClaimSessionRepositoryMockRecorder) DeleteBulk(ctx, conn, entity i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBulk", reflect.TypeOf((*MockClaimSessionRepository)(nil).DeleteBulk), ctx, conn, entity)
 }
 
+// Extend mocks base method.
+func (m *MockClaimSessionRepository) Extend(ctx context.Context, conn gotann.Connection, id uint, expiresAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Extend", ctx, conn, id, expiresAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Extend indicates an expected call of Extend.
+func (mr *MockClaimSessionRepositoryMockRecorder) Extend(ctx, conn, id, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Extend", reflect.TypeOf((*MockClaimSessionRepository)(nil).Extend), ctx, conn, id, expiresAt)
+}
+
 // FindActiveByScheduleID mocks base method.
 func (m *MockClaimSessionRepository) FindActiveByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.ClaimSession, error) {
 	m.ctrl.T.Helper()
@@ -209,3 +225,18 @@ func (mr *MockClaimSessionRepositoryMockRecorder) UpdateBulk(ctx, conn, sessions
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBulk", reflect.TypeOf((*MockClaimSessionRepository)(nil).UpdateBulk), ctx, conn, sessions)
 }
+
+// UpdateStatus mocks base method.
+func (m *MockClaimSessionRepository) UpdateStatus(ctx context.Context, conn gotann.Connection, id uint, from, to string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, conn, id, from, to)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockClaimSessionRepositoryMockRecorder) UpdateStatus(ctx, conn, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockClaimSessionRepository)(nil).UpdateStatus), ctx, conn, id, from, to)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/fee_component.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFeeComponentRepository is a mock of FeeComponentRepository interface.
+type MockFeeComponentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeeComponentRepositoryMockRecorder
+}
+
+// MockFeeComponentRepositoryMockRecorder is the mock recorder for MockFeeComponentRepository.
+type MockFeeComponentRepositoryMockRecorder struct {
+	mock *MockFeeComponentRepository
+}
+
+// NewMockFeeComponentRepository creates a new mock instance.
+func NewMockFeeComponentRepository(ctrl *gomock.Controller) *MockFeeComponentRepository {
+	mock := &MockFeeComponentRepository{ctrl: ctrl}
+	mock.recorder = &MockFeeComponentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeeComponentRepository) EXPECT() *MockFeeComponentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockFeeComponentRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conn)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockFeeComponentRepositoryMockRecorder) Count(ctx, conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockFeeComponentRepository)(nil).Count), ctx, conn)
+}
+
+// Delete mocks base method.
+func (m *MockFeeComponentRepository) Delete(ctx context.Context, conn gotann.Connection, entity *domain.FeeComponent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFeeComponentRepositoryMockRecorder) Delete(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFeeComponentRepository)(nil).Delete), ctx, conn, entity)
+}
+
+// FindActive mocks base method.
+func (m *MockFeeComponentRepository) FindActive(ctx context.Context, conn gotann.Connection) ([]*domain.FeeComponent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindActive", ctx, conn)
+	ret0, _ := ret[0].([]*domain.FeeComponent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindActive indicates an expected call of FindActive.
+func (mr *MockFeeComponentRepositoryMockRecorder) FindActive(ctx, conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindActive", reflect.TypeOf((*MockFeeComponentRepository)(nil).FindActive), ctx, conn)
+}
+
+// FindAll mocks base method.
+func (m *MockFeeComponentRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.FeeComponent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, conn, limit, offset, sort, search)
+	ret0, _ := ret[0].([]*domain.FeeComponent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockFeeComponentRepositoryMockRecorder) FindAll(ctx, conn, limit, offset, sort, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFeeComponentRepository)(nil).FindAll), ctx, conn, limit, offset, sort, search)
+}
+
+// FindByID mocks base method.
+func (m *MockFeeComponentRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.FeeComponent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, conn, id)
+	ret0, _ := ret[0].(*domain.FeeComponent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockFeeComponentRepositoryMockRecorder) FindByID(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockFeeComponentRepository)(nil).FindByID), ctx, conn, id)
+}
+
+// Insert mocks base method.
+func (m *MockFeeComponentRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.FeeComponent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockFeeComponentRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockFeeComponentRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Update mocks base method.
+func (m *MockFeeComponentRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.FeeComponent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFeeComponentRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFeeComponentRepository)(nil).Update), ctx, conn, entity)
+}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	mailer "eticket-api/internal/common/mailer"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,27 +35,37 @@ func (m *MockMailer) EXPECT() *MockMailerMockRecorder {
 }
 
 // Send mocks base method.
-func (m *MockMailer) Send(to, subject, body string) error {
+func (m *MockMailer) Send(to, subject, body string, attachments ...mailer.Attachment) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Send", to, subject, body)
+	varargs := []interface{}{to, subject, body}
+	for _, a := range attachments {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Send", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Send indicates an expected call of Send.
-func (mr *MockMailerMockRecorder) Send(to, subject, body interface{}) *gomock.Call {
+func (mr *MockMailerMockRecorder) Send(to, subject, body interface{}, attachments ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailer)(nil).Send), to, subject, body)
+	varargs := append([]interface{}{to, subject, body}, attachments...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMailer)(nil).Send), varargs...)
 }
 
 // SendAsync mocks base method.
-func (m *MockMailer) SendAsync(to, subject, body string) {
+func (m *MockMailer) SendAsync(to, subject, body string, attachments ...mailer.Attachment) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SendAsync", to, subject, body)
+	varargs := []interface{}{to, subject, body}
+	for _, a := range attachments {
+		varargs = append(varargs, a)
+	}
+	m.ctrl.Call(m, "SendAsync", varargs...)
 }
 
 // SendAsync indicates an expected call of SendAsync.
-func (mr *MockMailerMockRecorder) SendAsync(to, subject, body interface{}) *gomock.Call {
+func (mr *MockMailerMockRecorder) SendAsync(to, subject, body interface{}, attachments ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAsync", reflect.TypeOf((*MockMailer)(nil).SendAsync), to, subject, body)
+	varargs := append([]interface{}{to, subject, body}, attachments...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAsync", reflect.TypeOf((*MockMailer)(nil).SendAsync), varargs...)
 }
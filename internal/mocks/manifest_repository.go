@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/manifest.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockManifestRepository is a mock of ManifestRepository interface.
+type MockManifestRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockManifestRepositoryMockRecorder
+}
+
+// MockManifestRepositoryMockRecorder is the mock recorder for MockManifestRepository.
+type MockManifestRepositoryMockRecorder struct {
+	mock *MockManifestRepository
+}
+
+// NewMockManifestRepository creates a new mock instance.
+func NewMockManifestRepository(ctrl *gomock.Controller) *MockManifestRepository {
+	mock := &MockManifestRepository{ctrl: ctrl}
+	mock.recorder = &MockManifestRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockManifestRepository) EXPECT() *MockManifestRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByScheduleID mocks base method.
+func (m *MockManifestRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) (*domain.Manifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByScheduleID", ctx, conn, scheduleID)
+	ret0, _ := ret[0].(*domain.Manifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByScheduleID indicates an expected call of FindByScheduleID.
+func (mr *MockManifestRepositoryMockRecorder) FindByScheduleID(ctx, conn, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByScheduleID", reflect.TypeOf((*MockManifestRepository)(nil).FindByScheduleID), ctx, conn, scheduleID)
+}
+
+// FindUnfrozenScheduleIDs mocks base method.
+func (m *MockManifestRepository) FindUnfrozenScheduleIDs(ctx context.Context, conn gotann.Connection, departedBefore time.Time, limit int) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUnfrozenScheduleIDs", ctx, conn, departedBefore, limit)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUnfrozenScheduleIDs indicates an expected call of FindUnfrozenScheduleIDs.
+func (mr *MockManifestRepositoryMockRecorder) FindUnfrozenScheduleIDs(ctx, conn, departedBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUnfrozenScheduleIDs", reflect.TypeOf((*MockManifestRepository)(nil).FindUnfrozenScheduleIDs), ctx, conn, departedBefore, limit)
+}
+
+// Insert mocks base method.
+func (m *MockManifestRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.Manifest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockManifestRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockManifestRepository)(nil).Insert), ctx, conn, entity)
+}
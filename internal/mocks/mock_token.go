@@ -8,6 +8,7 @@ import (
 	token "eticket-api/internal/common/token"
 	domain "eticket-api/internal/domain"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -79,3 +80,123 @@ func (mr *MockTokenUtilMockRecorder) ValidateToken(token interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateToken", reflect.TypeOf((*MockTokenUtil)(nil).ValidateToken), token)
 }
+
+// GenerateBookingToken mocks base method.
+func (m *MockTokenUtil) GenerateBookingToken(booking *domain.Booking) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateBookingToken", booking)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateBookingToken indicates an expected call of GenerateBookingToken.
+func (mr *MockTokenUtilMockRecorder) GenerateBookingToken(booking interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateBookingToken", reflect.TypeOf((*MockTokenUtil)(nil).GenerateBookingToken), booking)
+}
+
+// ValidateBookingToken mocks base method.
+func (m *MockTokenUtil) ValidateBookingToken(tokenStr string) (*token.BookingClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateBookingToken", tokenStr)
+	ret0, _ := ret[0].(*token.BookingClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateBookingToken indicates an expected call of ValidateBookingToken.
+func (mr *MockTokenUtilMockRecorder) ValidateBookingToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateBookingToken", reflect.TypeOf((*MockTokenUtil)(nil).ValidateBookingToken), token)
+}
+
+// GenerateCustomerToken mocks base method.
+func (m *MockTokenUtil) GenerateCustomerToken(customer *domain.Customer) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateCustomerToken", customer)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateCustomerToken indicates an expected call of GenerateCustomerToken.
+func (mr *MockTokenUtilMockRecorder) GenerateCustomerToken(customer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateCustomerToken", reflect.TypeOf((*MockTokenUtil)(nil).GenerateCustomerToken), customer)
+}
+
+// ValidateCustomerToken mocks base method.
+func (m *MockTokenUtil) ValidateCustomerToken(tokenStr string) (*token.CustomerClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateCustomerToken", tokenStr)
+	ret0, _ := ret[0].(*token.CustomerClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateCustomerToken indicates an expected call of ValidateCustomerToken.
+func (mr *MockTokenUtilMockRecorder) ValidateCustomerToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateCustomerToken", reflect.TypeOf((*MockTokenUtil)(nil).ValidateCustomerToken), token)
+}
+
+// GenerateTicketToken mocks base method.
+func (m *MockTokenUtil) GenerateTicketToken(ticket *domain.Ticket, expiresAt time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateTicketToken", ticket, expiresAt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateTicketToken indicates an expected call of GenerateTicketToken.
+func (mr *MockTokenUtilMockRecorder) GenerateTicketToken(ticket, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateTicketToken", reflect.TypeOf((*MockTokenUtil)(nil).GenerateTicketToken), ticket, expiresAt)
+}
+
+// ValidateTicketToken mocks base method.
+func (m *MockTokenUtil) ValidateTicketToken(tokenStr string) (*token.TicketClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateTicketToken", tokenStr)
+	ret0, _ := ret[0].(*token.TicketClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateTicketToken indicates an expected call of ValidateTicketToken.
+func (mr *MockTokenUtilMockRecorder) ValidateTicketToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateTicketToken", reflect.TypeOf((*MockTokenUtil)(nil).ValidateTicketToken), token)
+}
+
+// GenerateSnapshotToken mocks base method.
+func (m *MockTokenUtil) GenerateSnapshotToken(scheduleID uint, expiresAt time.Time) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateSnapshotToken", scheduleID, expiresAt)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateSnapshotToken indicates an expected call of GenerateSnapshotToken.
+func (mr *MockTokenUtilMockRecorder) GenerateSnapshotToken(scheduleID, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateSnapshotToken", reflect.TypeOf((*MockTokenUtil)(nil).GenerateSnapshotToken), scheduleID, expiresAt)
+}
+
+// ValidateSnapshotToken mocks base method.
+func (m *MockTokenUtil) ValidateSnapshotToken(tokenStr string) (*token.SnapshotClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateSnapshotToken", tokenStr)
+	ret0, _ := ret[0].(*token.SnapshotClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ValidateSnapshotToken indicates an expected call of ValidateSnapshotToken.
+func (mr *MockTokenUtilMockRecorder) ValidateSnapshotToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSnapshotToken", reflect.TypeOf((*MockTokenUtil)(nil).ValidateSnapshotToken), token)
+}
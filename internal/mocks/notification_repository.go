@@ -0,0 +1,68 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/notification.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockNotificationLogRepository is a mock of NotificationLogRepository interface.
+type MockNotificationLogRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationLogRepositoryMockRecorder
+}
+
+// MockNotificationLogRepositoryMockRecorder is the mock recorder for MockNotificationLogRepository.
+type MockNotificationLogRepositoryMockRecorder struct {
+	mock *MockNotificationLogRepository
+}
+
+// NewMockNotificationLogRepository creates a new mock instance.
+func NewMockNotificationLogRepository(ctrl *gomock.Controller) *MockNotificationLogRepository {
+	mock := &MockNotificationLogRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationLogRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationLogRepository) EXPECT() *MockNotificationLogRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindDueReminders mocks base method.
+func (m *MockNotificationLogRepository) FindDueReminders(ctx context.Context, conn gotann.Connection, kind string, departsAfter, departsBefore time.Time, limit int) ([]*domain.DueNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDueReminders", ctx, conn, kind, departsAfter, departsBefore, limit)
+	ret0, _ := ret[0].([]*domain.DueNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDueReminders indicates an expected call of FindDueReminders.
+func (mr *MockNotificationLogRepositoryMockRecorder) FindDueReminders(ctx, conn, kind, departsAfter, departsBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDueReminders", reflect.TypeOf((*MockNotificationLogRepository)(nil).FindDueReminders), ctx, conn, kind, departsAfter, departsBefore, limit)
+}
+
+// Insert mocks base method.
+func (m *MockNotificationLogRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.NotificationLog) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockNotificationLogRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockNotificationLogRepository)(nil).Insert), ctx, conn, entity)
+}
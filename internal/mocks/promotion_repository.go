@@ -0,0 +1,278 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/promotion.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPromotionRepository is a mock of PromotionRepository interface.
+type MockPromotionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRepositoryMockRecorder
+}
+
+// MockPromotionRepositoryMockRecorder is the mock recorder for MockPromotionRepository.
+type MockPromotionRepositoryMockRecorder struct {
+	mock *MockPromotionRepository
+}
+
+// NewMockPromotionRepository creates a new mock instance.
+func NewMockPromotionRepository(ctrl *gomock.Controller) *MockPromotionRepository {
+	mock := &MockPromotionRepository{ctrl: ctrl}
+	mock.recorder = &MockPromotionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRepository) EXPECT() *MockPromotionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockPromotionRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conn)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockPromotionRepositoryMockRecorder) Count(ctx, conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockPromotionRepository)(nil).Count), ctx, conn)
+}
+
+// Delete mocks base method.
+func (m *MockPromotionRepository) Delete(ctx context.Context, conn gotann.Connection, entity *domain.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPromotionRepositoryMockRecorder) Delete(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPromotionRepository)(nil).Delete), ctx, conn, entity)
+}
+
+// FindAll mocks base method.
+func (m *MockPromotionRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, conn, limit, offset, sort, search)
+	ret0, _ := ret[0].([]*domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockPromotionRepositoryMockRecorder) FindAll(ctx, conn, limit, offset, sort, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockPromotionRepository)(nil).FindAll), ctx, conn, limit, offset, sort, search)
+}
+
+// FindByCode mocks base method.
+func (m *MockPromotionRepository) FindByCode(ctx context.Context, conn gotann.Connection, code string) (*domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCode", ctx, conn, code)
+	ret0, _ := ret[0].(*domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCode indicates an expected call of FindByCode.
+func (mr *MockPromotionRepositoryMockRecorder) FindByCode(ctx, conn, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCode", reflect.TypeOf((*MockPromotionRepository)(nil).FindByCode), ctx, conn, code)
+}
+
+// FindByID mocks base method.
+func (m *MockPromotionRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.Promotion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, conn, id)
+	ret0, _ := ret[0].(*domain.Promotion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockPromotionRepositoryMockRecorder) FindByID(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockPromotionRepository)(nil).FindByID), ctx, conn, id)
+}
+
+// Insert mocks base method.
+func (m *MockPromotionRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockPromotionRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockPromotionRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Redeem mocks base method.
+func (m *MockPromotionRepository) Redeem(ctx context.Context, conn gotann.Connection, id uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", ctx, conn, id)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockPromotionRepositoryMockRecorder) Redeem(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockPromotionRepository)(nil).Redeem), ctx, conn, id)
+}
+
+// Release mocks base method.
+func (m *MockPromotionRepository) Release(ctx context.Context, conn gotann.Connection, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, conn, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockPromotionRepositoryMockRecorder) Release(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockPromotionRepository)(nil).Release), ctx, conn, id)
+}
+
+// Update mocks base method.
+func (m *MockPromotionRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.Promotion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPromotionRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPromotionRepository)(nil).Update), ctx, conn, entity)
+}
+
+// MockPromotionRedemptionRepository is a mock of PromotionRedemptionRepository interface.
+type MockPromotionRedemptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPromotionRedemptionRepositoryMockRecorder
+}
+
+// MockPromotionRedemptionRepositoryMockRecorder is the mock recorder for MockPromotionRedemptionRepository.
+type MockPromotionRedemptionRepositoryMockRecorder struct {
+	mock *MockPromotionRedemptionRepository
+}
+
+// NewMockPromotionRedemptionRepository creates a new mock instance.
+func NewMockPromotionRedemptionRepository(ctrl *gomock.Controller) *MockPromotionRedemptionRepository {
+	mock := &MockPromotionRedemptionRepository{ctrl: ctrl}
+	mock.recorder = &MockPromotionRedemptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPromotionRedemptionRepository) EXPECT() *MockPromotionRedemptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByCustomer mocks base method.
+func (m *MockPromotionRedemptionRepository) CountByCustomer(ctx context.Context, conn gotann.Connection, promotionID, customerID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByCustomer", ctx, conn, promotionID, customerID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByCustomer indicates an expected call of CountByCustomer.
+func (mr *MockPromotionRedemptionRepositoryMockRecorder) CountByCustomer(ctx, conn, promotionID, customerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByCustomer", reflect.TypeOf((*MockPromotionRedemptionRepository)(nil).CountByCustomer), ctx, conn, promotionID, customerID)
+}
+
+// Delete mocks base method.
+func (m *MockPromotionRedemptionRepository) Delete(ctx context.Context, conn gotann.Connection, entity *domain.PromotionRedemption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPromotionRedemptionRepositoryMockRecorder) Delete(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPromotionRedemptionRepository)(nil).Delete), ctx, conn, entity)
+}
+
+// FindByBookingID mocks base method.
+func (m *MockPromotionRedemptionRepository) FindByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) (*domain.PromotionRedemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBookingID", ctx, conn, bookingID)
+	ret0, _ := ret[0].(*domain.PromotionRedemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBookingID indicates an expected call of FindByBookingID.
+func (mr *MockPromotionRedemptionRepositoryMockRecorder) FindByBookingID(ctx, conn, bookingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBookingID", reflect.TypeOf((*MockPromotionRedemptionRepository)(nil).FindByBookingID), ctx, conn, bookingID)
+}
+
+// FindByClaimSessionID mocks base method.
+func (m *MockPromotionRedemptionRepository) FindByClaimSessionID(ctx context.Context, conn gotann.Connection, claimSessionID uint) (*domain.PromotionRedemption, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByClaimSessionID", ctx, conn, claimSessionID)
+	ret0, _ := ret[0].(*domain.PromotionRedemption)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByClaimSessionID indicates an expected call of FindByClaimSessionID.
+func (mr *MockPromotionRedemptionRepositoryMockRecorder) FindByClaimSessionID(ctx, conn, claimSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByClaimSessionID", reflect.TypeOf((*MockPromotionRedemptionRepository)(nil).FindByClaimSessionID), ctx, conn, claimSessionID)
+}
+
+// Insert mocks base method.
+func (m *MockPromotionRedemptionRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.PromotionRedemption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockPromotionRedemptionRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockPromotionRedemptionRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Update mocks base method.
+func (m *MockPromotionRedemptionRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.PromotionRedemption) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPromotionRedemptionRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPromotionRedemptionRepository)(nil).Update), ctx, conn, entity)
+}
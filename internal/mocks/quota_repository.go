@@ -36,6 +36,21 @@ func (m *MockQuotaRepository) EXPECT() *MockQuotaRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Confirm mocks base method.
+func (m *MockQuotaRepository) Confirm(ctx context.Context, conn gotann.Connection, scheduleID, classID uint, quantity int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", ctx, conn, scheduleID, classID, quantity)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockQuotaRepositoryMockRecorder) Confirm(ctx, conn, scheduleID, classID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockQuotaRepository)(nil).Confirm), ctx, conn, scheduleID, classID, quantity)
+}
+
 // Count mocks base method.
 func (m *MockQuotaRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
 	m.ctrl.T.Helper()
@@ -153,6 +168,63 @@ func (mr *MockQuotaRepositoryMockRecorder) InsertBulk(ctx, conn, Quotas interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBulk", reflect.TypeOf((*MockQuotaRepository)(nil).InsertBulk), ctx, conn, Quotas)
 }
 
+// Release mocks base method.
+func (m *MockQuotaRepository) Release(ctx context.Context, conn gotann.Connection, scheduleID, classID uint, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", ctx, conn, scheduleID, classID, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockQuotaRepositoryMockRecorder) Release(ctx, conn, scheduleID, classID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockQuotaRepository)(nil).Release), ctx, conn, scheduleID, classID, quantity)
+}
+
+// ReplaceFares mocks base method.
+func (m *MockQuotaRepository) ReplaceFares(ctx context.Context, conn gotann.Connection, quotaID uint, fares []domain.Fare) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceFares", ctx, conn, quotaID, fares)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceFares indicates an expected call of ReplaceFares.
+func (mr *MockQuotaRepositoryMockRecorder) ReplaceFares(ctx, conn, quotaID, fares interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceFares", reflect.TypeOf((*MockQuotaRepository)(nil).ReplaceFares), ctx, conn, quotaID, fares)
+}
+
+// Reserve mocks base method.
+func (m *MockQuotaRepository) Reserve(ctx context.Context, conn gotann.Connection, scheduleID, classID uint, quantity int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reserve", ctx, conn, scheduleID, classID, quantity)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reserve indicates an expected call of Reserve.
+func (mr *MockQuotaRepositoryMockRecorder) Reserve(ctx, conn, scheduleID, classID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reserve", reflect.TypeOf((*MockQuotaRepository)(nil).Reserve), ctx, conn, scheduleID, classID, quantity)
+}
+
+// Restore mocks base method.
+func (m *MockQuotaRepository) Restore(ctx context.Context, conn gotann.Connection, scheduleID, classID uint, quantity int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, conn, scheduleID, classID, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockQuotaRepositoryMockRecorder) Restore(ctx, conn, scheduleID, classID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockQuotaRepository)(nil).Restore), ctx, conn, scheduleID, classID, quantity)
+}
+
 // Update mocks base method.
 func (m *MockQuotaRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.Quota) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/refund.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRefundRepository is a mock of RefundRepository interface.
+type MockRefundRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefundRepositoryMockRecorder
+}
+
+// MockRefundRepositoryMockRecorder is the mock recorder for MockRefundRepository.
+type MockRefundRepositoryMockRecorder struct {
+	mock *MockRefundRepository
+}
+
+// NewMockRefundRepository creates a new mock instance.
+func NewMockRefundRepository(ctrl *gomock.Controller) *MockRefundRepository {
+	mock := &MockRefundRepository{ctrl: ctrl}
+	mock.recorder = &MockRefundRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefundRepository) EXPECT() *MockRefundRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByBookingID mocks base method.
+func (m *MockRefundRepository) FindByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) ([]*domain.Refund, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBookingID", ctx, conn, bookingID)
+	ret0, _ := ret[0].([]*domain.Refund)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBookingID indicates an expected call of FindByBookingID.
+func (mr *MockRefundRepositoryMockRecorder) FindByBookingID(ctx, conn, bookingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBookingID", reflect.TypeOf((*MockRefundRepository)(nil).FindByBookingID), ctx, conn, bookingID)
+}
+
+// InsertBulk mocks base method.
+func (m *MockRefundRepository) InsertBulk(ctx context.Context, conn gotann.Connection, entities []*domain.Refund) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBulk", ctx, conn, entities)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertBulk indicates an expected call of InsertBulk.
+func (mr *MockRefundRepositoryMockRecorder) InsertBulk(ctx, conn, entities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBulk", reflect.TypeOf((*MockRefundRepository)(nil).InsertBulk), ctx, conn, entities)
+}
@@ -0,0 +1,229 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/schedule_cancellation.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockScheduleCancellationRepository is a mock of ScheduleCancellationRepository interface.
+type MockScheduleCancellationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleCancellationRepositoryMockRecorder
+}
+
+// MockScheduleCancellationRepositoryMockRecorder is the mock recorder for MockScheduleCancellationRepository.
+type MockScheduleCancellationRepositoryMockRecorder struct {
+	mock *MockScheduleCancellationRepository
+}
+
+// NewMockScheduleCancellationRepository creates a new mock instance.
+func NewMockScheduleCancellationRepository(ctrl *gomock.Controller) *MockScheduleCancellationRepository {
+	mock := &MockScheduleCancellationRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduleCancellationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleCancellationRepository) EXPECT() *MockScheduleCancellationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountItemsByStatus mocks base method.
+func (m *MockScheduleCancellationRepository) CountItemsByStatus(ctx context.Context, conn gotann.Connection, cancellationID uint) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountItemsByStatus", ctx, conn, cancellationID)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountItemsByStatus indicates an expected call of CountItemsByStatus.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) CountItemsByStatus(ctx, conn, cancellationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountItemsByStatus", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).CountItemsByStatus), ctx, conn, cancellationID)
+}
+
+// FindBookingIDs mocks base method.
+func (m *MockScheduleCancellationRepository) FindBookingIDs(ctx context.Context, conn gotann.Connection, scheduleID uint, statuses []string) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBookingIDs", ctx, conn, scheduleID, statuses)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBookingIDs indicates an expected call of FindBookingIDs.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) FindBookingIDs(ctx, conn, scheduleID, statuses interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingIDs", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).FindBookingIDs), ctx, conn, scheduleID, statuses)
+}
+
+// FindByID mocks base method.
+func (m *MockScheduleCancellationRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.ScheduleCancellation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, conn, id)
+	ret0, _ := ret[0].(*domain.ScheduleCancellation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) FindByID(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).FindByID), ctx, conn, id)
+}
+
+// FindByScheduleID mocks base method.
+func (m *MockScheduleCancellationRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) (*domain.ScheduleCancellation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByScheduleID", ctx, conn, scheduleID)
+	ret0, _ := ret[0].(*domain.ScheduleCancellation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByScheduleID indicates an expected call of FindByScheduleID.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) FindByScheduleID(ctx, conn, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByScheduleID", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).FindByScheduleID), ctx, conn, scheduleID)
+}
+
+// FindItemsByStatus mocks base method.
+func (m *MockScheduleCancellationRepository) FindItemsByStatus(ctx context.Context, conn gotann.Connection, cancellationID uint, status string) ([]*domain.ScheduleCancellationItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindItemsByStatus", ctx, conn, cancellationID, status)
+	ret0, _ := ret[0].([]*domain.ScheduleCancellationItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindItemsByStatus indicates an expected call of FindItemsByStatus.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) FindItemsByStatus(ctx, conn, cancellationID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindItemsByStatus", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).FindItemsByStatus), ctx, conn, cancellationID, status)
+}
+
+// FindPendingItems mocks base method.
+func (m *MockScheduleCancellationRepository) FindPendingItems(ctx context.Context, conn gotann.Connection, cancellationID uint, limit int) ([]*domain.ScheduleCancellationItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPendingItems", ctx, conn, cancellationID, limit)
+	ret0, _ := ret[0].([]*domain.ScheduleCancellationItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPendingItems indicates an expected call of FindPendingItems.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) FindPendingItems(ctx, conn, cancellationID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPendingItems", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).FindPendingItems), ctx, conn, cancellationID, limit)
+}
+
+// FindRunning mocks base method.
+func (m *MockScheduleCancellationRepository) FindRunning(ctx context.Context, conn gotann.Connection) ([]*domain.ScheduleCancellation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRunning", ctx, conn)
+	ret0, _ := ret[0].([]*domain.ScheduleCancellation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRunning indicates an expected call of FindRunning.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) FindRunning(ctx, conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRunning", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).FindRunning), ctx, conn)
+}
+
+// Insert mocks base method.
+func (m *MockScheduleCancellationRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.ScheduleCancellation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// InsertItems mocks base method.
+func (m *MockScheduleCancellationRepository) InsertItems(ctx context.Context, conn gotann.Connection, items []*domain.ScheduleCancellationItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertItems", ctx, conn, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertItems indicates an expected call of InsertItems.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) InsertItems(ctx, conn, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertItems", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).InsertItems), ctx, conn, items)
+}
+
+// RetryFailedItems mocks base method.
+func (m *MockScheduleCancellationRepository) RetryFailedItems(ctx context.Context, conn gotann.Connection, cancellationID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryFailedItems", ctx, conn, cancellationID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryFailedItems indicates an expected call of RetryFailedItems.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) RetryFailedItems(ctx, conn, cancellationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryFailedItems", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).RetryFailedItems), ctx, conn, cancellationID)
+}
+
+// SumRefunded mocks base method.
+func (m *MockScheduleCancellationRepository) SumRefunded(ctx context.Context, conn gotann.Connection, cancellationID uint) (float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumRefunded", ctx, conn, cancellationID)
+	ret0, _ := ret[0].(float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumRefunded indicates an expected call of SumRefunded.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) SumRefunded(ctx, conn, cancellationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumRefunded", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).SumRefunded), ctx, conn, cancellationID)
+}
+
+// Update mocks base method.
+func (m *MockScheduleCancellationRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.ScheduleCancellation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).Update), ctx, conn, entity)
+}
+
+// UpdatePendingItem mocks base method.
+func (m *MockScheduleCancellationRepository) UpdatePendingItem(ctx context.Context, conn gotann.Connection, item *domain.ScheduleCancellationItem) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePendingItem", ctx, conn, item)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePendingItem indicates an expected call of UpdatePendingItem.
+func (mr *MockScheduleCancellationRepositoryMockRecorder) UpdatePendingItem(ctx, conn, item interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePendingItem", reflect.TypeOf((*MockScheduleCancellationRepository)(nil).UpdatePendingItem), ctx, conn, item)
+}
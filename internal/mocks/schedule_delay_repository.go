@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/schedule_delay.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockScheduleDelayRepository is a mock of ScheduleDelayRepository interface.
+type MockScheduleDelayRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleDelayRepositoryMockRecorder
+}
+
+// MockScheduleDelayRepositoryMockRecorder is the mock recorder for MockScheduleDelayRepository.
+type MockScheduleDelayRepositoryMockRecorder struct {
+	mock *MockScheduleDelayRepository
+}
+
+// NewMockScheduleDelayRepository creates a new mock instance.
+func NewMockScheduleDelayRepository(ctrl *gomock.Controller) *MockScheduleDelayRepository {
+	mock := &MockScheduleDelayRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduleDelayRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleDelayRepository) EXPECT() *MockScheduleDelayRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindBookingsToNotify mocks base method.
+func (m *MockScheduleDelayRepository) FindBookingsToNotify(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBookingsToNotify", ctx, conn, scheduleID)
+	ret0, _ := ret[0].([]*domain.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBookingsToNotify indicates an expected call of FindBookingsToNotify.
+func (mr *MockScheduleDelayRepositoryMockRecorder) FindBookingsToNotify(ctx, conn, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBookingsToNotify", reflect.TypeOf((*MockScheduleDelayRepository)(nil).FindBookingsToNotify), ctx, conn, scheduleID)
+}
+
+// FindByScheduleID mocks base method.
+func (m *MockScheduleDelayRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.ScheduleDelay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByScheduleID", ctx, conn, scheduleID)
+	ret0, _ := ret[0].([]*domain.ScheduleDelay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByScheduleID indicates an expected call of FindByScheduleID.
+func (mr *MockScheduleDelayRepositoryMockRecorder) FindByScheduleID(ctx, conn, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByScheduleID", reflect.TypeOf((*MockScheduleDelayRepository)(nil).FindByScheduleID), ctx, conn, scheduleID)
+}
+
+// FindDepartingBetween mocks base method.
+func (m *MockScheduleDelayRepository) FindDepartingBetween(ctx context.Context, conn gotann.Connection, from, to time.Time) ([]*domain.ScheduleDelay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDepartingBetween", ctx, conn, from, to)
+	ret0, _ := ret[0].([]*domain.ScheduleDelay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDepartingBetween indicates an expected call of FindDepartingBetween.
+func (mr *MockScheduleDelayRepositoryMockRecorder) FindDepartingBetween(ctx, conn, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDepartingBetween", reflect.TypeOf((*MockScheduleDelayRepository)(nil).FindDepartingBetween), ctx, conn, from, to)
+}
+
+// Insert mocks base method.
+func (m *MockScheduleDelayRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.ScheduleDelay) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockScheduleDelayRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockScheduleDelayRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Update mocks base method.
+func (m *MockScheduleDelayRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.ScheduleDelay) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockScheduleDelayRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockScheduleDelayRepository)(nil).Update), ctx, conn, entity)
+}
@@ -9,6 +9,7 @@ import (
 	domain "eticket-api/internal/domain"
 	gotann "eticket-api/pkg/gotann"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -51,6 +52,21 @@ func (mr *MockScheduleRepositoryMockRecorder) Count(ctx, conn interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockScheduleRepository)(nil).Count), ctx, conn)
 }
 
+// CountDepartingBetween mocks base method.
+func (m *MockScheduleRepository) CountDepartingBetween(ctx context.Context, conn gotann.Connection, from, to time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDepartingBetween", ctx, conn, from, to)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountDepartingBetween indicates an expected call of CountDepartingBetween.
+func (mr *MockScheduleRepositoryMockRecorder) CountDepartingBetween(ctx, conn, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDepartingBetween", reflect.TypeOf((*MockScheduleRepository)(nil).CountDepartingBetween), ctx, conn, from, to)
+}
+
 // Delete mocks base method.
 func (m *MockScheduleRepository) Delete(ctx context.Context, conn gotann.Connection, entity *domain.Schedule) error {
 	m.ctrl.T.Helper()
@@ -110,6 +126,21 @@ func (mr *MockScheduleRepositoryMockRecorder) FindByID(ctx, conn, id interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockScheduleRepository)(nil).FindByID), ctx, conn, id)
 }
 
+// FindUpcomingOnRoute mocks base method.
+func (m *MockScheduleRepository) FindUpcomingOnRoute(ctx context.Context, conn gotann.Connection, departureHarborID, arrivalHarborID uint, after time.Time, limit int) ([]*domain.Schedule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUpcomingOnRoute", ctx, conn, departureHarborID, arrivalHarborID, after, limit)
+	ret0, _ := ret[0].([]*domain.Schedule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUpcomingOnRoute indicates an expected call of FindUpcomingOnRoute.
+func (mr *MockScheduleRepositoryMockRecorder) FindUpcomingOnRoute(ctx, conn, departureHarborID, arrivalHarborID, after, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUpcomingOnRoute", reflect.TypeOf((*MockScheduleRepository)(nil).FindUpcomingOnRoute), ctx, conn, departureHarborID, arrivalHarborID, after, limit)
+}
+
 // Insert mocks base method.
 func (m *MockScheduleRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.Schedule) error {
 	m.ctrl.T.Helper()
@@ -138,6 +169,21 @@ func (mr *MockScheduleRepositoryMockRecorder) InsertBulk(ctx, conn, schedules in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBulk", reflect.TypeOf((*MockScheduleRepository)(nil).InsertBulk), ctx, conn, schedules)
 }
 
+// Reschedule mocks base method.
+func (m *MockScheduleRepository) Reschedule(ctx context.Context, conn gotann.Connection, entity *domain.Schedule, previousDeparture time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", ctx, conn, entity, previousDeparture)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockScheduleRepositoryMockRecorder) Reschedule(ctx, conn, entity, previousDeparture interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockScheduleRepository)(nil).Reschedule), ctx, conn, entity, previousDeparture)
+}
+
 // Update mocks base method.
 func (m *MockScheduleRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.Schedule) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,197 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/schedule_seat.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockScheduleSeatRepository is a mock of ScheduleSeatRepository interface.
+type MockScheduleSeatRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduleSeatRepositoryMockRecorder
+}
+
+// MockScheduleSeatRepositoryMockRecorder is the mock recorder for MockScheduleSeatRepository.
+type MockScheduleSeatRepositoryMockRecorder struct {
+	mock *MockScheduleSeatRepository
+}
+
+// NewMockScheduleSeatRepository creates a new mock instance.
+func NewMockScheduleSeatRepository(ctrl *gomock.Controller) *MockScheduleSeatRepository {
+	mock := &MockScheduleSeatRepository{ctrl: ctrl}
+	mock.recorder = &MockScheduleSeatRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduleSeatRepository) EXPECT() *MockScheduleSeatRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByScheduleIDGroupByClass mocks base method.
+func (m *MockScheduleSeatRepository) CountByScheduleIDGroupByClass(ctx context.Context, conn gotann.Connection, scheduleID uint) (map[uint]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByScheduleIDGroupByClass", ctx, conn, scheduleID)
+	ret0, _ := ret[0].(map[uint]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByScheduleIDGroupByClass indicates an expected call of CountByScheduleIDGroupByClass.
+func (mr *MockScheduleSeatRepositoryMockRecorder) CountByScheduleIDGroupByClass(ctx, conn, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByScheduleIDGroupByClass", reflect.TypeOf((*MockScheduleSeatRepository)(nil).CountByScheduleIDGroupByClass), ctx, conn, scheduleID)
+}
+
+// FindAvailable mocks base method.
+func (m *MockScheduleSeatRepository) FindAvailable(ctx context.Context, conn gotann.Connection, scheduleID, classID uint, seatNumbers []string, limit int) ([]*domain.ScheduleSeat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAvailable", ctx, conn, scheduleID, classID, seatNumbers, limit)
+	ret0, _ := ret[0].([]*domain.ScheduleSeat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAvailable indicates an expected call of FindAvailable.
+func (mr *MockScheduleSeatRepositoryMockRecorder) FindAvailable(ctx, conn, scheduleID, classID, seatNumbers, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAvailable", reflect.TypeOf((*MockScheduleSeatRepository)(nil).FindAvailable), ctx, conn, scheduleID, classID, seatNumbers, limit)
+}
+
+// FindByClaimSessionID mocks base method.
+func (m *MockScheduleSeatRepository) FindByClaimSessionID(ctx context.Context, conn gotann.Connection, sessionID uint) ([]*domain.ScheduleSeat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByClaimSessionID", ctx, conn, sessionID)
+	ret0, _ := ret[0].([]*domain.ScheduleSeat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByClaimSessionID indicates an expected call of FindByClaimSessionID.
+func (mr *MockScheduleSeatRepositoryMockRecorder) FindByClaimSessionID(ctx, conn, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByClaimSessionID", reflect.TypeOf((*MockScheduleSeatRepository)(nil).FindByClaimSessionID), ctx, conn, sessionID)
+}
+
+// FindByScheduleID mocks base method.
+func (m *MockScheduleSeatRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.ScheduleSeat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByScheduleID", ctx, conn, scheduleID)
+	ret0, _ := ret[0].([]*domain.ScheduleSeat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByScheduleID indicates an expected call of FindByScheduleID.
+func (mr *MockScheduleSeatRepositoryMockRecorder) FindByScheduleID(ctx, conn, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByScheduleID", reflect.TypeOf((*MockScheduleSeatRepository)(nil).FindByScheduleID), ctx, conn, scheduleID)
+}
+
+// Hold mocks base method.
+func (m *MockScheduleSeatRepository) Hold(ctx context.Context, conn gotann.Connection, scheduleID, classID, sessionID uint, seatNumbers []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hold", ctx, conn, scheduleID, classID, sessionID, seatNumbers)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockScheduleSeatRepositoryMockRecorder) Hold(ctx, conn, scheduleID, classID, sessionID, seatNumbers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockScheduleSeatRepository)(nil).Hold), ctx, conn, scheduleID, classID, sessionID, seatNumbers)
+}
+
+// HoldAny mocks base method.
+func (m *MockScheduleSeatRepository) HoldAny(ctx context.Context, conn gotann.Connection, scheduleID, classID, sessionID uint, quantity int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HoldAny", ctx, conn, scheduleID, classID, sessionID, quantity)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HoldAny indicates an expected call of HoldAny.
+func (mr *MockScheduleSeatRepositoryMockRecorder) HoldAny(ctx, conn, scheduleID, classID, sessionID, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HoldAny", reflect.TypeOf((*MockScheduleSeatRepository)(nil).HoldAny), ctx, conn, scheduleID, classID, sessionID, quantity)
+}
+
+// InsertBulk mocks base method.
+func (m *MockScheduleSeatRepository) InsertBulk(ctx context.Context, conn gotann.Connection, seats []*domain.ScheduleSeat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertBulk", ctx, conn, seats)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertBulk indicates an expected call of InsertBulk.
+func (mr *MockScheduleSeatRepositoryMockRecorder) InsertBulk(ctx, conn, seats interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBulk", reflect.TypeOf((*MockScheduleSeatRepository)(nil).InsertBulk), ctx, conn, seats)
+}
+
+// ReleaseByClaimSessionID mocks base method.
+func (m *MockScheduleSeatRepository) ReleaseByClaimSessionID(ctx context.Context, conn gotann.Connection, sessionID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseByClaimSessionID", ctx, conn, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseByClaimSessionID indicates an expected call of ReleaseByClaimSessionID.
+func (mr *MockScheduleSeatRepositoryMockRecorder) ReleaseByClaimSessionID(ctx, conn, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseByClaimSessionID", reflect.TypeOf((*MockScheduleSeatRepository)(nil).ReleaseByClaimSessionID), ctx, conn, sessionID)
+}
+
+// ReleaseByScheduleIDAndTicketIDs mocks base method.
+func (m *MockScheduleSeatRepository) ReleaseByScheduleIDAndTicketIDs(ctx context.Context, conn gotann.Connection, scheduleID uint, ticketIDs []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseByScheduleIDAndTicketIDs", ctx, conn, scheduleID, ticketIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseByScheduleIDAndTicketIDs indicates an expected call of ReleaseByScheduleIDAndTicketIDs.
+func (mr *MockScheduleSeatRepositoryMockRecorder) ReleaseByScheduleIDAndTicketIDs(ctx, conn, scheduleID, ticketIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseByScheduleIDAndTicketIDs", reflect.TypeOf((*MockScheduleSeatRepository)(nil).ReleaseByScheduleIDAndTicketIDs), ctx, conn, scheduleID, ticketIDs)
+}
+
+// ReleaseByTicketIDs mocks base method.
+func (m *MockScheduleSeatRepository) ReleaseByTicketIDs(ctx context.Context, conn gotann.Connection, ticketIDs []uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseByTicketIDs", ctx, conn, ticketIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseByTicketIDs indicates an expected call of ReleaseByTicketIDs.
+func (mr *MockScheduleSeatRepositoryMockRecorder) ReleaseByTicketIDs(ctx, conn, ticketIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseByTicketIDs", reflect.TypeOf((*MockScheduleSeatRepository)(nil).ReleaseByTicketIDs), ctx, conn, ticketIDs)
+}
+
+// Update mocks base method.
+func (m *MockScheduleSeatRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.ScheduleSeat) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockScheduleSeatRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockScheduleSeatRepository)(nil).Update), ctx, conn, entity)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/seat_layout.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSeatLayoutRepository is a mock of SeatLayoutRepository interface.
+type MockSeatLayoutRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSeatLayoutRepositoryMockRecorder
+}
+
+// MockSeatLayoutRepositoryMockRecorder is the mock recorder for MockSeatLayoutRepository.
+type MockSeatLayoutRepositoryMockRecorder struct {
+	mock *MockSeatLayoutRepository
+}
+
+// NewMockSeatLayoutRepository creates a new mock instance.
+func NewMockSeatLayoutRepository(ctrl *gomock.Controller) *MockSeatLayoutRepository {
+	mock := &MockSeatLayoutRepository{ctrl: ctrl}
+	mock.recorder = &MockSeatLayoutRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSeatLayoutRepository) EXPECT() *MockSeatLayoutRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockSeatLayoutRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conn)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockSeatLayoutRepositoryMockRecorder) Count(ctx, conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockSeatLayoutRepository)(nil).Count), ctx, conn)
+}
+
+// Delete mocks base method.
+func (m *MockSeatLayoutRepository) Delete(ctx context.Context, conn gotann.Connection, entity *domain.SeatLayout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSeatLayoutRepositoryMockRecorder) Delete(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSeatLayoutRepository)(nil).Delete), ctx, conn, entity)
+}
+
+// FindAll mocks base method.
+func (m *MockSeatLayoutRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.SeatLayout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, conn, limit, offset, sort, search)
+	ret0, _ := ret[0].([]*domain.SeatLayout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockSeatLayoutRepositoryMockRecorder) FindAll(ctx, conn, limit, offset, sort, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSeatLayoutRepository)(nil).FindAll), ctx, conn, limit, offset, sort, search)
+}
+
+// FindByID mocks base method.
+func (m *MockSeatLayoutRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.SeatLayout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, conn, id)
+	ret0, _ := ret[0].(*domain.SeatLayout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockSeatLayoutRepositoryMockRecorder) FindByID(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockSeatLayoutRepository)(nil).FindByID), ctx, conn, id)
+}
+
+// FindByShipID mocks base method.
+func (m *MockSeatLayoutRepository) FindByShipID(ctx context.Context, conn gotann.Connection, shipID uint) ([]*domain.SeatLayout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByShipID", ctx, conn, shipID)
+	ret0, _ := ret[0].([]*domain.SeatLayout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByShipID indicates an expected call of FindByShipID.
+func (mr *MockSeatLayoutRepositoryMockRecorder) FindByShipID(ctx, conn, shipID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByShipID", reflect.TypeOf((*MockSeatLayoutRepository)(nil).FindByShipID), ctx, conn, shipID)
+}
+
+// Insert mocks base method.
+func (m *MockSeatLayoutRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.SeatLayout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockSeatLayoutRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockSeatLayoutRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Update mocks base method.
+func (m *MockSeatLayoutRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.SeatLayout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSeatLayoutRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSeatLayoutRepository)(nil).Update), ctx, conn, entity)
+}
@@ -36,20 +36,6 @@ func (m *MockTicketRepository) EXPECT() *MockTicketRepositoryMockRecorder {
 	return m.recorder
 }
 
-// CheckIn mocks base method.
-func (m *MockTicketRepository) CheckIn(ctx context.Context, conn gotann.Connection, id uint) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CheckIn", ctx, conn, id)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// CheckIn indicates an expected call of CheckIn.
-func (mr *MockTicketRepositoryMockRecorder) CheckIn(ctx, conn, id interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckIn", reflect.TypeOf((*MockTicketRepository)(nil).CheckIn), ctx, conn, id)
-}
-
 // Count mocks base method.
 func (m *MockTicketRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
 	m.ctrl.T.Helper()
@@ -65,6 +51,21 @@ func (mr *MockTicketRepositoryMockRecorder) Count(ctx, conn interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockTicketRepository)(nil).Count), ctx, conn)
 }
 
+// CountBoardingByScheduleID mocks base method.
+func (m *MockTicketRepository) CountBoardingByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) (map[string]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountBoardingByScheduleID", ctx, conn, scheduleID)
+	ret0, _ := ret[0].(map[string]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountBoardingByScheduleID indicates an expected call of CountBoardingByScheduleID.
+func (mr *MockTicketRepositoryMockRecorder) CountBoardingByScheduleID(ctx, conn, scheduleID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountBoardingByScheduleID", reflect.TypeOf((*MockTicketRepository)(nil).CountBoardingByScheduleID), ctx, conn, scheduleID)
+}
+
 // CountByScheduleID mocks base method.
 func (m *MockTicketRepository) CountByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) (int64, error) {
 	m.ctrl.T.Helper()
@@ -199,6 +200,21 @@ func (mr *MockTicketRepositoryMockRecorder) FindByScheduleID(ctx, conn, schedule
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByScheduleID", reflect.TypeOf((*MockTicketRepository)(nil).FindByScheduleID), ctx, conn, scheduleID)
 }
 
+// FindByTicketCode mocks base method.
+func (m *MockTicketRepository) FindByTicketCode(ctx context.Context, conn gotann.Connection, code string) (*domain.Ticket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTicketCode", ctx, conn, code)
+	ret0, _ := ret[0].(*domain.Ticket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTicketCode indicates an expected call of FindByTicketCode.
+func (mr *MockTicketRepositoryMockRecorder) FindByTicketCode(ctx, conn, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTicketCode", reflect.TypeOf((*MockTicketRepository)(nil).FindByTicketCode), ctx, conn, code)
+}
+
 // Insert mocks base method.
 func (m *MockTicketRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.Ticket) error {
 	m.ctrl.T.Helper()
@@ -227,6 +243,20 @@ func (mr *MockTicketRepositoryMockRecorder) InsertBulk(ctx, conn, tickets interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBulk", reflect.TypeOf((*MockTicketRepository)(nil).InsertBulk), ctx, conn, tickets)
 }
 
+// ReplaceFees mocks base method.
+func (m *MockTicketRepository) ReplaceFees(ctx context.Context, conn gotann.Connection, ticketID uint, fees []domain.TicketFee) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceFees", ctx, conn, ticketID, fees)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceFees indicates an expected call of ReplaceFees.
+func (mr *MockTicketRepositoryMockRecorder) ReplaceFees(ctx, conn, ticketID, fees interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceFees", reflect.TypeOf((*MockTicketRepository)(nil).ReplaceFees), ctx, conn, ticketID, fees)
+}
+
 // Update mocks base method.
 func (m *MockTicketRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.Ticket) error {
 	m.ctrl.T.Helper()
@@ -241,6 +271,21 @@ func (mr *MockTicketRepositoryMockRecorder) Update(ctx, conn, entity interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTicketRepository)(nil).Update), ctx, conn, entity)
 }
 
+// UpdateBoarding mocks base method.
+func (m *MockTicketRepository) UpdateBoarding(ctx context.Context, conn gotann.Connection, ticket *domain.Ticket, from string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBoarding", ctx, conn, ticket, from)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateBoarding indicates an expected call of UpdateBoarding.
+func (mr *MockTicketRepositoryMockRecorder) UpdateBoarding(ctx, conn, ticket, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBoarding", reflect.TypeOf((*MockTicketRepository)(nil).UpdateBoarding), ctx, conn, ticket, from)
+}
+
 // UpdateBulk mocks base method.
 func (m *MockTicketRepository) UpdateBulk(ctx context.Context, conn gotann.Connection, tickets []*domain.Ticket) error {
 	m.ctrl.T.Helper()
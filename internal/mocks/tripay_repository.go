@@ -9,6 +9,7 @@ import (
 	domain "eticket-api/internal/domain"
 	gotann "eticket-api/pkg/gotann"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -36,6 +37,21 @@ func (m *MockBookingRepository) EXPECT() *MockBookingRepositoryMockRecorder {
 	return m.recorder
 }
 
+// ClaimByEmail mocks base method.
+func (m *MockBookingRepository) ClaimByEmail(ctx context.Context, conn gotann.Connection, customerID uint, email string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimByEmail", ctx, conn, customerID, email)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimByEmail indicates an expected call of ClaimByEmail.
+func (mr *MockBookingRepositoryMockRecorder) ClaimByEmail(ctx, conn, customerID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimByEmail", reflect.TypeOf((*MockBookingRepository)(nil).ClaimByEmail), ctx, conn, customerID, email)
+}
+
 // Count mocks base method.
 func (m *MockBookingRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +67,21 @@ func (mr *MockBookingRepositoryMockRecorder) Count(ctx, conn interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockBookingRepository)(nil).Count), ctx, conn)
 }
 
+// CountByCustomerID mocks base method.
+func (m *MockBookingRepository) CountByCustomerID(ctx context.Context, conn gotann.Connection, customerID uint, upcoming bool, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByCustomerID", ctx, conn, customerID, upcoming, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByCustomerID indicates an expected call of CountByCustomerID.
+func (mr *MockBookingRepositoryMockRecorder) CountByCustomerID(ctx, conn, customerID, upcoming, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByCustomerID", reflect.TypeOf((*MockBookingRepository)(nil).CountByCustomerID), ctx, conn, customerID, upcoming, now)
+}
+
 // Delete mocks base method.
 func (m *MockBookingRepository) Delete(ctx context.Context, conn gotann.Connection, entity *domain.Booking) error {
 	m.ctrl.T.Helper()
@@ -80,6 +111,21 @@ func (mr *MockBookingRepositoryMockRecorder) FindAll(ctx, conn, limit, offset, s
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBookingRepository)(nil).FindAll), ctx, conn, limit, offset, sort, search)
 }
 
+// FindByCustomerID mocks base method.
+func (m *MockBookingRepository) FindByCustomerID(ctx context.Context, conn gotann.Connection, customerID uint, upcoming bool, now time.Time, limit, offset int) ([]*domain.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCustomerID", ctx, conn, customerID, upcoming, now, limit, offset)
+	ret0, _ := ret[0].([]*domain.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCustomerID indicates an expected call of FindByCustomerID.
+func (mr *MockBookingRepositoryMockRecorder) FindByCustomerID(ctx, conn, customerID, upcoming, now, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomerID", reflect.TypeOf((*MockBookingRepository)(nil).FindByCustomerID), ctx, conn, customerID, upcoming, now, limit, offset)
+}
+
 // FindByID mocks base method.
 func (m *MockBookingRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.Booking, error) {
 	m.ctrl.T.Helper()
@@ -110,6 +156,21 @@ func (mr *MockBookingRepositoryMockRecorder) FindByOrderID(ctx, conn, id interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByOrderID", reflect.TypeOf((*MockBookingRepository)(nil).FindByOrderID), ctx, conn, id)
 }
 
+// FindExpiredUnpaid mocks base method.
+func (m *MockBookingRepository) FindExpiredUnpaid(ctx context.Context, conn gotann.Connection, deadline, createdBefore time.Time, limit int) ([]*domain.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindExpiredUnpaid", ctx, conn, deadline, createdBefore, limit)
+	ret0, _ := ret[0].([]*domain.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindExpiredUnpaid indicates an expected call of FindExpiredUnpaid.
+func (mr *MockBookingRepositoryMockRecorder) FindExpiredUnpaid(ctx, conn, deadline, createdBefore, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindExpiredUnpaid", reflect.TypeOf((*MockBookingRepository)(nil).FindExpiredUnpaid), ctx, conn, deadline, createdBefore, limit)
+}
+
 // Insert mocks base method.
 func (m *MockBookingRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.Booking) error {
 	m.ctrl.T.Helper()
@@ -138,6 +199,20 @@ func (mr *MockBookingRepositoryMockRecorder) InsertBulk(ctx, conn, bookings inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertBulk", reflect.TypeOf((*MockBookingRepository)(nil).InsertBulk), ctx, conn, bookings)
 }
 
+// LockByOrderID mocks base method.
+func (m *MockBookingRepository) LockByOrderID(ctx context.Context, conn gotann.Connection, orderID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockByOrderID", ctx, conn, orderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockByOrderID indicates an expected call of LockByOrderID.
+func (mr *MockBookingRepositoryMockRecorder) LockByOrderID(ctx, conn, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockByOrderID", reflect.TypeOf((*MockBookingRepository)(nil).LockByOrderID), ctx, conn, orderID)
+}
+
 // Update mocks base method.
 func (m *MockBookingRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.Booking) error {
 	m.ctrl.T.Helper()
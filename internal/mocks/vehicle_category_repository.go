@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/vehicle_category.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockVehicleCategoryRepository is a mock of VehicleCategoryRepository interface.
+type MockVehicleCategoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVehicleCategoryRepositoryMockRecorder
+}
+
+// MockVehicleCategoryRepositoryMockRecorder is the mock recorder for MockVehicleCategoryRepository.
+type MockVehicleCategoryRepositoryMockRecorder struct {
+	mock *MockVehicleCategoryRepository
+}
+
+// NewMockVehicleCategoryRepository creates a new mock instance.
+func NewMockVehicleCategoryRepository(ctrl *gomock.Controller) *MockVehicleCategoryRepository {
+	mock := &MockVehicleCategoryRepository{ctrl: ctrl}
+	mock.recorder = &MockVehicleCategoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVehicleCategoryRepository) EXPECT() *MockVehicleCategoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockVehicleCategoryRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conn)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockVehicleCategoryRepositoryMockRecorder) Count(ctx, conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockVehicleCategoryRepository)(nil).Count), ctx, conn)
+}
+
+// Delete mocks base method.
+func (m *MockVehicleCategoryRepository) Delete(ctx context.Context, conn gotann.Connection, entity *domain.VehicleCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVehicleCategoryRepositoryMockRecorder) Delete(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVehicleCategoryRepository)(nil).Delete), ctx, conn, entity)
+}
+
+// FindAll mocks base method.
+func (m *MockVehicleCategoryRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.VehicleCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, conn, limit, offset, sort, search)
+	ret0, _ := ret[0].([]*domain.VehicleCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockVehicleCategoryRepositoryMockRecorder) FindAll(ctx, conn, limit, offset, sort, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockVehicleCategoryRepository)(nil).FindAll), ctx, conn, limit, offset, sort, search)
+}
+
+// FindByID mocks base method.
+func (m *MockVehicleCategoryRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.VehicleCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, conn, id)
+	ret0, _ := ret[0].(*domain.VehicleCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockVehicleCategoryRepositoryMockRecorder) FindByID(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockVehicleCategoryRepository)(nil).FindByID), ctx, conn, id)
+}
+
+// Insert mocks base method.
+func (m *MockVehicleCategoryRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.VehicleCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockVehicleCategoryRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockVehicleCategoryRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Update mocks base method.
+func (m *MockVehicleCategoryRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.VehicleCategory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockVehicleCategoryRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockVehicleCategoryRepository)(nil).Update), ctx, conn, entity)
+}
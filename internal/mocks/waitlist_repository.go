@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/domain/waitlist.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	domain "eticket-api/internal/domain"
+	gotann "eticket-api/pkg/gotann"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockWaitlistRepository is a mock of WaitlistRepository interface.
+type MockWaitlistRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWaitlistRepositoryMockRecorder
+}
+
+// MockWaitlistRepositoryMockRecorder is the mock recorder for MockWaitlistRepository.
+type MockWaitlistRepositoryMockRecorder struct {
+	mock *MockWaitlistRepository
+}
+
+// NewMockWaitlistRepository creates a new mock instance.
+func NewMockWaitlistRepository(ctrl *gomock.Controller) *MockWaitlistRepository {
+	mock := &MockWaitlistRepository{ctrl: ctrl}
+	mock.recorder = &MockWaitlistRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWaitlistRepository) EXPECT() *MockWaitlistRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Count mocks base method.
+func (m *MockWaitlistRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, conn)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockWaitlistRepositoryMockRecorder) Count(ctx, conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockWaitlistRepository)(nil).Count), ctx, conn)
+}
+
+// FindAll mocks base method.
+func (m *MockWaitlistRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.Waitlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, conn, limit, offset, sort, search)
+	ret0, _ := ret[0].([]*domain.Waitlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockWaitlistRepositoryMockRecorder) FindAll(ctx, conn, limit, offset, sort, search interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockWaitlistRepository)(nil).FindAll), ctx, conn, limit, offset, sort, search)
+}
+
+// FindByID mocks base method.
+func (m *MockWaitlistRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.Waitlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, conn, id)
+	ret0, _ := ret[0].(*domain.Waitlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockWaitlistRepositoryMockRecorder) FindByID(ctx, conn, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockWaitlistRepository)(nil).FindByID), ctx, conn, id)
+}
+
+// FindWaiting mocks base method.
+func (m *MockWaitlistRepository) FindWaiting(ctx context.Context, conn gotann.Connection, limit int) ([]*domain.Waitlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindWaiting", ctx, conn, limit)
+	ret0, _ := ret[0].([]*domain.Waitlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindWaiting indicates an expected call of FindWaiting.
+func (mr *MockWaitlistRepositoryMockRecorder) FindWaiting(ctx, conn, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindWaiting", reflect.TypeOf((*MockWaitlistRepository)(nil).FindWaiting), ctx, conn, limit)
+}
+
+// Insert mocks base method.
+func (m *MockWaitlistRepository) Insert(ctx context.Context, conn gotann.Connection, entity *domain.Waitlist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockWaitlistRepositoryMockRecorder) Insert(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockWaitlistRepository)(nil).Insert), ctx, conn, entity)
+}
+
+// Offer mocks base method.
+func (m *MockWaitlistRepository) Offer(ctx context.Context, conn gotann.Connection, id, claimSessionID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Offer", ctx, conn, id, claimSessionID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Offer indicates an expected call of Offer.
+func (mr *MockWaitlistRepositoryMockRecorder) Offer(ctx, conn, id, claimSessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Offer", reflect.TypeOf((*MockWaitlistRepository)(nil).Offer), ctx, conn, id, claimSessionID)
+}
+
+// SettleOffers mocks base method.
+func (m *MockWaitlistRepository) SettleOffers(ctx context.Context, conn gotann.Connection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SettleOffers", ctx, conn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SettleOffers indicates an expected call of SettleOffers.
+func (mr *MockWaitlistRepositoryMockRecorder) SettleOffers(ctx, conn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SettleOffers", reflect.TypeOf((*MockWaitlistRepository)(nil).SettleOffers), ctx, conn)
+}
+
+// Update mocks base method.
+func (m *MockWaitlistRepository) Update(ctx context.Context, conn gotann.Connection, entity *domain.Waitlist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, conn, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockWaitlistRepositoryMockRecorder) Update(ctx, conn, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWaitlistRepository)(nil).Update), ctx, conn, entity)
+}
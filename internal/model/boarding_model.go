@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// BoardingCounts is how far the tickets of paid bookings on a departure got.
+type BoardingCounts struct {
+	ScheduleID uint  `json:"schedule_id"`
+	Issued     int64 `json:"issued"`
+	CheckedIn  int64 `json:"checked_in"`
+	Boarded    int64 `json:"boarded"`
+	NoShow     int64 `json:"no_show"`
+	Cancelled  int64 `json:"cancelled"`
+	Total      int64 `json:"total"`
+}
+
+// BoardingSnapshot is the list of tickets a gate device scans against while
+// it is offline. Digest lets the device check the list it stored. Token signs
+// the departure and the time the list was taken, the device sends it back
+// with the scans it collected.
+type BoardingSnapshot struct {
+	ScheduleID        uint                     `json:"schedule_id"`
+	DepartureDatetime time.Time                `json:"departure_datetime"`
+	GeneratedAt       time.Time                `json:"generated_at"`
+	ValidUntil        time.Time                `json:"valid_until"`
+	Tickets           []BoardingSnapshotTicket `json:"tickets"`
+	Digest            string                   `json:"digest"`
+	Token             string                   `json:"token"`
+}
+
+// BoardingSnapshotTicket is a ticket as a gate device sees it offline.
+// PassHash is the SHA-256 of the ticket's QR payload, so a device can match a
+// scanned code without the signing key.
+type BoardingSnapshotTicket struct {
+	TicketCode     string  `json:"ticket_code"`
+	Type           string  `json:"type"`
+	PassengerName  string  `json:"passenger_name"`
+	ClassName      string  `json:"class_name"`
+	SeatNumber     *string `json:"seat_number"`
+	LicensePlate   *string `json:"license_plate"`
+	BoardingStatus string  `json:"boarding_status"`
+	PassHash       string  `json:"pass_hash"`
+}
+
+// BoardingScan is a scan a gate device made while offline.
+type BoardingScan struct {
+	TicketCode string    `json:"ticket_code"`
+	Status     string    `json:"status"`
+	ScannedAt  time.Time `json:"scanned_at"`
+}
+
+// BoardingSync is what became of an upload of offline scans.
+type BoardingSync struct {
+	ScheduleID      uint                 `json:"schedule_id"`
+	SnapshotTakenAt time.Time            `json:"snapshot_taken_at"`
+	Applied         int                  `json:"applied"`
+	Duplicates      int                  `json:"duplicates"`
+	Conflicts       int                  `json:"conflicts"`
+	Results         []BoardingSyncResult `json:"results"`
+}
+
+// BoardingSyncResult is what became of one offline scan. Result is APPLIED,
+// DUPLICATE when another scan got there first, or CONFLICT when the scan
+// could not be recorded and staff should look at it.
+type BoardingSyncResult struct {
+	TicketCode     string     `json:"ticket_code"`
+	Status         string     `json:"status"`
+	ScannedAt      time.Time  `json:"scanned_at"`
+	Result         string     `json:"result"`
+	Reason         string     `json:"reason,omitempty"`
+	BoardingStatus string     `json:"boarding_status,omitempty"`
+	PreviousScanAt *time.Time `json:"previous_scan_at,omitempty"`
+}
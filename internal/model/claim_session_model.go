@@ -33,10 +33,28 @@ type ClaimSessionItemClass struct {
 }
 
 type ClaimSessionItem struct {
-	ClassID  uint                  `json:"class_id"`
-	Class    ClaimSessionItemClass `json:"class"`
-	Subtotal float64               `json:"subtotal"` // Total price for this class (Quantity * Price)
-	Quantity int                   `json:"quantity"`
+	ScheduleID  uint                  `json:"schedule_id,omitempty"` // Leg of a multi-leg claim, defaults to the claim's schedule
+	ClassID     uint                  `json:"class_id"`
+	Class       ClaimSessionItemClass `json:"class"`
+	Subtotal    float64               `json:"subtotal"` // Total price for this class (Quantity * Price)
+	Quantity    int                   `json:"quantity"`
+	SeatNumbers []string              `json:"seat_numbers,omitempty"` // Optional explicit seats, the rest are auto-assigned
+}
+
+// ClaimSessionAddon is an add-on claimed on a leg. Quantity units are held
+// with the session and handed to tickets at data entry.
+type ClaimSessionAddon struct {
+	ScheduleID uint    `json:"schedule_id,omitempty"` // Leg of the add-on, defaults to the claim's schedule
+	AddonID    uint    `json:"addon_id"`
+	Name       string  `json:"name,omitempty"`
+	Quantity   int     `json:"quantity"`
+	Subtotal   float64 `json:"subtotal,omitempty"`
+}
+
+type ClaimSessionSeat struct {
+	ScheduleID uint   `json:"schedule_id"`
+	ClassID    uint   `json:"class_id"`
+	SeatNumber string `json:"seat_number"`
 }
 
 type ClaimSessionTicket struct {
@@ -107,8 +125,10 @@ type ReadClaimSessionLockTicketsResponse struct {
 }
 
 type TESTReadClaimSessionLockResponse struct {
-	SessionID string    `json:"session_id"` // UUID for the session
-	ExpiresAt time.Time `json:"expires_at"` // Expiration time for the claim
+	SessionID string    `json:"session_id"`           // UUID for the session
+	ExpiresAt time.Time `json:"expires_at"`           // Expiration time for the claim
+	PromoCode *string   `json:"promo_code,omitempty"` // Promo code applied to the claim
+	Discount  float64   `json:"discount,omitempty"`   // Amount taken off by the promo code
 }
 
 // Extended/test version with full session details
@@ -118,18 +138,25 @@ type TESTReadClaimSessionResponse struct {
 	Schedule   ClaimSessionSchedule `json:"schedule"`
 	Status     string               `json:"status"` // e.g., 'active', 'inactive', 'cancelled'
 	ClaimItems []ClaimSessionItem   `json:"claim_items"`
+	Addons     []ClaimSessionAddon  `json:"addons"`
+	Seats      []ClaimSessionSeat   `json:"seats"`
 	ExpiresAt  time.Time            `json:"expires_at"`
+	ExtendedAt *time.Time           `json:"extended_at"`
 	CreatedAt  time.Time            `json:"created_at"`
 	UpdatedAt  time.Time            `json:"updated_at"`
 }
 
 type TESTWriteClaimSessionRequest struct {
-	ScheduleID uint               `json:"schedule_id"` // The schedule the user wants tickets for
-	Items      []ClaimSessionItem `json:"items"`       // List of classes and quantities requested
+	ScheduleID uint                `json:"schedule_id"`          // The schedule the user wants tickets for
+	Items      []ClaimSessionItem  `json:"items"`                // List of classes and quantities requested
+	Addons     []ClaimSessionAddon `json:"addons,omitempty"`     // Optional add-ons held with the tickets
+	PromoCode  string              `json:"promo_code,omitempty"` // Optional promo code applied to the claim
+	CustomerID *uint               `json:"-"`                    // Set when a signed in customer claims
 }
 
 type TESTClaimSessionTicketDataEntry struct {
-	ClassID         uint    `json:"class_id"` // The class ID for the ticket
+	ScheduleID      uint    `json:"schedule_id,omitempty"` // Leg of the ticket, defaults to the claim's schedule
+	ClassID         uint    `json:"class_id"`              // The class ID for the ticket
 	PassengerName   string  `json:"passenger_name"`
 	IDType          string  `json:"id_type"`
 	IDNumber        string  `json:"id_number"`
@@ -138,6 +165,17 @@ type TESTClaimSessionTicketDataEntry struct {
 	Address         string  `json:"address"`
 	SeatNumber      *string `json:"seat_number"`
 	LicensePlate    *string `json:"license_plate"`
+
+	// Vehicles only. The driver is the passenger entry with this ID number
+	// on the same leg.
+	VehicleType    *string  `json:"vehicle_type,omitempty"`
+	VehicleBrand   *string  `json:"vehicle_brand,omitempty"`
+	VehicleLength  *float64 `json:"vehicle_length,omitempty"`
+	DriverIDNumber string   `json:"driver_id_number,omitempty"`
+
+	// Claimed add-ons for this ticket, one unit per ID. Units no ticket asks
+	// for go with the booking.
+	AddonIDs []uint `json:"addon_ids,omitempty"`
 }
 
 type TESTReadClaimSessionDataEntryResponse struct {
@@ -157,6 +195,7 @@ type TESTWriteClaimSessionDataEntryRequest struct {
 	BirthDate      time.Time                         `json:"birth_date"`
 	TicketData     []TESTClaimSessionTicketDataEntry `json:"ticket_data"`
 	PaymentMethod  string                            `json:"payment_method"`
+	CustomerID     *uint                             `json:"-"` // Set when a signed in customer books
 }
 
 // =========================
@@ -0,0 +1,73 @@
+package model
+
+import "time"
+
+// Manifest lists everyone and everything carried on a departure, from the
+// tickets of paid bookings. A final manifest is the copy frozen when the ship
+// left and does not change afterwards.
+type Manifest struct {
+	ScheduleID        uint                 `json:"schedule_id"`
+	ShipName          string               `json:"ship_name"`
+	DepartureHarbor   string               `json:"departure_harbor"`
+	ArrivalHarbor     string               `json:"arrival_harbor"`
+	DepartureDatetime time.Time            `json:"departure_datetime"`
+	ArrivalDatetime   time.Time            `json:"arrival_datetime"`
+	Final             bool                 `json:"final"`
+	GeneratedAt       time.Time            `json:"generated_at"`
+	Passengers        []ManifestPassenger  `json:"passengers"`
+	Vehicles          []ManifestVehicle    `json:"vehicles"`
+	Addons            []ManifestAddon      `json:"addons"`
+	Totals            []ManifestClassTotal `json:"totals"`
+	PassengerCount    int                  `json:"passenger_count"`
+	VehicleCount      int                  `json:"vehicle_count"`
+	CheckedInCount    int                  `json:"checked_in_count"`
+}
+
+type ManifestPassenger struct {
+	No           int     `json:"no"`
+	TicketCode   string  `json:"ticket_code"`
+	OrderID      string  `json:"order_id"`
+	Name         string  `json:"name"`
+	Gender       *string `json:"gender"`
+	Age          int     `json:"age"`
+	IDType       *string `json:"id_type"`
+	IDNumber     *string `json:"id_number"`
+	Address      string  `json:"address"`
+	ClassName    string  `json:"class_name"`
+	FareCategory string  `json:"fare_category"`
+	SeatNumber   *string `json:"seat_number"`
+	CheckedIn    bool    `json:"checked_in"`
+}
+
+type ManifestVehicle struct {
+	No               int      `json:"no"`
+	TicketCode       string   `json:"ticket_code"`
+	OrderID          string   `json:"order_id"`
+	LicensePlate     *string  `json:"license_plate"`
+	VehicleType      *string  `json:"vehicle_type"`
+	VehicleBrand     *string  `json:"vehicle_brand"`
+	VehicleLength    *float64 `json:"vehicle_length"`
+	ClassName        string   `json:"class_name"`
+	DriverName       string   `json:"driver_name"`
+	DriverTicketCode string   `json:"driver_ticket_code"`
+	CheckedIn        bool     `json:"checked_in"`
+}
+
+// ManifestAddon is an add-on to serve on board. Add-ons bought for the whole
+// booking have no ticket.
+type ManifestAddon struct {
+	OrderID       string `json:"order_id"`
+	TicketCode    string `json:"ticket_code"`
+	PassengerName string `json:"passenger_name"`
+	SeatNumber    string `json:"seat_number"`
+	Name          string `json:"name"`
+	Kind          string `json:"kind"`
+	Quantity      int    `json:"quantity"`
+}
+
+type ManifestClassTotal struct {
+	ClassName string `json:"class_name"`
+	Type      string `json:"type"`
+	Tickets   int    `json:"tickets"`
+	CheckedIn int    `json:"checked_in"`
+}
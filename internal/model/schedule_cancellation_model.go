@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// ScheduleCancellation is the progress of an operator's cancellation of a
+// departure: how many of its bookings were refunded, rebooked or cancelled
+// unpaid, and which ones failed.
+type ScheduleCancellation struct {
+	ID             uint                          `json:"id"`
+	ScheduleID     uint                          `json:"schedule_id"`
+	Reason         string                        `json:"reason"`
+	Resolution     string                        `json:"resolution"`
+	ToScheduleID   *uint                         `json:"to_schedule_id"`
+	Status         string                        `json:"status"`
+	Total          int                           `json:"total"`
+	Pending        int64                         `json:"pending"`
+	Refunded       int64                         `json:"refunded"`
+	Rebooked       int64                         `json:"rebooked"`
+	Cancelled      int64                         `json:"cancelled"`
+	Failed         int64                         `json:"failed"`
+	RefundedAmount float64                       `json:"refunded_amount"`
+	Failures       []ScheduleCancellationFailure `json:"failures"`
+	StartedAt      time.Time                     `json:"started_at"`
+	CompletedAt    *time.Time                    `json:"completed_at"`
+}
+
+// ScheduleCancellationFailure is a booking the cancellation gave up on after
+// retrying, for staff to settle by hand.
+type ScheduleCancellationFailure struct {
+	BookingID uint   `json:"booking_id"`
+	OrderID   string `json:"order_id"`
+	Attempts  int    `json:"attempts"`
+	Error     string `json:"error"`
+}
@@ -0,0 +1,27 @@
+package model
+
+import "time"
+
+// OnTimePerformance summarises how the departures leaving in a period kept
+// to their timetable. A departure is on time when its delays add up to no
+// more than ToleranceMinutes.
+type OnTimePerformance struct {
+	From                time.Time            `json:"from"`
+	To                  time.Time            `json:"to"`
+	ToleranceMinutes    int                  `json:"tolerance_minutes"`
+	Departures          int64                `json:"departures"`
+	OnTime              int64                `json:"on_time"`
+	Delayed             int64                `json:"delayed"`
+	OnTimeRate          float64              `json:"on_time_rate"` // Percentage of departures on time
+	AverageDelayMinutes float64              `json:"average_delay_minutes"`
+	MaxDelayMinutes     int                  `json:"max_delay_minutes"`
+	Reasons             []DelayReasonSummary `json:"reasons"`
+}
+
+// DelayReasonSummary is how often a reason delayed departures in the period
+// and by how much in total.
+type DelayReasonSummary struct {
+	Reason  string `json:"reason"`
+	Delays  int64  `json:"delays"`
+	Minutes int64  `json:"minutes"`
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+
+	"gorm.io/gorm"
+)
+
+type AddonRepository struct {
+	DB *gorm.DB
+}
+
+func NewAddonRepository(db *gorm.DB) *AddonRepository {
+	return &AddonRepository{DB: db}
+}
+
+func (r *AddonRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	var total int64
+	result := conn.Model(&domain.Addon{}).Count(&total)
+	return total, result.Error
+}
+
+func (r *AddonRepository) Insert(ctx context.Context, conn gotann.Connection, addon *domain.Addon) error {
+	result := conn.Create(addon)
+	return result.Error
+}
+
+func (r *AddonRepository) Update(ctx context.Context, conn gotann.Connection, addon *domain.Addon) error {
+	result := conn.Save(addon)
+	return result.Error
+}
+
+func (r *AddonRepository) Delete(ctx context.Context, conn gotann.Connection, addon *domain.Addon) error {
+	result := conn.Delete(addon)
+	return result.Error
+}
+
+func (r *AddonRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.Addon, error) {
+	addons := []*domain.Addon{}
+	query := conn.Model(&domain.Addon{})
+	if search != "" {
+		search = "%" + search + "%"
+		query = query.Where("name ILIKE ? OR kind ILIKE ?", search, search)
+	}
+	if sort == "" {
+		sort = "id asc"
+	} else {
+		sort = strings.Replace(sort, ":", " ", 1)
+	}
+	err := query.Order(sort).Limit(limit).Offset(offset).Find(&addons).Error
+	return addons, err
+}
+
+func (r *AddonRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.Addon, error) {
+	addon := new(domain.Addon)
+	result := conn.First(&addon, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return addon, result.Error
+}
+
+type AddonStockRepository struct {
+	DB *gorm.DB
+}
+
+func NewAddonStockRepository(db *gorm.DB) *AddonStockRepository {
+	return &AddonStockRepository{DB: db}
+}
+
+func (r *AddonStockRepository) Insert(ctx context.Context, conn gotann.Connection, stock *domain.AddonStock) error {
+	result := conn.Create(stock)
+	return result.Error
+}
+
+func (r *AddonStockRepository) Update(ctx context.Context, conn gotann.Connection, stock *domain.AddonStock) error {
+	result := conn.Save(stock)
+	return result.Error
+}
+
+func (r *AddonStockRepository) Delete(ctx context.Context, conn gotann.Connection, stock *domain.AddonStock) error {
+	result := conn.Delete(stock)
+	return result.Error
+}
+
+func (r *AddonStockRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.AddonStock, error) {
+	stock := new(domain.AddonStock)
+	result := conn.Preload("Addon").First(&stock, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return stock, result.Error
+}
+
+func (r *AddonStockRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.AddonStock, error) {
+	stocks := []*domain.AddonStock{}
+	result := conn.Preload("Addon").Where("schedule_id = ?", scheduleID).Order("addon_id asc").Find(&stocks)
+	return stocks, result.Error
+}
+
+// Reserve moves quantity into the held counter only while held + quantity
+// still fits inside the remaining stock. It reports false when it does not.
+func (r *AddonStockRepository) Reserve(ctx context.Context, conn gotann.Connection, scheduleID uint, addonID uint, quantity int) (bool, error) {
+	result := conn.Model(&domain.AddonStock{}).
+		Where("schedule_id = ? AND addon_id = ?", scheduleID, addonID).
+		Where("held + ? <= stock", quantity).
+		Updates(map[string]interface{}{
+			"held":       gorm.Expr("held + ?", quantity),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Release gives held units back without touching the sold stock.
+func (r *AddonStockRepository) Release(ctx context.Context, conn gotann.Connection, scheduleID uint, addonID uint, quantity int) error {
+	result := conn.Model(&domain.AddonStock{}).
+		Where("schedule_id = ? AND addon_id = ?", scheduleID, addonID).
+		Updates(map[string]interface{}{
+			"held":       gorm.Expr("GREATEST(held - ?, 0)", quantity),
+			"updated_at": time.Now(),
+		})
+	return result.Error
+}
+
+// Confirm turns held units into sold ones by decrementing both counters.
+func (r *AddonStockRepository) Confirm(ctx context.Context, conn gotann.Connection, scheduleID uint, addonID uint, quantity int) (bool, error) {
+	result := conn.Model(&domain.AddonStock{}).
+		Where("schedule_id = ? AND addon_id = ?", scheduleID, addonID).
+		Where("held >= ? AND stock >= ?", quantity, quantity).
+		Updates(map[string]interface{}{
+			"held":       gorm.Expr("held - ?", quantity),
+			"stock":      gorm.Expr("stock - ?", quantity),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Restore returns sold units to the remaining stock, capped at capacity.
+func (r *AddonStockRepository) Restore(ctx context.Context, conn gotann.Connection, scheduleID uint, addonID uint, quantity int) error {
+	result := conn.Model(&domain.AddonStock{}).
+		Where("schedule_id = ? AND addon_id = ?", scheduleID, addonID).
+		Updates(map[string]interface{}{
+			"stock":      gorm.Expr("LEAST(stock + ?, capacity)", quantity),
+			"updated_at": time.Now(),
+		})
+	return result.Error
+}
+
+type BookingAddonRepository struct {
+	DB *gorm.DB
+}
+
+func NewBookingAddonRepository(db *gorm.DB) *BookingAddonRepository {
+	return &BookingAddonRepository{DB: db}
+}
+
+func (r *BookingAddonRepository) InsertBulk(ctx context.Context, conn gotann.Connection, addons []*domain.BookingAddon) error {
+	result := conn.Create(&addons)
+	return result.Error
+}
+
+func (r *BookingAddonRepository) UpdateBulk(ctx context.Context, conn gotann.Connection, addons []*domain.BookingAddon) error {
+	result := conn.Omit("Ticket").Save(&addons)
+	return result.Error
+}
+
+// FindByScheduleID lists the add-ons of paid bookings on a schedule, with the
+// ticket each is attached to, for the crew.
+func (r *BookingAddonRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.BookingAddon, error) {
+	addons := []*domain.BookingAddon{}
+	result := conn.Preload("Ticket").
+		Joins("JOIN booking ON booking.id = booking_addon.booking_id").
+		Where("booking_addon.schedule_id = ? AND booking.status = ?", scheduleID, enum.BookingPaid.String()).
+		Order("booking_addon.addon_id asc, booking_addon.id asc").
+		Find(&addons)
+	return addons, result.Error
+}
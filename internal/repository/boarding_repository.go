@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+
+	"gorm.io/gorm"
+)
+
+type BoardingEventRepository struct {
+	DB *gorm.DB
+}
+
+func NewBoardingEventRepository(db *gorm.DB) *BoardingEventRepository {
+	return &BoardingEventRepository{DB: db}
+}
+
+func (r *BoardingEventRepository) Insert(ctx context.Context, conn gotann.Connection, event *domain.BoardingEvent) error {
+	result := conn.Create(event)
+	return result.Error
+}
+
+func (r *BoardingEventRepository) Update(ctx context.Context, conn gotann.Connection, event *domain.BoardingEvent) error {
+	result := conn.Save(event)
+	return result.Error
+}
+
+// FindByTicketID returns every event of a ticket, undone ones included,
+// oldest first.
+func (r *BoardingEventRepository) FindByTicketID(ctx context.Context, conn gotann.Connection, ticketID uint) ([]*domain.BoardingEvent, error) {
+	events := []*domain.BoardingEvent{}
+	result := conn.Where("ticket_id = ?", ticketID).Order("id ASC").Find(&events)
+	return events, result.Error
+}
+
+// FindLastByTicketID returns the latest event of a ticket that is not undone.
+func (r *BoardingEventRepository) FindLastByTicketID(ctx context.Context, conn gotann.Connection, ticketID uint) (*domain.BoardingEvent, error) {
+	event := new(domain.BoardingEvent)
+	result := conn.Where("ticket_id = ? AND undone_at IS NULL", ticketID).Order("id DESC").First(event)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return event, result.Error
+}
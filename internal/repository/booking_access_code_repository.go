@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingAccessCodeRepository struct {
+	DB *gorm.DB
+}
+
+func NewBookingAccessCodeRepository(db *gorm.DB) *BookingAccessCodeRepository {
+	return &BookingAccessCodeRepository{DB: db}
+}
+
+func (r *BookingAccessCodeRepository) Insert(ctx context.Context, conn gotann.Connection, code *domain.BookingAccessCode) error {
+	result := conn.Create(code)
+	return result.Error
+}
+
+func (r *BookingAccessCodeRepository) Update(ctx context.Context, conn gotann.Connection, code *domain.BookingAccessCode) error {
+	result := conn.Save(code)
+	return result.Error
+}
+
+// FindLatestByBookingID locks the most recently issued code of a booking;
+// issuing a new code replaces the older ones.
+func (r *BookingAccessCodeRepository) FindLatestByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) (*domain.BookingAccessCode, error) {
+	code := new(domain.BookingAccessCode)
+	result := conn.
+		Where("booking_id = ?", bookingID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Order("id desc").
+		First(code)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return code, result.Error
+}
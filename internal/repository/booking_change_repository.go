@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type BookingChangeRepository struct {
+	DB *gorm.DB
+}
+
+func NewBookingChangeRepository(db *gorm.DB) *BookingChangeRepository {
+	return &BookingChangeRepository{DB: db}
+}
+
+func (r *BookingChangeRepository) Insert(ctx context.Context, conn gotann.Connection, change *domain.BookingChange) error {
+	result := conn.Create(change)
+	return result.Error
+}
+
+func (r *BookingChangeRepository) Update(ctx context.Context, conn gotann.Connection, change *domain.BookingChange) error {
+	result := conn.Omit(clause.Associations).Save(change)
+	return result.Error
+}
+
+func (r *BookingChangeRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.BookingChange, error) {
+	change := new(domain.BookingChange)
+	result := conn.First(change, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return change, result.Error
+}
+
+func (r *BookingChangeRepository) FindByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) ([]*domain.BookingChange, error) {
+	changes := []*domain.BookingChange{}
+	result := conn.
+		Preload("FromSchedule").
+		Preload("ToSchedule").
+		Where("booking_id = ?", bookingID).
+		Order("created_at asc, id asc").
+		Find(&changes)
+	return changes, result.Error
+}
+
+func (r *BookingChangeRepository) FindByMerchantRef(ctx context.Context, conn gotann.Connection, merchantRef string) (*domain.BookingChange, error) {
+	change := new(domain.BookingChange)
+	result := conn.
+		Where("merchant_ref = ?", merchantRef).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(change)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return change, result.Error
+}
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"errors"
+	enum "eticket-api/internal/common/enums"
 	"eticket-api/internal/domain"
 	"eticket-api/pkg/gotann"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -79,6 +81,17 @@ func (r *BookingRepository) FindByID(ctx context.Context, conn gotann.Connection
 		Preload("Schedule.Ship").
 		Preload("Schedule.DepartureHarbor").
 		Preload("Schedule.ArrivalHarbor").
+		Preload("Changes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc, id asc")
+		}).
+		Preload("Changes.FromSchedule").
+		Preload("Changes.ToSchedule").
+		Preload("Fees", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id asc")
+		}).
+		Preload("Addons", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id asc")
+		}).
 		First(&booking, id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -95,9 +108,92 @@ func (r *BookingRepository) FindByOrderID(ctx context.Context, conn gotann.Conne
 		Preload("Schedule").
 		Preload("Schedule.DepartureHarbor").
 		Preload("Schedule.ArrivalHarbor").
+		Preload("Changes", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at asc, id asc")
+		}).
+		Preload("Changes.FromSchedule").
+		Preload("Changes.ToSchedule").
+		Preload("Fees", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id asc")
+		}).
+		Preload("Addons", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id asc")
+		}).
 		Where("order_id = ?", id).First(&booking)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
 	return booking, result.Error
 }
+
+// FindExpiredUnpaid finds UNPAID bookings whose payment deadline passed.
+// Bookings made before deadlines were recorded count as expired once they
+// were created before createdBefore. Each is locked with LockByOrderID when
+// it is settled.
+func (r *BookingRepository) FindExpiredUnpaid(ctx context.Context, conn gotann.Connection, deadline, createdBefore time.Time, limit int) ([]*domain.Booking, error) {
+	bookings := []*domain.Booking{}
+	result := conn.
+		Where("status = ?", enum.BookingUnpaid.String()).
+		Where("payment_deadline <= ? OR (payment_deadline IS NULL AND created_at <= ?)", deadline, createdBefore).
+		Order("id asc").
+		Limit(limit).
+		Find(&bookings)
+	return bookings, result.Error
+}
+
+// LockByOrderID locks the booking of an order until the transaction ends, so
+// payment callbacks and the expiry sweep settle it one at a time.
+func (r *BookingRepository) LockByOrderID(ctx context.Context, conn gotann.Connection, orderID string) error {
+	var ids []uint
+	result := conn.Model(&domain.Booking{}).
+		Where("order_id = ?", orderID).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Pluck("id", &ids)
+	return result.Error
+}
+
+// customerTrips narrows bookings to the ones of a customer departing from now
+// on, or for past trips before now.
+func customerTrips(conn gotann.Connection, customerID uint, upcoming bool, now time.Time) *gorm.DB {
+	query := conn.Model(&domain.Booking{}).
+		Joins("JOIN schedule ON schedule.id = booking.schedule_id").
+		Where("booking.customer_id = ?", customerID)
+	if upcoming {
+		return query.Where("schedule.departure_datetime >= ?", now)
+	}
+	return query.Where("schedule.departure_datetime < ?", now)
+}
+
+func (r *BookingRepository) CountByCustomerID(ctx context.Context, conn gotann.Connection, customerID uint, upcoming bool, now time.Time) (int64, error) {
+	var total int64
+	result := customerTrips(conn, customerID, upcoming, now).Count(&total)
+	return total, result.Error
+}
+
+// FindByCustomerID lists the trips of a customer, upcoming ones soonest first
+// and past ones latest first.
+func (r *BookingRepository) FindByCustomerID(ctx context.Context, conn gotann.Connection, customerID uint, upcoming bool, now time.Time, limit, offset int) ([]*domain.Booking, error) {
+	bookings := []*domain.Booking{}
+	order := "schedule.departure_datetime desc, booking.id desc"
+	if upcoming {
+		order = "schedule.departure_datetime asc, booking.id asc"
+	}
+	err := customerTrips(conn, customerID, upcoming, now).
+		Preload("Tickets").
+		Preload("Tickets.Class").
+		Preload("Schedule").
+		Preload("Schedule.Ship").
+		Preload("Schedule.DepartureHarbor").
+		Preload("Schedule.ArrivalHarbor").
+		Order(order).Limit(limit).Offset(offset).Find(&bookings).Error
+	return bookings, err
+}
+
+// ClaimByEmail links the guest bookings made with email to a customer.
+// Bookings already in an account are left alone.
+func (r *BookingRepository) ClaimByEmail(ctx context.Context, conn gotann.Connection, customerID uint, email string) (int64, error) {
+	result := conn.Model(&domain.Booking{}).
+		Where("customer_id IS NULL AND LOWER(TRIM(email)) = ?", email).
+		Update("customer_id", customerID)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type CancellationPolicyRepository struct {
+	DB *gorm.DB
+}
+
+func NewCancellationPolicyRepository(db *gorm.DB) *CancellationPolicyRepository {
+	return &CancellationPolicyRepository{DB: db}
+}
+
+func (r *CancellationPolicyRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	var total int64
+	result := conn.Model(&domain.CancellationPolicy{}).Count(&total)
+	return total, result.Error
+}
+
+func (r *CancellationPolicyRepository) Insert(ctx context.Context, conn gotann.Connection, policy *domain.CancellationPolicy) error {
+	result := conn.Create(policy)
+	return result.Error
+}
+
+// Update replaces the policy's rules with the ones on the entity.
+func (r *CancellationPolicyRepository) Update(ctx context.Context, conn gotann.Connection, policy *domain.CancellationPolicy) error {
+	if err := conn.Where("policy_id = ?", policy.ID).Delete(&domain.CancellationRule{}).Error; err != nil {
+		return err
+	}
+	for i := range policy.Rules {
+		policy.Rules[i].ID = 0
+		policy.Rules[i].PolicyID = policy.ID
+	}
+	result := conn.Save(policy)
+	return result.Error
+}
+
+func (r *CancellationPolicyRepository) Delete(ctx context.Context, conn gotann.Connection, policy *domain.CancellationPolicy) error {
+	result := conn.Select("Rules").Delete(policy)
+	return result.Error
+}
+
+func (r *CancellationPolicyRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.CancellationPolicy, error) {
+	policies := []*domain.CancellationPolicy{}
+	query := conn.Model(&domain.CancellationPolicy{}).
+		Preload("DepartureHarbor").
+		Preload("ArrivalHarbor").
+		Preload("Class").
+		Preload("Rules", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_hours_before desc")
+		})
+	if search != "" {
+		search = "%" + search + "%"
+		query = query.Where("name ILIKE ?", search)
+	}
+	if sort == "" {
+		sort = "id asc"
+	} else {
+		sort = strings.Replace(sort, ":", " ", 1)
+	}
+	err := query.Order(sort).Limit(limit).Offset(offset).Find(&policies).Error
+	return policies, err
+}
+
+func (r *CancellationPolicyRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.CancellationPolicy, error) {
+	policy := new(domain.CancellationPolicy)
+	result := conn.
+		Preload("DepartureHarbor").
+		Preload("ArrivalHarbor").
+		Preload("Class").
+		Preload("Rules", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_hours_before desc")
+		}).
+		First(&policy, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return policy, result.Error
+}
+
+// FindApplicable returns the most specific policy for a route and class: route
+// and class first, then class only, then route only, then the default policy.
+func (r *CancellationPolicyRepository) FindApplicable(ctx context.Context, conn gotann.Connection, departureHarborID, arrivalHarborID, classID uint) (*domain.CancellationPolicy, error) {
+	policy := new(domain.CancellationPolicy)
+	result := conn.
+		Preload("DepartureHarbor").
+		Preload("ArrivalHarbor").
+		Preload("Class").
+		Preload("Rules", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_hours_before desc")
+		}).
+		Where("(departure_harbor_id IS NULL OR (departure_harbor_id = ? AND arrival_harbor_id = ?))", departureHarborID, arrivalHarborID).
+		Where("(class_id IS NULL OR class_id = ?)", classID).
+		Order("class_id IS NOT NULL DESC, departure_harbor_id IS NOT NULL DESC, id asc").
+		First(&policy)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return policy, result.Error
+}
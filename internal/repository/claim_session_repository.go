@@ -66,7 +66,9 @@ func (r *ClaimSessionRepository) FindAll(ctx context.Context, conn gotann.Connec
 		Preload("Schedule.ArrivalHarbor").
 		Preload("Schedule.Ship").
 		Preload("ClaimItems").
-		Preload("ClaimItems.Class")
+		Preload("ClaimItems.Class").
+		Preload("Addons").
+		Preload("Addons.Addon")
 	if search != "" {
 		search = "%" + search + "%"
 		query = query.Where("session_id ILIKE ?", search)
@@ -88,6 +90,8 @@ func (r *ClaimSessionRepository) FindByID(ctx context.Context, conn gotann.Conne
 		Preload("Schedule.Ship").
 		Preload("ClaimItems").
 		Preload("ClaimItems.Class").
+		Preload("Addons").
+		Preload("Addons.Addon").
 		First(&session, id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -104,6 +108,8 @@ func (r *ClaimSessionRepository) FindBySessionID(ctx context.Context, conn gotan
 		Preload("Schedule.Ship").
 		Preload("ClaimItems").
 		Preload("ClaimItems.Class").
+		Preload("Addons").
+		Preload("Addons.Addon").
 		Where("session_id = ?", uuid).First(&session)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -119,6 +125,8 @@ func (r *ClaimSessionRepository) FindActiveByScheduleID(ctx context.Context, con
 		Preload("Schedule.Ship").
 		Preload("ClaimItems").
 		Preload("ClaimItems.Class").
+		Preload("Addons").
+		Preload("Addons.Addon").
 		Where("schedule_id = ? AND status = ?", scheduleID, enum.ClaimSessionPending).
 		Where("expires_at > ?", time.Now()).
 		Find(&sessions)
@@ -128,15 +136,43 @@ func (r *ClaimSessionRepository) FindActiveByScheduleID(ctx context.Context, con
 	return sessions, result.Error
 }
 
+// UpdateStatus moves a session from one status to another and reports how many
+// rows changed, so callers racing on the same session see who won.
+func (r *ClaimSessionRepository) UpdateStatus(ctx context.Context, conn gotann.Connection, id uint, from, to string) (int64, error) {
+	result := conn.Model(&domain.ClaimSession{}).
+		Where("id = ? AND status = ?", id, from).
+		Updates(map[string]interface{}{
+			"status":     to,
+			"updated_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// Extend pushes back the expiry of a pending, unexpired session that was not
+// extended before.
+func (r *ClaimSessionRepository) Extend(ctx context.Context, conn gotann.Connection, id uint, expiresAt time.Time) (int64, error) {
+	now := time.Now()
+	result := conn.Model(&domain.ClaimSession{}).
+		Where("id = ? AND status IN ?", id, enum.GetPendingClaimSessionStatuses()).
+		Where("extended_at IS NULL AND expires_at > ?", now).
+		Updates(map[string]interface{}{
+			"expires_at":  expiresAt,
+			"extended_at": now,
+			"updated_at":  now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// FindExpired locks pending sessions that are past their expiry.
 func (r *ClaimSessionRepository) FindExpired(ctx context.Context, conn gotann.Connection, limit int) ([]*domain.ClaimSession, error) {
 	var sessions []*domain.ClaimSession
 	now := time.Now()
-	result := conn.Where(
-		"(expires_at <= ? AND status NOT IN ?) OR status IN ?",
-		now,
-		enum.GetSuccessClaimSessionStatuses(),
-		enum.GetFailedClaimSessionStatuses(),
-	).Limit(limit).Find(&sessions)
+	result := conn.Preload("ClaimItems").
+		Preload("Addons").
+		Where("expires_at <= ? AND status IN ?", now, enum.GetPendingClaimSessionStatuses()).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Order("expires_at asc").
+		Limit(limit).Find(&sessions)
 
 	if result.Error != nil {
 		return nil, fmt.Errorf("failed to find expired claim sessions: %w", result.Error)
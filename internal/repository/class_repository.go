@@ -52,7 +52,7 @@ func (r *ClassRepository) Delete(ctx context.Context, conn gotann.Connection, cl
 
 func (r *ClassRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.Class, error) {
 	classes := []*domain.Class{}
-	query := conn.Model(&domain.Class{})
+	query := conn.Model(&domain.Class{}).Preload("VehicleCategory")
 	if search != "" {
 		search = "%" + search + "%"
 		query = query.Where("class_name ILIKE ?", search)
@@ -68,7 +68,7 @@ func (r *ClassRepository) FindAll(ctx context.Context, conn gotann.Connection, l
 
 func (r *ClassRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.Class, error) {
 	class := new(domain.Class)
-	result := conn.First(&class, id)
+	result := conn.Preload("VehicleCategory").First(&class, id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
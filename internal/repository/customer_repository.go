@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+
+	"gorm.io/gorm"
+)
+
+type CustomerRepository struct {
+	DB *gorm.DB
+}
+
+func NewCustomerRepository(db *gorm.DB) *CustomerRepository {
+	return &CustomerRepository{DB: db}
+}
+
+func (r *CustomerRepository) Insert(ctx context.Context, conn gotann.Connection, customer *domain.Customer) error {
+	result := conn.Create(customer)
+	return result.Error
+}
+
+func (r *CustomerRepository) Update(ctx context.Context, conn gotann.Connection, customer *domain.Customer) error {
+	result := conn.Save(customer)
+	return result.Error
+}
+
+func (r *CustomerRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.Customer, error) {
+	customer := new(domain.Customer)
+	result := conn.First(customer, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return customer, result.Error
+}
+
+func (r *CustomerRepository) FindByEmail(ctx context.Context, conn gotann.Connection, email string) (*domain.Customer, error) {
+	customer := new(domain.Customer)
+	result := conn.Where("email = ?", email).First(customer)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return customer, result.Error
+}
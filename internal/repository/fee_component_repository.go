@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+
+	"gorm.io/gorm"
+)
+
+type FeeComponentRepository struct {
+	DB *gorm.DB
+}
+
+func NewFeeComponentRepository(db *gorm.DB) *FeeComponentRepository {
+	return &FeeComponentRepository{DB: db}
+}
+
+func (r *FeeComponentRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	var total int64
+	result := conn.Model(&domain.FeeComponent{}).Count(&total)
+	return total, result.Error
+}
+
+func (r *FeeComponentRepository) Insert(ctx context.Context, conn gotann.Connection, component *domain.FeeComponent) error {
+	result := conn.Create(component)
+	return result.Error
+}
+
+func (r *FeeComponentRepository) Update(ctx context.Context, conn gotann.Connection, component *domain.FeeComponent) error {
+	result := conn.Save(component)
+	return result.Error
+}
+
+func (r *FeeComponentRepository) Delete(ctx context.Context, conn gotann.Connection, component *domain.FeeComponent) error {
+	result := conn.Delete(component)
+	return result.Error
+}
+
+func (r *FeeComponentRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.FeeComponent, error) {
+	components := []*domain.FeeComponent{}
+	query := conn.Model(&domain.FeeComponent{})
+	if search != "" {
+		search = "%" + search + "%"
+		query = query.Where("name ILIKE ? OR kind ILIKE ?", search, search)
+	}
+	if sort == "" {
+		sort = "id asc"
+	} else {
+		sort = strings.Replace(sort, ":", " ", 1)
+	}
+	err := query.Order(sort).Limit(limit).Offset(offset).Find(&components).Error
+	return components, err
+}
+
+func (r *FeeComponentRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.FeeComponent, error) {
+	component := new(domain.FeeComponent)
+	result := conn.First(&component, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return component, result.Error
+}
+
+// FindActive returns every active component in the order they were created,
+// which is the order they are listed on tickets.
+func (r *FeeComponentRepository) FindActive(ctx context.Context, conn gotann.Connection) ([]*domain.FeeComponent, error) {
+	components := []*domain.FeeComponent{}
+	result := conn.Where("is_active = ?", true).Order("id asc").Find(&components)
+	return components, result.Error
+}
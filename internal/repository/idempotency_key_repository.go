@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IdempotencyKeyRepository struct {
+	DB *gorm.DB
+}
+
+func NewIdempotencyKeyRepository(db *gorm.DB) *IdempotencyKeyRepository {
+	return &IdempotencyKeyRepository{DB: db}
+}
+
+func (r *IdempotencyKeyRepository) Insert(ctx context.Context, conn gotann.Connection, key *domain.IdempotencyKey) error {
+	result := conn.Create(key)
+	return result.Error
+}
+
+func (r *IdempotencyKeyRepository) Update(ctx context.Context, conn gotann.Connection, key *domain.IdempotencyKey) error {
+	result := conn.Save(key)
+	return result.Error
+}
+
+func (r *IdempotencyKeyRepository) Delete(ctx context.Context, conn gotann.Connection, key *domain.IdempotencyKey) error {
+	result := conn.Delete(key)
+	return result.Error
+}
+
+func (r *IdempotencyKeyRepository) FindByKey(ctx context.Context, conn gotann.Connection, key, scope string) (*domain.IdempotencyKey, error) {
+	record := new(domain.IdempotencyKey)
+	result := conn.
+		Where("key = ? AND scope = ?", key, scope).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(record)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return record, result.Error
+}
+
+func (r *IdempotencyKeyRepository) DeleteExpired(ctx context.Context, conn gotann.Connection, now time.Time) (int64, error) {
+	result := conn.Where("expires_at <= ?", now).Delete(&domain.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
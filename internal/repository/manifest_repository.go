@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ManifestRepository struct {
+	DB *gorm.DB
+}
+
+func NewManifestRepository(db *gorm.DB) *ManifestRepository {
+	return &ManifestRepository{DB: db}
+}
+
+func (r *ManifestRepository) Insert(ctx context.Context, conn gotann.Connection, manifest *domain.Manifest) error {
+	result := conn.Create(manifest)
+	return result.Error
+}
+
+func (r *ManifestRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) (*domain.Manifest, error) {
+	manifest := new(domain.Manifest)
+	result := conn.Where("schedule_id = ?", scheduleID).First(manifest)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return manifest, result.Error
+}
+
+// FindUnfrozenScheduleIDs returns departures that left before departedBefore
+// and have no final manifest yet, oldest first. Cancelled departures are
+// skipped.
+func (r *ManifestRepository) FindUnfrozenScheduleIDs(ctx context.Context, conn gotann.Connection, departedBefore time.Time, limit int) ([]uint, error) {
+	var ids []uint
+	result := conn.Model(&domain.Schedule{}).
+		Where("departure_datetime <= ?", departedBefore).
+		Where("status <> ?", enum.ScheduleCancelled.String()).
+		Where("NOT EXISTS (SELECT 1 FROM manifest WHERE manifest.schedule_id = schedule.id)").
+		Order("departure_datetime asc").
+		Limit(limit).
+		Pluck("id", &ids)
+	return ids, result.Error
+}
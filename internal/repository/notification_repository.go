@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type NotificationLogRepository struct {
+	DB *gorm.DB
+}
+
+func NewNotificationLogRepository(db *gorm.DB) *NotificationLogRepository {
+	return &NotificationLogRepository{DB: db}
+}
+
+// Insert logs a notification unless it was logged already, and returns how
+// many rows it wrote.
+func (r *NotificationLogRepository) Insert(ctx context.Context, conn gotann.Connection, log *domain.NotificationLog) (int64, error) {
+	result := conn.Clauses(clause.OnConflict{DoNothing: true}).Create(log)
+	return result.RowsAffected, result.Error
+}
+
+// FindDueReminders returns the legs of paid bookings departing after
+// departsAfter and no later than departsBefore that have no reminder of kind
+// yet, soonest departure first. Cancelled departures are skipped.
+func (r *NotificationLogRepository) FindDueReminders(ctx context.Context, conn gotann.Connection, kind string, departsAfter, departsBefore time.Time, limit int) ([]*domain.DueNotification, error) {
+	dues := []*domain.DueNotification{}
+	result := conn.Table("ticket").
+		Select("ticket.booking_id, ticket.schedule_id").
+		Joins("JOIN booking ON booking.id = ticket.booking_id").
+		Joins("JOIN schedule ON schedule.id = ticket.schedule_id").
+		Where("booking.status = ?", enum.BookingPaid.String()).
+		Where("schedule.status <> ?", enum.ScheduleCancelled.String()).
+		Where("schedule.departure_datetime > ? AND schedule.departure_datetime <= ?", departsAfter, departsBefore).
+		Where("NOT EXISTS (SELECT 1 FROM notification_log WHERE notification_log.booking_id = ticket.booking_id AND notification_log.schedule_id = ticket.schedule_id AND notification_log.kind = ?)", kind).
+		Group("ticket.booking_id, ticket.schedule_id, schedule.departure_datetime").
+		Order("schedule.departure_datetime asc").
+		Limit(limit).
+		Scan(&dues)
+	return dues, result.Error
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxRepository struct {
+	DB *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{DB: db}
+}
+
+func (r *OutboxRepository) Insert(ctx context.Context, conn gotann.Connection, event *domain.Outbox) error {
+	result := conn.Create(event)
+	return result.Error
+}
+
+func (r *OutboxRepository) Update(ctx context.Context, conn gotann.Connection, event *domain.Outbox) error {
+	result := conn.Save(event)
+	return result.Error
+}
+
+// FindDue locks pending events whose next attempt is due, oldest first.
+func (r *OutboxRepository) FindDue(ctx context.Context, conn gotann.Connection, limit int) ([]*domain.Outbox, error) {
+	events := []*domain.Outbox{}
+	result := conn.
+		Where("status = ? AND next_attempt_at <= ?", enum.OutboxPending.String(), time.Now()).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Order("id asc").
+		Limit(limit).
+		Find(&events)
+	return events, result.Error
+}
+
+// Claim counts an attempt on a due pending event and hides it from other
+// dispatchers until leaseUntil. It reports how many rows changed, so only one
+// dispatcher runs the event.
+func (r *OutboxRepository) Claim(ctx context.Context, conn gotann.Connection, id uint, leaseUntil time.Time) (int64, error) {
+	now := time.Now()
+	result := conn.Model(&domain.Outbox{}).
+		Where("id = ? AND status = ? AND next_attempt_at <= ?", id, enum.OutboxPending.String(), now).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"next_attempt_at": leaseUntil,
+			"updated_at":      now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// CancelPending cancels the pending events of a type for an order, such as a
+// payment that was never created for a booking that expired meanwhile.
+func (r *OutboxRepository) CancelPending(ctx context.Context, conn gotann.Connection, eventType, aggregateID string) (int64, error) {
+	result := conn.Model(&domain.Outbox{}).
+		Where("event_type = ? AND aggregate_id = ? AND status = ?", eventType, aggregateID, enum.OutboxPending.String()).
+		Updates(map[string]interface{}{
+			"status":     enum.OutboxCancelled.String(),
+			"updated_at": time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+
+	"gorm.io/gorm"
+)
+
+type PromotionRedemptionRepository struct {
+	DB *gorm.DB
+}
+
+func NewPromotionRedemptionRepository(db *gorm.DB) *PromotionRedemptionRepository {
+	return &PromotionRedemptionRepository{DB: db}
+}
+
+func (r *PromotionRedemptionRepository) Insert(ctx context.Context, conn gotann.Connection, redemption *domain.PromotionRedemption) error {
+	result := conn.Create(redemption)
+	return result.Error
+}
+
+func (r *PromotionRedemptionRepository) Update(ctx context.Context, conn gotann.Connection, redemption *domain.PromotionRedemption) error {
+	result := conn.Save(redemption)
+	return result.Error
+}
+
+func (r *PromotionRedemptionRepository) Delete(ctx context.Context, conn gotann.Connection, redemption *domain.PromotionRedemption) error {
+	result := conn.Delete(redemption)
+	return result.Error
+}
+
+func (r *PromotionRedemptionRepository) CountByCustomer(ctx context.Context, conn gotann.Connection, promotionID, customerID uint) (int64, error) {
+	var total int64
+	result := conn.Model(&domain.PromotionRedemption{}).
+		Where("promotion_id = ? AND customer_id = ?", promotionID, customerID).
+		Count(&total)
+	return total, result.Error
+}
+
+func (r *PromotionRedemptionRepository) FindByClaimSessionID(ctx context.Context, conn gotann.Connection, claimSessionID uint) (*domain.PromotionRedemption, error) {
+	redemption := new(domain.PromotionRedemption)
+	result := conn.Where("claim_session_id = ?", claimSessionID).First(redemption)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return redemption, result.Error
+}
+
+func (r *PromotionRedemptionRepository) FindByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) (*domain.PromotionRedemption, error) {
+	redemption := new(domain.PromotionRedemption)
+	result := conn.Where("booking_id = ?", bookingID).First(redemption)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return redemption, result.Error
+}
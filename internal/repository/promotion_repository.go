@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type PromotionRepository struct {
+	DB *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) *PromotionRepository {
+	return &PromotionRepository{DB: db}
+}
+
+func (r *PromotionRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	var total int64
+	result := conn.Model(&domain.Promotion{}).Count(&total)
+	return total, result.Error
+}
+
+func (r *PromotionRepository) Insert(ctx context.Context, conn gotann.Connection, promotion *domain.Promotion) error {
+	result := conn.Create(promotion)
+	return result.Error
+}
+
+// Update saves the promotion without touching UsedCount, which only Redeem
+// and Release change.
+func (r *PromotionRepository) Update(ctx context.Context, conn gotann.Connection, promotion *domain.Promotion) error {
+	result := conn.Select("*").Omit("used_count", "created_at").Save(promotion)
+	return result.Error
+}
+
+func (r *PromotionRepository) Delete(ctx context.Context, conn gotann.Connection, promotion *domain.Promotion) error {
+	result := conn.Delete(promotion)
+	return result.Error
+}
+
+func (r *PromotionRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.Promotion, error) {
+	promotions := []*domain.Promotion{}
+	query := conn.Model(&domain.Promotion{})
+	if search != "" {
+		search = "%" + search + "%"
+		query = query.Where("code ILIKE ? OR name ILIKE ?", search, search)
+	}
+	if sort == "" {
+		sort = "id asc"
+	} else {
+		sort = strings.Replace(sort, ":", " ", 1)
+	}
+	err := query.Order(sort).Limit(limit).Offset(offset).Find(&promotions).Error
+	return promotions, err
+}
+
+func (r *PromotionRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.Promotion, error) {
+	promotion := new(domain.Promotion)
+	result := conn.First(promotion, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return promotion, result.Error
+}
+
+func (r *PromotionRepository) FindByCode(ctx context.Context, conn gotann.Connection, code string) (*domain.Promotion, error) {
+	promotion := new(domain.Promotion)
+	result := conn.Where("code = ?", code).First(promotion)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return promotion, result.Error
+}
+
+// Redeem counts one use of the promotion unless that would pass its usage
+// limit, in which case no row is affected. The update locks the promotion row
+// until the transaction ends, so concurrent redemptions queue up.
+func (r *PromotionRepository) Redeem(ctx context.Context, conn gotann.Connection, id uint) (int64, error) {
+	result := conn.Model(&domain.Promotion{}).
+		Where("id = ? AND (usage_limit IS NULL OR used_count < usage_limit)", id).
+		Update("used_count", gorm.Expr("used_count + 1"))
+	return result.RowsAffected, result.Error
+}
+
+func (r *PromotionRepository) Release(ctx context.Context, conn gotann.Connection, id uint) error {
+	result := conn.Model(&domain.Promotion{}).
+		Where("id = ? AND used_count > 0", id).
+		Update("used_count", gorm.Expr("used_count - 1"))
+	return result.Error
+}
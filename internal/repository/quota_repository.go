@@ -6,6 +6,7 @@ import (
 	"eticket-api/internal/domain"
 	"eticket-api/pkg/gotann"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -35,13 +36,15 @@ func (r *QuotaRepository) InsertBulk(ctx context.Context, conn gotann.Connection
 	return result.Error
 }
 
+// Update saves a quota except its held counter, which only Reserve, Confirm
+// and Release move.
 func (r *QuotaRepository) Update(ctx context.Context, conn gotann.Connection, quota *domain.Quota) error {
-	result := conn.Clauses(clause.Locking{Strength: "UPDATE"}).Save(quota)
+	result := conn.Clauses(clause.Locking{Strength: "UPDATE"}).Select("*").Omit("held", "created_at").Save(quota)
 	return result.Error
 }
 
 func (r *QuotaRepository) UpdateBulk(ctx context.Context, conn gotann.Connection, Quotas []*domain.Quota) error {
-	result := conn.Clauses(clause.Locking{Strength: "UPDATE"}).Save(&Quotas)
+	result := conn.Clauses(clause.Locking{Strength: "UPDATE"}).Select("*").Omit("held", "created_at").Save(&Quotas)
 	return result.Error
 }
 
@@ -53,10 +56,14 @@ func (r *QuotaRepository) Delete(ctx context.Context, conn gotann.Connection, qu
 func (r *QuotaRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.Quota, error) {
 	Quotas := []*domain.Quota{}
 	query := conn.Model(&domain.Quota{}).Preload("Class").
+		Preload("Class.VehicleCategory").
 		Preload("Schedule").
 		Preload("Schedule.DepartureHarbor").
 		Preload("Schedule.ArrivalHarbor").
-		Preload("Schedule.Ship")
+		Preload("Schedule.Ship").
+		Preload("Fares", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_age asc")
+		})
 	if search != "" {
 		search = "%" + search + "%"
 		query = query.Where("schedule_id ILIKE ?", search)
@@ -73,10 +80,14 @@ func (r *QuotaRepository) FindAll(ctx context.Context, conn gotann.Connection, l
 func (r *QuotaRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.Quota, error) {
 	Quota := new(domain.Quota)
 	result := conn.Preload("Class").
+		Preload("Class.VehicleCategory").
 		Preload("Schedule").
 		Preload("Schedule.DepartureHarbor").
 		Preload("Schedule.ArrivalHarbor").
-		Preload("Schedule.Ship").Clauses(clause.Locking{Strength: "UPDATE"}).First(&Quota, id)
+		Preload("Schedule.Ship").
+		Preload("Fares", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_age asc")
+		}).Clauses(clause.Locking{Strength: "UPDATE"}).First(&Quota, id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
@@ -86,10 +97,14 @@ func (r *QuotaRepository) FindByID(ctx context.Context, conn gotann.Connection,
 func (r *QuotaRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.Quota, error) {
 	Quotas := []*domain.Quota{}
 	result := conn.Preload("Class").
+		Preload("Class.VehicleCategory").
 		Preload("Schedule").
 		Preload("Schedule.DepartureHarbor").
 		Preload("Schedule.ArrivalHarbor").
-		Preload("Schedule.Ship").Clauses(clause.Locking{Strength: "UPDATE"}).Where("schedule_id = ?", scheduleID).Find(&Quotas)
+		Preload("Schedule.Ship").
+		Preload("Fares", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_age asc")
+		}).Where("schedule_id = ?", scheduleID).Find(&Quotas)
 	if result.Error != nil {
 		return nil, result.Error // Handle database errors
 	}
@@ -99,12 +114,86 @@ func (r *QuotaRepository) FindByScheduleID(ctx context.Context, conn gotann.Conn
 func (r *QuotaRepository) FindByScheduleIDAndClassID(ctx context.Context, conn gotann.Connection, scheduleID uint, classID uint) (*domain.Quota, error) {
 	quota := new(domain.Quota)
 	result := conn.Preload("Class").
+		Preload("Class.VehicleCategory").
 		Preload("Schedule").
 		Preload("Schedule.DepartureHarbor").
 		Preload("Schedule.ArrivalHarbor").
-		Preload("Schedule.Ship").Where("schedule_id = ? AND class_id = ?", scheduleID, classID).Clauses(clause.Locking{Strength: "UPDATE"}).First(quota)
+		Preload("Schedule.Ship").
+		Preload("Fares", func(db *gorm.DB) *gorm.DB {
+			return db.Order("min_age asc")
+		}).Where("schedule_id = ? AND class_id = ?", scheduleID, classID).Clauses(clause.Locking{Strength: "UPDATE"}).First(quota)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
 	return quota, result.Error
 }
+
+// Reserve moves quantity into the held counter only while held + quantity
+// still fits inside the remaining quota. It reports false when it does not.
+func (r *QuotaRepository) Reserve(ctx context.Context, conn gotann.Connection, scheduleID uint, classID uint, quantity int) (bool, error) {
+	result := conn.Model(&domain.Quota{}).
+		Where("schedule_id = ? AND class_id = ?", scheduleID, classID).
+		Where("held + ? <= quota", quantity).
+		Updates(map[string]interface{}{
+			"held":       gorm.Expr("held + ?", quantity),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Release gives held units back without touching the sold quota.
+func (r *QuotaRepository) Release(ctx context.Context, conn gotann.Connection, scheduleID uint, classID uint, quantity int) error {
+	result := conn.Model(&domain.Quota{}).
+		Where("schedule_id = ? AND class_id = ?", scheduleID, classID).
+		Updates(map[string]interface{}{
+			"held":       gorm.Expr("GREATEST(held - ?, 0)", quantity),
+			"updated_at": time.Now(),
+		})
+	return result.Error
+}
+
+// Confirm turns held units into sold ones by decrementing both counters.
+func (r *QuotaRepository) Confirm(ctx context.Context, conn gotann.Connection, scheduleID uint, classID uint, quantity int) (bool, error) {
+	result := conn.Model(&domain.Quota{}).
+		Where("schedule_id = ? AND class_id = ?", scheduleID, classID).
+		Where("held >= ? AND quota >= ?", quantity, quantity).
+		Updates(map[string]interface{}{
+			"held":       gorm.Expr("held - ?", quantity),
+			"quota":      gorm.Expr("quota - ?", quantity),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Restore returns sold units to the remaining quota, capped at capacity.
+func (r *QuotaRepository) Restore(ctx context.Context, conn gotann.Connection, scheduleID uint, classID uint, quantity int) error {
+	result := conn.Model(&domain.Quota{}).
+		Where("schedule_id = ? AND class_id = ?", scheduleID, classID).
+		Updates(map[string]interface{}{
+			"quota":      gorm.Expr("LEAST(quota + ?, capacity)", quantity),
+			"updated_at": time.Now(),
+		})
+	return result.Error
+}
+
+// ReplaceFares swaps the fares of a quota for the given ones.
+func (r *QuotaRepository) ReplaceFares(ctx context.Context, conn gotann.Connection, quotaID uint, fares []domain.Fare) error {
+	if err := conn.Where("quota_id = ?", quotaID).Delete(&domain.Fare{}).Error; err != nil {
+		return err
+	}
+	if len(fares) == 0 {
+		return nil
+	}
+	for i := range fares {
+		fares[i].ID = 0
+		fares[i].QuotaID = quotaID
+	}
+	result := conn.Create(&fares)
+	return result.Error
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RefundRepository struct {
+	DB *gorm.DB
+}
+
+func NewRefundRepository(db *gorm.DB) *RefundRepository {
+	return &RefundRepository{DB: db}
+}
+
+func (r *RefundRepository) InsertBulk(ctx context.Context, conn gotann.Connection, refunds []*domain.Refund) error {
+	result := conn.Omit(clause.Associations).Create(&refunds)
+	return result.Error
+}
+
+func (r *RefundRepository) FindByBookingID(ctx context.Context, conn gotann.Connection, bookingID uint) ([]*domain.Refund, error) {
+	refunds := []*domain.Refund{}
+	result := conn.Preload("Ticket").Where("booking_id = ?", bookingID).Order("id asc").Find(&refunds)
+	return refunds, result.Error
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ScheduleCancellationRepository struct {
+	DB *gorm.DB
+}
+
+func NewScheduleCancellationRepository(db *gorm.DB) *ScheduleCancellationRepository {
+	return &ScheduleCancellationRepository{DB: db}
+}
+
+func (r *ScheduleCancellationRepository) Insert(ctx context.Context, conn gotann.Connection, cancellation *domain.ScheduleCancellation) error {
+	result := conn.Omit(clause.Associations).Create(cancellation)
+	return result.Error
+}
+
+func (r *ScheduleCancellationRepository) Update(ctx context.Context, conn gotann.Connection, cancellation *domain.ScheduleCancellation) error {
+	result := conn.Omit(clause.Associations).Save(cancellation)
+	return result.Error
+}
+
+func (r *ScheduleCancellationRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.ScheduleCancellation, error) {
+	cancellation := new(domain.ScheduleCancellation)
+	result := conn.
+		Preload("Schedule").
+		Preload("Schedule.Ship").
+		Preload("Schedule.DepartureHarbor").
+		Preload("Schedule.ArrivalHarbor").
+		First(cancellation, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return cancellation, result.Error
+}
+
+func (r *ScheduleCancellationRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) (*domain.ScheduleCancellation, error) {
+	cancellation := new(domain.ScheduleCancellation)
+	result := conn.
+		Preload("Schedule").
+		Preload("Schedule.Ship").
+		Preload("Schedule.DepartureHarbor").
+		Preload("Schedule.ArrivalHarbor").
+		Where("schedule_id = ?", scheduleID).
+		First(cancellation)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return cancellation, result.Error
+}
+
+// FindRunning returns the cancellations that still have bookings to settle,
+// oldest first, with their schedules.
+func (r *ScheduleCancellationRepository) FindRunning(ctx context.Context, conn gotann.Connection) ([]*domain.ScheduleCancellation, error) {
+	cancellations := []*domain.ScheduleCancellation{}
+	result := conn.
+		Preload("Schedule").
+		Preload("Schedule.Ship").
+		Preload("Schedule.DepartureHarbor").
+		Preload("Schedule.ArrivalHarbor").
+		Where("status = ?", enum.ScheduleCancellationRunning.String()).
+		Order("id asc").
+		Find(&cancellations)
+	return cancellations, result.Error
+}
+
+// FindBookingIDs returns the bookings in one of statuses that have a ticket
+// on the schedule.
+func (r *ScheduleCancellationRepository) FindBookingIDs(ctx context.Context, conn gotann.Connection, scheduleID uint, statuses []string) ([]uint, error) {
+	var ids []uint
+	result := conn.Model(&domain.Booking{}).
+		Where("status IN ?", statuses).
+		Where("id IN (SELECT booking_id FROM ticket WHERE schedule_id = ?)", scheduleID).
+		Order("id asc").
+		Pluck("id", &ids)
+	return ids, result.Error
+}
+
+func (r *ScheduleCancellationRepository) InsertItems(ctx context.Context, conn gotann.Connection, items []*domain.ScheduleCancellationItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	result := conn.Omit(clause.Associations).CreateInBatches(items, 500)
+	return result.Error
+}
+
+func (r *ScheduleCancellationRepository) UpdatePendingItem(ctx context.Context, conn gotann.Connection, item *domain.ScheduleCancellationItem) (int64, error) {
+	result := conn.Model(&domain.ScheduleCancellationItem{}).
+		Where("id = ? AND status = ?", item.ID, enum.CancellationItemPending.String()).
+		Updates(map[string]interface{}{
+			"status":         item.Status,
+			"to_schedule_id": item.ToScheduleID,
+			"refund_amount":  item.RefundAmount,
+			"attempts":       item.Attempts,
+			"error":          item.Error,
+			"processed_at":   item.ProcessedAt,
+		})
+	return result.RowsAffected, result.Error
+}
+
+func (r *ScheduleCancellationRepository) FindPendingItems(ctx context.Context, conn gotann.Connection, cancellationID uint, limit int) ([]*domain.ScheduleCancellationItem, error) {
+	items := []*domain.ScheduleCancellationItem{}
+	result := conn.
+		Where("cancellation_id = ? AND status = ?", cancellationID, enum.CancellationItemPending.String()).
+		Order("attempts asc, id asc").
+		Limit(limit).
+		Find(&items)
+	return items, result.Error
+}
+
+func (r *ScheduleCancellationRepository) FindItemsByStatus(ctx context.Context, conn gotann.Connection, cancellationID uint, status string) ([]*domain.ScheduleCancellationItem, error) {
+	items := []*domain.ScheduleCancellationItem{}
+	result := conn.
+		Preload("Booking").
+		Where("cancellation_id = ? AND status = ?", cancellationID, status).
+		Order("id asc").
+		Find(&items)
+	return items, result.Error
+}
+
+// CountItemsByStatus counts the items of a cancellation by status.
+func (r *ScheduleCancellationRepository) CountItemsByStatus(ctx context.Context, conn gotann.Connection, cancellationID uint) (map[string]int64, error) {
+	var rows []struct {
+		Status string
+		Count  int64
+	}
+	result := conn.Model(&domain.ScheduleCancellationItem{}).
+		Select("status, COUNT(*) AS count").
+		Where("cancellation_id = ?", cancellationID).
+		Group("status").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *ScheduleCancellationRepository) SumRefunded(ctx context.Context, conn gotann.Connection, cancellationID uint) (float64, error) {
+	var total float64
+	result := conn.Model(&domain.ScheduleCancellationItem{}).
+		Select("COALESCE(SUM(refund_amount), 0)").
+		Where("cancellation_id = ?", cancellationID).
+		Scan(&total)
+	return total, result.Error
+}
+
+// RetryFailedItems puts the failed items of a cancellation back in the queue
+// with fresh attempts, and returns how many there were.
+func (r *ScheduleCancellationRepository) RetryFailedItems(ctx context.Context, conn gotann.Connection, cancellationID uint) (int64, error) {
+	result := conn.Model(&domain.ScheduleCancellationItem{}).
+		Where("cancellation_id = ? AND status = ?", cancellationID, enum.CancellationItemFailed.String()).
+		Updates(map[string]interface{}{
+			"status":   enum.CancellationItemPending.String(),
+			"attempts": 0,
+		})
+	return result.RowsAffected, result.Error
+}
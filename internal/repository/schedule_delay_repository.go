@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ScheduleDelayRepository struct {
+	DB *gorm.DB
+}
+
+func NewScheduleDelayRepository(db *gorm.DB) *ScheduleDelayRepository {
+	return &ScheduleDelayRepository{DB: db}
+}
+
+func (r *ScheduleDelayRepository) Insert(ctx context.Context, conn gotann.Connection, delay *domain.ScheduleDelay) error {
+	result := conn.Create(delay)
+	return result.Error
+}
+
+func (r *ScheduleDelayRepository) Update(ctx context.Context, conn gotann.Connection, delay *domain.ScheduleDelay) error {
+	result := conn.Save(delay)
+	return result.Error
+}
+
+// FindByScheduleID returns the delay history of a schedule, oldest first.
+func (r *ScheduleDelayRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.ScheduleDelay, error) {
+	delays := []*domain.ScheduleDelay{}
+	result := conn.
+		Where("schedule_id = ?", scheduleID).
+		Order("id asc").
+		Find(&delays)
+	return delays, result.Error
+}
+
+// FindDepartingBetween returns the delays of the departures that now leave
+// from from up to to, cancelled ones left out, oldest first.
+func (r *ScheduleDelayRepository) FindDepartingBetween(ctx context.Context, conn gotann.Connection, from, to time.Time) ([]*domain.ScheduleDelay, error) {
+	delays := []*domain.ScheduleDelay{}
+	result := conn.
+		Joins("JOIN schedule ON schedule.id = schedule_delay.schedule_id").
+		Where("schedule.departure_datetime >= ? AND schedule.departure_datetime < ?", from, to).
+		Where("schedule.status <> ?", enum.ScheduleCancelled.String()).
+		Order("schedule_delay.id asc").
+		Find(&delays)
+	return delays, result.Error
+}
+
+// FindBookingsToNotify returns the paid bookings with a ticket on the
+// schedule that is still to board.
+func (r *ScheduleDelayRepository) FindBookingsToNotify(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.Booking, error) {
+	bookings := []*domain.Booking{}
+	result := conn.
+		Where("status = ?", enum.BookingPaid.String()).
+		Where("id IN (SELECT booking_id FROM ticket WHERE schedule_id = ? AND boarding_status <> ?)", scheduleID, enum.BoardingCancelled.String()).
+		Order("id asc").
+		Find(&bookings)
+	return bookings, result.Error
+}
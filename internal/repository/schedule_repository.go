@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	enum "eticket-api/internal/common/enums"
 	"eticket-api/internal/domain"
 	"eticket-api/pkg/gotann"
 	"strings"
@@ -58,7 +59,10 @@ func (r *ScheduleRepository) FindAll(ctx context.Context, conn gotann.Connection
 		Preload("ArrivalHarbor").
 		Preload("Ship").
 		Preload("Quotas").
-		Preload("Quotas.Class")
+		Preload("Quotas.Class").
+		Preload("Delays", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id asc")
+		})
 	if search != "" {
 		search = "%" + search + "%"
 		query = query.Where("schedule_id ILIKE ?", search)
@@ -80,6 +84,9 @@ func (r *ScheduleRepository) FindByID(ctx context.Context, conn gotann.Connectio
 		Preload("Ship").
 		Preload("Quotas").
 		Preload("Quotas.Class").
+		Preload("Delays", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id asc")
+		}).
 		First(&schedule, id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -111,6 +118,9 @@ func (r *ScheduleRepository) FindActiveSchedules(ctx context.Context, conn gotan
 		Preload("Ship").
 		Preload("Quotas").
 		Preload("Quotas.Class").
+		Preload("Delays", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id asc")
+		}).
 		Where("departure_datetime > ?", time.Now()).
 		Limit(7).
 		Find(&schedules)
@@ -121,3 +131,42 @@ func (r *ScheduleRepository) FindActiveSchedules(ctx context.Context, conn gotan
 
 	return schedules, nil
 }
+
+// FindUpcomingOnRoute returns the scheduled departures between two harbors
+// that leave after a time, soonest first.
+func (r *ScheduleRepository) FindUpcomingOnRoute(ctx context.Context, conn gotann.Connection, departureHarborID, arrivalHarborID uint, after time.Time, limit int) ([]*domain.Schedule, error) {
+	schedules := []*domain.Schedule{}
+	result := conn.
+		Preload("DepartureHarbor").
+		Preload("ArrivalHarbor").
+		Preload("Ship").
+		Where("departure_harbor_id = ? AND arrival_harbor_id = ?", departureHarborID, arrivalHarborID).
+		Where("departure_datetime > ? AND status = ?", after, enum.ScheduleActive.String()).
+		Order("departure_datetime asc").
+		Limit(limit).
+		Find(&schedules)
+	return schedules, result.Error
+}
+
+// Reschedule moves a departure to its new times unless it moved since it was
+// read at previousDeparture, and returns how many rows it changed.
+func (r *ScheduleRepository) Reschedule(ctx context.Context, conn gotann.Connection, schedule *domain.Schedule, previousDeparture time.Time) (int64, error) {
+	result := conn.Model(&domain.Schedule{}).
+		Where("id = ? AND departure_datetime = ?", schedule.ID, previousDeparture).
+		Updates(map[string]interface{}{
+			"departure_datetime": schedule.DepartureDatetime,
+			"arrival_datetime":   schedule.ArrivalDatetime,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// CountDepartingBetween counts the departures leaving from from up to to,
+// cancelled ones left out.
+func (r *ScheduleRepository) CountDepartingBetween(ctx context.Context, conn gotann.Connection, from, to time.Time) (int64, error) {
+	var total int64
+	result := conn.Model(&domain.Schedule{}).
+		Where("departure_datetime >= ? AND departure_datetime < ?", from, to).
+		Where("status <> ?", enum.ScheduleCancelled.String()).
+		Count(&total)
+	return total, result.Error
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ScheduleSeatRepository struct {
+	DB *gorm.DB
+}
+
+func NewScheduleSeatRepository(db *gorm.DB) *ScheduleSeatRepository {
+	return &ScheduleSeatRepository{DB: db}
+}
+
+// InsertBulk skips seats that already exist, so two requests initialising the
+// same schedule at once both succeed.
+func (r *ScheduleSeatRepository) InsertBulk(ctx context.Context, conn gotann.Connection, seats []*domain.ScheduleSeat) error {
+	result := conn.Clauses(clause.OnConflict{DoNothing: true}).Create(&seats)
+	return result.Error
+}
+
+func (r *ScheduleSeatRepository) Update(ctx context.Context, conn gotann.Connection, seat *domain.ScheduleSeat) error {
+	result := conn.Omit(clause.Associations).Save(seat)
+	return result.Error
+}
+
+func (r *ScheduleSeatRepository) CountByScheduleIDGroupByClass(ctx context.Context, conn gotann.Connection, scheduleID uint) (map[uint]int64, error) {
+	var rows []struct {
+		ClassID uint
+		Total   int64
+	}
+	result := conn.Model(&domain.ScheduleSeat{}).
+		Select("class_id, COUNT(*) AS total").
+		Where("schedule_id = ?", scheduleID).
+		Group("class_id").
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	totals := make(map[uint]int64, len(rows))
+	for _, row := range rows {
+		totals[row.ClassID] = row.Total
+	}
+	return totals, nil
+}
+
+func (r *ScheduleSeatRepository) FindByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) ([]*domain.ScheduleSeat, error) {
+	seats := []*domain.ScheduleSeat{}
+	result := conn.
+		Preload("Class").
+		Where("schedule_id = ?", scheduleID).
+		Order("class_id asc, row_number asc, column_number asc").
+		Find(&seats)
+	return seats, result.Error
+}
+
+func (r *ScheduleSeatRepository) FindByClaimSessionID(ctx context.Context, conn gotann.Connection, sessionID uint) ([]*domain.ScheduleSeat, error) {
+	seats := []*domain.ScheduleSeat{}
+	result := conn.
+		Where("claim_session_id = ? AND status = ?", sessionID, enum.SeatHeld.String()).
+		Order("class_id asc, row_number asc, column_number asc").
+		Find(&seats)
+	return seats, result.Error
+}
+
+// FindAvailable locks up to limit free seats of a class. When seatNumbers is
+// given only those seats are considered.
+func (r *ScheduleSeatRepository) FindAvailable(ctx context.Context, conn gotann.Connection, scheduleID, classID uint, seatNumbers []string, limit int) ([]*domain.ScheduleSeat, error) {
+	seats := []*domain.ScheduleSeat{}
+	query := conn.
+		Where("schedule_id = ? AND class_id = ? AND status = ?", scheduleID, classID, enum.SeatAvailable.String())
+	if len(seatNumbers) > 0 {
+		query = query.Where("seat_number IN ?", seatNumbers)
+	}
+	result := query.
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Order("row_number asc, column_number asc").
+		Limit(limit).
+		Find(&seats)
+	return seats, result.Error
+}
+
+// Hold takes the given seats for a claim session. It returns how many of them
+// were still available; the caller rolls back when that is short.
+func (r *ScheduleSeatRepository) Hold(ctx context.Context, conn gotann.Connection, scheduleID, classID, sessionID uint, seatNumbers []string) (int64, error) {
+	result := conn.Model(&domain.ScheduleSeat{}).
+		Where("schedule_id = ? AND class_id = ? AND status = ?", scheduleID, classID, enum.SeatAvailable.String()).
+		Where("seat_number IN ?", seatNumbers).
+		Updates(map[string]interface{}{
+			"status":           enum.SeatHeld.String(),
+			"claim_session_id": sessionID,
+			"updated_at":       time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// HoldAny takes the first free seats of a class for a claim session, skipping
+// rows other transactions are holding.
+func (r *ScheduleSeatRepository) HoldAny(ctx context.Context, conn gotann.Connection, scheduleID, classID, sessionID uint, quantity int) (int64, error) {
+	result := conn.Exec(`
+		UPDATE schedule_seat SET status = ?, claim_session_id = ?, updated_at = ?
+		WHERE id IN (
+			SELECT id FROM schedule_seat
+			WHERE schedule_id = ? AND class_id = ? AND status = ?
+			ORDER BY row_number, column_number
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)`,
+		enum.SeatHeld.String(), sessionID, time.Now(),
+		scheduleID, classID, enum.SeatAvailable.String(),
+		quantity,
+	)
+	return result.RowsAffected, result.Error
+}
+
+func (r *ScheduleSeatRepository) ReleaseByClaimSessionID(ctx context.Context, conn gotann.Connection, sessionID uint) error {
+	result := conn.Model(&domain.ScheduleSeat{}).
+		Where("claim_session_id = ? AND status = ?", sessionID, enum.SeatHeld.String()).
+		Updates(map[string]interface{}{
+			"status":           enum.SeatAvailable.String(),
+			"claim_session_id": nil,
+			"updated_at":       time.Now(),
+		})
+	return result.Error
+}
+
+func (r *ScheduleSeatRepository) ReleaseByTicketIDs(ctx context.Context, conn gotann.Connection, ticketIDs []uint) error {
+	if len(ticketIDs) == 0 {
+		return nil
+	}
+	result := conn.Model(&domain.ScheduleSeat{}).
+		Where("ticket_id IN ? AND status = ?", ticketIDs, enum.SeatSold.String()).
+		Updates(map[string]interface{}{
+			"status":     enum.SeatAvailable.String(),
+			"ticket_id":  nil,
+			"updated_at": time.Now(),
+		})
+	return result.Error
+}
+
+// ReleaseByScheduleIDAndTicketIDs frees the seats of tickets on one schedule
+// only, for tickets that hold a seat on two departures while a change to
+// their booking awaits payment.
+func (r *ScheduleSeatRepository) ReleaseByScheduleIDAndTicketIDs(ctx context.Context, conn gotann.Connection, scheduleID uint, ticketIDs []uint) error {
+	if len(ticketIDs) == 0 {
+		return nil
+	}
+	result := conn.Model(&domain.ScheduleSeat{}).
+		Where("schedule_id = ? AND ticket_id IN ? AND status = ?", scheduleID, ticketIDs, enum.SeatSold.String()).
+		Updates(map[string]interface{}{
+			"status":     enum.SeatAvailable.String(),
+			"ticket_id":  nil,
+			"updated_at": time.Now(),
+		})
+	return result.Error
+}
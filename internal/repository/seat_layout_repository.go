@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type SeatLayoutRepository struct {
+	DB *gorm.DB
+}
+
+func NewSeatLayoutRepository(db *gorm.DB) *SeatLayoutRepository {
+	return &SeatLayoutRepository{DB: db}
+}
+
+func (r *SeatLayoutRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	var total int64
+	result := conn.Model(&domain.SeatLayout{}).Count(&total)
+	return total, result.Error
+}
+
+func (r *SeatLayoutRepository) Insert(ctx context.Context, conn gotann.Connection, layout *domain.SeatLayout) error {
+	result := conn.Create(layout)
+	return result.Error
+}
+
+// Update replaces the layout's seats with the ones on the entity.
+func (r *SeatLayoutRepository) Update(ctx context.Context, conn gotann.Connection, layout *domain.SeatLayout) error {
+	if err := conn.Where("seat_layout_id = ?", layout.ID).Delete(&domain.LayoutSeat{}).Error; err != nil {
+		return err
+	}
+	for i := range layout.Seats {
+		layout.Seats[i].ID = 0
+		layout.Seats[i].SeatLayoutID = layout.ID
+	}
+	result := conn.Save(layout)
+	return result.Error
+}
+
+func (r *SeatLayoutRepository) Delete(ctx context.Context, conn gotann.Connection, layout *domain.SeatLayout) error {
+	result := conn.Select("Seats").Delete(layout)
+	return result.Error
+}
+
+func (r *SeatLayoutRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.SeatLayout, error) {
+	layouts := []*domain.SeatLayout{}
+	query := conn.Model(&domain.SeatLayout{}).
+		Preload("Ship").
+		Preload("Class").
+		Preload("Seats", func(db *gorm.DB) *gorm.DB {
+			return db.Order("row_number asc, column_number asc")
+		})
+	if search != "" {
+		search = "%" + search + "%"
+		query = query.Where("ship_id IN (SELECT id FROM ship WHERE ship_name ILIKE ?)", search)
+	}
+	if sort == "" {
+		sort = "id asc"
+	} else {
+		sort = strings.Replace(sort, ":", " ", 1)
+	}
+	err := query.Order(sort).Limit(limit).Offset(offset).Find(&layouts).Error
+	return layouts, err
+}
+
+func (r *SeatLayoutRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.SeatLayout, error) {
+	layout := new(domain.SeatLayout)
+	result := conn.
+		Preload("Ship").
+		Preload("Class").
+		Preload("Seats", func(db *gorm.DB) *gorm.DB {
+			return db.Order("row_number asc, column_number asc")
+		}).
+		First(&layout, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return layout, result.Error
+}
+
+func (r *SeatLayoutRepository) FindByShipID(ctx context.Context, conn gotann.Connection, shipID uint) ([]*domain.SeatLayout, error) {
+	layouts := []*domain.SeatLayout{}
+	result := conn.
+		Preload("Class").
+		Preload("Seats", func(db *gorm.DB) *gorm.DB {
+			return db.Order("row_number asc, column_number asc")
+		}).
+		Where("ship_id = ?", shipID).
+		Find(&layouts)
+	return layouts, result.Error
+}
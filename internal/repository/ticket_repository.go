@@ -6,6 +6,7 @@ import (
 	enum "eticket-api/internal/common/enums"
 	"eticket-api/internal/domain"
 	"eticket-api/pkg/gotann"
+	"fmt"
 	"strings"
 	"time"
 
@@ -108,6 +109,8 @@ func (r *TicketRepository) FindByID(ctx context.Context, conn gotann.Connection,
 		Preload("Schedule.Ship").
 		Preload("Schedule.DepartureHarbor").
 		Preload("Schedule.ArrivalHarbor").
+		Preload("Fees").
+		Preload("Addons").
 		First(&ticket, id)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -115,6 +118,24 @@ func (r *TicketRepository) FindByID(ctx context.Context, conn gotann.Connection,
 	return ticket, result.Error
 }
 
+func (r *TicketRepository) FindByTicketCode(ctx context.Context, conn gotann.Connection, code string) (*domain.Ticket, error) {
+	ticket := new(domain.Ticket)
+	result := conn.Preload("Class").
+		Preload("Booking").
+		Preload("Schedule").
+		Preload("Schedule.Ship").
+		Preload("Schedule.DepartureHarbor").
+		Preload("Schedule.ArrivalHarbor").
+		Preload("Fees").
+		Preload("Addons").
+		Where("ticket_code = ?", code).
+		First(&ticket)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return ticket, result.Error
+}
+
 func (r *TicketRepository) FindByIDs(ctx context.Context, conn gotann.Connection, ids []uint) ([]*domain.Ticket, error) {
 	tickets := []*domain.Ticket{}
 	result := conn.Preload("Class").
@@ -138,6 +159,8 @@ func (r *TicketRepository) FindByBookingID(ctx context.Context, conn gotann.Conn
 		Preload("Schedule.DepartureHarbor").
 		Preload("Schedule.ArrivalHarbor").
 		Preload("Booking").
+		Preload("Fees").
+		Preload("Addons").
 		Where("booking_id = ?", bookingID).
 		Find(&tickets)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
@@ -154,6 +177,8 @@ func (r *TicketRepository) FindByScheduleID(ctx context.Context, conn gotann.Con
 		Preload("Schedule.DepartureHarbor").
 		Preload("Schedule.ArrivalHarbor").
 		Preload("Booking").
+		Preload("Driver").
+		Preload("Addons").
 		Where("schedule_id = ?", scheduleID).
 		Find(&tickets)
 	if result.Error != nil {
@@ -177,9 +202,62 @@ func (r *TicketRepository) FindByClaimSessionID(ctx context.Context, conn gotann
 	return tickets, nil
 }
 
-func (r *TicketRepository) CheckIn(ctx context.Context, conn gotann.Connection, id uint) error {
-	return conn.Model(&domain.Ticket{}).
-		Where("id = ?", id).
-		Update("status", "checkin").
-		Error
+// UpdateBoarding writes the boarding state of a ticket if it is still in
+// from, and returns how many rows changed. Zero means another scan moved the
+// ticket first.
+func (r *TicketRepository) UpdateBoarding(ctx context.Context, conn gotann.Connection, ticket *domain.Ticket, from string) (int64, error) {
+	result := conn.Model(&domain.Ticket{}).
+		Where("id = ? AND boarding_status = ?", ticket.ID, from).
+		Updates(map[string]interface{}{
+			"boarding_status": ticket.BoardingStatus,
+			"is_checked_in":   ticket.IsCheckedIn,
+			"checked_in_at":   ticket.CheckedInAt,
+			"boarded_at":      ticket.BoardedAt,
+			"updated_at":      time.Now(),
+		})
+	return result.RowsAffected, result.Error
+}
+
+// CountBoardingByScheduleID counts the tickets of paid bookings on a departure
+// by boarding status. Tickets checked in before boarding was tracked are
+// still ISSUED in the column and count as checked in.
+func (r *TicketRepository) CountBoardingByScheduleID(ctx context.Context, conn gotann.Connection, scheduleID uint) (map[string]int64, error) {
+	var rows []struct {
+		BoardingStatus string
+		Count          int64
+	}
+	stored := fmt.Sprintf("COALESCE(NULLIF(ticket.boarding_status, ''), '%s')", enum.BoardingIssued.String())
+	status := fmt.Sprintf("CASE WHEN %s = '%s' AND ticket.is_checked_in THEN '%s' ELSE %s END",
+		stored, enum.BoardingIssued.String(), enum.BoardingCheckedIn.String(), stored)
+	result := conn.Model(&domain.Ticket{}).
+		Select(status+" AS boarding_status, COUNT(*) AS count").
+		Joins("JOIN booking ON booking.id = ticket.booking_id").
+		Where("ticket.schedule_id = ? AND booking.status = ?", scheduleID, enum.BookingPaid.String()).
+		Group(status).
+		Scan(&rows)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	counts := make(map[string]int64, len(rows))
+	for _, row := range rows {
+		counts[row.BoardingStatus] = row.Count
+	}
+	return counts, nil
+}
+
+// ReplaceFees swaps the fees charged on a ticket for fees, e.g. after the
+// ticket was repriced.
+func (r *TicketRepository) ReplaceFees(ctx context.Context, conn gotann.Connection, ticketID uint, fees []domain.TicketFee) error {
+	if err := conn.Where("ticket_id = ?", ticketID).Delete(&domain.TicketFee{}).Error; err != nil {
+		return err
+	}
+	if len(fees) == 0 {
+		return nil
+	}
+	for i := range fees {
+		fees[i].ID = 0
+		fees[i].TicketID = ticketID
+	}
+	result := conn.Create(&fees)
+	return result.Error
 }
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+
+	"gorm.io/gorm"
+)
+
+type VehicleCategoryRepository struct {
+	DB *gorm.DB
+}
+
+func NewVehicleCategoryRepository(db *gorm.DB) *VehicleCategoryRepository {
+	return &VehicleCategoryRepository{DB: db}
+}
+
+func (r *VehicleCategoryRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	var total int64
+	result := conn.Model(&domain.VehicleCategory{}).Count(&total)
+	return total, result.Error
+}
+
+func (r *VehicleCategoryRepository) Insert(ctx context.Context, conn gotann.Connection, category *domain.VehicleCategory) error {
+	result := conn.Create(category)
+	return result.Error
+}
+
+func (r *VehicleCategoryRepository) Update(ctx context.Context, conn gotann.Connection, category *domain.VehicleCategory) error {
+	result := conn.Save(category)
+	return result.Error
+}
+
+func (r *VehicleCategoryRepository) Delete(ctx context.Context, conn gotann.Connection, category *domain.VehicleCategory) error {
+	result := conn.Delete(category)
+	return result.Error
+}
+
+func (r *VehicleCategoryRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.VehicleCategory, error) {
+	categories := []*domain.VehicleCategory{}
+	query := conn.Model(&domain.VehicleCategory{})
+	if search != "" {
+		search = "%" + search + "%"
+		query = query.Where("golongan ILIKE ? OR name ILIKE ?", search, search)
+	}
+	if sort == "" {
+		sort = "min_length asc"
+	} else {
+		sort = strings.Replace(sort, ":", " ", 1)
+	}
+	err := query.Order(sort).Limit(limit).Offset(offset).Find(&categories).Error
+	return categories, err
+}
+
+func (r *VehicleCategoryRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.VehicleCategory, error) {
+	category := new(domain.VehicleCategory)
+	result := conn.First(&category, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return category, result.Error
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WaitlistRepository struct {
+	DB *gorm.DB
+}
+
+func NewWaitlistRepository(db *gorm.DB) *WaitlistRepository {
+	return &WaitlistRepository{DB: db}
+}
+
+func (r *WaitlistRepository) Count(ctx context.Context, conn gotann.Connection) (int64, error) {
+	var total int64
+	result := conn.Model(&domain.Waitlist{}).Count(&total)
+	return total, result.Error
+}
+
+func (r *WaitlistRepository) Insert(ctx context.Context, conn gotann.Connection, waitlist *domain.Waitlist) error {
+	result := conn.Create(waitlist)
+	return result.Error
+}
+
+func (r *WaitlistRepository) Update(ctx context.Context, conn gotann.Connection, waitlist *domain.Waitlist) error {
+	result := conn.Omit(clause.Associations).Save(waitlist)
+	return result.Error
+}
+
+func (r *WaitlistRepository) FindAll(ctx context.Context, conn gotann.Connection, limit, offset int, sort, search string) ([]*domain.Waitlist, error) {
+	waitlists := []*domain.Waitlist{}
+	query := conn.Model(&domain.Waitlist{}).
+		Preload("Schedule").
+		Preload("Schedule.DepartureHarbor").
+		Preload("Schedule.ArrivalHarbor").
+		Preload("Class").
+		Preload("ClaimSession")
+	if search != "" {
+		search = "%" + search + "%"
+		query = query.Where("customer_name ILIKE ? OR email ILIKE ?", search, search)
+	}
+	if sort == "" {
+		sort = "id asc"
+	} else {
+		sort = strings.Replace(sort, ":", " ", 1)
+	}
+	err := query.Order(sort).Limit(limit).Offset(offset).Find(&waitlists).Error
+	return waitlists, err
+}
+
+func (r *WaitlistRepository) FindByID(ctx context.Context, conn gotann.Connection, id uint) (*domain.Waitlist, error) {
+	waitlist := new(domain.Waitlist)
+	result := conn.
+		Preload("Schedule").
+		Preload("Schedule.DepartureHarbor").
+		Preload("Schedule.ArrivalHarbor").
+		Preload("Class").
+		Preload("ClaimSession").
+		First(&waitlist, id)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return waitlist, result.Error
+}
+
+// FindWaiting returns waiting entries oldest first, so offers follow the queue.
+func (r *WaitlistRepository) FindWaiting(ctx context.Context, conn gotann.Connection, limit int) ([]*domain.Waitlist, error) {
+	waitlists := []*domain.Waitlist{}
+	result := conn.
+		Where("status = ?", enum.WaitlistWaiting.String()).
+		Order("created_at asc, id asc").
+		Limit(limit).
+		Find(&waitlists)
+	return waitlists, result.Error
+}
+
+// Offer binds a waiting entry to the claim session created for it. It reports
+// zero rows when the entry stopped waiting in the meantime.
+func (r *WaitlistRepository) Offer(ctx context.Context, conn gotann.Connection, id uint, claimSessionID uint) (int64, error) {
+	now := time.Now()
+	result := conn.Model(&domain.Waitlist{}).
+		Where("id = ? AND status = ?", id, enum.WaitlistWaiting.String()).
+		Updates(map[string]interface{}{
+			"status":           enum.WaitlistOffered.String(),
+			"claim_session_id": claimSessionID,
+			"offered_at":       now,
+			"updated_at":       now,
+		})
+	return result.RowsAffected, result.Error
+}
+
+// SettleOffers closes offers whose claim session is no longer pending: reserved
+// sessions fulfil the entry, anything else lets the offer lapse.
+func (r *WaitlistRepository) SettleOffers(ctx context.Context, conn gotann.Connection) error {
+	now := time.Now()
+	fulfilled := conn.Model(&domain.Waitlist{}).
+		Where("status = ?", enum.WaitlistOffered.String()).
+		Where("claim_session_id IN (SELECT id FROM claim_session WHERE status IN ?)", enum.GetSuccessClaimSessionStatuses()).
+		Updates(map[string]interface{}{
+			"status":     enum.WaitlistFulfilled.String(),
+			"updated_at": now,
+		})
+	if fulfilled.Error != nil {
+		return fulfilled.Error
+	}
+
+	lapsed := conn.Model(&domain.Waitlist{}).
+		Where("status = ?", enum.WaitlistOffered.String()).
+		Where("NOT EXISTS (SELECT 1 FROM claim_session WHERE claim_session.id = waitlist.claim_session_id AND claim_session.status IN ?)", enum.GetPendingClaimSessionStatuses()).
+		Updates(map[string]interface{}{
+			"status":     enum.WaitlistLapsed.String(),
+			"updated_at": now,
+		})
+	return lapsed.Error
+}
@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/model"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"sort"
+)
+
+// legAddon identifies one add-on on one leg (schedule) of a claim or booking.
+type legAddon struct {
+	ScheduleID uint
+	AddonID    uint
+}
+
+type addonQuantity struct {
+	ScheduleID uint
+	AddonID    uint
+	Quantity   int
+}
+
+// claimAddons prices the add-ons requested with a claim at the stock of their
+// leg. Add-ons without a leg belong to scheduleID, and every leg must be one
+// the claim books.
+func claimAddons(scheduleID uint, legs []uint, requested []model.ClaimSessionAddon, stockByLeg map[legAddon]*domain.AddonStock) ([]domain.ClaimAddon, error) {
+	claimed := make(map[uint]bool, len(legs))
+	for _, leg := range legs {
+		claimed[leg] = true
+	}
+	addons := make([]domain.ClaimAddon, len(requested))
+	for i, request := range requested {
+		leg := request.ScheduleID
+		if leg == 0 {
+			leg = scheduleID
+		}
+		if !claimed[leg] {
+			return nil, fmt.Errorf("add-on %d is for schedule %d, which is not claimed: %w", request.AddonID, leg, errs.ErrBadRequest)
+		}
+		if request.Quantity <= 0 {
+			return nil, fmt.Errorf("add-on %d quantity must be positive: %w", request.AddonID, errs.ErrBadRequest)
+		}
+		stock, ok := stockByLeg[legAddon{ScheduleID: leg, AddonID: request.AddonID}]
+		if !ok || !stock.Addon.IsActive {
+			return nil, fmt.Errorf("add-on %d is not sold on schedule %d: %w", request.AddonID, leg, errs.ErrBadRequest)
+		}
+		addons[i] = domain.ClaimAddon{
+			ScheduleID: leg,
+			AddonID:    request.AddonID,
+			Quantity:   request.Quantity,
+			Subtotal:   float64(request.Quantity) * stock.UnitPrice(),
+		}
+	}
+	return addons, nil
+}
+
+// groupClaimAddons folds add-ons into one total per leg and add-on, ordered
+// so concurrent reservations touch stock rows in the same order.
+func groupClaimAddons(addons []domain.ClaimAddon) []addonQuantity {
+	totals := make(map[legAddon]int)
+	for _, addon := range addons {
+		totals[legAddon{ScheduleID: addon.ScheduleID, AddonID: addon.AddonID}] += addon.Quantity
+	}
+	return sortedAddonQuantities(totals)
+}
+
+func groupBookingAddons(addons []domain.BookingAddon) []addonQuantity {
+	totals := make(map[legAddon]int)
+	for _, addon := range addons {
+		totals[legAddon{ScheduleID: addon.ScheduleID, AddonID: addon.AddonID}] += addon.Quantity
+	}
+	return sortedAddonQuantities(totals)
+}
+
+func sortedAddonQuantities(totals map[legAddon]int) []addonQuantity {
+	quantities := make([]addonQuantity, 0, len(totals))
+	for key, quantity := range totals {
+		quantities = append(quantities, addonQuantity{ScheduleID: key.ScheduleID, AddonID: key.AddonID, Quantity: quantity})
+	}
+	sort.Slice(quantities, func(i, j int) bool {
+		if quantities[i].ScheduleID != quantities[j].ScheduleID {
+			return quantities[i].ScheduleID < quantities[j].ScheduleID
+		}
+		return quantities[i].AddonID < quantities[j].AddonID
+	})
+	return quantities
+}
+
+// reserveClaimAddons holds stock for every add-on or fails with
+// ErrQuotaExceeded, leaving the rollback to undo earlier holds.
+func reserveClaimAddons(ctx context.Context, conn gotann.Connection, stocks domain.AddonStockRepository, addons []domain.ClaimAddon) error {
+	for _, q := range groupClaimAddons(addons) {
+		ok, err := stocks.Reserve(ctx, conn, q.ScheduleID, q.AddonID, q.Quantity)
+		if err != nil {
+			return fmt.Errorf("reserve add-on %d on schedule %d: %w", q.AddonID, q.ScheduleID, err)
+		}
+		if !ok {
+			return fmt.Errorf("add-on %d on schedule %d: %w", q.AddonID, q.ScheduleID, errs.ErrQuotaExceeded)
+		}
+	}
+	return nil
+}
+
+func releaseClaimAddons(ctx context.Context, conn gotann.Connection, stocks domain.AddonStockRepository, addons []domain.ClaimAddon) error {
+	for _, q := range groupClaimAddons(addons) {
+		if err := stocks.Release(ctx, conn, q.ScheduleID, q.AddonID, q.Quantity); err != nil {
+			return fmt.Errorf("release add-on %d on schedule %d: %w", q.AddonID, q.ScheduleID, err)
+		}
+	}
+	return nil
+}
+
+func confirmClaimAddons(ctx context.Context, conn gotann.Connection, stocks domain.AddonStockRepository, addons []domain.ClaimAddon) error {
+	for _, q := range groupClaimAddons(addons) {
+		ok, err := stocks.Confirm(ctx, conn, q.ScheduleID, q.AddonID, q.Quantity)
+		if err != nil {
+			return fmt.Errorf("confirm add-on %d on schedule %d: %w", q.AddonID, q.ScheduleID, err)
+		}
+		if !ok {
+			return fmt.Errorf("add-on %d on schedule %d: %w", q.AddonID, q.ScheduleID, errs.ErrQuotaExceeded)
+		}
+	}
+	return nil
+}
+
+// restoreBookingAddons puts the add-ons of a booking back on sale.
+func restoreBookingAddons(ctx context.Context, conn gotann.Connection, stocks domain.AddonStockRepository, addons []domain.BookingAddon) error {
+	for _, q := range groupBookingAddons(addons) {
+		if err := stocks.Restore(ctx, conn, q.ScheduleID, q.AddonID, q.Quantity); err != nil {
+			return fmt.Errorf("restore add-on %d on schedule %d: %w", q.AddonID, q.ScheduleID, err)
+		}
+	}
+	return nil
+}
+
+// sellBookingAddons takes stock for add-ons moved onto a leg outside the claim
+// flow, failing with ErrQuotaExceeded when the leg is short.
+func sellBookingAddons(ctx context.Context, conn gotann.Connection, stocks domain.AddonStockRepository, addons []domain.BookingAddon) error {
+	for _, q := range groupBookingAddons(addons) {
+		ok, err := stocks.Reserve(ctx, conn, q.ScheduleID, q.AddonID, q.Quantity)
+		if err != nil {
+			return fmt.Errorf("reserve add-on %d on schedule %d: %w", q.AddonID, q.ScheduleID, err)
+		}
+		if !ok {
+			return fmt.Errorf("add-on %d on schedule %d: %w", q.AddonID, q.ScheduleID, errs.ErrQuotaExceeded)
+		}
+		ok, err = stocks.Confirm(ctx, conn, q.ScheduleID, q.AddonID, q.Quantity)
+		if err != nil {
+			return fmt.Errorf("confirm add-on %d on schedule %d: %w", q.AddonID, q.ScheduleID, err)
+		}
+		if !ok {
+			return fmt.Errorf("add-on %d on schedule %d: %w", q.AddonID, q.ScheduleID, errs.ErrQuotaExceeded)
+		}
+	}
+	return nil
+}
+
+// bookingAddons hands the add-ons held by a claim out to the tickets that
+// asked for them, one unit per requested ID on the ticket's leg. Units no
+// ticket asked for stay with the booking. addonIDs runs parallel to tickets,
+// which must already have their IDs.
+func bookingAddons(claimed []domain.ClaimAddon, bookingID uint, tickets []*domain.Ticket, addonIDs [][]uint) ([]*domain.BookingAddon, error) {
+	left := make(map[legAddon]int, len(claimed))
+	byLeg := make(map[legAddon]domain.ClaimAddon, len(claimed))
+	var order []legAddon
+	for _, addon := range claimed {
+		key := legAddon{ScheduleID: addon.ScheduleID, AddonID: addon.AddonID}
+		if _, ok := byLeg[key]; !ok {
+			order = append(order, key)
+			byLeg[key] = addon
+		}
+		left[key] += addon.Quantity
+	}
+
+	newAddon := func(key legAddon, ticketID *uint, quantity int) *domain.BookingAddon {
+		addon := byLeg[key]
+		return &domain.BookingAddon{
+			BookingID:  bookingID,
+			TicketID:   ticketID,
+			ScheduleID: key.ScheduleID,
+			AddonID:    key.AddonID,
+			Name:       addon.Addon.Name,
+			Kind:       addon.Addon.Kind,
+			Price:      addon.Subtotal / float64(addon.Quantity),
+			Quantity:   quantity,
+		}
+	}
+
+	var addons []*domain.BookingAddon
+	for i, ticket := range tickets {
+		for _, addonID := range addonIDs[i] {
+			key := legAddon{ScheduleID: ticket.ScheduleID, AddonID: addonID}
+			if left[key] == 0 {
+				return nil, fmt.Errorf("add-on %d for ticket of %s is not held on schedule %d: %w", addonID, ticket.PassengerName, ticket.ScheduleID, errs.ErrBadRequest)
+			}
+			left[key]--
+			ticketID := ticket.ID
+			addons = append(addons, newAddon(key, &ticketID, 1))
+		}
+	}
+	for _, key := range order {
+		if left[key] > 0 {
+			addons = append(addons, newAddon(key, nil, left[key]))
+		}
+	}
+	return addons, nil
+}
+
+func addonTotal(addons []domain.BookingAddon) float64 {
+	var total float64
+	for _, addon := range addons {
+		total += addon.Price * float64(addon.Quantity)
+	}
+	return total
+}
+
+// groupAddons adds up add-ons of the same leg and product, in the order they
+// first appear, for one order item each.
+func groupAddons(addons []domain.BookingAddon) []domain.BookingAddon {
+	var grouped []domain.BookingAddon
+	index := make(map[legAddon]int)
+	for _, addon := range addons {
+		key := legAddon{ScheduleID: addon.ScheduleID, AddonID: addon.AddonID}
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(grouped)
+			grouped = append(grouped, domain.BookingAddon{
+				ScheduleID: addon.ScheduleID,
+				AddonID:    addon.AddonID,
+				Name:       addon.Name,
+				Kind:       addon.Kind,
+				Price:      addon.Price,
+				Quantity:   addon.Quantity,
+			})
+			continue
+		}
+		grouped[i].Quantity += addon.Quantity
+	}
+	return grouped
+}
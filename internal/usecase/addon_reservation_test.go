@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"testing"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClaimAddons(t *testing.T) {
+	t.Parallel()
+	outbound, inbound := uint(1), uint(2)
+	lunch := 45000.0
+	stockByLeg := map[legAddon]*domain.AddonStock{
+		{ScheduleID: outbound, AddonID: 10}: {ScheduleID: outbound, AddonID: 10, Addon: domain.Addon{ID: 10, Name: "Nasi Box", Price: 35000, IsActive: true}},
+		{ScheduleID: inbound, AddonID: 10}:  {ScheduleID: inbound, AddonID: 10, Price: &lunch, Addon: domain.Addon{ID: 10, Name: "Nasi Box", Price: 35000, IsActive: true}},
+		{ScheduleID: outbound, AddonID: 11}: {ScheduleID: outbound, AddonID: 11, Addon: domain.Addon{ID: 11, Name: "Kabin", Price: 250000}},
+	}
+	tests := []struct {
+		name      string
+		requested []model.ClaimSessionAddon
+		want      []float64
+		err       error
+	}{
+		{
+			name:      "leg defaults to claim schedule",
+			requested: []model.ClaimSessionAddon{{AddonID: 10, Quantity: 2}},
+			want:      []float64{70000},
+		},
+		{
+			name:      "schedule price overrides product price",
+			requested: []model.ClaimSessionAddon{{ScheduleID: inbound, AddonID: 10, Quantity: 1}},
+			want:      []float64{45000},
+		},
+		{
+			name:      "leg not claimed",
+			requested: []model.ClaimSessionAddon{{ScheduleID: 3, AddonID: 10, Quantity: 1}},
+			err:       errs.ErrBadRequest,
+		},
+		{
+			name:      "zero quantity",
+			requested: []model.ClaimSessionAddon{{AddonID: 10}},
+			err:       errs.ErrBadRequest,
+		},
+		{
+			name:      "inactive add-on",
+			requested: []model.ClaimSessionAddon{{AddonID: 11, Quantity: 1}},
+			err:       errs.ErrBadRequest,
+		},
+		{
+			name:      "not sold on leg",
+			requested: []model.ClaimSessionAddon{{ScheduleID: inbound, AddonID: 11, Quantity: 1}},
+			err:       errs.ErrBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			addons, err := claimAddons(outbound, []uint{outbound, inbound}, tc.requested, stockByLeg)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, addons, len(tc.want))
+			for i, addon := range addons {
+				require.Equal(t, tc.want[i], addon.Subtotal)
+			}
+		})
+	}
+}
+
+func TestBookingAddons(t *testing.T) {
+	t.Parallel()
+	meal := domain.Addon{ID: 10, Name: "Nasi Box", Kind: "MEAL"}
+	luggage := domain.Addon{ID: 12, Name: "Bagasi 20kg", Kind: "LUGGAGE"}
+	claimed := []domain.ClaimAddon{
+		{ScheduleID: 1, AddonID: 10, Quantity: 3, Subtotal: 105000, Addon: meal},
+		{ScheduleID: 1, AddonID: 12, Quantity: 1, Subtotal: 50000, Addon: luggage},
+	}
+	tickets := []*domain.Ticket{
+		{ID: 100, ScheduleID: 1, PassengerName: "Budi"},
+		{ID: 101, ScheduleID: 1, PassengerName: "Sari"},
+	}
+
+	t.Run("split between tickets and booking", func(t *testing.T) {
+		addons, err := bookingAddons(claimed, 7, tickets, [][]uint{{10, 12}, {10}})
+		require.NoError(t, err)
+		require.Len(t, addons, 4)
+		require.Equal(t, uint(100), *addons[0].TicketID)
+		require.Equal(t, uint(100), *addons[1].TicketID)
+		require.Equal(t, uint(101), *addons[2].TicketID)
+		require.Nil(t, addons[3].TicketID)
+		require.Equal(t, uint(10), addons[3].AddonID)
+		require.Equal(t, 1, addons[3].Quantity)
+		for _, addon := range addons {
+			require.Equal(t, uint(7), addon.BookingID)
+		}
+		require.Equal(t, 35000.0, addons[0].Price)
+		require.Equal(t, "Bagasi 20kg", addons[1].Name)
+	})
+
+	t.Run("more than held", func(t *testing.T) {
+		_, err := bookingAddons(claimed, 7, tickets, [][]uint{{12}, {12}})
+		require.ErrorIs(t, err, errs.ErrBadRequest)
+	})
+}
+
+func TestGroupAddons(t *testing.T) {
+	t.Parallel()
+	ticketID := uint(100)
+	addons := []domain.BookingAddon{
+		{ScheduleID: 1, AddonID: 10, TicketID: &ticketID, Name: "Nasi Box", Price: 35000, Quantity: 1},
+		{ScheduleID: 1, AddonID: 12, Name: "Bagasi 20kg", Price: 50000, Quantity: 1},
+		{ScheduleID: 1, AddonID: 10, Name: "Nasi Box", Price: 35000, Quantity: 2},
+		{ScheduleID: 2, AddonID: 10, Name: "Nasi Box", Price: 45000, Quantity: 1},
+	}
+	grouped := groupAddons(addons)
+	require.Equal(t, []domain.BookingAddon{
+		{ScheduleID: 1, AddonID: 10, Name: "Nasi Box", Price: 35000, Quantity: 3},
+		{ScheduleID: 1, AddonID: 12, Name: "Bagasi 20kg", Price: 50000, Quantity: 1},
+		{ScheduleID: 2, AddonID: 10, Name: "Nasi Box", Price: 45000, Quantity: 1},
+	}, grouped)
+	require.Equal(t, 200000.0, addonTotal(addons))
+}
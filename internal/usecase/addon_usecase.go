@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"strings"
+)
+
+type AddonUsecase struct {
+	Transactor             transact.Transactor
+	AddonRepository        domain.AddonRepository
+	AddonStockRepository   domain.AddonStockRepository
+	BookingAddonRepository domain.BookingAddonRepository
+	ScheduleRepository     domain.ScheduleRepository
+}
+
+func NewAddonUsecase(
+	transactor transact.Transactor,
+	addon_repository domain.AddonRepository,
+	addon_stock_repository domain.AddonStockRepository,
+	booking_addon_repository domain.BookingAddonRepository,
+	schedule_repository domain.ScheduleRepository,
+) *AddonUsecase {
+	return &AddonUsecase{
+		Transactor:             transactor,
+		AddonRepository:        addon_repository,
+		AddonStockRepository:   addon_stock_repository,
+		BookingAddonRepository: booking_addon_repository,
+		ScheduleRepository:     schedule_repository,
+	}
+}
+
+func (uc *AddonUsecase) CreateAddon(ctx context.Context, e *domain.Addon) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if err := checkAddon(e); err != nil {
+			return err
+		}
+
+		addon := &domain.Addon{
+			Name:        e.Name,
+			Kind:        e.Kind,
+			Description: e.Description,
+			Price:       e.Price,
+			IsActive:    e.IsActive,
+		}
+		if err := uc.AddonRepository.Insert(ctx, tx, addon); err != nil {
+			return fmt.Errorf("failed to create add-on: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *AddonUsecase) ListAddons(ctx context.Context, limit, offset int, sort, search string) ([]*domain.Addon, int, error) {
+	var err error
+	var total int64
+	var addons []*domain.Addon
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		total, err = uc.AddonRepository.Count(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to count add-ons: %w", err)
+		}
+		addons, err = uc.AddonRepository.FindAll(ctx, tx, limit, offset, sort, search)
+		if err != nil {
+			return fmt.Errorf("failed to get all add-ons: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to list add-ons: %w", err)
+	}
+
+	return addons, int(total), nil
+}
+
+func (uc *AddonUsecase) GetAddonByID(ctx context.Context, id uint) (*domain.Addon, error) {
+	var err error
+	var addon *domain.Addon
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		addon, err = uc.AddonRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get add-on: %w", err)
+		}
+		if addon == nil {
+			return errs.ErrNotFound
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get add-on by ID: %w", err)
+	}
+	return addon, nil
+}
+
+// UpdateAddon changes the product for sales from now on. Add-ons already sold
+// keep the name and price they were sold with.
+func (uc *AddonUsecase) UpdateAddon(ctx context.Context, e *domain.Addon) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		addon, err := uc.AddonRepository.FindByID(ctx, tx, e.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find add-on: %w", err)
+		}
+		if addon == nil {
+			return errs.ErrNotFound
+		}
+		if err := checkAddon(e); err != nil {
+			return err
+		}
+
+		addon.Name = e.Name
+		addon.Kind = e.Kind
+		addon.Description = e.Description
+		addon.Price = e.Price
+		addon.IsActive = e.IsActive
+
+		if err := uc.AddonRepository.Update(ctx, tx, addon); err != nil {
+			return fmt.Errorf("failed to update add-on: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *AddonUsecase) DeleteAddon(ctx context.Context, id uint) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		addon, err := uc.AddonRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get add-on: %w", err)
+		}
+		if addon == nil {
+			return errs.ErrNotFound
+		}
+
+		if err := uc.AddonRepository.Delete(ctx, tx, addon); err != nil {
+			return fmt.Errorf("failed to delete add-on: %w", err)
+		}
+		return nil
+	})
+}
+
+// CreateAddonStock puts an add-on on sale on a schedule with its full
+// capacity available.
+func (uc *AddonUsecase) CreateAddonStock(ctx context.Context, e *domain.AddonStock) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if e.Capacity <= 0 || (e.Price != nil && *e.Price < 0) {
+			return fmt.Errorf("invalid capacity or price: %w", errs.ErrBadRequest)
+		}
+		schedule, err := uc.ScheduleRepository.FindByID(ctx, tx, e.ScheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if schedule == nil {
+			return fmt.Errorf("schedule %d: %w", e.ScheduleID, errs.ErrNotFound)
+		}
+		addon, err := uc.AddonRepository.FindByID(ctx, tx, e.AddonID)
+		if err != nil {
+			return fmt.Errorf("failed to get add-on: %w", err)
+		}
+		if addon == nil {
+			return fmt.Errorf("add-on %d: %w", e.AddonID, errs.ErrNotFound)
+		}
+
+		stock := &domain.AddonStock{
+			ScheduleID: e.ScheduleID,
+			AddonID:    e.AddonID,
+			Stock:      e.Capacity,
+			Capacity:   e.Capacity,
+			Price:      e.Price,
+		}
+		if err := uc.AddonStockRepository.Insert(ctx, tx, stock); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("failed to create add-on stock: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *AddonUsecase) ListAddonStocks(ctx context.Context, scheduleID uint) ([]*domain.AddonStock, error) {
+	var err error
+	var stocks []*domain.AddonStock
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		stocks, err = uc.AddonStockRepository.FindByScheduleID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get add-on stock: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list add-on stock: %w", err)
+	}
+	return stocks, nil
+}
+
+// UpdateAddonStock changes the capacity and price of an add-on on a
+// schedule. Units already sold or held stay taken, so the capacity cannot
+// drop below them.
+func (uc *AddonUsecase) UpdateAddonStock(ctx context.Context, e *domain.AddonStock) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		stock, err := uc.AddonStockRepository.FindByID(ctx, tx, e.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find add-on stock: %w", err)
+		}
+		if stock == nil {
+			return errs.ErrNotFound
+		}
+		if e.Capacity <= 0 || (e.Price != nil && *e.Price < 0) {
+			return fmt.Errorf("invalid capacity or price: %w", errs.ErrBadRequest)
+		}
+		remaining := stock.Stock + e.Capacity - stock.Capacity
+		if remaining < stock.Held {
+			return fmt.Errorf("capacity below units already sold or held: %w", errs.ErrConflict)
+		}
+
+		stock.Stock = remaining
+		stock.Capacity = e.Capacity
+		stock.Price = e.Price
+		stock.Addon = domain.Addon{}
+		if err := uc.AddonStockRepository.Update(ctx, tx, stock); err != nil {
+			return fmt.Errorf("failed to update add-on stock: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteAddonStock takes an add-on off sale on a schedule, as long as none of
+// it was sold or is held.
+func (uc *AddonUsecase) DeleteAddonStock(ctx context.Context, id uint) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		stock, err := uc.AddonStockRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get add-on stock: %w", err)
+		}
+		if stock == nil {
+			return errs.ErrNotFound
+		}
+		if stock.Held > 0 || stock.Stock < stock.Capacity {
+			return fmt.Errorf("add-on already sold or held on this schedule: %w", errs.ErrConflict)
+		}
+
+		if err := uc.AddonStockRepository.Delete(ctx, tx, stock); err != nil {
+			return fmt.Errorf("failed to delete add-on stock: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListScheduleAddons lists what paid bookings on a schedule ordered, with the
+// ticket each add-on belongs to, for the crew.
+func (uc *AddonUsecase) ListScheduleAddons(ctx context.Context, scheduleID uint) ([]*domain.BookingAddon, error) {
+	var err error
+	var addons []*domain.BookingAddon
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		addons, err = uc.BookingAddonRepository.FindByScheduleID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking add-ons: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list schedule add-ons: %w", err)
+	}
+	return addons, nil
+}
+
+func checkAddon(addon *domain.Addon) error {
+	addon.Name = strings.TrimSpace(addon.Name)
+	if addon.Name == "" {
+		return fmt.Errorf("add-on name is empty: %w", errs.ErrBadRequest)
+	}
+	switch addon.Kind {
+	case enum.AddonMeal.String(), enum.AddonCabin.String(), enum.AddonLuggage.String():
+	default:
+		return fmt.Errorf("unknown add-on kind %s: %w", addon.Kind, errs.ErrBadRequest)
+	}
+	if addon.Price < 0 {
+		return fmt.Errorf("add-on price must not be negative: %w", errs.ErrBadRequest)
+	}
+	return nil
+}
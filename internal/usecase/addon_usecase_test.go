@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func addonUsecase(t *testing.T) (*AddonUsecase, *mocks.MockAddonRepository, *mocks.MockAddonStockRepository, *mocks.MockScheduleRepository, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	addonRepo := mocks.NewMockAddonRepository(ctrl)
+	stockRepo := mocks.NewMockAddonStockRepository(ctrl)
+	scheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	uc := NewAddonUsecase(transactor, addonRepo, stockRepo, mocks.NewMockBookingAddonRepository(ctrl), scheduleRepo)
+	return uc, addonRepo, stockRepo, scheduleRepo, transactor
+}
+
+func TestAddonUsecase_CreateAddon(t *testing.T) {
+	t.Parallel()
+	uc, addonRepo, _, _, transactor := addonUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	tests := []struct {
+		name  string
+		addon *domain.Addon
+		mock  func()
+		err   error
+	}{
+		{
+			name:  "success",
+			addon: &domain.Addon{Name: " Nasi Box ", Kind: "MEAL", Price: 35000, IsActive: true},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				addonRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, addon *domain.Addon) error {
+						require.Equal(t, "Nasi Box", addon.Name)
+						return nil
+					},
+				)
+			},
+		},
+		{
+			name:  "unknown kind",
+			addon: &domain.Addon{Name: "Wifi", Kind: "WIFI", Price: 10000},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name:  "negative price",
+			addon: &domain.Addon{Name: "Nasi Box", Kind: "MEAL", Price: -1},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name:  "repo error",
+			addon: &domain.Addon{Name: "Nasi Box", Kind: "MEAL", Price: 35000},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				addonRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.CreateAddon(context.Background(), tc.addon)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddonUsecase_CreateAddonStock(t *testing.T) {
+	t.Parallel()
+	uc, addonRepo, stockRepo, scheduleRepo, transactor := addonUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	tests := []struct {
+		name  string
+		stock *domain.AddonStock
+		mock  func()
+		err   error
+	}{
+		{
+			name:  "success",
+			stock: &domain.AddonStock{ScheduleID: 1, AddonID: 10, Capacity: 40},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Schedule{ID: 1}, nil)
+				addonRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(10)).Return(&domain.Addon{ID: 10}, nil)
+				stockRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, stock *domain.AddonStock) error {
+						require.Equal(t, 40, stock.Stock)
+						return nil
+					},
+				)
+			},
+		},
+		{
+			name:  "zero capacity",
+			stock: &domain.AddonStock{ScheduleID: 1, AddonID: 10},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name:  "add-on not found",
+			stock: &domain.AddonStock{ScheduleID: 1, AddonID: 10, Capacity: 40},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Schedule{ID: 1}, nil)
+				addonRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(10)).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.CreateAddonStock(context.Background(), tc.stock)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddonUsecase_UpdateAddonStock(t *testing.T) {
+	t.Parallel()
+	uc, _, stockRepo, _, transactor := addonUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	tests := []struct {
+		name     string
+		capacity int
+		mock     func()
+		err      error
+	}{
+		{
+			name:     "grow keeps units sold",
+			capacity: 50,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				stockRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.AddonStock{ID: 1, Stock: 30, Capacity: 40, Held: 5}, nil)
+				stockRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, stock *domain.AddonStock) error {
+						require.Equal(t, 40, stock.Stock)
+						require.Equal(t, 50, stock.Capacity)
+						return nil
+					},
+				)
+			},
+		},
+		{
+			name:     "below held",
+			capacity: 12,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				stockRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.AddonStock{ID: 1, Stock: 30, Capacity: 40, Held: 5}, nil)
+			},
+			err: errs.ErrConflict,
+		},
+		{
+			name:     "not found",
+			capacity: 50,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				stockRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.UpdateAddonStock(context.Background(), &domain.AddonStock{ID: 1, Capacity: tc.capacity})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAddonUsecase_DeleteAddonStock(t *testing.T) {
+	t.Parallel()
+	uc, _, stockRepo, _, transactor := addonUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	tests := []struct {
+		name string
+		mock func()
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				stockRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.AddonStock{ID: 1, Stock: 40, Capacity: 40}, nil)
+				stockRepo.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "already sold",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				stockRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.AddonStock{ID: 1, Stock: 39, Capacity: 40}, nil)
+			},
+			err: errs.ErrConflict,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.DeleteAddonStock(context.Background(), 1)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"time"
+)
+
+// boardingMoves lists where a ticket may go from each boarding status.
+// Boarded and no-show tickets only move back through an undo. Cancelled is
+// final: the cancellation may have refunded the ticket and given its quota,
+// seat and add-ons back to sale.
+var boardingMoves = map[string][]string{
+	enum.BoardingIssued.String():    {enum.BoardingCheckedIn.String(), enum.BoardingNoShow.String(), enum.BoardingCancelled.String()},
+	enum.BoardingCheckedIn.String(): {enum.BoardingBoarded.String(), enum.BoardingNoShow.String(), enum.BoardingCancelled.String()},
+}
+
+// BoardingActor is the staff member and gate behind a boarding move.
+type BoardingActor struct {
+	UserID   uint
+	Gate     string
+	HarborID *uint
+	Device   string
+}
+
+// storedBoardingStatus returns the boarding status column of a ticket. Tickets
+// created in this process are ISSUED before the database default is read back.
+func storedBoardingStatus(ticket *domain.Ticket) string {
+	if ticket.BoardingStatus == "" {
+		return enum.BoardingIssued.String()
+	}
+	return ticket.BoardingStatus
+}
+
+// boardingStatus returns where a ticket is in boarding. Tickets checked in
+// before boarding was tracked are still ISSUED in the column.
+func boardingStatus(ticket *domain.Ticket) string {
+	status := storedBoardingStatus(ticket)
+	if status == enum.BoardingIssued.String() && ticket.IsCheckedIn {
+		return enum.BoardingCheckedIn.String()
+	}
+	return status
+}
+
+// moveTicket moves a ticket to another boarding status at a point in time and
+// records who did it. A second check-in is a conflict that names the first.
+func moveTicket(ctx context.Context, conn gotann.Connection, tickets domain.TicketRepository, events domain.BoardingEventRepository, ticket *domain.Ticket, to string, actor *BoardingActor, at time.Time) (*domain.BoardingEvent, error) {
+	stored, from := storedBoardingStatus(ticket), boardingStatus(ticket)
+	if to == enum.BoardingCheckedIn.String() && (from == enum.BoardingCheckedIn.String() || from == enum.BoardingBoarded.String()) {
+		if ticket.CheckedInAt == nil {
+			return nil, fmt.Errorf("ticket %s was already checked in: %w", ticket.TicketCode, errs.ErrConflict)
+		}
+		return nil, fmt.Errorf("ticket %s was already checked in at %s: %w", ticket.TicketCode, ticket.CheckedInAt.Format(time.RFC3339), errs.ErrConflict)
+	}
+	allowed := false
+	for _, next := range boardingMoves[from] {
+		if next == to {
+			allowed = true
+		}
+	}
+	if !allowed {
+		return nil, fmt.Errorf("ticket %s cannot go from %s to %s: %w", ticket.TicketCode, from, to, errs.ErrConflict)
+	}
+
+	ticket.BoardingStatus = to
+	switch to {
+	case enum.BoardingCheckedIn.String():
+		ticket.CheckedInAt = &at
+	case enum.BoardingBoarded.String():
+		ticket.BoardedAt = &at
+	}
+	ticket.IsCheckedIn = to == enum.BoardingCheckedIn.String() || to == enum.BoardingBoarded.String()
+	if err := updateBoarding(ctx, conn, tickets, ticket, stored); err != nil {
+		return nil, err
+	}
+
+	event := &domain.BoardingEvent{
+		TicketID:   ticket.ID,
+		ScheduleID: ticket.ScheduleID,
+		FromStatus: from,
+		ToStatus:   to,
+		Gate:       actor.Gate,
+		Device:     actor.Device,
+		HarborID:   actor.HarborID,
+		OccurredAt: at,
+	}
+	if actor.UserID != 0 {
+		event.UserID = &actor.UserID
+	}
+	if err := events.Insert(ctx, conn, event); err != nil {
+		return nil, fmt.Errorf("failed to record boarding event: %w", err)
+	}
+	return event, nil
+}
+
+// undoTicket takes back the last boarding move of a ticket. A cancellation
+// cannot be taken back.
+func undoTicket(ctx context.Context, conn gotann.Connection, tickets domain.TicketRepository, events domain.BoardingEventRepository, ticket *domain.Ticket, actor *BoardingActor, at time.Time) (*domain.BoardingEvent, error) {
+	event, err := events.FindLastByTicketID(ctx, conn, ticket.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last boarding event: %w", err)
+	}
+	current := storedBoardingStatus(ticket)
+	if event == nil || event.ToStatus != current {
+		return nil, fmt.Errorf("ticket %s has no boarding move to undo: %w", ticket.TicketCode, errs.ErrConflict)
+	}
+	if current == enum.BoardingCancelled.String() {
+		return nil, fmt.Errorf("ticket %s was cancelled and cannot be restored: %w", ticket.TicketCode, errs.ErrConflict)
+	}
+
+	ticket.BoardingStatus = event.FromStatus
+	switch current {
+	case enum.BoardingCheckedIn.String():
+		ticket.CheckedInAt = nil
+	case enum.BoardingBoarded.String():
+		ticket.BoardedAt = nil
+	}
+	ticket.IsCheckedIn = event.FromStatus == enum.BoardingCheckedIn.String() || event.FromStatus == enum.BoardingBoarded.String()
+	if err := updateBoarding(ctx, conn, tickets, ticket, current); err != nil {
+		return nil, err
+	}
+
+	event.UndoneAt = &at
+	if actor.UserID != 0 {
+		event.UndoneBy = &actor.UserID
+	}
+	if err := events.Update(ctx, conn, event); err != nil {
+		return nil, fmt.Errorf("failed to update boarding event: %w", err)
+	}
+	return event, nil
+}
+
+func updateBoarding(ctx context.Context, conn gotann.Connection, tickets domain.TicketRepository, ticket *domain.Ticket, from string) error {
+	updated, err := tickets.UpdateBoarding(ctx, conn, ticket, from)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket boarding: %w", err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("ticket %s was moved by another scan: %w", ticket.TicketCode, errs.ErrConflict)
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/model"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// Devices may upload offline scans this long after the crossing is over
+	snapshotUploadGrace = 72 * time.Hour
+
+	SyncApplied   = "APPLIED"
+	SyncDuplicate = "DUPLICATE"
+	SyncConflict  = "CONFLICT"
+)
+
+// Snapshot lists the tickets of paid bookings on a departure that can still
+// board, for a gate device to scan against while it is offline.
+func (uc *BoardingUsecase) Snapshot(ctx context.Context, scheduleID uint) (*model.BoardingSnapshot, error) {
+	var schedule *domain.Schedule
+	var tickets []*domain.Ticket
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		schedule, err = uc.ScheduleRepository.FindByID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if schedule == nil {
+			return errs.ErrNotFound
+		}
+		tickets, err = uc.TicketRepository.FindByScheduleID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get tickets: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get boarding snapshot: %w", err)
+	}
+
+	snapshot := &model.BoardingSnapshot{
+		ScheduleID:        scheduleID,
+		DepartureDatetime: schedule.DepartureDatetime,
+		GeneratedAt:       time.Now(),
+		ValidUntil:        crossingEnd(schedule),
+		Tickets:           []model.BoardingSnapshotTicket{},
+	}
+	for _, ticket := range tickets {
+		if ticket.Booking.Status != enum.BookingPaid.String() || storedBoardingStatus(ticket) == enum.BoardingCancelled.String() {
+			continue
+		}
+		pass, err := signTicket(uc.TokenUtil, ticket)
+		if err != nil {
+			return nil, err
+		}
+		hash := sha256.Sum256([]byte(pass))
+		snapshot.Tickets = append(snapshot.Tickets, model.BoardingSnapshotTicket{
+			TicketCode:     ticket.TicketCode,
+			Type:           ticket.Type,
+			PassengerName:  ticket.PassengerName,
+			ClassName:      ticket.Class.ClassName,
+			SeatNumber:     ticket.SeatNumber,
+			LicensePlate:   ticket.LicensePlate,
+			BoardingStatus: boardingStatus(ticket),
+			PassHash:       hex.EncodeToString(hash[:]),
+		})
+	}
+
+	body, err := json.Marshal(snapshot.Tickets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	digest := sha256.Sum256(body)
+	snapshot.Digest = hex.EncodeToString(digest[:])
+	snapshot.Token, err = uc.TokenUtil.GenerateSnapshotToken(scheduleID, snapshot.ValidUntil.Add(snapshotUploadGrace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// Sync records the scans a gate device collected offline, oldest first, each
+// in its own transaction. The first scan of a ticket wins, whichever device
+// made it. Scans of tickets that cannot board any more, such as ones refunded
+// after the snapshot was taken, are reported as conflicts for staff to look at.
+// A no-show marked while the device was offline gives way to an earlier scan.
+func (uc *BoardingUsecase) Sync(ctx context.Context, scheduleID uint, snapshotToken string, scans []model.BoardingScan, actor *BoardingActor) (*model.BoardingSync, error) {
+	claims, err := uc.TokenUtil.ValidateSnapshotToken(snapshotToken)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot, %v: %w", err, errs.ErrBadRequest)
+	}
+	if claims.ScheduleID != scheduleID {
+		return nil, fmt.Errorf("snapshot is for another departure: %w", errs.ErrBadRequest)
+	}
+
+	sorted := make([]model.BoardingScan, len(scans))
+	copy(sorted, scans)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].ScannedAt.Before(sorted[j].ScannedAt) })
+
+	sync := &model.BoardingSync{
+		ScheduleID:      scheduleID,
+		SnapshotTakenAt: claims.IssuedAt.Time,
+		Results:         make([]model.BoardingSyncResult, 0, len(sorted)),
+	}
+	for _, scan := range sorted {
+		result, err := uc.syncScan(ctx, scheduleID, scan, sync.SnapshotTakenAt, actor)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sync scan of ticket %s: %w", scan.TicketCode, err)
+		}
+		switch result.Result {
+		case SyncApplied:
+			sync.Applied++
+		case SyncDuplicate:
+			sync.Duplicates++
+		default:
+			sync.Conflicts++
+		}
+		sync.Results = append(sync.Results, *result)
+	}
+	return sync, nil
+}
+
+// syncScan records one offline scan. Only unexpected failures are returned as
+// errors, anything the scan runs into is in the result.
+func (uc *BoardingUsecase) syncScan(ctx context.Context, scheduleID uint, scan model.BoardingScan, takenAt time.Time, actor *BoardingActor) (*model.BoardingSyncResult, error) {
+	result := &model.BoardingSyncResult{
+		TicketCode: scan.TicketCode,
+		Status:     scan.Status,
+		ScannedAt:  scan.ScannedAt,
+		Result:     SyncApplied,
+	}
+	// A device clock ahead of ours does not put a scan in the future
+	at := scan.ScannedAt
+	if now := time.Now(); at.After(now) {
+		at = now
+	}
+
+	err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		err := uc.applyScan(ctx, tx, scheduleID, scan, at, takenAt, actor, result)
+		if errors.Is(err, errs.ErrConflict) {
+			result.Result = SyncConflict
+			result.Reason = strings.TrimSuffix(err.Error(), ": "+errs.ErrConflict.Error())
+		}
+		return err
+	})
+	if err != nil && result.Result != SyncConflict {
+		return nil, err
+	}
+	return result, nil
+}
+
+// applyScan moves the ticket of an offline scan at the time it was scanned.
+func (uc *BoardingUsecase) applyScan(ctx context.Context, conn gotann.Connection, scheduleID uint, scan model.BoardingScan, at, takenAt time.Time, actor *BoardingActor, result *model.BoardingSyncResult) error {
+	ticket, err := uc.TicketRepository.FindByTicketCode(ctx, conn, scan.TicketCode)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket: %w", err)
+	}
+	if ticket == nil {
+		return fmt.Errorf("unknown ticket: %w", errs.ErrConflict)
+	}
+	result.BoardingStatus = boardingStatus(ticket)
+	if ticket.ScheduleID != scheduleID {
+		return fmt.Errorf("ticket is for another departure: %w", errs.ErrConflict)
+	}
+	if ticket.Booking.Status != enum.BookingPaid.String() {
+		if ticket.Booking.UpdatedAt.After(takenAt) {
+			return fmt.Errorf("booking became %s after the snapshot was taken: %w", ticket.Booking.Status, errs.ErrConflict)
+		}
+		return fmt.Errorf("booking is %s: %w", ticket.Booking.Status, errs.ErrConflict)
+	}
+
+	status := boardingStatus(ticket)
+	if status == enum.BoardingNoShow.String() {
+		last, err := uc.BoardingEventRepository.FindLastByTicketID(ctx, conn, ticket.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get last boarding event: %w", err)
+		}
+		if last == nil || last.ToStatus != enum.BoardingNoShow.String() || !last.OccurredAt.After(at) {
+			return fmt.Errorf("ticket was marked %s before it was scanned: %w", status, errs.ErrConflict)
+		}
+		if _, err := undoTicket(ctx, conn, uc.TicketRepository, uc.BoardingEventRepository, ticket, actor, time.Now()); err != nil {
+			return err
+		}
+		status = boardingStatus(ticket)
+	}
+
+	switch {
+	case scan.Status == enum.BoardingCheckedIn.String() && (status == enum.BoardingCheckedIn.String() || status == enum.BoardingBoarded.String()):
+		result.Result, result.PreviousScanAt = SyncDuplicate, ticket.CheckedInAt
+		return nil
+	case scan.Status == enum.BoardingBoarded.String() && status == enum.BoardingBoarded.String():
+		result.Result, result.PreviousScanAt = SyncDuplicate, ticket.BoardedAt
+		return nil
+	}
+	// Boarding a ticket nobody checked in checks it in at the same time
+	if scan.Status == enum.BoardingBoarded.String() && status == enum.BoardingIssued.String() {
+		if _, err := moveTicket(ctx, conn, uc.TicketRepository, uc.BoardingEventRepository, ticket, enum.BoardingCheckedIn.String(), actor, at); err != nil {
+			return err
+		}
+	}
+	if _, err := moveTicket(ctx, conn, uc.TicketRepository, uc.BoardingEventRepository, ticket, scan.Status, actor, at); err != nil {
+		return err
+	}
+	result.BoardingStatus = boardingStatus(ticket)
+	return nil
+}
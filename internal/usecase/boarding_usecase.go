@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/token"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/model"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"time"
+)
+
+// BoardingUsecase moves tickets through boarding at the gates: check-in,
+// boarding, no-show and cancellation. Every move records who made it and
+// where, and the last one of a ticket can be undone.
+type BoardingUsecase struct {
+	Transactor              transact.Transactor
+	TicketRepository        domain.TicketRepository
+	BoardingEventRepository domain.BoardingEventRepository
+	ScheduleRepository      domain.ScheduleRepository
+	TokenUtil               token.TokenUtil
+}
+
+func NewBoardingUsecase(
+	transactor transact.Transactor,
+	ticket_repository domain.TicketRepository,
+	boarding_event_repository domain.BoardingEventRepository,
+	schedule_repository domain.ScheduleRepository,
+	token_util token.TokenUtil,
+) *BoardingUsecase {
+	return &BoardingUsecase{
+		Transactor:              transactor,
+		TicketRepository:        ticket_repository,
+		BoardingEventRepository: boarding_event_repository,
+		ScheduleRepository:      schedule_repository,
+		TokenUtil:               token_util,
+	}
+}
+
+// Move moves a ticket to a boarding status. Only tickets of paid bookings
+// are checked in or boarded.
+func (uc *BoardingUsecase) Move(ctx context.Context, ticketID uint, to string, actor *BoardingActor) (*domain.Ticket, error) {
+	var ticket *domain.Ticket
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		ticket, err = uc.TicketRepository.FindByID(ctx, tx, ticketID)
+		if err != nil {
+			return fmt.Errorf("failed to get ticket: %w", err)
+		}
+		if ticket == nil {
+			return errs.ErrNotFound
+		}
+		if (to == enum.BoardingCheckedIn.String() || to == enum.BoardingBoarded.String()) &&
+			ticket.Booking.Status != enum.BookingPaid.String() {
+			return fmt.Errorf("booking of ticket %s is %s: %w", ticket.TicketCode, ticket.Booking.Status, errs.ErrBadRequest)
+		}
+		_, err = moveTicket(ctx, tx, uc.TicketRepository, uc.BoardingEventRepository, ticket, to, actor, time.Now())
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to move ticket to %s: %w", to, err)
+	}
+	return ticket, nil
+}
+
+// Undo takes back the last boarding move of a ticket.
+func (uc *BoardingUsecase) Undo(ctx context.Context, ticketID uint, actor *BoardingActor) (*domain.Ticket, error) {
+	var ticket *domain.Ticket
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		ticket, err = uc.TicketRepository.FindByID(ctx, tx, ticketID)
+		if err != nil {
+			return fmt.Errorf("failed to get ticket: %w", err)
+		}
+		if ticket == nil {
+			return errs.ErrNotFound
+		}
+		_, err = undoTicket(ctx, tx, uc.TicketRepository, uc.BoardingEventRepository, ticket, actor, time.Now())
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to undo boarding move: %w", err)
+	}
+	return ticket, nil
+}
+
+// History returns every boarding move of a ticket, undone ones included.
+func (uc *BoardingUsecase) History(ctx context.Context, ticketID uint) ([]*domain.BoardingEvent, error) {
+	var events []*domain.BoardingEvent
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		ticket, err := uc.TicketRepository.FindByID(ctx, tx, ticketID)
+		if err != nil {
+			return fmt.Errorf("failed to get ticket: %w", err)
+		}
+		if ticket == nil {
+			return errs.ErrNotFound
+		}
+		events, err = uc.BoardingEventRepository.FindByTicketID(ctx, tx, ticketID)
+		if err != nil {
+			return fmt.Errorf("failed to get boarding events: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get boarding history: %w", err)
+	}
+	return events, nil
+}
+
+// Counts counts the tickets of paid bookings on a departure by boarding
+// status.
+func (uc *BoardingUsecase) Counts(ctx context.Context, scheduleID uint) (*model.BoardingCounts, error) {
+	var counts map[string]int64
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		counts, err = uc.TicketRepository.CountBoardingByScheduleID(ctx, tx, scheduleID)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to count boarding: %w", err)
+	}
+
+	result := &model.BoardingCounts{
+		ScheduleID: scheduleID,
+		Issued:     counts[enum.BoardingIssued.String()],
+		CheckedIn:  counts[enum.BoardingCheckedIn.String()],
+		Boarded:    counts[enum.BoardingBoarded.String()],
+		NoShow:     counts[enum.BoardingNoShow.String()],
+		Cancelled:  counts[enum.BoardingCancelled.String()],
+	}
+	for _, count := range counts {
+		result.Total += count
+	}
+	return result, nil
+}
+
+// CloseGate marks every ticket of a paid booking on a departure that did not
+// board as a no-show, and returns how many were marked. Each mark can be
+// undone like any other move.
+func (uc *BoardingUsecase) CloseGate(ctx context.Context, scheduleID uint, actor *BoardingActor) (int, error) {
+	marked := 0
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		tickets, err := uc.TicketRepository.FindByScheduleID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get tickets: %w", err)
+		}
+		now := time.Now()
+		for _, ticket := range tickets {
+			status := boardingStatus(ticket)
+			if ticket.Booking.Status != enum.BookingPaid.String() ||
+				(status != enum.BoardingIssued.String() && status != enum.BoardingCheckedIn.String()) {
+				continue
+			}
+			if _, err := moveTicket(ctx, tx, uc.TicketRepository, uc.BoardingEventRepository, ticket, enum.BoardingNoShow.String(), actor, now); err != nil {
+				return err
+			}
+			marked++
+		}
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("failed to close gate: %w", err)
+	}
+	return marked, nil
+}
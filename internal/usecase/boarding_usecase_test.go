@@ -0,0 +1,338 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/token"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/internal/model"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func boardingUsecase(t *testing.T) (*BoardingUsecase, *mocks.MockTicketRepository, *mocks.MockBoardingEventRepository, *mocks.MockTokenUtil, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	ticketRepo := mocks.NewMockTicketRepository(ctrl)
+	boardingEventRepo := mocks.NewMockBoardingEventRepository(ctrl)
+	scheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	tokenUtil := mocks.NewMockTokenUtil(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	uc := NewBoardingUsecase(transactor, ticketRepo, boardingEventRepo, scheduleRepo, tokenUtil)
+	return uc, ticketRepo, boardingEventRepo, tokenUtil, transactor
+}
+
+func TestBoardingUsecase_Move(t *testing.T) {
+	t.Parallel()
+	uc, ticketRepo, boardingEventRepo, _, transactor := boardingUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	checkedInAt := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	ticket := func(boardingStatus, bookingStatus string) *domain.Ticket {
+		ticket := &domain.Ticket{
+			ID:             1,
+			ScheduleID:     1,
+			TicketCode:     "T-1",
+			BoardingStatus: boardingStatus,
+			Booking:        domain.Booking{Status: bookingStatus},
+		}
+		if boardingStatus == "CHECKED_IN" {
+			ticket.IsCheckedIn = true
+			ticket.CheckedInAt = &checkedInAt
+		}
+		return ticket
+	}
+	tests := []struct {
+		name   string
+		to     string
+		mock   func()
+		err    error
+		errMsg string
+	}{
+		{
+			name: "check in",
+			to:   "CHECKED_IN",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(ticket("ISSUED", "PAID"), nil)
+				ticketRepo.EXPECT().UpdateBoarding(gomock.Any(), gomock.Any(), gomock.Any(), "ISSUED").Return(int64(1), nil)
+				boardingEventRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "board",
+			to:   "BOARDED",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(ticket("CHECKED_IN", "PAID"), nil)
+				ticketRepo.EXPECT().UpdateBoarding(gomock.Any(), gomock.Any(), gomock.Any(), "CHECKED_IN").DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, ticket *domain.Ticket, from string) (int64, error) {
+						require.Equal(t, "BOARDED", ticket.BoardingStatus)
+						require.NotNil(t, ticket.BoardedAt)
+						return 1, nil
+					})
+				boardingEventRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:   "double check-in",
+			to:     "CHECKED_IN",
+			err:    errs.ErrConflict,
+			errMsg: checkedInAt.Format(time.RFC3339),
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(ticket("CHECKED_IN", "PAID"), nil)
+			},
+		},
+		{
+			name: "board without check-in",
+			to:   "BOARDED",
+			err:  errs.ErrConflict,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(ticket("ISSUED", "PAID"), nil)
+			},
+		},
+		{
+			name: "unpaid booking",
+			to:   "CHECKED_IN",
+			err:  errs.ErrBadRequest,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(ticket("ISSUED", "PENDING"), nil)
+			},
+		},
+		{
+			name: "moved by another scan",
+			to:   "CHECKED_IN",
+			err:  errs.ErrConflict,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(ticket("ISSUED", "PAID"), nil)
+				ticketRepo.EXPECT().UpdateBoarding(gomock.Any(), gomock.Any(), gomock.Any(), "ISSUED").Return(int64(0), nil)
+			},
+		},
+		{
+			name: "not found",
+			to:   "CHECKED_IN",
+			err:  errs.ErrNotFound,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			_, err := uc.Move(context.Background(), 1, tc.to, &BoardingActor{UserID: 7, Gate: "A"})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				require.Contains(t, err.Error(), tc.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBoardingUsecase_Undo(t *testing.T) {
+	t.Parallel()
+	uc, ticketRepo, boardingEventRepo, _, transactor := boardingUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	checkedInAt := time.Now()
+	checkedIn := func() *domain.Ticket {
+		return &domain.Ticket{ID: 1, TicketCode: "T-1", BoardingStatus: "CHECKED_IN", IsCheckedIn: true, CheckedInAt: &checkedInAt}
+	}
+	tests := []struct {
+		name string
+		mock func()
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(checkedIn(), nil)
+				boardingEventRepo.EXPECT().FindLastByTicketID(gomock.Any(), gomock.Any(), uint(1)).
+					Return(&domain.BoardingEvent{ID: 3, FromStatus: "ISSUED", ToStatus: "CHECKED_IN"}, nil)
+				ticketRepo.EXPECT().UpdateBoarding(gomock.Any(), gomock.Any(), gomock.Any(), "CHECKED_IN").DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, ticket *domain.Ticket, from string) (int64, error) {
+						require.Equal(t, "ISSUED", ticket.BoardingStatus)
+						require.False(t, ticket.IsCheckedIn)
+						require.Nil(t, ticket.CheckedInAt)
+						return 1, nil
+					})
+				boardingEventRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, event *domain.BoardingEvent) error {
+						require.NotNil(t, event.UndoneAt)
+						require.Equal(t, uint(7), *event.UndoneBy)
+						return nil
+					})
+			},
+		},
+		{
+			name: "cancellation is final",
+			err:  errs.ErrConflict,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).
+					Return(&domain.Ticket{ID: 1, TicketCode: "T-1", BoardingStatus: "CANCELLED"}, nil)
+				boardingEventRepo.EXPECT().FindLastByTicketID(gomock.Any(), gomock.Any(), uint(1)).
+					Return(&domain.BoardingEvent{ID: 4, FromStatus: "ISSUED", ToStatus: "CANCELLED"}, nil)
+			},
+		},
+		{
+			name: "nothing to undo",
+			err:  errs.ErrConflict,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(checkedIn(), nil)
+				boardingEventRepo.EXPECT().FindLastByTicketID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			_, err := uc.Undo(context.Background(), 1, &BoardingActor{UserID: 7})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBoardingUsecase_Counts(t *testing.T) {
+	t.Parallel()
+	uc, ticketRepo, _, _, transactor := boardingUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+	ticketRepo.EXPECT().CountBoardingByScheduleID(gomock.Any(), gomock.Any(), uint(1)).
+		Return(map[string]int64{"ISSUED": 4, "CHECKED_IN": 3, "BOARDED": 2, "NO_SHOW": 1}, nil)
+
+	counts, err := uc.Counts(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), counts.CheckedIn)
+	require.Equal(t, int64(2), counts.Boarded)
+	require.Equal(t, int64(1), counts.NoShow)
+	require.Equal(t, int64(10), counts.Total)
+}
+
+func TestBoardingUsecase_Sync(t *testing.T) {
+	t.Parallel()
+	uc, ticketRepo, boardingEventRepo, tokenUtil, transactor := boardingUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	takenAt := time.Now().Add(-2 * time.Hour)
+	scannedAt := time.Now().Add(-time.Hour)
+	ticket := func(boardingStatus, bookingStatus string, bookingUpdatedAt time.Time) *domain.Ticket {
+		ticket := &domain.Ticket{
+			ID:             1,
+			ScheduleID:     1,
+			TicketCode:     "T-1",
+			BoardingStatus: boardingStatus,
+			Booking:        domain.Booking{Status: bookingStatus, UpdatedAt: bookingUpdatedAt},
+		}
+		if boardingStatus == "CHECKED_IN" {
+			ticket.IsCheckedIn = true
+			ticket.CheckedInAt = &takenAt
+		}
+		return ticket
+	}
+	tests := []struct {
+		name   string
+		status string
+		mock   func()
+		result string
+		reason string
+	}{
+		{
+			name:   "applied at scan time",
+			status: "CHECKED_IN",
+			result: SyncApplied,
+			mock: func() {
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(ticket("ISSUED", "PAID", takenAt), nil)
+				ticketRepo.EXPECT().UpdateBoarding(gomock.Any(), gomock.Any(), gomock.Any(), "ISSUED").Return(int64(1), nil)
+				boardingEventRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, event *domain.BoardingEvent) error {
+						require.True(t, event.OccurredAt.Equal(scannedAt))
+						require.Equal(t, "gate-1", event.Device)
+						return nil
+					})
+			},
+		},
+		{
+			name:   "board checks in too",
+			status: "BOARDED",
+			result: SyncApplied,
+			mock: func() {
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(ticket("ISSUED", "PAID", takenAt), nil)
+				ticketRepo.EXPECT().UpdateBoarding(gomock.Any(), gomock.Any(), gomock.Any(), "ISSUED").Return(int64(1), nil)
+				ticketRepo.EXPECT().UpdateBoarding(gomock.Any(), gomock.Any(), gomock.Any(), "CHECKED_IN").Return(int64(1), nil)
+				boardingEventRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+		},
+		{
+			name:   "scanned on another device",
+			status: "CHECKED_IN",
+			result: SyncDuplicate,
+			mock: func() {
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(ticket("CHECKED_IN", "PAID", takenAt), nil)
+			},
+		},
+		{
+			name:   "refunded after snapshot",
+			status: "CHECKED_IN",
+			result: SyncConflict,
+			reason: "booking became REFUNDED after the snapshot was taken",
+			mock: func() {
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(ticket("ISSUED", "REFUNDED", takenAt.Add(time.Minute)), nil)
+			},
+		},
+		{
+			name:   "no-show marked while offline",
+			status: "CHECKED_IN",
+			result: SyncApplied,
+			mock: func() {
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(ticket("NO_SHOW", "PAID", takenAt), nil)
+				boardingEventRepo.EXPECT().FindLastByTicketID(gomock.Any(), gomock.Any(), uint(1)).
+					Return(&domain.BoardingEvent{FromStatus: "ISSUED", ToStatus: "NO_SHOW", OccurredAt: scannedAt.Add(time.Minute)}, nil).Times(2)
+				ticketRepo.EXPECT().UpdateBoarding(gomock.Any(), gomock.Any(), gomock.Any(), "NO_SHOW").Return(int64(1), nil)
+				boardingEventRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				ticketRepo.EXPECT().UpdateBoarding(gomock.Any(), gomock.Any(), gomock.Any(), "ISSUED").Return(int64(1), nil)
+				boardingEventRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:   "unknown ticket",
+			status: "CHECKED_IN",
+			result: SyncConflict,
+			reason: "unknown ticket",
+			mock: func() {
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(nil, nil)
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenUtil.EXPECT().ValidateSnapshotToken("snapshot").
+				Return(&token.SnapshotClaims{ScheduleID: 1, RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(takenAt)}}, nil)
+			transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			tc.mock()
+			scans := []model.BoardingScan{{TicketCode: "T-1", Status: tc.status, ScannedAt: scannedAt}}
+			sync, err := uc.Sync(context.Background(), 1, "snapshot", scans, &BoardingActor{UserID: 7, Device: "gate-1"})
+			require.NoError(t, err)
+			require.Len(t, sync.Results, 1)
+			require.Equal(t, tc.result, sync.Results[0].Result)
+			require.Equal(t, tc.reason, sync.Results[0].Reason)
+		})
+	}
+}
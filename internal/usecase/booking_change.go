@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+)
+
+// movedTicket is a ticket as it was before a booking change moved it.
+type movedTicket struct {
+	ID           uint               `json:"id"`
+	FareCategory string             `json:"fare_category"`
+	Price        float64            `json:"price"`
+	SeatNumber   *string            `json:"seat_number,omitempty"`
+	Fees         []domain.TicketFee `json:"fees"`
+}
+
+// changePrevious is what a booking change awaiting payment moved. Until the
+// charge settles the tickets hold quota and seats on both departures, so a
+// failed payment can put them back where they were.
+type changePrevious struct {
+	ScheduleID uint          `json:"schedule_id"` // The booking's schedule before the change
+	Tickets    []movedTicket `json:"tickets"`
+	AddonIDs   []uint        `json:"addon_ids"`
+}
+
+// newChangePrevious records tickets and add-ons of booking before they move.
+func newChangePrevious(booking *domain.Booking, tickets []*domain.Ticket, addons []*domain.BookingAddon) changePrevious {
+	fees := make(map[uint][]domain.TicketFee)
+	for _, fee := range booking.Fees {
+		fees[fee.TicketID] = append(fees[fee.TicketID], fee)
+	}
+	previous := changePrevious{ScheduleID: booking.ScheduleID}
+	for _, ticket := range tickets {
+		previous.Tickets = append(previous.Tickets, movedTicket{
+			ID:           ticket.ID,
+			FareCategory: ticket.FareCategory,
+			Price:        ticket.Price,
+			SeatNumber:   ticket.SeatNumber,
+			Fees:         fees[ticket.ID],
+		})
+	}
+	for _, addon := range addons {
+		previous.AddonIDs = append(previous.AddonIDs, addon.ID)
+	}
+	return previous
+}
+
+func (p changePrevious) encode() (*string, error) {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode booking change: %w", err)
+	}
+	encoded := string(body)
+	return &encoded, nil
+}
+
+func decodeChangePrevious(change *domain.BookingChange) (*changePrevious, error) {
+	if change.Previous == nil {
+		return nil, nil
+	}
+	previous := new(changePrevious)
+	if err := json.Unmarshal([]byte(*change.Previous), previous); err != nil {
+		return nil, fmt.Errorf("failed to decode booking change %d: %w", change.ID, err)
+	}
+	return previous, nil
+}
+
+// moved picks the tickets and add-ons of booking that the change moved.
+func (p changePrevious) moved(booking *domain.Booking) ([]*domain.Ticket, []*domain.BookingAddon) {
+	ticketIDs := make(map[uint]bool, len(p.Tickets))
+	for _, ticket := range p.Tickets {
+		ticketIDs[ticket.ID] = true
+	}
+	addonIDs := make(map[uint]bool, len(p.AddonIDs))
+	for _, id := range p.AddonIDs {
+		addonIDs[id] = true
+	}
+
+	var tickets []*domain.Ticket
+	for i := range booking.Tickets {
+		if ticketIDs[booking.Tickets[i].ID] {
+			tickets = append(tickets, &booking.Tickets[i])
+		}
+	}
+	var addons []*domain.BookingAddon
+	for i := range booking.Addons {
+		if addonIDs[booking.Addons[i].ID] {
+			addons = append(addons, &booking.Addons[i])
+		}
+	}
+	return tickets, addons
+}
+
+// before returns copies of tickets as they were on scheduleID.
+func (p changePrevious) before(scheduleID uint, tickets []*domain.Ticket) []*domain.Ticket {
+	previous := make(map[uint]movedTicket, len(p.Tickets))
+	for _, ticket := range p.Tickets {
+		previous[ticket.ID] = ticket
+	}
+	copies := make([]*domain.Ticket, len(tickets))
+	for i, ticket := range tickets {
+		was := *ticket
+		was.ScheduleID = scheduleID
+		was.FareCategory = previous[ticket.ID].FareCategory
+		was.Price = previous[ticket.ID].Price
+		was.SeatNumber = previous[ticket.ID].SeatNumber
+		copies[i] = &was
+	}
+	return copies
+}
+
+// vacateLeg gives back the quota, seats and add-on stock that tickets and
+// add-ons hold on scheduleID. They are taken as they are on that leg, which
+// decides e.g. whether an infant rides on a lap.
+func vacateLeg(ctx context.Context, conn gotann.Connection, quotas domain.QuotaRepository, seats domain.ScheduleSeatRepository, stocks domain.AddonStockRepository, scheduleID uint, tickets []*domain.Ticket, addons []*domain.BookingAddon) error {
+	ids := make([]uint, len(tickets))
+	onLeg := make([]*domain.Ticket, len(tickets))
+	for i, ticket := range tickets {
+		ids[i] = ticket.ID
+		was := *ticket
+		was.ScheduleID = scheduleID
+		onLeg[i] = &was
+	}
+	if err := seats.ReleaseByScheduleIDAndTicketIDs(ctx, conn, scheduleID, ids); err != nil {
+		return fmt.Errorf("release ticket seats: %w", err)
+	}
+	if err := restoreTickets(ctx, conn, quotas, onLeg); err != nil {
+		return fmt.Errorf("failed to restore quota: %w", err)
+	}
+	held := make([]domain.BookingAddon, len(addons))
+	for i, addon := range addons {
+		held[i] = *addon
+		held[i].ScheduleID = scheduleID
+	}
+	return restoreBookingAddons(ctx, conn, stocks, held)
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/templates"
+	"eticket-api/internal/common/token"
+	"eticket-api/internal/domain"
+	"fmt"
+)
+
+// Documents a booking can be downloaded as
+const (
+	DocumentETicket = "e-ticket" // boarding passes, one page per ticket
+	DocumentInvoice = "invoice"
+)
+
+// bookingDocument renders a document of a booking as a PDF. E-tickets are only
+// issued for paid bookings.
+func bookingDocument(tokens token.TokenUtil, booking *domain.Booking, tickets []*domain.Ticket, document string) (*domain.EmailAttachment, error) {
+	switch document {
+	case DocumentETicket:
+		if booking.Status != enum.BookingPaid.String() {
+			return nil, fmt.Errorf("booking %s is not paid: %w", booking.OrderID, errs.ErrConflict)
+		}
+		passes := make([]string, len(tickets))
+		for i, ticket := range tickets {
+			payload, err := tokens.GenerateTicketToken(ticket, ticketPassExpiry(ticket))
+			if err != nil {
+				return nil, fmt.Errorf("failed to sign ticket: %w", err)
+			}
+			passes[i] = payload
+		}
+		content, err := templates.BoardingPassPDF(booking, tickets, passes)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.EmailAttachment{
+			Filename:    fmt.Sprintf("e-ticket-%s.pdf", booking.OrderID),
+			ContentType: "application/pdf",
+			Content:     content,
+		}, nil
+
+	case DocumentInvoice:
+		return &domain.EmailAttachment{
+			Filename:    fmt.Sprintf("invoice-%s.pdf", booking.OrderID),
+			ContentType: "application/pdf",
+			Content:     templates.InvoicePDF(booking, tickets),
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown document %s: %w", document, errs.ErrBadRequest)
+	}
+}
+
+// confirmationAttachments renders the e-tickets and the receipt sent with the
+// confirmation email of a paid booking.
+func confirmationAttachments(tokens token.TokenUtil, booking *domain.Booking, tickets []*domain.Ticket) ([]domain.EmailAttachment, error) {
+	var attachments []domain.EmailAttachment
+	for _, document := range []string{DocumentETicket, DocumentInvoice} {
+		attachment, err := bookingDocument(tokens, booking, tickets, document)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, *attachment)
+	}
+	return attachments, nil
+}
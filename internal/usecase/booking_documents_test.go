@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBookingDocument(t *testing.T) {
+	t.Parallel()
+	departure := time.Date(2025, 7, 1, 8, 0, 0, 0, time.UTC)
+	schedule := domain.Schedule{ID: 1, DepartureDatetime: departure, ArrivalDatetime: departure.Add(2 * time.Hour)}
+	driverID := uint(10)
+	plate := "B 1234 XY"
+	tickets := []*domain.Ticket{
+		{ID: 10, ScheduleID: 1, Type: "passenger", TicketCode: "T-10", PassengerName: "Budi", Price: 20000, Schedule: schedule},
+		{ID: 11, ScheduleID: 1, Type: "vehicle", TicketCode: "T-11", LicensePlate: &plate, DriverTicketID: &driverID, Price: 150000, Schedule: schedule},
+	}
+
+	t.Run("e-tickets of a paid booking", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tokenUtil := mocks.NewMockTokenUtil(ctrl)
+		tokenUtil.EXPECT().GenerateTicketToken(tickets[0], departure.Add(2*time.Hour)).Return("qr-10", nil)
+		tokenUtil.EXPECT().GenerateTicketToken(tickets[1], departure.Add(2*time.Hour)).Return("qr-11", nil)
+
+		document, err := bookingDocument(tokenUtil, &domain.Booking{OrderID: "ORD-1", Status: "PAID"}, tickets, DocumentETicket)
+		require.NoError(t, err)
+		require.Equal(t, "e-ticket-ORD-1.pdf", document.Filename)
+		require.Equal(t, "application/pdf", document.ContentType)
+		require.True(t, bytes.HasPrefix(document.Content, []byte("%PDF-")))
+		require.Equal(t, 2, bytes.Count(document.Content, []byte("/Type /Page ")))
+	})
+
+	t.Run("e-tickets of an unpaid booking", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := bookingDocument(mocks.NewMockTokenUtil(ctrl), &domain.Booking{OrderID: "ORD-1", Status: "UNPAID"}, tickets, DocumentETicket)
+		require.ErrorIs(t, err, errs.ErrConflict)
+	})
+
+	t.Run("invoice", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		document, err := bookingDocument(mocks.NewMockTokenUtil(ctrl), &domain.Booking{OrderID: "ORD-1", Status: "UNPAID"}, tickets, DocumentInvoice)
+		require.NoError(t, err)
+		require.Equal(t, "invoice-ORD-1.pdf", document.Filename)
+		require.True(t, bytes.Contains(document.Content, []byte("(Rp 170000)")))
+	})
+
+	t.Run("unknown document", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		_, err := bookingDocument(mocks.NewMockTokenUtil(ctrl), &domain.Booking{OrderID: "ORD-1", Status: "PAID"}, tickets, "receipt")
+		require.ErrorIs(t, err, errs.ErrBadRequest)
+	})
+}
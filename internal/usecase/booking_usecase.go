@@ -2,28 +2,72 @@ package usecase
 
 import (
 	"context"
+	enum "eticket-api/internal/common/enums"
 	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/templates"
+	"eticket-api/internal/common/token"
 	"eticket-api/internal/common/transact"
 	"eticket-api/internal/domain"
 	"eticket-api/pkg/gotann"
 	"fmt"
+	"strings"
+	"time"
 )
 
 type BookingUsecase struct {
-	Transactor        transact.Transactor
-	BookingRepository domain.BookingRepository
-	QuotaRepository   domain.QuotaRepository
+	Transactor                   transact.Transactor
+	BookingRepository            domain.BookingRepository
+	QuotaRepository              domain.QuotaRepository
+	ScheduleSeatRepository       domain.ScheduleSeatRepository
+	TicketRepository             domain.TicketRepository
+	ScheduleRepository           domain.ScheduleRepository
+	SeatLayoutRepository         domain.SeatLayoutRepository
+	BookingChangeRepository      domain.BookingChangeRepository
+	CancellationPolicyRepository domain.CancellationPolicyRepository
+	RefundRepository             domain.RefundRepository
+	FeeComponentRepository       domain.FeeComponentRepository
+	AddonStockRepository         domain.AddonStockRepository
+	BookingAddonRepository       domain.BookingAddonRepository
+	OutboxRepository             domain.OutboxRepository
+	Outbox                       *OutboxUsecase
+	TokenUtil                    token.TokenUtil
 }
 
 func NewBookingUsecase(
 	transactor transact.Transactor,
 	booking_repository domain.BookingRepository,
 	quota_repository domain.QuotaRepository,
+	schedule_seat_repository domain.ScheduleSeatRepository,
+	ticket_repository domain.TicketRepository,
+	schedule_repository domain.ScheduleRepository,
+	seat_layout_repository domain.SeatLayoutRepository,
+	booking_change_repository domain.BookingChangeRepository,
+	cancellation_policy_repository domain.CancellationPolicyRepository,
+	refund_repository domain.RefundRepository,
+	fee_component_repository domain.FeeComponentRepository,
+	addon_stock_repository domain.AddonStockRepository,
+	booking_addon_repository domain.BookingAddonRepository,
+	outbox_repository domain.OutboxRepository,
+	outbox *OutboxUsecase,
+	token_util token.TokenUtil,
 ) *BookingUsecase {
 	return &BookingUsecase{
-		Transactor:        transactor,
-		BookingRepository: booking_repository,
-		QuotaRepository:   quota_repository,
+		Transactor:                   transactor,
+		BookingRepository:            booking_repository,
+		QuotaRepository:              quota_repository,
+		ScheduleSeatRepository:       schedule_seat_repository,
+		TicketRepository:             ticket_repository,
+		ScheduleRepository:           schedule_repository,
+		SeatLayoutRepository:         seat_layout_repository,
+		BookingChangeRepository:      booking_change_repository,
+		CancellationPolicyRepository: cancellation_policy_repository,
+		RefundRepository:             refund_repository,
+		FeeComponentRepository:       fee_component_repository,
+		AddonStockRepository:         addon_stock_repository,
+		BookingAddonRepository:       booking_addon_repository,
+		OutboxRepository:             outbox_repository,
+		Outbox:                       outbox,
+		TokenUtil:                    token_util,
 	}
 }
 
@@ -91,6 +135,31 @@ func (uc *BookingUsecase) GetBookingByID(ctx context.Context, id uint) (*domain.
 	return booking, nil
 }
 
+// GetDocument renders the e-tickets or the invoice of a booking as a PDF, for
+// counter staff printing them.
+func (uc *BookingUsecase) GetDocument(ctx context.Context, id uint, document string) (*domain.EmailAttachment, error) {
+	var booking *domain.Booking
+	var tickets []*domain.Ticket
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		booking, err = uc.BookingRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking == nil {
+			return errs.ErrNotFound
+		}
+		tickets, err = uc.TicketRepository.FindByBookingID(ctx, tx, booking.ID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve tickets: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get booking document: %w", err)
+	}
+	return bookingDocument(uc.TokenUtil, booking, tickets, document)
+}
+
 func (uc *BookingUsecase) GetBookingByOrderID(ctx context.Context, orderID string) (*domain.Booking, error) {
 	var err error
 	var booking *domain.Booking
@@ -136,8 +205,13 @@ func (uc *BookingUsecase) UpdateBooking(ctx context.Context, e *domain.Booking)
 	})
 }
 
-func (uc *BookingUsecase) RefundBooking(ctx context.Context, orderId string, email, IdNumber string) error {
-	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+// RefundBooking cancels a paid booking and refunds every ticket according to
+// the cancellation policy of its route and class. The refunds are written to
+// the refund ledger and mailed to the customer.
+func (uc *BookingUsecase) RefundBooking(ctx context.Context, orderId string, email, IdNumber string) ([]*domain.Refund, error) {
+	var refunds []*domain.Refund
+	var notice *domain.Outbox
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
 		booking, err := uc.BookingRepository.FindByOrderID(ctx, tx, orderId)
 		if err != nil {
 			return fmt.Errorf("failed to get booking: %w", err)
@@ -146,20 +220,42 @@ func (uc *BookingUsecase) RefundBooking(ctx context.Context, orderId string, ema
 			return errs.ErrNotFound
 		}
 
-		// Validate customer data without revealing which field is wrong
+		// Validate customer data without revealing which field is wrong, or
+		// that the order exists
 		if booking.Email != email ||
 			booking.IDNumber != IdNumber {
-			return fmt.Errorf("customer information does not match")
+			return fmt.Errorf("customer information does not match: %w", errs.ErrNotFound)
 		}
 
 		// Check if booking can be refunded
 		if booking.Status != "PAID" {
-			return fmt.Errorf("booking is not eligible for refund")
+			return fmt.Errorf("booking is not eligible for refund: %w", errs.ErrConflict)
 		}
 
 		// Check if tickets exist
 		if len(booking.Tickets) == 0 {
-			return fmt.Errorf("no tickets found for this booking")
+			return fmt.Errorf("no tickets found for this booking: %w", errs.ErrConflict)
+		}
+
+		// Tickets an operator cancellation already refunded are left out, their
+		// quota, seats and add-ons went back then
+		refunded, err := uc.RefundRepository.FindByBookingID(ctx, tx, booking.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get refunds: %w", err)
+		}
+		all, tickets, addons := openTickets(booking, refunded)
+		if len(tickets) == 0 {
+			return fmt.Errorf("every ticket of the booking was already refunded: %w", errs.ErrConflict)
+		}
+
+		refunds, err = uc.computeRefunds(ctx, tx, tickets, time.Now())
+		if err != nil {
+			return err
+		}
+		discountRefunds(refunds, all, booking.Discount)
+		refundCharges(refunds, booking.Fees, addons)
+		if err := uc.RefundRepository.InsertBulk(ctx, tx, refunds); err != nil {
+			return fmt.Errorf("failed to record refunds: %w", err)
 		}
 
 		// Update booking status to refunded
@@ -168,35 +264,375 @@ func (uc *BookingUsecase) RefundBooking(ctx context.Context, orderId string, ema
 			return fmt.Errorf("failed to process refund")
 		}
 
-		restored := make(map[uint]bool)
-		for _, ticket := range booking.Tickets {
-			// Skip if already processed this class
-			if restored[ticket.ClassID] {
+		if err := restoreTickets(ctx, tx, uc.QuotaRepository, tickets); err != nil {
+			return fmt.Errorf("failed to restore quota: %w", err)
+		}
+		if err := releaseTicketSeats(ctx, tx, uc.ScheduleSeatRepository, tickets); err != nil {
+			return err
+		}
+		if err := restoreBookingAddons(ctx, tx, uc.AddonStockRepository, addons); err != nil {
+			return err
+		}
+
+		notice, err = enqueueEmail(ctx, tx, uc.OutboxRepository, booking.OrderID, booking.Email, "Booking Cancelled - Refund Details", templates.RefundEmail(booking, refunds))
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	_ = uc.Outbox.Dispatch(ctx, notice)
+
+	return refunds, nil
+}
+
+// openTickets splits the tickets of a booking into all of them and those
+// still to refund: not cancelled at the gate and without a refund entry. The
+// add-ons returned are the ones of the tickets still to refund, and the
+// booking's own add-ons on the legs they travel.
+func openTickets(booking *domain.Booking, refunded []*domain.Refund) ([]*domain.Ticket, []*domain.Ticket, []domain.BookingAddon) {
+	done := make(map[uint]bool, len(refunded))
+	for _, refund := range refunded {
+		done[refund.TicketID] = true
+	}
+
+	all := make([]*domain.Ticket, len(booking.Tickets))
+	var open []*domain.Ticket
+	openIDs := make(map[uint]bool)
+	openLegs := make(map[uint]bool)
+	for i := range booking.Tickets {
+		ticket := &booking.Tickets[i]
+		all[i] = ticket
+		if done[ticket.ID] || storedBoardingStatus(ticket) == enum.BoardingCancelled.String() {
+			continue
+		}
+		open = append(open, ticket)
+		openIDs[ticket.ID] = true
+		openLegs[ticket.ScheduleID] = true
+	}
+
+	var addons []domain.BookingAddon
+	for _, addon := range booking.Addons {
+		if addon.TicketID != nil && openIDs[*addon.TicketID] || addon.TicketID == nil && openLegs[addon.ScheduleID] {
+			addons = append(addons, addon)
+		}
+	}
+	return all, open, addons
+}
+
+// computeRefunds applies the cancellation policy of each ticket's leg and
+// class. Schedules and policies are looked up once per leg and class.
+func (uc *BookingUsecase) computeRefunds(ctx context.Context, conn gotann.Connection, tickets []*domain.Ticket, now time.Time) ([]*domain.Refund, error) {
+	schedules := make(map[uint]*domain.Schedule)
+	policies := make(map[legClass]*domain.CancellationPolicy)
+	refunds := make([]*domain.Refund, len(tickets))
+	for i, ticket := range tickets {
+		schedule, ok := schedules[ticket.ScheduleID]
+		if !ok {
+			var err error
+			schedule, err = uc.ScheduleRepository.FindByID(ctx, conn, ticket.ScheduleID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get schedule: %w", err)
+			}
+			if schedule == nil {
+				return nil, fmt.Errorf("schedule %d: %w", ticket.ScheduleID, errs.ErrNotFound)
+			}
+			schedules[ticket.ScheduleID] = schedule
+		}
+
+		key := legClass{ScheduleID: ticket.ScheduleID, ClassID: ticket.ClassID}
+		policy, ok := policies[key]
+		if !ok {
+			var err error
+			policy, err = uc.CancellationPolicyRepository.FindApplicable(ctx, conn, schedule.DepartureHarborID, schedule.ArrivalHarborID, ticket.ClassID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cancellation policy: %w", err)
+			}
+			if policy == nil {
+				policy = &defaultCancellationPolicy
+			}
+			policies[key] = policy
+		}
+
+		refunds[i] = ticketRefund(policy, ticket, schedule.DepartureDatetime, now)
+	}
+	return refunds, nil
+}
+
+// RescheduleBooking moves the tickets of one leg of a paid booking to another
+// departure on the same route. Quota and seats are taken on the new departure
+// and given back on the old one. A higher fare is charged through a new Tripay
+// transaction, and the old departure is kept until it is paid, see
+// HandleChangePayment; a lower one is kept as credit on the booking. Every
+// move is recorded as a BookingChange.
+func (uc *BookingUsecase) RescheduleBooking(ctx context.Context, bookingID, fromScheduleID, toScheduleID uint, paymentMethod string) (*domain.BookingChange, *domain.Transaction, error) {
+	var change *domain.BookingChange
+	var charge *domain.Outbox
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		booking, err := uc.BookingRepository.FindByID(ctx, tx, bookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking == nil {
+			return errs.ErrNotFound
+		}
+		if booking.Status != enum.BookingPaid.String() {
+			return fmt.Errorf("booking is %s: %w", booking.Status, errs.ErrConflict)
+		}
+		for _, pending := range booking.Changes {
+			if pending.Status == enum.BookingChangeAwaitingPayment.String() {
+				return fmt.Errorf("change %d awaits payment: %w", pending.ID, errs.ErrConflict)
+			}
+		}
+		if fromScheduleID == 0 {
+			fromScheduleID = booking.ScheduleID
+		}
+		if fromScheduleID == toScheduleID {
+			return fmt.Errorf("booking is already on schedule %d: %w", toScheduleID, errs.ErrBadRequest)
+		}
+
+		var tickets []*domain.Ticket
+		for i := range booking.Tickets {
+			ticket := &booking.Tickets[i]
+			if ticket.ScheduleID != fromScheduleID {
 				continue
 			}
+			if ticket.IsCheckedIn {
+				return fmt.Errorf("ticket %s already checked in: %w", ticket.TicketCode, errs.ErrConflict)
+			}
+			tickets = append(tickets, ticket)
+		}
+		if len(tickets) == 0 {
+			return fmt.Errorf("booking has no tickets on schedule %d: %w", fromScheduleID, errs.ErrBadRequest)
+		}
+
+		from, err := uc.ScheduleRepository.FindByID(ctx, tx, fromScheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		to, err := uc.ScheduleRepository.FindByID(ctx, tx, toScheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if from == nil || to == nil {
+			return errs.ErrNotFound
+		}
+		if !from.DepartureDatetime.After(time.Now()) {
+			return fmt.Errorf("schedule %d already departed: %w", from.ID, errs.ErrConflict)
+		}
+		if !to.DepartureDatetime.After(time.Now()) {
+			return fmt.Errorf("schedule %d already departed: %w", to.ID, errs.ErrBadRequest)
+		}
+		if from.DepartureHarborID != to.DepartureHarborID || from.ArrivalHarborID != to.ArrivalHarborID {
+			return fmt.Errorf("schedule %d is on another route: %w", to.ID, errs.ErrBadRequest)
+		}
+
+		quotas, err := uc.QuotaRepository.FindByScheduleID(ctx, tx, to.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get quotas: %w", err)
+		}
+		quotaByClass := make(map[uint]*domain.Quota, len(quotas))
+		for _, quota := range quotas {
+			quotaByClass[quota.ClassID] = quota
+		}
+
+		// Add-ons of the leg move along at the price paid for them
+		var addons []*domain.BookingAddon
+		var movedAddons []domain.BookingAddon
+		for i := range booking.Addons {
+			addon := &booking.Addons[i]
+			if addon.ScheduleID != fromScheduleID {
+				continue
+			}
+			addons = append(addons, addon)
+			movedAddons = append(movedAddons, *addon)
+		}
+		previous := newChangePrevious(booking, tickets, addons)
+
+		// Price the tickets on the new departure's fares, which may also
+		// change whether an infant rides on a lap
+		fares := make([]float64, len(tickets))
+		for i, ticket := range tickets {
+			quota, ok := quotaByClass[ticket.ClassID]
+			if !ok {
+				return fmt.Errorf("class %d is not sold on schedule %d: %w", ticket.ClassID, to.ID, errs.ErrBadRequest)
+			}
+			ticket.FareCategory, fares[i], err = ticketFare(quota, ticket)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Fees follow the new fares; what the tickets paid so far counts
+		// against the difference
+		feeComponents, err := uc.FeeComponentRepository.FindActive(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to get fee components: %w", err)
+		}
+		paidFees := make(map[uint]float64)
+		for _, fee := range booking.Fees {
+			paidFees[fee.TicketID] += fee.Amount
+		}
+
+		seated, err := ensureScheduleSeats(ctx, tx, uc.SeatLayoutRepository, uc.ScheduleSeatRepository, to)
+		if err != nil {
+			return err
+		}
+		seats, err := takeSeats(ctx, tx, uc.ScheduleSeatRepository, to.ID, seated, tickets)
+		if err != nil {
+			return err
+		}
 
-			quota, err := uc.QuotaRepository.FindByScheduleIDAndClassID(ctx, tx, booking.ScheduleID, ticket.ClassID)
-			if err == nil && quota != nil {
-				// Count tickets for this class
-				count := 0
-				for _, t := range booking.Tickets {
-					if t.ClassID == ticket.ClassID {
-						count++
-					}
-				}
+		var difference float64
+		details := make([]string, len(tickets))
+		fees := make([][]domain.TicketFee, len(tickets))
+		for i, ticket := range tickets {
+			oldSeat := "-"
+			if ticket.SeatNumber != nil {
+				oldSeat = *ticket.SeatNumber
+			}
+			ticket.SeatNumber = nil
+			newSeat := "-"
+			if seats[i] != nil {
+				ticket.SeatNumber = &seats[i].SeatNumber
+				newSeat = seats[i].SeatNumber
+			}
+			oldPrice := ticket.Price
+			ticket.ScheduleID = to.ID
+			ticket.Price = fares[i]
+			fees[i] = ticketFees(feeComponents, to, ticket)
+			details[i] = fmt.Sprintf("%s: fare %.0f -> %.0f, fees %.0f -> %.0f, seat %s -> %s", ticket.TicketCode, oldPrice, fares[i], paidFees[ticket.ID], feeTotal(fees[i]), oldSeat, newSeat)
+			difference += fares[i] - oldPrice + feeTotal(fees[i]) - paidFees[ticket.ID]
+			ticket.Fees = nil
+		}
+
+		// Take quota on the new departure, then record the move on the tickets
+		if err := sellTickets(ctx, tx, uc.QuotaRepository, tickets); err != nil {
+			return err
+		}
+		if err := uc.TicketRepository.UpdateBulk(ctx, tx, tickets); err != nil {
+			return fmt.Errorf("failed to move tickets: %w", err)
+		}
+		for i, ticket := range tickets {
+			if err := uc.TicketRepository.ReplaceFees(ctx, tx, ticket.ID, fees[i]); err != nil {
+				return fmt.Errorf("failed to reprice ticket fees: %w", err)
+			}
+		}
+		if err := sellSeats(ctx, tx, uc.ScheduleSeatRepository, tickets, seats); err != nil {
+			return err
+		}
+		if len(addons) > 0 {
+			for i, addon := range addons {
+				addon.ScheduleID = to.ID
+				movedAddons[i].ScheduleID = to.ID
+			}
+			if err := sellBookingAddons(ctx, tx, uc.AddonStockRepository, movedAddons); err != nil {
+				return err
+			}
+			if err := uc.BookingAddonRepository.UpdateBulk(ctx, tx, addons); err != nil {
+				return fmt.Errorf("failed to move add-ons: %w", err)
+			}
+			for _, addon := range groupAddons(movedAddons) {
+				details = append(details, fmt.Sprintf("%s x%d: moved", addon.Name, addon.Quantity))
+			}
+		}
+
+		change = &domain.BookingChange{
+			BookingID:      booking.ID,
+			Type:           enum.BookingChangeReschedule.String(),
+			FromScheduleID: from.ID,
+			ToScheduleID:   to.ID,
+			FareDifference: difference,
+			Settlement:     enum.FareSettlementNone.String(),
+			Status:         enum.BookingChangeCompleted.String(),
+			Detail:         strings.Join(details, "\n"),
+		}
+		switch {
+		case difference > 0:
+			if paymentMethod == "" {
+				return fmt.Errorf("payment method required for a fare difference of %.0f: %w", difference, errs.ErrBadRequest)
+			}
+			change.Settlement = enum.FareSettlementCharge.String()
+			change.Status = enum.BookingChangeAwaitingPayment.String()
+		case difference < 0:
+			change.Settlement = enum.FareSettlementCredit.String()
+			booking.Credit -= difference
+		}
+
+		// The old departure is given back once the change is paid for, until
+		// then a failed charge can still move the tickets back onto it
+		if change.Status == enum.BookingChangeAwaitingPayment.String() {
+			change.Previous, err = previous.encode()
+			if err != nil {
+				return err
+			}
+		} else if err := vacateLeg(ctx, tx, uc.QuotaRepository, uc.ScheduleSeatRepository, uc.AddonStockRepository, from.ID, previous.before(from.ID, tickets), addons); err != nil {
+			return err
+		}
+		if err := uc.BookingChangeRepository.Insert(ctx, tx, change); err != nil {
+			return fmt.Errorf("failed to record booking change: %w", err)
+		}
 
-				quota.Quota += count
-				if err := uc.QuotaRepository.Update(ctx, tx, quota); err != nil {
-					fmt.Printf("Warning: failed to restore quota for class %d: %v\n", ticket.ClassID, err)
-				}
-				restored[ticket.ClassID] = true
-			} else if err != nil {
-				fmt.Printf("Warning: failed to find quota for class %d: %v\n", ticket.ClassID, err)
+		if difference > 0 {
+			charge, err = uc.chargeDifference(ctx, tx, booking, change, paymentMethod)
+			if err != nil {
+				return err
 			}
 		}
 
+		if booking.ScheduleID == from.ID {
+			booking.ScheduleID = to.ID
+		}
+		booking.Schedule = domain.Schedule{}
+		booking.Changes = nil
+		booking.Fees = nil
+		booking.Addons = nil
+		if err := uc.BookingRepository.Update(ctx, tx, booking); err != nil {
+			return fmt.Errorf("failed to update booking: %w", err)
+		}
+		change.FromSchedule = *from
+		change.ToSchedule = *to
 		return nil
-	})
+	}); err != nil {
+		return nil, nil, fmt.Errorf("failed to reschedule booking: %w", err)
+	}
+
+	// The tickets are moved either way; a failed charge is retried by the outbox job
+	_ = uc.Outbox.Dispatch(ctx, charge)
+	return change, paymentResult(charge), nil
+}
+
+// chargeDifference queues a Tripay transaction for the extra fare of a change.
+// Its merchant ref points back at the change so the callback can settle it.
+func (uc *BookingUsecase) chargeDifference(ctx context.Context, conn gotann.Connection, booking *domain.Booking, change *domain.BookingChange, paymentMethod string) (*domain.Outbox, error) {
+	amount := int(change.FareDifference)
+	merchantRef := fmt.Sprintf("%s-RS%d", booking.OrderID, change.ID)
+	change.MerchantRef = &merchantRef
+	if err := uc.BookingChangeRepository.Update(ctx, conn, change); err != nil {
+		return nil, fmt.Errorf("failed to update booking change: %w", err)
+	}
+
+	return enqueuePayment(ctx, conn, uc.OutboxRepository, &domain.PaymentOutboxPayload{
+		BookingID:       booking.ID,
+		BookingChangeID: &change.ID,
+		Request: domain.TransactionRequest{
+			Method:        paymentMethod,
+			Amount:        amount,
+			CustomerName:  booking.CustomerName,
+			CustomerEmail: booking.Email,
+			CustomerPhone: booking.PhoneNumber,
+			MerchantRef:   merchantRef,
+			OrderItems: []domain.OrderItem{{
+				SKU:      enum.BookingChangeReschedule.String(),
+				Name:     fmt.Sprintf("Reschedule %s", booking.OrderID),
+				Price:    amount,
+				Quantity: 1,
+				Subtotal: amount,
+			}},
+			CallbackUrl: "https://example.com/callback",
+			ReturnUrl:   "https://example.com/callback",
+			ExpiredTime: int(time.Now().Add(30 * time.Minute).Unix()),
+		},
+	}, outboxMaxAttempts)
 }
 
 func (uc *BookingUsecase) DeleteBooking(ctx context.Context, id uint) error {
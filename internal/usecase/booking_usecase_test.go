@@ -3,27 +3,42 @@ package usecase
 import (
 	"context"
 	"testing"
+	"time"
 
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
 	"eticket-api/internal/domain"
 	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
-func bookingUsecase(t *testing.T) (*BookingUsecase, *mocks.MockBookingRepository, *mocks.MockTransactor) {
+func bookingUsecase(t *testing.T) (*BookingUsecase, *mocks.MockBookingRepository, *mocks.MockScheduleRepository, *mocks.MockCancellationPolicyRepository, *mocks.MockRefundRepository, *mocks.MockQuotaRepository, *mocks.MockScheduleSeatRepository, *mocks.MockOutboxRepository, *mocks.MockMailer, *mocks.MockTransactor) {
 	t.Helper()
 	ctrl := gomock.NewController(t)
 	bookingRepo := mocks.NewMockBookingRepository(ctrl)
 	quotaRepository := mocks.NewMockQuotaRepository(ctrl)
+	scheduleSeatRepo := mocks.NewMockScheduleSeatRepository(ctrl)
+	ticketRepo := mocks.NewMockTicketRepository(ctrl)
+	scheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	seatLayoutRepo := mocks.NewMockSeatLayoutRepository(ctrl)
+	bookingChangeRepo := mocks.NewMockBookingChangeRepository(ctrl)
+	tripayClient := mocks.NewMockTripayClient(ctrl)
+	policyRepo := mocks.NewMockCancellationPolicyRepository(ctrl)
+	refundRepo := mocks.NewMockRefundRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	mailer := mocks.NewMockMailer(ctrl)
 	transactor := mocks.NewMockTransactor(ctrl)
-	uc := NewBookingUsecase(transactor, bookingRepo, quotaRepository)
-	return uc, bookingRepo, transactor
+	outbox := NewOutboxUsecase(transactor, outboxRepo, bookingRepo, bookingChangeRepo, tripayClient, mailer, nil)
+	uc := NewBookingUsecase(transactor, bookingRepo, quotaRepository, scheduleSeatRepo, ticketRepo, scheduleRepo, seatLayoutRepo, bookingChangeRepo, policyRepo, refundRepo, mocks.NewMockFeeComponentRepository(ctrl), mocks.NewMockAddonStockRepository(ctrl), mocks.NewMockBookingAddonRepository(ctrl), outboxRepo, outbox, mocks.NewMockTokenUtil(ctrl))
+	return uc, bookingRepo, scheduleRepo, policyRepo, refundRepo, quotaRepository, scheduleSeatRepo, outboxRepo, mailer, transactor
 }
 
 func TestBookingUsecase_CreateBooking(t *testing.T) {
 	t.Parallel()
-	uc, _, transactor := bookingUsecase(t)
+	uc, _, _, _, _, _, _, _, _, transactor := bookingUsecase(t)
 	tests := []struct {
 		name string
 		mock func()
@@ -56,3 +71,279 @@ func TestBookingUsecase_CreateBooking(t *testing.T) {
 		})
 	}
 }
+
+func TestBookingUsecase_RescheduleBooking(t *testing.T) {
+	t.Parallel()
+	uc, bookingRepo, _, _, _, _, _, _, _, transactor := bookingUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	tests := []struct {
+		name string
+		to   uint
+		mock func()
+		err  error
+	}{
+		{
+			name: "booking not found",
+			to:   2,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+		{
+			name: "booking not paid",
+			to:   2,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Booking{ID: 1, ScheduleID: 1, Status: enum.BookingUnpaid.String()}, nil)
+			},
+			err: errs.ErrConflict,
+		},
+		{
+			name: "same schedule",
+			to:   1,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Booking{ID: 1, ScheduleID: 1, Status: enum.BookingPaid.String()}, nil)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name: "ticket checked in",
+			to:   2,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Booking{
+					ID:         1,
+					ScheduleID: 1,
+					Status:     enum.BookingPaid.String(),
+					Tickets:    []domain.Ticket{{ID: 1, ScheduleID: 1, IsCheckedIn: true}},
+				}, nil)
+			},
+			err: errs.ErrConflict,
+		},
+		{
+			name: "repo error",
+			to:   2,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			_, _, err := uc.RescheduleBooking(context.Background(), 1, 0, tc.to, "")
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestBookingUsecase_RefundBooking(t *testing.T) {
+	t.Parallel()
+	uc, bookingRepo, scheduleRepo, policyRepo, refundRepo, quotaRepo, seatRepo, outboxRepo, mailer, transactor := bookingUsecase(t)
+	addonStockRepo := uc.AddonStockRepository.(*mocks.MockAddonStockRepository)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	bookingID := uint(1)
+	paid := func() *domain.Booking {
+		return &domain.Booking{
+			ID:       bookingID,
+			OrderID:  "ORD-1",
+			Email:    "a@b.c",
+			IDNumber: "123",
+			Status:   enum.BookingPaid.String(),
+			Tickets: []domain.Ticket{
+				{ID: 1, BookingID: &bookingID, ScheduleID: 7, ClassID: 2, Price: 100000},
+				{ID: 2, BookingID: &bookingID, ScheduleID: 7, ClassID: 2, Price: 100000},
+			},
+		}
+	}
+	policy := &domain.CancellationPolicy{
+		ID:   4,
+		Name: "Standard",
+		Rules: []domain.CancellationRule{
+			{MinHoursBefore: 48, RefundPercent: 100},
+			{MinHoursBefore: 6, RefundPercent: 50},
+		},
+	}
+	tests := []struct {
+		name   string
+		mock   func()
+		amount float64
+		err    error
+	}{
+		{
+			name: "partial refund",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD-1").Return(paid(), nil)
+				refundRepo.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), bookingID).Return(nil, nil)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(7)).Return(&domain.Schedule{
+					ID:                7,
+					DepartureHarborID: 1,
+					ArrivalHarborID:   2,
+					DepartureDatetime: time.Now().Add(12 * time.Hour),
+				}, nil)
+				policyRepo.EXPECT().FindApplicable(gomock.Any(), gomock.Any(), uint(1), uint(2), uint(2)).Return(policy, nil)
+				refundRepo.EXPECT().InsertBulk(gomock.Any(), gomock.Any(), gomock.Len(2)).Return(nil)
+				bookingRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(7), uint(2), 2).Return(nil)
+				seatRepo.EXPECT().ReleaseByTicketIDs(gomock.Any(), gomock.Any(), []uint{1, 2}).Return(nil)
+				outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, event *domain.Outbox) error {
+						require.Equal(t, "ORD-1", event.AggregateID)
+						event.ID = 5
+						return nil
+					},
+				)
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).Times(2)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), uint(5), gomock.Any()).Return(int64(1), nil)
+				mailer.EXPECT().Send("a@b.c", gomock.Any(), gomock.Any()).Return(nil)
+				outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			amount: 100000,
+			err:    nil,
+		},
+		{
+			name: "after an operator cancelled one leg",
+			mock: func() {
+				// Ticket 1 was on a departure the operator cancelled and refunded
+				booking := paid()
+				booking.Tickets[0].ScheduleID = 8
+				booking.Tickets[0].BoardingStatus = enum.BoardingCancelled.String()
+				first, second := uint(1), uint(2)
+				booking.Addons = []domain.BookingAddon{
+					{ScheduleID: 8, AddonID: 3, TicketID: &first, Price: 20000, Quantity: 1},
+					{ScheduleID: 7, AddonID: 3, TicketID: &second, Price: 20000, Quantity: 1},
+					{ScheduleID: 7, AddonID: 4, Price: 10000, Quantity: 2},
+				}
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD-1").Return(booking, nil)
+				refundRepo.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), bookingID).Return([]*domain.Refund{{BookingID: bookingID, TicketID: 1}}, nil)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(7)).Return(&domain.Schedule{
+					ID:                7,
+					DepartureHarborID: 1,
+					ArrivalHarborID:   2,
+					DepartureDatetime: time.Now().Add(72 * time.Hour),
+				}, nil)
+				policyRepo.EXPECT().FindApplicable(gomock.Any(), gomock.Any(), uint(1), uint(2), uint(2)).Return(policy, nil)
+				refundRepo.EXPECT().InsertBulk(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, refunds []*domain.Refund) error {
+						require.Len(t, refunds, 1)
+						require.Equal(t, uint(2), refunds[0].TicketID)
+						return nil
+					},
+				)
+				bookingRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(7), uint(2), 1).Return(nil)
+				seatRepo.EXPECT().ReleaseByTicketIDs(gomock.Any(), gomock.Any(), []uint{2}).Return(nil)
+				addonStockRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(7), uint(3), 1).Return(nil)
+				addonStockRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(7), uint(4), 2).Return(nil)
+				outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+			},
+			amount: 140000,
+		},
+		{
+			name: "fees and add-ons at the policy percentage",
+			mock: func() {
+				booking := paid()
+				first := uint(1)
+				booking.Fees = []domain.TicketFee{
+					{TicketID: 1, Amount: 5000},
+					{TicketID: 2, Amount: 5000},
+				}
+				booking.Addons = []domain.BookingAddon{
+					{ScheduleID: 7, AddonID: 3, TicketID: &first, Price: 20000, Quantity: 1},
+					{ScheduleID: 7, AddonID: 4, Price: 10000, Quantity: 2},
+				}
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD-1").Return(booking, nil)
+				refundRepo.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), bookingID).Return(nil, nil)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(7)).Return(&domain.Schedule{
+					ID:                7,
+					DepartureHarborID: 1,
+					ArrivalHarborID:   2,
+					DepartureDatetime: time.Now().Add(12 * time.Hour),
+				}, nil)
+				policyRepo.EXPECT().FindApplicable(gomock.Any(), gomock.Any(), uint(1), uint(2), uint(2)).Return(policy, nil)
+				refundRepo.EXPECT().InsertBulk(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, refunds []*domain.Refund) error {
+						// Half of the fare, the fee and both add-ons on the first ticket
+						require.Equal(t, float64(72500), refunds[0].Amount)
+						require.Equal(t, float64(52500), refunds[1].Amount)
+						return nil
+					},
+				)
+				bookingRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(7), uint(2), 2).Return(nil)
+				seatRepo.EXPECT().ReleaseByTicketIDs(gomock.Any(), gomock.Any(), []uint{1, 2}).Return(nil)
+				addonStockRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(7), uint(3), 1).Return(nil)
+				addonStockRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(7), uint(4), 2).Return(nil)
+				outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+			},
+			amount: 125000,
+		},
+		{
+			name: "every ticket already refunded",
+			mock: func() {
+				booking := paid()
+				booking.Tickets[0].BoardingStatus = enum.BoardingCancelled.String()
+				booking.Tickets[1].BoardingStatus = enum.BoardingCancelled.String()
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD-1").Return(booking, nil)
+				refundRepo.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), bookingID).Return(nil, nil)
+			},
+			err: errs.ErrConflict,
+		},
+		{
+			name: "customer information does not match",
+			mock: func() {
+				booking := paid()
+				booking.IDNumber = "456"
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD-1").Return(booking, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+		{
+			name: "not paid",
+			mock: func() {
+				booking := paid()
+				booking.Status = enum.BookingRefund.String()
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD-1").Return(booking, nil)
+			},
+			err: errs.ErrConflict,
+		},
+		{
+			name: "repo error",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			refunds, err := uc.RefundBooking(context.Background(), "ORD-1", "a@b.c", "123")
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			var total float64
+			for _, refund := range refunds {
+				require.Equal(t, bookingID, refund.BookingID)
+				total += refund.Amount
+			}
+			require.Equal(t, tc.amount, total)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+)
+
+type CancellationPolicyUsecase struct {
+	Transactor                   transact.Transactor
+	CancellationPolicyRepository domain.CancellationPolicyRepository
+	HarborRepository             domain.HarborRepository
+	ClassRepository              domain.ClassRepository
+}
+
+func NewCancellationPolicyUsecase(
+	transactor transact.Transactor,
+	cancellation_policy_repository domain.CancellationPolicyRepository,
+	harbor_repository domain.HarborRepository,
+	class_repository domain.ClassRepository,
+) *CancellationPolicyUsecase {
+	return &CancellationPolicyUsecase{
+		Transactor:                   transactor,
+		CancellationPolicyRepository: cancellation_policy_repository,
+		HarborRepository:             harbor_repository,
+		ClassRepository:              class_repository,
+	}
+}
+
+func (uc *CancellationPolicyUsecase) CreateCancellationPolicy(ctx context.Context, e *domain.CancellationPolicy) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if err := uc.checkPolicy(ctx, tx, e); err != nil {
+			return err
+		}
+
+		policy := &domain.CancellationPolicy{
+			Name:              e.Name,
+			DepartureHarborID: e.DepartureHarborID,
+			ArrivalHarborID:   e.ArrivalHarborID,
+			ClassID:           e.ClassID,
+			Rules:             e.Rules,
+		}
+		if err := uc.CancellationPolicyRepository.Insert(ctx, tx, policy); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("failed to create cancellation policy: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *CancellationPolicyUsecase) ListCancellationPolicies(ctx context.Context, limit, offset int, sort, search string) ([]*domain.CancellationPolicy, int, error) {
+	var err error
+	var total int64
+	var policies []*domain.CancellationPolicy
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		total, err = uc.CancellationPolicyRepository.Count(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to count cancellation policies: %w", err)
+		}
+		policies, err = uc.CancellationPolicyRepository.FindAll(ctx, tx, limit, offset, sort, search)
+		if err != nil {
+			return fmt.Errorf("failed to get all cancellation policies: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to list cancellation policies: %w", err)
+	}
+
+	return policies, int(total), nil
+}
+
+func (uc *CancellationPolicyUsecase) GetCancellationPolicyByID(ctx context.Context, id uint) (*domain.CancellationPolicy, error) {
+	var err error
+	var policy *domain.CancellationPolicy
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		policy, err = uc.CancellationPolicyRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get cancellation policy: %w", err)
+		}
+		if policy == nil {
+			return errs.ErrNotFound
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get cancellation policy by ID: %w", err)
+	}
+	return policy, nil
+}
+
+// UpdateCancellationPolicy replaces the policy's rules. Refunds already in the
+// ledger keep the amounts they were computed with.
+func (uc *CancellationPolicyUsecase) UpdateCancellationPolicy(ctx context.Context, e *domain.CancellationPolicy) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		policy, err := uc.CancellationPolicyRepository.FindByID(ctx, tx, e.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find cancellation policy: %w", err)
+		}
+		if policy == nil {
+			return errs.ErrNotFound
+		}
+		if err := uc.checkPolicy(ctx, tx, e); err != nil {
+			return err
+		}
+
+		policy.Name = e.Name
+		policy.DepartureHarborID = e.DepartureHarborID
+		policy.ArrivalHarborID = e.ArrivalHarborID
+		policy.ClassID = e.ClassID
+		policy.Rules = e.Rules
+		policy.DepartureHarbor = nil
+		policy.ArrivalHarbor = nil
+		policy.Class = nil
+
+		if err := uc.CancellationPolicyRepository.Update(ctx, tx, policy); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("failed to update cancellation policy: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *CancellationPolicyUsecase) DeleteCancellationPolicy(ctx context.Context, id uint) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		policy, err := uc.CancellationPolicyRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get cancellation policy: %w", err)
+		}
+		if policy == nil {
+			return errs.ErrNotFound
+		}
+
+		if err := uc.CancellationPolicyRepository.Delete(ctx, tx, policy); err != nil {
+			return fmt.Errorf("failed to delete cancellation policy: %w", err)
+		}
+		return nil
+	})
+}
+
+// checkPolicy validates the policy's scope and rules. A route needs both
+// harbors and every rule needs its own notice period.
+func (uc *CancellationPolicyUsecase) checkPolicy(ctx context.Context, conn gotann.Connection, policy *domain.CancellationPolicy) error {
+	if (policy.DepartureHarborID == nil) != (policy.ArrivalHarborID == nil) {
+		return fmt.Errorf("route needs both departure and arrival harbor: %w", errs.ErrBadRequest)
+	}
+	hours := make(map[int]bool, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		if rule.MinHoursBefore < 0 || rule.RefundPercent < 0 || rule.RefundPercent > 100 {
+			return fmt.Errorf("invalid rule for %d hours: %w", rule.MinHoursBefore, errs.ErrBadRequest)
+		}
+		if hours[rule.MinHoursBefore] {
+			return fmt.Errorf("more than one rule for %d hours: %w", rule.MinHoursBefore, errs.ErrBadRequest)
+		}
+		hours[rule.MinHoursBefore] = true
+	}
+
+	for _, harborID := range []*uint{policy.DepartureHarborID, policy.ArrivalHarborID} {
+		if harborID == nil {
+			continue
+		}
+		harbor, err := uc.HarborRepository.FindByID(ctx, conn, *harborID)
+		if err != nil {
+			return fmt.Errorf("failed to get harbor: %w", err)
+		}
+		if harbor == nil {
+			return fmt.Errorf("harbor %d: %w", *harborID, errs.ErrNotFound)
+		}
+	}
+	if policy.ClassID != nil {
+		class, err := uc.ClassRepository.FindByID(ctx, conn, *policy.ClassID)
+		if err != nil {
+			return fmt.Errorf("failed to get class: %w", err)
+		}
+		if class == nil {
+			return fmt.Errorf("class %d: %w", *policy.ClassID, errs.ErrNotFound)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func cancellationPolicyUsecase(t *testing.T) (*CancellationPolicyUsecase, *mocks.MockCancellationPolicyRepository, *mocks.MockHarborRepository, *mocks.MockClassRepository, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	policyRepo := mocks.NewMockCancellationPolicyRepository(ctrl)
+	harborRepo := mocks.NewMockHarborRepository(ctrl)
+	classRepo := mocks.NewMockClassRepository(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	uc := NewCancellationPolicyUsecase(transactor, policyRepo, harborRepo, classRepo)
+	return uc, policyRepo, harborRepo, classRepo, transactor
+}
+
+func TestCancellationPolicyUsecase_CreateCancellationPolicy(t *testing.T) {
+	t.Parallel()
+	uc, policyRepo, harborRepo, classRepo, transactor := cancellationPolicyUsecase(t)
+	departure, arrival, class := uint(1), uint(2), uint(3)
+	rules := []domain.CancellationRule{{MinHoursBefore: 48, RefundPercent: 100}, {MinHoursBefore: 6, RefundPercent: 50}}
+	tests := []struct {
+		name   string
+		policy *domain.CancellationPolicy
+		mock   func()
+		err    error
+	}{
+		{
+			name:   "success",
+			policy: &domain.CancellationPolicy{Name: "Standard", DepartureHarborID: &departure, ArrivalHarborID: &arrival, ClassID: &class, Rules: rules},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+						return fn(nil)
+					},
+				)
+				harborRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Harbor{ID: 1}, nil)
+				harborRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(2)).Return(&domain.Harbor{ID: 2}, nil)
+				classRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(3)).Return(&domain.Class{ID: 3}, nil)
+				policyRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name:   "route without arrival harbor",
+			policy: &domain.CancellationPolicy{Name: "Standard", DepartureHarborID: &departure, Rules: rules},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+						return fn(nil)
+					},
+				)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name:   "duplicate notice period",
+			policy: &domain.CancellationPolicy{Name: "Standard", Rules: append(rules, domain.CancellationRule{MinHoursBefore: 6, RefundPercent: 25})},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+						return fn(nil)
+					},
+				)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name:   "repo error",
+			policy: &domain.CancellationPolicy{Name: "Standard", Rules: rules},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.CreateCancellationPolicy(context.Background(), tc.policy)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -6,8 +6,6 @@ import (
 	"eticket-api/internal/client"
 	enum "eticket-api/internal/common/enums"
 	errs "eticket-api/internal/common/errors"
-	"eticket-api/internal/common/mailer"
-	"eticket-api/internal/common/templates"
 	"eticket-api/internal/common/transact"
 	"eticket-api/internal/common/utils"
 	"eticket-api/internal/domain"
@@ -15,21 +13,37 @@ import (
 	"eticket-api/internal/model"
 	"eticket-api/pkg/gotann"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const (
+	claimSessionTTL         = 16 * time.Minute
+	claimSessionExtension   = 10 * time.Minute
+	claimSessionMaxLifetime = 30 * time.Minute // extensions never push expiry past this from creation
+	claimSessionExpiryBatch = 50
+)
+
 type ClaimSessionUsecase struct {
-	Transactor             transact.Transactor
-	ClaimSessionRepository domain.ClaimSessionRepository
-	ClaimItemRepository    domain.ClaimItemRepository // Assuming you have a ClaimItemRepository
-	TicketRepository       domain.TicketRepository
-	ScheduleRepository     domain.ScheduleRepository
-	BookingRepository      domain.BookingRepository
-	QuotaRepository        domain.QuotaRepository
-	TripayClient           domain.TripayClient
-	Mailer                 mailer.Mailer // Assuming you have a Mailer interface for sending emails
+	Transactor                    transact.Transactor
+	ClaimSessionRepository        domain.ClaimSessionRepository
+	ClaimItemRepository           domain.ClaimItemRepository // Assuming you have a ClaimItemRepository
+	TicketRepository              domain.TicketRepository
+	ScheduleRepository            domain.ScheduleRepository
+	BookingRepository             domain.BookingRepository
+	QuotaRepository               domain.QuotaRepository
+	SeatLayoutRepository          domain.SeatLayoutRepository
+	ScheduleSeatRepository        domain.ScheduleSeatRepository
+	PromotionRepository           domain.PromotionRepository
+	PromotionRedemptionRepository domain.PromotionRedemptionRepository
+	FeeComponentRepository        domain.FeeComponentRepository
+	AddonStockRepository          domain.AddonStockRepository
+	BookingAddonRepository        domain.BookingAddonRepository
+	OutboxRepository              domain.OutboxRepository
+	Outbox                        *OutboxUsecase
 }
 
 func NewClaimSessionUsecase(
@@ -40,19 +54,33 @@ func NewClaimSessionUsecase(
 	schedule_repository domain.ScheduleRepository,
 	booking_repository domain.BookingRepository,
 	quota_repository domain.QuotaRepository,
-	tripay_client domain.TripayClient,
-	mailer mailer.Mailer, // Assuming you have a Mailer interface for sending emails
+	seat_layout_repository domain.SeatLayoutRepository,
+	schedule_seat_repository domain.ScheduleSeatRepository,
+	promotion_repository domain.PromotionRepository,
+	promotion_redemption_repository domain.PromotionRedemptionRepository,
+	fee_component_repository domain.FeeComponentRepository,
+	addon_stock_repository domain.AddonStockRepository,
+	booking_addon_repository domain.BookingAddonRepository,
+	outbox_repository domain.OutboxRepository,
+	outbox *OutboxUsecase,
 ) *ClaimSessionUsecase {
 	return &ClaimSessionUsecase{
-		Transactor:             transactor,
-		ClaimSessionRepository: claim_session_repository,
-		ClaimItemRepository:    claim_item_repository,
-		TicketRepository:       ticket_repository,
-		ScheduleRepository:     schedule_repository,
-		BookingRepository:      booking_repository,
-		QuotaRepository:        quota_repository,
-		TripayClient:           tripay_client,
-		Mailer:                 mailer, // Initialize the Mailer
+		Transactor:                    transactor,
+		ClaimSessionRepository:        claim_session_repository,
+		ClaimItemRepository:           claim_item_repository,
+		TicketRepository:              ticket_repository,
+		ScheduleRepository:            schedule_repository,
+		BookingRepository:             booking_repository,
+		QuotaRepository:               quota_repository,
+		SeatLayoutRepository:          seat_layout_repository,
+		ScheduleSeatRepository:        schedule_seat_repository,
+		PromotionRepository:           promotion_repository,
+		PromotionRedemptionRepository: promotion_redemption_repository,
+		FeeComponentRepository:        fee_component_repository,
+		AddonStockRepository:          addon_stock_repository,
+		BookingAddonRepository:        booking_addon_repository,
+		OutboxRepository:              outbox_repository,
+		Outbox:                        outbox,
 	}
 }
 
@@ -64,74 +92,66 @@ func (uc *ClaimSessionUsecase) LockClaimSession(
 	var claimSession *domain.ClaimSession
 
 	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
-		// Step 1: Validate schedule existence
-		schedule, err := uc.ScheduleRepository.FindByID(ctx, tx, request.ScheduleID)
-		if err != nil {
-			return fmt.Errorf("retrieve schedule: %w", err)
-		}
-		if schedule == nil {
-			return errs.ErrNotFound
-		}
-
-		// Step 2: Fetch and map quotas
-		quotas, err := uc.QuotaRepository.FindByScheduleID(ctx, tx, request.ScheduleID)
-		if err != nil {
-
-			return fmt.Errorf("fetch quotas: %w", err)
+		// Step 1: Validate every leg and fetch its prices per class
+		legs := claimLegs(request.ScheduleID, request.Items)
+		if len(legs) == 0 {
+			return fmt.Errorf("no schedule requested: %w", errs.ErrBadRequest)
 		}
-		quotaByClass := make(map[uint]*domain.Quota, len(quotas))
-		for i := range quotas {
-			quotaByClass[quotas[i].ClassID] = quotas[i]
-		}
-
-		// Step 3: Fetch active claim sessions and accumulate usage
-		sessions, err := uc.ClaimSessionRepository.FindActiveByScheduleID(ctx, tx, request.ScheduleID)
+		quotaByLeg, seated, err := uc.prepareLegs(ctx, tx, legs)
 		if err != nil {
-			return fmt.Errorf("load active sessions: %w", err)
-		}
-		usedByClass := make(map[uint]int64)
-		for i := range sessions {
-			for j := range sessions[i].ClaimItems {
-				item := sessions[i].ClaimItems[j]
-				usedByClass[item.ClassID] += int64(item.Quantity)
-			}
-		}
-
-		// Step 4: Validate quota availability
-		for i := range request.Items {
-			classID := request.Items[i].ClassID
-			quota, exists := quotaByClass[classID]
-			if !exists {
-				return fmt.Errorf("quota not found for class %d", classID)
-			}
-			used := usedByClass[classID]
-			requested := int64(request.Items[i].Quantity)
-			if used+requested > int64(quota.Quota) {
-				return fmt.Errorf("quota exceeded for class %d", classID)
-			}
+			return err
 		}
 
 		claimItems := make([]domain.ClaimItem, len(request.Items))
 		for i, item := range request.Items {
-			quota, exists := quotaByClass[item.ClassID]
+			leg := itemLeg(legs[0], item)
+			quota, exists := quotaByLeg[legClass{ScheduleID: leg, ClassID: item.ClassID}]
 			if !exists {
-				return fmt.Errorf("quota not found for class %d", item.ClassID)
+				return fmt.Errorf("quota not found for schedule %d class %d", leg, item.ClassID)
 			}
 			subtotal := float64(item.Quantity) * quota.Price
 			claimItems[i] = domain.ClaimItem{
-				ClassID:  item.ClassID,
-				Quantity: item.Quantity,
-				Subtotal: subtotal, // <-- set subtotal by quota price
+				ScheduleID: leg,
+				ClassID:    item.ClassID,
+				Quantity:   item.Quantity,
+				QuotaUnits: item.Quantity * laneMeters(quota),
+				Subtotal:   subtotal, // <-- set subtotal by quota price
 			}
 		}
+		if err := checkDriverSeats(claimItems, quotaByLeg); err != nil {
+			return err
+		}
+		addons, err := uc.prepareAddons(ctx, tx, legs, request.Addons)
+		if err != nil {
+			return err
+		}
 
-		// Step 5: Create claim session
+		// Step 2: Hold quota with one conditional update per leg and class,
+		// any leg running out fails the whole lock
+		if err := reserveClaimItems(ctx, tx, uc.QuotaRepository, legs[0], claimItems); err != nil {
+			return err
+		}
+		if err := reserveClaimAddons(ctx, tx, uc.AddonStockRepository, addons); err != nil {
+			return err
+		}
+
+		// Step 3: Create claim session, bound to the first leg
 		claimSession = &domain.ClaimSession{
 			SessionID:  uuid.NewString(),
-			ScheduleID: request.ScheduleID,
+			ScheduleID: legs[0],
 			Status:     enum.ClaimSessionPending.String(),
-			ExpiresAt:  time.Now().Add(16 * time.Minute),
+			ExpiresAt:  time.Now().Add(claimSessionTTL),
 			ClaimItems: claimItems, // attach here
+			Addons:     addons,
+		}
+		var promotion *domain.Promotion
+		if request.PromoCode != "" {
+			promotion, claimSession.Discount, err = uc.redeemPromotion(ctx, tx, request.PromoCode, request.CustomerID, claimItems, quotaByLeg)
+			if err != nil {
+				return err
+			}
+			claimSession.PromotionID = &promotion.ID
+			claimSession.PromoCode = &promotion.Code
 		}
 		if err := uc.ClaimSessionRepository.Insert(ctx, tx, claimSession); err != nil {
 			if errs.IsUniqueConstraintError(err) {
@@ -139,16 +159,33 @@ func (uc *ClaimSessionUsecase) LockClaimSession(
 			}
 			return fmt.Errorf("create session: %w", err)
 		}
+		if promotion != nil {
+			if err := uc.PromotionRedemptionRepository.Insert(ctx, tx, &domain.PromotionRedemption{
+				PromotionID:    promotion.ID,
+				CustomerID:     request.CustomerID,
+				ClaimSessionID: claimSession.ID,
+				Discount:       claimSession.Discount,
+			}); err != nil {
+				return fmt.Errorf("failed to record promo redemption: %w", err)
+			}
+		}
+
+		// Step 4: Hold the chosen seats, auto-assigning the rest
+		if err := holdSeats(ctx, tx, uc.ScheduleSeatRepository, claimSession.ID, seated, claimSeatRequests(legs[0], request.Items)); err != nil {
+			return err
+		}
 
 		return nil
 	}); err != nil {
 		return nil, fmt.Errorf("execute transaction: %w", err)
 	}
 
-	// Step 8: Response
+	// Step 5: Response
 	return &model.TESTReadClaimSessionLockResponse{
 		SessionID: claimSession.SessionID,
 		ExpiresAt: claimSession.ExpiresAt,
+		PromoCode: claimSession.PromoCode,
+		Discount:  claimSession.Discount,
 	}, nil
 }
 
@@ -159,6 +196,7 @@ func (cd *ClaimSessionUsecase) EntryClaimSession(
 ) (*model.TESTReadClaimSessionDataEntryResponse, error) {
 
 	var booking *domain.Booking
+	var payment *domain.Outbox
 	if err := cd.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
 		session, err := cd.ClaimSessionRepository.FindBySessionID(ctx, tx, sessionID)
 		if err != nil {
@@ -172,19 +210,35 @@ func (cd *ClaimSessionUsecase) EntryClaimSession(
 		if session.ExpiresAt.Before(time.Now()) {
 			return errors.New("claim session expired")
 		}
+		if session.Status != enum.ClaimSessionPending.String() {
+			return errs.ErrConflict
+		}
+		// Claim the session first so a concurrent cancel or expiry cannot release it
+		reserved, err := cd.ClaimSessionRepository.UpdateStatus(ctx, tx, session.ID, enum.ClaimSessionPending.String(), enum.ClaimSessionSuccess.String())
+		if err != nil {
+			return fmt.Errorf("failed to reserve session: %w", err)
+		}
+		if reserved == 0 {
+			return errs.ErrConflict
+		}
 		// Generate order ID
 		orderID := utils.GenerateOrderID(session.Schedule.DepartureHarbor.HarborAlias)
 
 		// Create booking
+		deadline := time.Now().Add(bookingPaymentWindow)
 		booking = &domain.Booking{
-			OrderID:      orderID,
-			ScheduleID:   session.ScheduleID,
-			IDType:       request.IDType,
-			IDNumber:     request.IDNumber,
-			PhoneNumber:  request.PhoneNumber,
-			CustomerName: request.CustomerName,
-			Email:        request.Email,
-			Status:       enum.BookingUnpaid.String(),
+			OrderID:         orderID,
+			ScheduleID:      session.ScheduleID,
+			CustomerID:      request.CustomerID,
+			IDType:          request.IDType,
+			IDNumber:        request.IDNumber,
+			PhoneNumber:     request.PhoneNumber,
+			CustomerName:    request.CustomerName,
+			Email:           request.Email,
+			Status:          enum.BookingUnpaid.String(),
+			PaymentDeadline: &deadline,
+			PromoCode:       session.PromoCode,
+			Discount:        session.Discount,
 		}
 		if err := cd.BookingRepository.Insert(ctx, tx, booking); err != nil {
 			if errs.IsUniqueConstraintError(err) {
@@ -193,35 +247,87 @@ func (cd *ClaimSessionUsecase) EntryClaimSession(
 			return fmt.Errorf("create booking failed: %w", err)
 		}
 
-		// Fetch quota and map by ClassID
-		quotas, err := cd.QuotaRepository.FindByScheduleID(ctx, tx, session.ScheduleID)
-		if err != nil {
+		// Fetch quota of every leg and map by leg and ClassID
+		var legs []uint
+		fetched := make(map[uint]bool)
+		quotaByLeg := make(map[legClass]*domain.Quota)
+		for _, item := range session.ClaimItems {
+			leg := itemScheduleID(session.ScheduleID, item)
+			if fetched[leg] {
+				continue
+			}
+			fetched[leg] = true
+			quotas, err := cd.QuotaRepository.FindByScheduleID(ctx, tx, leg)
+			if err != nil {
+
+				return fmt.Errorf("fetch quotas failed: %w", err)
+			}
+			for _, q := range quotas {
+				quotaByLeg[legClass{ScheduleID: leg, ClassID: q.ClassID}] = q
+			}
+			legs = append(legs, leg)
+		}
 
-			return fmt.Errorf("fetch quotas failed: %w", err)
+		heldSeatList, err := cd.ScheduleSeatRepository.FindByClaimSessionID(ctx, tx, session.ID)
+		if err != nil {
+			return fmt.Errorf("fetch held seats failed: %w", err)
 		}
-		quotaByClass := make(map[uint]*domain.Quota, len(quotas))
-		for _, q := range quotas {
-			quotaByClass[q.ClassID] = q
+		held := newHeldSeats(heldSeatList)
+
+		feeComponents, err := cd.FeeComponentRepository.FindActive(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("fetch fee components failed: %w", err)
 		}
 
-		// Organize passenger data by ClassID
-		dataQueue := make(map[uint][]model.TESTClaimSessionTicketDataEntry)
+		// Organize passenger data by leg and ClassID
+		dataQueue := make(map[legClass][]model.TESTClaimSessionTicketDataEntry)
 		for _, d := range request.TicketData {
-			dataQueue[d.ClassID] = append(dataQueue[d.ClassID], d)
+			leg := d.ScheduleID
+			if leg == 0 {
+				leg = session.ScheduleID
+			}
+			key := legClass{ScheduleID: leg, ClassID: d.ClassID}
+			dataQueue[key] = append(dataQueue[key], d)
+		}
+
+		// Infants on a lap come on top of the claimed quantity
+		lapQueue := make(map[legClass][]model.TESTClaimSessionTicketDataEntry)
+		for key, data := range dataQueue {
+			quota, ok := quotaByLeg[key]
+			if !ok || quota.Class.Type != "passenger" {
+				continue
+			}
+			var seated []model.TESTClaimSessionTicketDataEntry
+			for _, d := range data {
+				category, _, err := passengerFare(quota, d.PassengerAge)
+				if err != nil {
+					return err
+				}
+				if category == enum.FareInfant.String() {
+					lapQueue[key] = append(lapQueue[key], d)
+				} else {
+					seated = append(seated, d)
+				}
+			}
+			dataQueue[key] = seated
 		}
 
 		// Build ticket list
 		var tickets []*domain.Ticket
+		var seats []*domain.ScheduleSeat
+		var driverIDs []string
+		var addonIDs [][]uint
 		var amounts float64
 		for _, item := range session.ClaimItems {
-			quota, ok := quotaByClass[item.ClassID]
+			key := legClass{ScheduleID: itemScheduleID(session.ScheduleID, item), ClassID: item.ClassID}
+			quota, ok := quotaByLeg[key]
 			if !ok {
-				return fmt.Errorf("quota not found for class %d", item.ClassID)
+				return fmt.Errorf("quota not found for schedule %d class %d", key.ScheduleID, item.ClassID)
 			}
 
-			classData := dataQueue[item.ClassID]
+			classData := dataQueue[key]
 			if len(classData) < item.Quantity {
-				return fmt.Errorf("not enough ticket data for class %d", item.ClassID)
+				return fmt.Errorf("not enough ticket data for schedule %d class %d", key.ScheduleID, item.ClassID)
 			}
 
 			for i := 0; i < item.Quantity; i++ {
@@ -232,54 +338,139 @@ func (cd *ClaimSessionUsecase) EntryClaimSession(
 
 						return fmt.Errorf("missing passenger info for class %d", item.ClassID)
 					}
-					seat := fmt.Sprintf("%s%d", quota.Class.ClassAlias, quota.Capacity-quota.Quota+1)
-					data.SeatNumber = &seat
 				case "vehicle":
-					if data.LicensePlate == nil || *data.LicensePlate == "" {
-
-						return fmt.Errorf("missing license plate for vehicle class %d", item.ClassID)
+					if err := checkVehicle(quota, data); err != nil {
+						return err
 					}
 				default:
 
 					return fmt.Errorf("unsupported ticket type or missing required fields for class %d", item.ClassID)
 				}
-				tickets = append(tickets, &domain.Ticket{
-					TicketCode:      utils.GenerateTicketReferenceID(), // Unique ticket code
-					BookingID:       &booking.ID,
-					ClassID:         item.ClassID,
-					Price:           quota.Price,
-					Type:            quota.Class.Type,
-					PassengerName:   data.PassengerName,
-					PassengerAge:    data.PassengerAge,
-					Address:         data.Address,
-					PassengerGender: &data.PassengerGender,
-					IDType:          &data.IDType,
-					IDNumber:        &data.IDNumber,
-					SeatNumber:      data.SeatNumber,
-					LicensePlate:    data.LicensePlate,
-					ScheduleID:      session.ScheduleID,
-				})
-				amounts += quota.Price
+
+				// Only seats held by this session can be assigned
+				seat := held.take(key.ScheduleID, item.ClassID, data.SeatNumber)
+				data.SeatNumber = nil
+				if seat != nil {
+					data.SeatNumber = &seat.SeatNumber
+				}
+				ticket, err := entryTicket(quota, booking.ID, key.ScheduleID, data)
+				if err != nil {
+					return err
+				}
+				ticket.Fees = ticketFees(feeComponents, &quota.Schedule, ticket)
+				seats = append(seats, seat)
+				tickets = append(tickets, ticket)
+				driverIDs = append(driverIDs, data.DriverIDNumber)
+				addonIDs = append(addonIDs, data.AddonIDs)
+				amounts += ticket.Price + feeTotal(ticket.Fees)
 			}
 
 			// Trim used data
-			dataQueue[item.ClassID] = classData[item.Quantity:]
+			dataQueue[key] = classData[item.Quantity:]
+
+			for _, data := range lapQueue[key] {
+				if data.PassengerName == "" {
+					return fmt.Errorf("missing infant name for class %d", item.ClassID)
+				}
+				data.SeatNumber = nil
+				ticket, err := entryTicket(quota, booking.ID, key.ScheduleID, data)
+				if err != nil {
+					return err
+				}
+				ticket.Fees = ticketFees(feeComponents, &quota.Schedule, ticket)
+				seats = append(seats, nil)
+				tickets = append(tickets, ticket)
+				driverIDs = append(driverIDs, "")
+				addonIDs = append(addonIDs, data.AddonIDs)
+				amounts += ticket.Price + feeTotal(ticket.Fees)
+			}
+			delete(lapQueue, key)
+		}
+		if err := checkLaps(tickets); err != nil {
+			return err
+		}
+
+		// Every vehicle goes with a driver from the passengers of this booking
+		drivers, err := matchDrivers(tickets, driverIDs)
+		if err != nil {
+			return err
 		}
 
-		// Insert tickets
+		// Insert tickets along with their fees
 		if err := cd.TicketRepository.InsertBulk(ctx, tx, tickets); err != nil {
 			if errs.IsUniqueConstraintError(err) {
 				return errs.ErrConflict
 			}
 			return fmt.Errorf("failed to create tickets: %w", err)
 		}
+		if err := sellSeats(ctx, tx, cd.ScheduleSeatRepository, tickets, seats); err != nil {
+			return err
+		}
+		if len(drivers) > 0 {
+			vehicles := make([]*domain.Ticket, len(drivers))
+			for i, link := range drivers {
+				link.Vehicle.DriverTicketID = &link.Driver.ID
+				vehicles[i] = link.Vehicle
+			}
+			if err := cd.TicketRepository.UpdateBulk(ctx, tx, vehicles); err != nil {
+				return fmt.Errorf("failed to link drivers: %w", err)
+			}
+		}
+
+		// Hand the claimed add-ons to the tickets that asked for them
+		addons, err := bookingAddons(session.Addons, booking.ID, tickets, addonIDs)
+		if err != nil {
+			return err
+		}
+		if len(addons) > 0 {
+			if err := cd.BookingAddonRepository.InsertBulk(ctx, tx, addons); err != nil {
+				return fmt.Errorf("failed to create add-ons: %w", err)
+			}
+		}
 
+		// One Tripay transaction covers every leg; name the leg when there are several
 		orderItems := make([]domain.OrderItem, len(tickets))
+		var fees []domain.TicketFee
 		for i, ticket := range tickets {
 			orderItems[i] = client.TicketToItem(ticket)
+			if len(legs) > 1 {
+				schedule := quotaByLeg[legClass{ScheduleID: ticket.ScheduleID, ClassID: ticket.ClassID}].Schedule
+				orderItems[i].Name = fmt.Sprintf("%s (%s - %s)", orderItems[i].Name, schedule.DepartureHarbor.HarborName, schedule.ArrivalHarbor.HarborName)
+			}
+			fees = append(fees, ticket.Fees...)
+		}
+		for _, fee := range groupFees(fees) {
+			orderItems = append(orderItems, client.FeeToItem(fee))
+		}
+		soldAddons := make([]domain.BookingAddon, len(addons))
+		for i, addon := range addons {
+			soldAddons[i] = *addon
+		}
+		for _, addon := range groupAddons(soldAddons) {
+			orderItems = append(orderItems, client.AddonToItem(addon))
+		}
+		amounts += addonTotal(soldAddons)
+		booking.Discount, err = cd.entryDiscount(ctx, tx, session, tickets, quotaByLeg, amounts)
+		if err != nil {
+			return err
+		}
+		if booking.Discount == 0 {
+			booking.PromoCode = nil
+		}
+		if booking.Discount != session.Discount {
+			if err := cd.BookingRepository.Update(ctx, tx, booking); err != nil {
+				return fmt.Errorf("failed to update discount: %w", err)
+			}
+		}
+		if booking.Discount > 0 {
+			orderItems = append(orderItems, client.DiscountToItem(*booking.PromoCode, booking.Discount))
+			amounts -= booking.Discount
+			if err := cd.attachPromotion(ctx, tx, session.ID, booking.ID); err != nil {
+				return err
+			}
 		}
 
-		payload := &domain.TransactionRequest{
+		payload := domain.TransactionRequest{
 			Method:        request.PaymentMethod,
 			Amount:        int(amounts), // Convert to integer cents
 			CustomerName:  booking.CustomerName,
@@ -289,46 +480,25 @@ func (cd *ClaimSessionUsecase) EntryClaimSession(
 			OrderItems:    orderItems,
 			CallbackUrl:   "https://example.com/callback",
 			ReturnUrl:     "https://example.com/callback",
-			ExpiredTime:   int(time.Now().Add(30 * time.Minute).Unix()),
+			ExpiredTime:   int(deadline.Unix()),
 		}
 
-		payment, err := cd.TripayClient.CreatePayment(payload)
+		// The payment and its invoice email run after commit, see OutboxUsecase
+		payment, err = enqueuePayment(ctx, tx, cd.OutboxRepository, &domain.PaymentOutboxPayload{
+			BookingID:   booking.ID,
+			SendInvoice: true,
+			Request:     payload,
+		}, outboxMaxAttempts)
 		if err != nil {
-			return fmt.Errorf("create Tripay payment failed: %w", err)
-		}
-
-		booking.ReferenceNumber = &payment.Reference
-		if err := cd.BookingRepository.Update(ctx, tx, booking); err != nil {
-			return fmt.Errorf("failed to update booking with reference number: %w", err)
-		}
-
-		// Decrement quota usage
-		for _, item := range session.ClaimItems {
-			quota, ok := quotaByClass[item.ClassID]
-			if !ok {
-				return fmt.Errorf("quota not found for class %d", item.ClassID)
-			}
-
-			if quota.Quota < item.Quantity {
-				return fmt.Errorf("not enough quota for class %d", item.ClassID)
-			}
-
-			quota.Quota -= item.Quantity
-
-			if err := cd.QuotaRepository.Update(ctx, tx, quota); err != nil {
-				return fmt.Errorf("failed to update quota usage: %w", err)
-			}
+			return err
 		}
 
-		subject := "Your Booking is Confirmed"
-		htmlBody := templates.BookingInvoiceEmail(booking, payment)
-		// cd.Mailer.SendAsync(booking.Email, subject, htmlBody)
-		if err := cd.Mailer.Send(booking.Email, subject, htmlBody); err != nil {
-			return fmt.Errorf("failed to send booking confirmation email: %w", err)
+		// Turn the held quota into sold quota
+		if err := confirmClaimItems(ctx, tx, cd.QuotaRepository, session.ScheduleID, session.ClaimItems); err != nil {
+			return err
 		}
-		session.Status = enum.ClaimSessionSuccess.String()
-		if err := cd.ClaimSessionRepository.Update(ctx, tx, session); err != nil {
-			return fmt.Errorf("failed to update session: %w", err)
+		if err := confirmClaimAddons(ctx, tx, cd.AddonStockRepository, session.Addons); err != nil {
+			return err
 		}
 
 		return nil
@@ -336,6 +506,9 @@ func (cd *ClaimSessionUsecase) EntryClaimSession(
 		return nil, fmt.Errorf("execute transaction: %w", err)
 	}
 
+	// The booking is committed either way; a failed payment is retried by the outbox job
+	_ = cd.Outbox.Dispatch(ctx, payment)
+
 	return &model.TESTReadClaimSessionDataEntryResponse{
 		OrderID: booking.OrderID,
 	}, nil
@@ -344,26 +517,42 @@ func (cd *ClaimSessionUsecase) EntryClaimSession(
 func (uc *ClaimSessionUsecase) CreateClaimSession(ctx context.Context, request *model.TESTWriteClaimSessionRequest) error {
 
 	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		legs := claimLegs(request.ScheduleID, request.Items)
+		if len(legs) == 0 {
+			return fmt.Errorf("no schedule requested: %w", errs.ErrBadRequest)
+		}
+		_, seated, err := uc.prepareLegs(ctx, tx, legs)
+		if err != nil {
+			return err
+		}
+
 		claimSession := &domain.ClaimSession{
 			SessionID:  uuid.NewString(),
-			ScheduleID: request.ScheduleID,
+			ScheduleID: legs[0],
 			Status:     enum.ClaimSessionPending.String(),
-			ExpiresAt:  time.Now().Add(16 * time.Minute),
+			ExpiresAt:  time.Now().Add(claimSessionTTL),
 			ClaimItems: make([]domain.ClaimItem, len(request.Items)),
 		}
 		for i, item := range request.Items {
 			claimSession.ClaimItems[i] = domain.ClaimItem{
-				ClassID:  item.ClassID,
-				Quantity: item.Quantity,
-				Subtotal: item.Subtotal, // Assuming subtotal is provided in the request
+				ScheduleID: itemLeg(legs[0], item),
+				ClassID:    item.ClassID,
+				Quantity:   item.Quantity,
+				Subtotal:   item.Subtotal, // Assuming subtotal is provided in the request
 			}
 		}
+		if err := reserveClaimItems(ctx, tx, uc.QuotaRepository, claimSession.ScheduleID, claimSession.ClaimItems); err != nil {
+			return err
+		}
 		if err := uc.ClaimSessionRepository.Insert(ctx, tx, claimSession); err != nil {
 			if errs.IsUniqueConstraintError(err) {
 				return errs.ErrConflict
 			}
 			return fmt.Errorf("failed to create claim session: %w", err)
 		}
+		if err := holdSeats(ctx, tx, uc.ScheduleSeatRepository, claimSession.ID, seated, claimSeatRequests(legs[0], request.Items)); err != nil {
+			return err
+		}
 
 		return nil
 	})
@@ -380,15 +569,44 @@ func (uc *ClaimSessionUsecase) UpdateClaimSession(ctx context.Context, request *
 			return errs.ErrNotFound
 		}
 
+		// Swap holds: give back what the old items held, then hold the new ones
+		if claimSession.Status == enum.ClaimSessionPending.String() {
+			if err := releaseClaimItems(ctx, tx, uc.QuotaRepository, claimSession.ScheduleID, claimSession.ClaimItems); err != nil {
+				return err
+			}
+			if err := releaseClaimAddons(ctx, tx, uc.AddonStockRepository, claimSession.Addons); err != nil {
+				return err
+			}
+		}
+		if err := uc.ScheduleSeatRepository.ReleaseByClaimSessionID(ctx, tx, claimSession.ID); err != nil {
+			return fmt.Errorf("failed to release seats: %w", err)
+		}
+
 		claimSession.ScheduleID = request.ScheduleID
 		claimSession.Status = request.Status
 		claimSession.ExpiresAt = request.ExpiresAt
 		claimSession.ClaimItems = make([]domain.ClaimItem, len(request.ClaimItems))
 		for i, item := range request.ClaimItems {
 			claimSession.ClaimItems[i] = domain.ClaimItem{
-				ClassID:  item.ClassID,
-				Quantity: item.Quantity,
-				Subtotal: item.Subtotal, // Assuming subtotal is provided in the request
+				ScheduleID: itemLeg(claimSession.ScheduleID, item),
+				ClassID:    item.ClassID,
+				Quantity:   item.Quantity,
+				Subtotal:   item.Subtotal, // Assuming subtotal is provided in the request
+			}
+		}
+		if claimSession.Status == enum.ClaimSessionPending.String() {
+			if err := reserveClaimItems(ctx, tx, uc.QuotaRepository, claimSession.ScheduleID, claimSession.ClaimItems); err != nil {
+				return err
+			}
+			if err := reserveClaimAddons(ctx, tx, uc.AddonStockRepository, claimSession.Addons); err != nil {
+				return err
+			}
+			_, seated, err := uc.prepareLegs(ctx, tx, claimLegs(claimSession.ScheduleID, request.ClaimItems))
+			if err != nil {
+				return err
+			}
+			if err := holdSeats(ctx, tx, uc.ScheduleSeatRepository, claimSession.ID, seated, claimSeatRequests(claimSession.ScheduleID, request.ClaimItems)); err != nil {
+				return err
 			}
 		}
 
@@ -428,6 +646,7 @@ func (uc *ClaimSessionUsecase) ListClaimSessions(ctx context.Context, limit, off
 func (uc *ClaimSessionUsecase) GetClaimSessionByID(ctx context.Context, id uint) (*model.TESTReadClaimSessionResponse, error) {
 	var err error
 	var claimSession *domain.ClaimSession
+	var seats []*domain.ScheduleSeat
 	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
 		claimSession, err = uc.ClaimSessionRepository.FindByID(ctx, tx, id)
 		if err != nil {
@@ -436,17 +655,24 @@ func (uc *ClaimSessionUsecase) GetClaimSessionByID(ctx context.Context, id uint)
 		if claimSession == nil {
 			return errs.ErrNotFound
 		}
+		seats, err = uc.ScheduleSeatRepository.FindByClaimSessionID(ctx, tx, claimSession.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get held seats: %w", err)
+		}
 		return nil
 	}); err != nil {
 		return nil, fmt.Errorf("failed to get claim session by id: %w", err)
 	}
 
-	return mapper.TESTClaimSessionToResponse(claimSession), nil
+	response := mapper.TESTClaimSessionToResponse(claimSession)
+	response.Seats = mapper.ScheduleSeatsToClaimSessionSeats(seats)
+	return response, nil
 }
 
 func (uc *ClaimSessionUsecase) GetBySessionID(ctx context.Context, sessionUUID string) (*model.TESTReadClaimSessionResponse, error) {
 	var err error
 	var claimSession *domain.ClaimSession
+	var seats []*domain.ScheduleSeat
 	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
 		claimSession, err = uc.ClaimSessionRepository.FindBySessionID(ctx, tx, sessionUUID)
 		if err != nil {
@@ -455,11 +681,17 @@ func (uc *ClaimSessionUsecase) GetBySessionID(ctx context.Context, sessionUUID s
 		if claimSession == nil {
 			return errs.ErrNotFound
 		}
+		seats, err = uc.ScheduleSeatRepository.FindByClaimSessionID(ctx, tx, claimSession.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get held seats: %w", err)
+		}
 		return nil
 	}); err != nil {
 		return nil, fmt.Errorf("failed to get claim session by session ID: %w", err)
 	}
-	return mapper.TESTClaimSessionToResponse(claimSession), nil
+	response := mapper.TESTClaimSessionToResponse(claimSession)
+	response.Seats = mapper.ScheduleSeatsToClaimSessionSeats(seats)
+	return response, nil
 }
 
 func (uc *ClaimSessionUsecase) DeleteClaimSession(ctx context.Context, id uint) error {
@@ -474,6 +706,18 @@ func (uc *ClaimSessionUsecase) DeleteClaimSession(ctx context.Context, id uint)
 			return errs.ErrNotFound
 		}
 
+		if claimSession.Status == enum.ClaimSessionPending.String() {
+			if err := releaseClaimItems(ctx, tx, uc.QuotaRepository, claimSession.ScheduleID, claimSession.ClaimItems); err != nil {
+				return err
+			}
+			if err := releaseClaimAddons(ctx, tx, uc.AddonStockRepository, claimSession.Addons); err != nil {
+				return err
+			}
+		}
+		if err := uc.ScheduleSeatRepository.ReleaseByClaimSessionID(ctx, tx, claimSession.ID); err != nil {
+			return fmt.Errorf("failed to release seats: %w", err)
+		}
+
 		if err := uc.ClaimSessionRepository.Delete(ctx, tx, claimSession); err != nil {
 			return fmt.Errorf("failed to delete fare: %w", err)
 		}
@@ -481,19 +725,367 @@ func (uc *ClaimSessionUsecase) DeleteClaimSession(ctx context.Context, id uint)
 	})
 }
 
-func (uc *ClaimSessionUsecase) DeleteExpiredClaimSession(ctx context.Context) error {
+// CancelClaimSession ends a pending session at the customer's request and puts
+// its quota and seats straight back on sale.
+func (uc *ClaimSessionUsecase) CancelClaimSession(ctx context.Context, sessionID string) error {
 	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
-		expiredSessions, err := uc.ClaimSessionRepository.FindExpired(ctx, tx, 50)
+		session, err := uc.ClaimSessionRepository.FindBySessionID(ctx, tx, sessionID)
 		if err != nil {
-			return fmt.Errorf("failed to find expired sessions: %w", err)
+			return fmt.Errorf("failed to get session: %w", err)
 		}
-		if len(expiredSessions) == 0 {
+		if session == nil {
+			return errs.ErrNotFound
+		}
+		if session.Status != enum.ClaimSessionPending.String() {
+			return errs.ErrConflict
+		}
+		if !session.ExpiresAt.After(time.Now()) {
+			return errs.ErrExpired
+		}
+		return uc.closeClaimSession(ctx, tx, session, enum.ClaimSessionCancelled)
+	})
+}
+
+// ExtendClaimSession gives a pending session one more claimSessionExtension,
+// capped at claimSessionMaxLifetime after it was created.
+func (uc *ClaimSessionUsecase) ExtendClaimSession(ctx context.Context, sessionID string) (*model.TESTReadClaimSessionLockResponse, error) {
+	var session *domain.ClaimSession
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		session, err = uc.ClaimSessionRepository.FindBySessionID(ctx, tx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		if session == nil {
+			return errs.ErrNotFound
+		}
+		if session.Status != enum.ClaimSessionPending.String() {
+			return errs.ErrConflict
+		}
+		if !session.ExpiresAt.After(time.Now()) {
+			return errs.ErrExpired
+		}
+		if session.ExtendedAt != nil {
+			return fmt.Errorf("session already extended: %w", errs.ErrConflict)
+		}
+
+		expiresAt := session.ExpiresAt.Add(claimSessionExtension)
+		if limit := session.CreatedAt.Add(claimSessionMaxLifetime); expiresAt.After(limit) {
+			expiresAt = limit
+		}
+		if !expiresAt.After(session.ExpiresAt) {
+			return fmt.Errorf("session reached its maximum lifetime: %w", errs.ErrConflict)
+		}
+
+		extended, err := uc.ClaimSessionRepository.Extend(ctx, tx, session.ID, expiresAt)
+		if err != nil {
+			return fmt.Errorf("failed to extend session: %w", err)
+		}
+		if extended == 0 {
+			return errs.ErrConflict
+		}
+		session.ExpiresAt = expiresAt
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to extend claim session: %w", err)
+	}
+
+	return &model.TESTReadClaimSessionLockResponse{
+		SessionID: session.SessionID,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// ExpireClaimSessions marks pending sessions past their expiry as EXPIRED and
+// releases what they hold. Rows are kept for abandonment reporting.
+func (uc *ClaimSessionUsecase) ExpireClaimSessions(ctx context.Context) error {
+	for {
+		var found int
+		if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+			expiredSessions, err := uc.ClaimSessionRepository.FindExpired(ctx, tx, claimSessionExpiryBatch)
+			if err != nil {
+				return fmt.Errorf("failed to find expired sessions: %w", err)
+			}
+			found = len(expiredSessions)
+
+			for _, session := range expiredSessions {
+				if err := uc.closeClaimSession(ctx, tx, session, enum.ClaimSessionExpired); err != nil {
+					if errors.Is(err, errs.ErrConflict) {
+						continue
+					}
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("failed to expire claim sessions: %w", err)
+		}
+		if found < claimSessionExpiryBatch {
 			return nil
 		}
+	}
+}
+
+// closeClaimSession moves a pending session to a final status and releases its
+// quota and seats. It returns ErrConflict when the session already left PENDING.
+func (uc *ClaimSessionUsecase) closeClaimSession(ctx context.Context, conn gotann.Connection, session *domain.ClaimSession, status enum.ClaimSessionStatus) error {
+	closed, err := uc.ClaimSessionRepository.UpdateStatus(ctx, conn, session.ID, enum.ClaimSessionPending.String(), status.String())
+	if err != nil {
+		return fmt.Errorf("failed to update session status: %w", err)
+	}
+	if closed == 0 {
+		return errs.ErrConflict
+	}
 
-		if err := uc.ClaimSessionRepository.DeleteBulk(ctx, tx, expiredSessions); err != nil {
-			return fmt.Errorf("failed to delete expired sessions: %w", err)
+	if err := releaseClaimItems(ctx, conn, uc.QuotaRepository, session.ScheduleID, session.ClaimItems); err != nil {
+		return err
+	}
+	if err := releaseClaimAddons(ctx, conn, uc.AddonStockRepository, session.Addons); err != nil {
+		return err
+	}
+	if err := uc.ScheduleSeatRepository.ReleaseByClaimSessionID(ctx, conn, session.ID); err != nil {
+		return fmt.Errorf("failed to release seats: %w", err)
+	}
+	if session.PromotionID != nil {
+		if err := uc.releasePromotion(ctx, conn, session); err != nil {
+			return err
 		}
+	}
+	session.Status = status.String()
+	return nil
+}
+
+// entryTicket builds the ticket for one passenger or vehicle entry, priced at
+// the fare that fits the passenger. Vehicles take their lane meters of quota.
+func entryTicket(quota *domain.Quota, bookingID, scheduleID uint, data model.TESTClaimSessionTicketDataEntry) (*domain.Ticket, error) {
+	ticket := &domain.Ticket{
+		TicketCode:      utils.GenerateTicketReferenceID(), // Unique ticket code
+		BookingID:       &bookingID,
+		ClassID:         quota.ClassID,
+		Type:            quota.Class.Type,
+		PassengerName:   data.PassengerName,
+		PassengerAge:    data.PassengerAge,
+		Address:         data.Address,
+		PassengerGender: &data.PassengerGender,
+		IDType:          &data.IDType,
+		IDNumber:        &data.IDNumber,
+		SeatNumber:      data.SeatNumber,
+		LicensePlate:    data.LicensePlate,
+		ScheduleID:      scheduleID,
+	}
+	if ticket.Type == "vehicle" {
+		ticket.VehicleType = data.VehicleType
+		ticket.VehicleBrand = data.VehicleBrand
+		ticket.VehicleLength = data.VehicleLength
+		ticket.QuotaUnits = laneMeters(quota)
+	}
+	var err error
+	ticket.FareCategory, ticket.Price, err = ticketFare(quota, ticket)
+	if err != nil {
+		return nil, err
+	}
+	return ticket, nil
+}
+
+// redeemPromotion checks a promo code against the claim and counts one use of
+// it, returning the promotion and its discount. Codes are matched case
+// insensitively.
+func (uc *ClaimSessionUsecase) redeemPromotion(ctx context.Context, conn gotann.Connection, code string, customerID *uint, items []domain.ClaimItem, quotaByLeg map[legClass]*domain.Quota) (*domain.Promotion, float64, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	promotion, err := uc.PromotionRepository.FindByCode(ctx, conn, code)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get promotion: %w", err)
+	}
+	if promotion == nil {
+		return nil, 0, fmt.Errorf("promo code %s not found: %w", code, errs.ErrPromoUnavailable)
+	}
+
+	schedules := make(map[uint]*domain.Schedule)
+	for key, quota := range quotaByLeg {
+		schedules[key.ScheduleID] = &quota.Schedule
+	}
+	discount, err := promotionDiscount(promotion, items, schedules, time.Now())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Count the use first: the update holds the promotion row, so the
+	// per customer count below cannot race another redemption
+	redeemed, err := uc.PromotionRepository.Redeem(ctx, conn, promotion.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to redeem promotion: %w", err)
+	}
+	if redeemed == 0 {
+		return nil, 0, fmt.Errorf("promo code %s is fully redeemed: %w", code, errs.ErrPromoUnavailable)
+	}
+	if promotion.PerCustomerLimit != nil {
+		if customerID == nil {
+			return nil, 0, fmt.Errorf("promo code %s needs a signed in customer: %w", code, errs.ErrPromoUnavailable)
+		}
+		used, err := uc.PromotionRedemptionRepository.CountByCustomer(ctx, conn, promotion.ID, *customerID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to count promo redemptions: %w", err)
+		}
+		if used >= int64(*promotion.PerCustomerLimit) {
+			return nil, 0, fmt.Errorf("promo code %s already used: %w", code, errs.ErrPromoUnavailable)
+		}
+	}
+	return promotion, discount, nil
+}
+
+// entryDiscount recomputes the promo discount of a session from its priced
+// tickets, never more than total. A code that no longer applies to them, such
+// as one whose minimum spend the cheaper fares fall below, is given back and
+// the booking pays in full.
+func (uc *ClaimSessionUsecase) entryDiscount(ctx context.Context, conn gotann.Connection, session *domain.ClaimSession, tickets []*domain.Ticket, quotaByLeg map[legClass]*domain.Quota, total float64) (float64, error) {
+	if session.PromotionID == nil {
+		return 0, nil
+	}
+	promotion, err := uc.PromotionRepository.FindByID(ctx, conn, *session.PromotionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get promotion: %w", err)
+	}
+
+	var discount float64
+	if promotion != nil {
+		schedules := make(map[uint]*domain.Schedule)
+		for key, quota := range quotaByLeg {
+			schedules[key.ScheduleID] = &quota.Schedule
+		}
+		discount, err = ticketDiscount(promotion, tickets, schedules, session.CreatedAt)
+		if err != nil && !errors.Is(err, errs.ErrPromoUnavailable) {
+			return 0, err
+		}
+	}
+	if discount <= 0 {
+		return 0, uc.releasePromotion(ctx, conn, session)
+	}
+	return math.Min(discount, total), nil
+}
+
+// attachPromotion links the promo redemption of a session to the booking it
+// became. The use stays counted from then on.
+func (uc *ClaimSessionUsecase) attachPromotion(ctx context.Context, conn gotann.Connection, sessionID, bookingID uint) error {
+	redemption, err := uc.PromotionRedemptionRepository.FindByClaimSessionID(ctx, conn, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get promo redemption: %w", err)
+	}
+	if redemption == nil {
 		return nil
-	})
+	}
+	redemption.BookingID = &bookingID
+	if err := uc.PromotionRedemptionRepository.Update(ctx, conn, redemption); err != nil {
+		return fmt.Errorf("failed to update promo redemption: %w", err)
+	}
+	return nil
+}
+
+// releasePromotion gives back the promo code use of a session that ended
+// without a booking.
+func (uc *ClaimSessionUsecase) releasePromotion(ctx context.Context, conn gotann.Connection, session *domain.ClaimSession) error {
+	redemption, err := uc.PromotionRedemptionRepository.FindByClaimSessionID(ctx, conn, session.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get promo redemption: %w", err)
+	}
+	return releaseRedemption(ctx, conn, uc.PromotionRepository, uc.PromotionRedemptionRepository, redemption)
+}
+
+// prepareLegs checks every leg exists and returns its quota per class and the
+// classes sold by seat, creating seat inventory where it is still missing.
+func (uc *ClaimSessionUsecase) prepareLegs(ctx context.Context, conn gotann.Connection, legs []uint) (map[legClass]*domain.Quota, map[legClass]bool, error) {
+	quotaByLeg := make(map[legClass]*domain.Quota)
+	seated := make(map[legClass]bool)
+	for _, scheduleID := range legs {
+		schedule, err := uc.ScheduleRepository.FindByID(ctx, conn, scheduleID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("retrieve schedule: %w", err)
+		}
+		if schedule == nil {
+			return nil, nil, fmt.Errorf("schedule %d: %w", scheduleID, errs.ErrNotFound)
+		}
+		// Quota given back by a cancellation must not be sold again
+		if schedule.Status == enum.ScheduleCancelled.String() {
+			return nil, nil, fmt.Errorf("schedule %d was cancelled: %w", scheduleID, errs.ErrConflict)
+		}
+		classes, err := ensureScheduleSeats(ctx, conn, uc.SeatLayoutRepository, uc.ScheduleSeatRepository, schedule)
+		if err != nil {
+			return nil, nil, err
+		}
+		for classID := range classes {
+			seated[legClass{ScheduleID: scheduleID, ClassID: classID}] = true
+		}
+
+		quotas, err := uc.QuotaRepository.FindByScheduleID(ctx, conn, scheduleID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("fetch quotas: %w", err)
+		}
+		for _, quota := range quotas {
+			quotaByLeg[legClass{ScheduleID: scheduleID, ClassID: quota.ClassID}] = quota
+		}
+	}
+	return quotaByLeg, seated, nil
+}
+
+// prepareAddons prices the requested add-ons at the stock of the legs they
+// are for. legs comes from claimLegs, the claim's own schedule first.
+func (uc *ClaimSessionUsecase) prepareAddons(ctx context.Context, conn gotann.Connection, legs []uint, requested []model.ClaimSessionAddon) ([]domain.ClaimAddon, error) {
+	if len(requested) == 0 {
+		return nil, nil
+	}
+	stockByLeg := make(map[legAddon]*domain.AddonStock)
+	for _, leg := range legs {
+		stocks, err := uc.AddonStockRepository.FindByScheduleID(ctx, conn, leg)
+		if err != nil {
+			return nil, fmt.Errorf("fetch add-on stock failed: %w", err)
+		}
+		for _, stock := range stocks {
+			stockByLeg[legAddon{ScheduleID: leg, AddonID: stock.AddonID}] = stock
+		}
+	}
+	addons, err := claimAddons(legs[0], legs, requested, stockByLeg)
+	if err != nil {
+		return nil, err
+	}
+	for i := range addons {
+		addons[i].Addon = stockByLeg[legAddon{ScheduleID: addons[i].ScheduleID, AddonID: addons[i].AddonID}].Addon
+	}
+	return addons, nil
+}
+
+// claimLegs lists the distinct schedules of a claim, the claim's own schedule
+// first and the others in the order their items appear.
+func claimLegs(scheduleID uint, items []model.ClaimSessionItem) []uint {
+	var legs []uint
+	seen := make(map[uint]bool)
+	add := func(id uint) {
+		if id != 0 && !seen[id] {
+			seen[id] = true
+			legs = append(legs, id)
+		}
+	}
+	add(scheduleID)
+	for _, item := range items {
+		add(item.ScheduleID)
+	}
+	return legs
+}
+
+func itemLeg(scheduleID uint, item model.ClaimSessionItem) uint {
+	if item.ScheduleID != 0 {
+		return item.ScheduleID
+	}
+	return scheduleID
+}
+
+func claimSeatRequests(scheduleID uint, items []model.ClaimSessionItem) []seatRequest {
+	requests := make([]seatRequest, len(items))
+	for i, item := range items {
+		requests[i] = seatRequest{
+			ScheduleID:  itemLeg(scheduleID, item),
+			ClassID:     item.ClassID,
+			Quantity:    item.Quantity,
+			SeatNumbers: item.SeatNumbers,
+		}
+	}
+	return requests
 }
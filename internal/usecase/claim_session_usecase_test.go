@@ -3,15 +3,20 @@ package usecase
 import (
 	"context"
 	"testing"
+	"time"
 
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
 	"eticket-api/internal/mocks"
 	"eticket-api/internal/model"
+	"eticket-api/pkg/gotann"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
-func claimSessionUsecase(t *testing.T) (*ClaimSessionUsecase, *mocks.MockClaimSessionRepository, *mocks.MockClaimItemRepository, *mocks.MockTicketRepository, *mocks.MockScheduleRepository, *mocks.MockBookingRepository, *mocks.MockQuotaRepository, *mocks.MockTripayClient, *mocks.MockMailer, *mocks.MockTransactor) {
+func claimSessionUsecase(t *testing.T) (*ClaimSessionUsecase, *mocks.MockClaimSessionRepository, *mocks.MockClaimItemRepository, *mocks.MockTicketRepository, *mocks.MockScheduleRepository, *mocks.MockBookingRepository, *mocks.MockQuotaRepository, *mocks.MockOutboxRepository, *mocks.MockTransactor) {
 	t.Helper()
 	ctrl := gomock.NewController(t)
 	claimSessionRepo := mocks.NewMockClaimSessionRepository(ctrl)
@@ -20,16 +25,18 @@ func claimSessionUsecase(t *testing.T) (*ClaimSessionUsecase, *mocks.MockClaimSe
 	scheduleRepo := mocks.NewMockScheduleRepository(ctrl)
 	bookingRepo := mocks.NewMockBookingRepository(ctrl)
 	quotaRepo := mocks.NewMockQuotaRepository(ctrl)
-	tripayClient := mocks.NewMockTripayClient(ctrl)
-	mailer := mocks.NewMockMailer(ctrl)
+	seatLayoutRepo := mocks.NewMockSeatLayoutRepository(ctrl)
+	scheduleSeatRepo := mocks.NewMockScheduleSeatRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	transactor := mocks.NewMockTransactor(ctrl)
-	uc := NewClaimSessionUsecase(transactor, claimSessionRepo, claimItemRepo, ticketRepo, scheduleRepo, bookingRepo, quotaRepo, tripayClient, mailer)
-	return uc, claimSessionRepo, claimItemRepo, ticketRepo, scheduleRepo, bookingRepo, quotaRepo, tripayClient, mailer, transactor
+	outbox := NewOutboxUsecase(transactor, outboxRepo, bookingRepo, mocks.NewMockBookingChangeRepository(ctrl), mocks.NewMockTripayClient(ctrl), mocks.NewMockMailer(ctrl), nil)
+	uc := NewClaimSessionUsecase(transactor, claimSessionRepo, claimItemRepo, ticketRepo, scheduleRepo, bookingRepo, quotaRepo, seatLayoutRepo, scheduleSeatRepo, mocks.NewMockPromotionRepository(ctrl), mocks.NewMockPromotionRedemptionRepository(ctrl), mocks.NewMockFeeComponentRepository(ctrl), mocks.NewMockAddonStockRepository(ctrl), mocks.NewMockBookingAddonRepository(ctrl), outboxRepo, outbox)
+	return uc, claimSessionRepo, claimItemRepo, ticketRepo, scheduleRepo, bookingRepo, quotaRepo, outboxRepo, transactor
 }
 
 func TestClaimSessionUsecase_CreateClaimSession(t *testing.T) {
 	t.Parallel()
-	uc, _, _, _, _, _, _, _, _, transactor := claimSessionUsecase(t)
+	uc, _, _, _, _, _, _, _, transactor := claimSessionUsecase(t)
 	tests := []struct {
 		name string
 		mock func()
@@ -63,9 +70,41 @@ func TestClaimSessionUsecase_CreateClaimSession(t *testing.T) {
 	}
 }
 
-func TestClaimSessionUsecase_DeleteExpiredClaimSession(t *testing.T) {
+func TestClaimSessionUsecase_LockClaimSession(t *testing.T) {
 	t.Parallel()
-	uc, _, _, _, _, _, _, _, _, transactor := claimSessionUsecase(t)
+	uc, _, _, _, _, _, _, _, transactor := claimSessionUsecase(t)
+	tests := []struct {
+		name string
+		mock func()
+		err  error
+	}{
+		{
+			name: "quota exceeded",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(errs.ErrQuotaExceeded)
+			},
+			err: errs.ErrQuotaExceeded,
+		},
+		{
+			name: "repo error",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			_, err := uc.LockClaimSession(context.Background(), &model.TESTWriteClaimSessionRequest{})
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestClaimSessionUsecase_ExpireClaimSessions(t *testing.T) {
+	t.Parallel()
+	uc, _, _, _, _, _, _, _, transactor := claimSessionUsecase(t)
 	tests := []struct {
 		name string
 		mock func()
@@ -89,7 +128,7 @@ func TestClaimSessionUsecase_DeleteExpiredClaimSession(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock()
-			err := uc.DeleteExpiredClaimSession(context.Background())
+			err := uc.ExpireClaimSessions(context.Background())
 			if tc.err != nil {
 				require.ErrorIs(t, err, tc.err)
 			} else {
@@ -99,5 +138,182 @@ func TestClaimSessionUsecase_DeleteExpiredClaimSession(t *testing.T) {
 	}
 }
 
+func TestClaimSessionUsecase_CancelClaimSession(t *testing.T) {
+	t.Parallel()
+	uc, claimSessionRepo, _, _, _, _, _, _, transactor := claimSessionUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+		return fn(nil)
+	}
+	tests := []struct {
+		name string
+		mock func()
+		err  error
+	}{
+		{
+			name: "not found",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				claimSessionRepo.EXPECT().FindBySessionID(gomock.Any(), gomock.Any(), "abc").Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+		{
+			name: "already reserved",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				claimSessionRepo.EXPECT().FindBySessionID(gomock.Any(), gomock.Any(), "abc").Return(&domain.ClaimSession{
+					ID:        1,
+					Status:    enum.ClaimSessionSuccess.String(),
+					ExpiresAt: time.Now().Add(time.Minute),
+				}, nil)
+			},
+			err: errs.ErrConflict,
+		},
+		{
+			name: "expired",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				claimSessionRepo.EXPECT().FindBySessionID(gomock.Any(), gomock.Any(), "abc").Return(&domain.ClaimSession{
+					ID:        1,
+					Status:    enum.ClaimSessionPending.String(),
+					ExpiresAt: time.Now().Add(-time.Minute),
+				}, nil)
+			},
+			err: errs.ErrExpired,
+		},
+		{
+			name: "lost race",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				claimSessionRepo.EXPECT().FindBySessionID(gomock.Any(), gomock.Any(), "abc").Return(&domain.ClaimSession{
+					ID:        1,
+					Status:    enum.ClaimSessionPending.String(),
+					ExpiresAt: time.Now().Add(time.Minute),
+				}, nil)
+				claimSessionRepo.EXPECT().UpdateStatus(gomock.Any(), gomock.Any(), uint(1), "PENDING", "CANCELLED").Return(int64(0), nil)
+			},
+			err: errs.ErrConflict,
+		},
+		{
+			name: "repo error",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.CancelClaimSession(context.Background(), "abc")
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestClaimSessionUsecase_ExtendClaimSession(t *testing.T) {
+	t.Parallel()
+	uc, claimSessionRepo, _, _, _, _, _, _, transactor := claimSessionUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+		return fn(nil)
+	}
+	now := time.Now()
+	extendedAt := now.Add(-time.Minute)
+	tests := []struct {
+		name      string
+		session   *domain.ClaimSession
+		mock      func()
+		expiresAt time.Time
+		err       error
+	}{
+		{
+			name: "success",
+			session: &domain.ClaimSession{
+				ID:        1,
+				SessionID: "abc",
+				Status:    enum.ClaimSessionPending.String(),
+				ExpiresAt: now.Add(5 * time.Minute),
+				CreatedAt: now.Add(-11 * time.Minute),
+			},
+			mock: func() {
+				claimSessionRepo.EXPECT().Extend(gomock.Any(), gomock.Any(), uint(1), now.Add(15*time.Minute)).Return(int64(1), nil)
+			},
+			expiresAt: now.Add(15 * time.Minute),
+		},
+		{
+			name: "capped at max lifetime",
+			session: &domain.ClaimSession{
+				ID:        1,
+				SessionID: "abc",
+				Status:    enum.ClaimSessionPending.String(),
+				ExpiresAt: now.Add(10 * time.Minute),
+				CreatedAt: now.Add(-15 * time.Minute),
+			},
+			mock: func() {
+				claimSessionRepo.EXPECT().Extend(gomock.Any(), gomock.Any(), uint(1), now.Add(15*time.Minute)).Return(int64(1), nil)
+			},
+			expiresAt: now.Add(15 * time.Minute),
+		},
+		{
+			name: "already extended",
+			session: &domain.ClaimSession{
+				ID:         1,
+				Status:     enum.ClaimSessionPending.String(),
+				ExpiresAt:  now.Add(5 * time.Minute),
+				ExtendedAt: &extendedAt,
+			},
+			mock: func() {},
+			err:  errs.ErrConflict,
+		},
+		{
+			name: "expired",
+			session: &domain.ClaimSession{
+				ID:        1,
+				Status:    enum.ClaimSessionPending.String(),
+				ExpiresAt: now.Add(-time.Minute),
+			},
+			mock: func() {},
+			err:  errs.ErrExpired,
+		},
+		{
+			name: "cancelled",
+			session: &domain.ClaimSession{
+				ID:        1,
+				Status:    enum.ClaimSessionCancelled.String(),
+				ExpiresAt: now.Add(5 * time.Minute),
+			},
+			mock: func() {},
+			err:  errs.ErrConflict,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			claimSessionRepo.EXPECT().FindBySessionID(gomock.Any(), gomock.Any(), "abc").Return(tc.session, nil)
+			tc.mock()
+			result, err := uc.ExtendClaimSession(context.Background(), "abc")
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expiresAt, result.ExpiresAt)
+		})
+	}
+}
+
+func TestClaimLegs(t *testing.T) {
+	t.Parallel()
+	items := []model.ClaimSessionItem{
+		{ClassID: 1, Quantity: 2},
+		{ScheduleID: 9, ClassID: 1, Quantity: 2},
+		{ScheduleID: 7, ClassID: 2, Quantity: 1},
+		{ScheduleID: 9, ClassID: 2, Quantity: 1},
+	}
+	require.Equal(t, []uint{7, 9}, claimLegs(7, items))
+	require.Equal(t, []uint{9, 7}, claimLegs(0, items))
+	require.Empty(t, claimLegs(0, nil))
+}
+
 // Lakukan hal serupa untuk usecase lain (Class, Harbor, Quota, Schedule, Ticket, Booking, ClaimSession, ClaimItem, Payment)
 // Copy helper dan test function di atas, ganti dependency dan method sesuai usecase/constructor masing-masing.
@@ -10,26 +10,34 @@ import (
 )
 
 type ClassUsecase struct {
-	Transactor      transact.Transactor
-	ClassRepository domain.ClassRepository
+	Transactor                transact.Transactor
+	ClassRepository           domain.ClassRepository
+	VehicleCategoryRepository domain.VehicleCategoryRepository
 }
 
 func NewClassUsecase(
 	transactor transact.Transactor,
 	class_repository domain.ClassRepository,
+	vehicle_category_repository domain.VehicleCategoryRepository,
 ) *ClassUsecase {
 	return &ClassUsecase{
-		Transactor:      transactor,
-		ClassRepository: class_repository,
+		Transactor:                transactor,
+		ClassRepository:           class_repository,
+		VehicleCategoryRepository: vehicle_category_repository,
 	}
 }
 
 func (uc *ClassUsecase) CreateClass(ctx context.Context, e *domain.Class) error {
 	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if err := uc.checkVehicleCategory(ctx, tx, e); err != nil {
+			return err
+		}
+
 		class := &domain.Class{
-			ClassName:  e.ClassName,
-			Type:       e.Type,
-			ClassAlias: e.ClassAlias,
+			ClassName:         e.ClassName,
+			Type:              e.Type,
+			ClassAlias:        e.ClassAlias,
+			VehicleCategoryID: e.VehicleCategoryID,
 		}
 
 		if err := uc.ClassRepository.Insert(ctx, tx, class); err != nil {
@@ -91,10 +99,16 @@ func (uc *ClassUsecase) UpdateClass(ctx context.Context, e *domain.Class) error
 		if class == nil {
 			return errs.ErrNotFound
 		}
+		if err := uc.checkVehicleCategory(ctx, tx, e); err != nil {
+			return err
+		}
 
 		class.ClassName = e.ClassName
 		class.Type = e.Type
 		class.ClassAlias = e.ClassAlias
+		// Drop the loaded category so saving does not put the old key back
+		class.VehicleCategoryID = e.VehicleCategoryID
+		class.VehicleCategory = nil
 
 		if err := uc.ClassRepository.Update(ctx, tx, class); err != nil {
 			return fmt.Errorf("failed to update class: %w", err)
@@ -122,3 +136,22 @@ func (uc *ClassUsecase) DeleteClass(ctx context.Context, id uint) error {
 		return nil
 	})
 }
+
+// checkVehicleCategory makes sure only vehicle classes carry a golongan and
+// that the golongan exists.
+func (uc *ClassUsecase) checkVehicleCategory(ctx context.Context, conn gotann.Connection, class *domain.Class) error {
+	if class.VehicleCategoryID == nil {
+		return nil
+	}
+	if class.Type != "vehicle" {
+		return fmt.Errorf("only vehicle classes have a vehicle category: %w", errs.ErrBadRequest)
+	}
+	category, err := uc.VehicleCategoryRepository.FindByID(ctx, conn, *class.VehicleCategoryID)
+	if err != nil {
+		return fmt.Errorf("failed to get vehicle category: %w", err)
+	}
+	if category == nil {
+		return fmt.Errorf("vehicle category %d: %w", *class.VehicleCategoryID, errs.ErrNotFound)
+	}
+	return nil
+}
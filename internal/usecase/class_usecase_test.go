@@ -16,7 +16,7 @@ func classUsecase(t *testing.T) (*ClassUsecase, *mocks.MockClassRepository, *moc
 	ctrl := gomock.NewController(t)
 	repo := mocks.NewMockClassRepository(ctrl)
 	transactor := mocks.NewMockTransactor(ctrl)
-	uc := NewClassUsecase(transactor, repo)
+	uc := NewClassUsecase(transactor, repo, mocks.NewMockVehicleCategoryRepository(ctrl))
 	return uc, repo, transactor
 }
 
@@ -0,0 +1,295 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/templates"
+	"eticket-api/internal/common/token"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/common/utils"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	customerVerifyCodeLength      = 6
+	customerVerifyCodeTTL         = 30 * time.Minute
+	customerVerifyCodeCooldown    = time.Minute
+	customerVerifyCodeMaxAttempts = 5
+)
+
+// CustomerUsecase manages passenger accounts. Accounts are kept apart from
+// staff users and sign in with a customer token instead of the staff cookie.
+type CustomerUsecase struct {
+	Transactor         transact.Transactor
+	CustomerRepository domain.CustomerRepository
+	BookingRepository  domain.BookingRepository
+	OutboxRepository   domain.OutboxRepository
+	Outbox             *OutboxUsecase
+	TokenUtil          token.TokenUtil
+}
+
+func NewCustomerUsecase(
+	transactor transact.Transactor,
+	customer_repository domain.CustomerRepository,
+	booking_repository domain.BookingRepository,
+	outbox_repository domain.OutboxRepository,
+	outbox *OutboxUsecase,
+	token_util token.TokenUtil,
+) *CustomerUsecase {
+	return &CustomerUsecase{
+		Transactor:         transactor,
+		CustomerRepository: customer_repository,
+		BookingRepository:  booking_repository,
+		OutboxRepository:   outbox_repository,
+		Outbox:             outbox,
+		TokenUtil:          token_util,
+	}
+}
+
+// Register creates an account and emails a code to verify its address. The
+// customer is signed in right away; only claiming guest bookings waits for
+// the verification.
+func (uc *CustomerUsecase) Register(ctx context.Context, customer *domain.Customer) (*domain.Customer, string, error) {
+	var notice *domain.Outbox
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		customer.Email = normalizeEmail(customer.Email)
+		existing, err := uc.CustomerRepository.FindByEmail(ctx, tx, customer.Email)
+		if err != nil {
+			return fmt.Errorf("failed to get customer: %w", err)
+		}
+		if existing != nil {
+			return errs.ErrConflict
+		}
+
+		hashed, err := utils.HashPassword(customer.Password)
+		if err != nil {
+			return fmt.Errorf("failed to hash password: %w", err)
+		}
+		customer.Password = hashed
+		code, err := issueVerifyCode(customer, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := uc.CustomerRepository.Insert(ctx, tx, customer); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("failed to create customer: %w", err)
+		}
+
+		notice, err = enqueueEmail(ctx, tx, uc.OutboxRepository, customer.Email, customer.Email,
+			"Verifikasi Email Akun Tiket Hebat", templates.CustomerVerificationEmail(customer, code, customerVerifyCodeTTL))
+		return err
+	}); err != nil {
+		return nil, "", fmt.Errorf("failed to register customer: %w", err)
+	}
+
+	_ = uc.Outbox.Dispatch(ctx, notice)
+
+	accessToken, err := uc.TokenUtil.GenerateCustomerToken(customer)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate customer token: %w", err)
+	}
+	return customer, accessToken, nil
+}
+
+// Login signs a customer in with email and password. Unknown emails and wrong
+// passwords give the same ErrUnauthorized.
+func (uc *CustomerUsecase) Login(ctx context.Context, email, password string) (*domain.Customer, string, error) {
+	var err error
+	var customer *domain.Customer
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		customer, err = uc.CustomerRepository.FindByEmail(ctx, tx, normalizeEmail(email))
+		if err != nil {
+			return fmt.Errorf("failed to get customer: %w", err)
+		}
+		if customer == nil || !utils.CheckPasswordHash(password, customer.Password) {
+			return errs.ErrUnauthorized
+		}
+		return nil
+	}); err != nil {
+		return nil, "", fmt.Errorf("failed to log in customer: %w", err)
+	}
+
+	accessToken, err := uc.TokenUtil.GenerateCustomerToken(customer)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate customer token: %w", err)
+	}
+	return customer, accessToken, nil
+}
+
+func (uc *CustomerUsecase) GetCustomer(ctx context.Context, id uint) (*domain.Customer, error) {
+	var err error
+	var customer *domain.Customer
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		customer, err = uc.CustomerRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get customer: %w", err)
+		}
+		if customer == nil {
+			return errs.ErrNotFound
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get customer: %w", err)
+	}
+	return customer, nil
+}
+
+// ResendVerification emails a new verification code, replacing the old one.
+// Verified accounts are left alone.
+func (uc *CustomerUsecase) ResendVerification(ctx context.Context, id uint) error {
+	var notice *domain.Outbox
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		customer, err := uc.CustomerRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get customer: %w", err)
+		}
+		if customer == nil {
+			return errs.ErrNotFound
+		}
+		if customer.EmailVerifiedAt != nil {
+			return nil
+		}
+		now := time.Now()
+		if customer.VerifyCodeSentAt != nil && customer.VerifyCodeSentAt.Add(customerVerifyCodeCooldown).After(now) {
+			return fmt.Errorf("verification code was sent less than a minute ago: %w", errs.ErrConflict)
+		}
+
+		code, err := issueVerifyCode(customer, now)
+		if err != nil {
+			return err
+		}
+		if err := uc.CustomerRepository.Update(ctx, tx, customer); err != nil {
+			return fmt.Errorf("failed to update customer: %w", err)
+		}
+
+		notice, err = enqueueEmail(ctx, tx, uc.OutboxRepository, customer.Email, customer.Email,
+			"Verifikasi Email Akun Tiket Hebat", templates.CustomerVerificationEmail(customer, code, customerVerifyCodeTTL))
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to resend verification code: %w", err)
+	}
+
+	_ = uc.Outbox.Dispatch(ctx, notice)
+	return nil
+}
+
+// VerifyEmail marks the account email as verified when the code matches. A
+// code expires after thirty minutes and is burned after five wrong guesses.
+func (uc *CustomerUsecase) VerifyEmail(ctx context.Context, id uint, code string) (*domain.Customer, error) {
+	var valid bool
+	var customer *domain.Customer
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		customer, err = uc.CustomerRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get customer: %w", err)
+		}
+		if customer == nil {
+			return errs.ErrNotFound
+		}
+		if customer.EmailVerifiedAt != nil {
+			valid = true
+			return nil
+		}
+		now := time.Now()
+		if customer.VerifyCodeHash == "" || customer.VerifyCodeSentAt == nil ||
+			!customer.VerifyCodeSentAt.Add(customerVerifyCodeTTL).After(now) ||
+			customer.VerifyCodeAttempts >= customerVerifyCodeMaxAttempts {
+			return nil
+		}
+
+		// Wrong guesses are counted, so the transaction commits either way
+		valid = subtle.ConstantTimeCompare([]byte(customer.VerifyCodeHash), []byte(utils.HashToken(strings.TrimSpace(code)))) == 1
+		if valid {
+			customer.EmailVerifiedAt = &now
+			customer.VerifyCodeHash = ""
+		} else {
+			customer.VerifyCodeAttempts++
+		}
+		if err := uc.CustomerRepository.Update(ctx, tx, customer); err != nil {
+			return fmt.Errorf("failed to update customer: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to verify email: %w", err)
+	}
+	if !valid {
+		return nil, fmt.Errorf("failed to verify email: %w", errs.ErrUnauthorized)
+	}
+	return customer, nil
+}
+
+// ListTrips returns the bookings in the account, upcoming or past depending
+// on upcoming.
+func (uc *CustomerUsecase) ListTrips(ctx context.Context, id uint, upcoming bool, limit, offset int) ([]*domain.Booking, int, error) {
+	var err error
+	var total int64
+	var bookings []*domain.Booking
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		now := time.Now()
+		total, err = uc.BookingRepository.CountByCustomerID(ctx, tx, id, upcoming, now)
+		if err != nil {
+			return fmt.Errorf("failed to count trips: %w", err)
+		}
+		bookings, err = uc.BookingRepository.FindByCustomerID(ctx, tx, id, upcoming, now, limit, offset)
+		if err != nil {
+			return fmt.Errorf("failed to get trips: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to list trips: %w", err)
+	}
+	return bookings, int(total), nil
+}
+
+// ClaimGuestBookings moves the guest bookings made with the account email
+// into the account. The email must be verified first, otherwise anyone could
+// sign up with someone else's address and read their bookings.
+func (uc *CustomerUsecase) ClaimGuestBookings(ctx context.Context, id uint) (int64, error) {
+	var claimed int64
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		customer, err := uc.CustomerRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get customer: %w", err)
+		}
+		if customer == nil {
+			return errs.ErrNotFound
+		}
+		if customer.EmailVerifiedAt == nil {
+			return fmt.Errorf("email %s is not verified: %w", customer.Email, errs.ErrForbidden)
+		}
+
+		claimed, err = uc.BookingRepository.ClaimByEmail(ctx, tx, customer.ID, customer.Email)
+		if err != nil {
+			return fmt.Errorf("failed to claim bookings: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("failed to claim guest bookings: %w", err)
+	}
+	return claimed, nil
+}
+
+// issueVerifyCode sets a fresh verification code on customer and returns it
+// in plain text for the email.
+func issueVerifyCode(customer *domain.Customer, now time.Time) (string, error) {
+	code, err := utils.GenerateNumericCode(customerVerifyCodeLength)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate verification code: %w", err)
+	}
+	customer.VerifyCodeHash = utils.HashToken(code)
+	customer.VerifyCodeSentAt = &now
+	customer.VerifyCodeAttempts = 0
+	return code, nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
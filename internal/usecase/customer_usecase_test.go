@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/utils"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func customerUsecase(t *testing.T) (*CustomerUsecase, *mocks.MockCustomerRepository, *mocks.MockBookingRepository, *mocks.MockOutboxRepository, *mocks.MockTokenUtil, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	customerRepo := mocks.NewMockCustomerRepository(ctrl)
+	bookingRepo := mocks.NewMockBookingRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	bookingChangeRepo := mocks.NewMockBookingChangeRepository(ctrl)
+	tripayClient := mocks.NewMockTripayClient(ctrl)
+	mailer := mocks.NewMockMailer(ctrl)
+	tokenUtil := mocks.NewMockTokenUtil(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	outbox := NewOutboxUsecase(transactor, outboxRepo, bookingRepo, bookingChangeRepo, tripayClient, mailer, nil)
+	uc := NewCustomerUsecase(transactor, customerRepo, bookingRepo, outboxRepo, outbox, tokenUtil)
+	return uc, customerRepo, bookingRepo, outboxRepo, tokenUtil, transactor
+}
+
+func TestCustomerUsecase_Register(t *testing.T) {
+	t.Parallel()
+	uc, customerRepo, _, outboxRepo, tokenUtil, transactor := customerUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	tests := []struct {
+		name string
+		mock func()
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).Times(2)
+				customerRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "budi@mail.com").Return(nil, nil)
+				customerRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, customer *domain.Customer) error {
+						require.True(t, utils.CheckPasswordHash("secret123", customer.Password))
+						require.Len(t, customer.VerifyCodeHash, 64)
+						require.Nil(t, customer.EmailVerifiedAt)
+						customer.ID = 1
+						return nil
+					},
+				)
+				outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+				tokenUtil.EXPECT().GenerateCustomerToken(gomock.Any()).Return("token", nil)
+			},
+		},
+		{
+			name: "email taken",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				customerRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "budi@mail.com").Return(&domain.Customer{ID: 2}, nil)
+			},
+			err: errs.ErrConflict,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			customer, accessToken, err := uc.Register(context.Background(), &domain.Customer{
+				Email:       " Budi@Mail.com ",
+				Password:    "secret123",
+				FullName:    "Budi",
+				PhoneNumber: "0812",
+			})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "token", accessToken)
+			require.Equal(t, "budi@mail.com", customer.Email)
+		})
+	}
+}
+
+func TestCustomerUsecase_Login(t *testing.T) {
+	t.Parallel()
+	uc, customerRepo, _, _, tokenUtil, transactor := customerUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	hashed, err := utils.HashPassword("secret123")
+	require.NoError(t, err)
+	customer := &domain.Customer{ID: 1, Email: "budi@mail.com", Password: hashed}
+	tests := []struct {
+		name     string
+		password string
+		found    *domain.Customer
+		err      error
+	}{
+		{name: "success", password: "secret123", found: customer},
+		{name: "wrong password", password: "nope", found: customer, err: errs.ErrUnauthorized},
+		{name: "unknown email", password: "secret123", err: errs.ErrUnauthorized},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			customerRepo.EXPECT().FindByEmail(gomock.Any(), gomock.Any(), "budi@mail.com").Return(tc.found, nil)
+			if tc.err == nil {
+				tokenUtil.EXPECT().GenerateCustomerToken(customer).Return("token", nil)
+			}
+			_, accessToken, err := uc.Login(context.Background(), "budi@mail.com", tc.password)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "token", accessToken)
+		})
+	}
+}
+
+func TestCustomerUsecase_VerifyEmail(t *testing.T) {
+	t.Parallel()
+	uc, customerRepo, _, _, _, transactor := customerUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	unverified := func(sentAt time.Time) *domain.Customer {
+		return &domain.Customer{ID: 1, Email: "budi@mail.com", VerifyCodeHash: utils.HashToken("123456"), VerifyCodeSentAt: &sentAt}
+	}
+	tests := []struct {
+		name string
+		code string
+		mock func()
+		err  error
+	}{
+		{
+			name: "valid code",
+			code: "123456",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				customerRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(unverified(time.Now()), nil)
+				customerRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, customer *domain.Customer) error {
+						require.NotNil(t, customer.EmailVerifiedAt)
+						require.Empty(t, customer.VerifyCodeHash)
+						return nil
+					},
+				)
+			},
+		},
+		{
+			name: "wrong code counts an attempt",
+			code: "654321",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				customerRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(unverified(time.Now()), nil)
+				customerRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, customer *domain.Customer) error {
+						require.Equal(t, 1, customer.VerifyCodeAttempts)
+						require.Nil(t, customer.EmailVerifiedAt)
+						return nil
+					},
+				)
+			},
+			err: errs.ErrUnauthorized,
+		},
+		{
+			name: "expired code",
+			code: "123456",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				customerRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(unverified(time.Now().Add(-time.Hour)), nil)
+			},
+			err: errs.ErrUnauthorized,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			customer, err := uc.VerifyEmail(context.Background(), 1, tc.code)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, customer.EmailVerifiedAt)
+		})
+	}
+}
+
+func TestCustomerUsecase_ClaimGuestBookings(t *testing.T) {
+	t.Parallel()
+	uc, customerRepo, bookingRepo, _, _, transactor := customerUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	verifiedAt := time.Now()
+	tests := []struct {
+		name     string
+		customer *domain.Customer
+		mock     func()
+		claimed  int64
+		err      error
+	}{
+		{
+			name:     "verified email",
+			customer: &domain.Customer{ID: 1, Email: "budi@mail.com", EmailVerifiedAt: &verifiedAt},
+			mock: func() {
+				bookingRepo.EXPECT().ClaimByEmail(gomock.Any(), gomock.Any(), uint(1), "budi@mail.com").Return(int64(2), nil)
+			},
+			claimed: 2,
+		},
+		{
+			name:     "unverified email",
+			customer: &domain.Customer{ID: 1, Email: "budi@mail.com"},
+			mock:     func() {},
+			err:      errs.ErrForbidden,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			customerRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(tc.customer, nil)
+			tc.mock()
+			claimed, err := uc.ClaimGuestBookings(context.Background(), 1)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.claimed, claimed)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"fmt"
+)
+
+// passengerFare picks the fare category and price for a passenger of age on
+// quota. Quotas without fares sell every passenger as an adult at the quota
+// price; quotas with fares reject ages none of them covers.
+func passengerFare(quota *domain.Quota, age int) (string, float64, error) {
+	if len(quota.Fares) == 0 {
+		return enum.FareAdult.String(), quota.Price, nil
+	}
+	for _, fare := range quota.Fares {
+		if age >= fare.MinAge && (fare.MaxAge == nil || age <= *fare.MaxAge) {
+			return fare.Category, fare.Price, nil
+		}
+	}
+	return "", 0, fmt.Errorf("no fare for passenger age %d on class %d: %w", age, quota.ClassID, errs.ErrBadRequest)
+}
+
+// ticketFare is passengerFare for a ticket. Vehicles have no fare category
+// and pay the quota price.
+func ticketFare(quota *domain.Quota, ticket *domain.Ticket) (string, float64, error) {
+	if ticket.Type != "passenger" {
+		return "", quota.Price, nil
+	}
+	return passengerFare(quota, ticket.PassengerAge)
+}
+
+// onLap reports whether a ticket is for an infant on a lap, which takes
+// neither quota nor a seat.
+func onLap(ticket *domain.Ticket) bool {
+	return ticket.FareCategory == enum.FareInfant.String()
+}
+
+// checkLaps rejects tickets with more infants on a lap on a leg than adults
+// and seniors to hold them.
+func checkLaps(tickets []*domain.Ticket) error {
+	laps := make(map[uint]int)
+	for _, ticket := range tickets {
+		switch {
+		case onLap(ticket):
+			laps[ticket.ScheduleID]++
+		case ticket.FareCategory == enum.FareAdult.String() || ticket.FareCategory == enum.FareSenior.String():
+			laps[ticket.ScheduleID]--
+		}
+	}
+	for scheduleID, excess := range laps {
+		if excess > 0 {
+			return fmt.Errorf("more infants than adults on schedule %d: %w", scheduleID, errs.ErrBadRequest)
+		}
+	}
+	return nil
+}
+
+// checkFares validates the fares of a quota: known categories, each at most
+// once, and age ranges that do not overlap.
+func checkFares(fares []domain.Fare) error {
+	categories := map[string]bool{
+		enum.FareAdult.String():  true,
+		enum.FareChild.String():  true,
+		enum.FareInfant.String(): true,
+		enum.FareSenior.String(): true,
+	}
+	seen := make(map[string]bool, len(fares))
+	for i, fare := range fares {
+		if !categories[fare.Category] {
+			return fmt.Errorf("unknown fare category %s: %w", fare.Category, errs.ErrBadRequest)
+		}
+		if seen[fare.Category] {
+			return fmt.Errorf("more than one %s fare: %w", fare.Category, errs.ErrBadRequest)
+		}
+		seen[fare.Category] = true
+		if fare.MinAge < 0 || fare.Price < 0 || (fare.MaxAge != nil && *fare.MaxAge < fare.MinAge) {
+			return fmt.Errorf("invalid %s fare: %w", fare.Category, errs.ErrBadRequest)
+		}
+		for _, other := range fares[:i] {
+			if ageRangesOverlap(fare, other) {
+				return fmt.Errorf("%s and %s fares cover the same ages: %w", other.Category, fare.Category, errs.ErrBadRequest)
+			}
+		}
+	}
+	return nil
+}
+
+func ageRangesOverlap(a, b domain.Fare) bool {
+	aBeforeB := a.MaxAge != nil && *a.MaxAge < b.MinAge
+	bBeforeA := b.MaxAge != nil && *b.MaxAge < a.MinAge
+	return !aBeforeB && !bBeforeA
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"testing"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPassengerFare(t *testing.T) {
+	t.Parallel()
+	two, eleven, fiftyNine := 2, 11, 59
+	quota := &domain.Quota{
+		ClassID: 1,
+		Price:   100000,
+		Fares: []domain.Fare{
+			{Category: "INFANT", MinAge: 0, MaxAge: &two, Price: 10000},
+			{Category: "CHILD", MinAge: 3, MaxAge: &eleven, Price: 75000},
+			{Category: "ADULT", MinAge: 12, MaxAge: &fiftyNine, Price: 100000},
+			{Category: "SENIOR", MinAge: 60, Price: 80000},
+		},
+	}
+	tests := []struct {
+		name     string
+		quota    *domain.Quota
+		age      int
+		category string
+		price    float64
+		err      error
+	}{
+		{name: "infant", quota: quota, age: 1, category: "INFANT", price: 10000},
+		{name: "child on the lower bound", quota: quota, age: 3, category: "CHILD", price: 75000},
+		{name: "adult on the upper bound", quota: quota, age: 59, category: "ADULT", price: 100000},
+		{name: "senior without upper bound", quota: quota, age: 90, category: "SENIOR", price: 80000},
+		{name: "no fares", quota: &domain.Quota{Price: 120000}, age: 1, category: "ADULT", price: 120000},
+		{
+			name:  "age not covered",
+			quota: &domain.Quota{ClassID: 1, Fares: []domain.Fare{{Category: "ADULT", MinAge: 12, Price: 100000}}},
+			age:   5,
+			err:   errs.ErrBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			category, price, err := passengerFare(tc.quota, tc.age)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.category, category)
+			require.Equal(t, tc.price, price)
+		})
+	}
+}
+
+func TestCheckFares(t *testing.T) {
+	t.Parallel()
+	two, eleven, five := 2, 11, 5
+	tests := []struct {
+		name  string
+		fares []domain.Fare
+		err   error
+	}{
+		{
+			name: "valid",
+			fares: []domain.Fare{
+				{Category: "INFANT", MaxAge: &two},
+				{Category: "CHILD", MinAge: 3, MaxAge: &eleven, Price: 75000},
+				{Category: "ADULT", MinAge: 12, Price: 100000},
+			},
+		},
+		{
+			name:  "unknown category",
+			fares: []domain.Fare{{Category: "STUDENT", Price: 50000}},
+			err:   errs.ErrBadRequest,
+		},
+		{
+			name:  "category twice",
+			fares: []domain.Fare{{Category: "ADULT", MinAge: 12}, {Category: "ADULT", MinAge: 60}},
+			err:   errs.ErrBadRequest,
+		},
+		{
+			name:  "overlapping ages",
+			fares: []domain.Fare{{Category: "CHILD", MinAge: 3, MaxAge: &eleven}, {Category: "ADULT", MinAge: 10}},
+			err:   errs.ErrBadRequest,
+		},
+		{
+			name:  "upper age below lower age",
+			fares: []domain.Fare{{Category: "CHILD", MinAge: 12, MaxAge: &five}},
+			err:   errs.ErrBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkFares(tc.fares)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCheckLaps(t *testing.T) {
+	t.Parallel()
+	ticket := func(scheduleID uint, category string) *domain.Ticket {
+		return &domain.Ticket{ScheduleID: scheduleID, FareCategory: category}
+	}
+	tests := []struct {
+		name    string
+		tickets []*domain.Ticket
+		err     error
+	}{
+		{
+			name:    "an infant per adult and senior",
+			tickets: []*domain.Ticket{ticket(1, "ADULT"), ticket(1, "SENIOR"), ticket(1, "INFANT"), ticket(1, "INFANT")},
+		},
+		{
+			name:    "children hold no infants",
+			tickets: []*domain.Ticket{ticket(1, "CHILD"), ticket(1, "INFANT")},
+			err:     errs.ErrBadRequest,
+		},
+		{
+			name:    "adults on another leg",
+			tickets: []*domain.Ticket{ticket(1, "ADULT"), ticket(1, "INFANT"), ticket(2, "ADULT"), ticket(1, "INFANT")},
+			err:     errs.ErrBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkLaps(tc.tickets)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"eticket-api/internal/domain"
+	"math"
+)
+
+// ticketFees works out the fees charged on a ticket departing on schedule
+// from the active fee components. Percentages are taken of the ticket price,
+// and every fee is rounded to whole rupiah.
+func ticketFees(components []*domain.FeeComponent, schedule *domain.Schedule, ticket *domain.Ticket) []domain.TicketFee {
+	var fees []domain.TicketFee
+	for _, component := range components {
+		if !feeApplies(component, schedule, ticket.ClassID) {
+			continue
+		}
+		amount := component.Amount
+		if component.Percent > 0 {
+			amount = ticket.Price * component.Percent / 100
+		}
+		amount = math.Round(amount)
+		if amount <= 0 {
+			continue
+		}
+		componentID := component.ID
+		fees = append(fees, domain.TicketFee{
+			BookingID:      ticket.BookingID,
+			FeeComponentID: &componentID,
+			Name:           component.Name,
+			Kind:           component.Kind,
+			Amount:         amount,
+		})
+	}
+	return fees
+}
+
+// feeApplies reports whether a component's harbor, route and class
+// restrictions all hold for a ticket of classID on schedule.
+func feeApplies(component *domain.FeeComponent, schedule *domain.Schedule, classID uint) bool {
+	if component.HarborID != nil && *component.HarborID != schedule.DepartureHarborID {
+		return false
+	}
+	if component.DepartureHarborID != nil && *component.DepartureHarborID != schedule.DepartureHarborID {
+		return false
+	}
+	if component.ArrivalHarborID != nil && *component.ArrivalHarborID != schedule.ArrivalHarborID {
+		return false
+	}
+	if component.ClassID != nil && *component.ClassID != classID {
+		return false
+	}
+	return true
+}
+
+func feeTotal(fees []domain.TicketFee) float64 {
+	var total float64
+	for _, fee := range fees {
+		total += fee.Amount
+	}
+	return total
+}
+
+// groupFees adds up fees of the same name across tickets, in the order the
+// names first appear, for one order item per fee.
+func groupFees(fees []domain.TicketFee) []domain.TicketFee {
+	var grouped []domain.TicketFee
+	index := make(map[string]int)
+	for _, fee := range fees {
+		i, ok := index[fee.Name]
+		if !ok {
+			index[fee.Name] = len(grouped)
+			grouped = append(grouped, domain.TicketFee{Name: fee.Name, Kind: fee.Kind, Amount: fee.Amount})
+			continue
+		}
+		grouped[i].Amount += fee.Amount
+	}
+	return grouped
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"strings"
+)
+
+type FeeComponentUsecase struct {
+	Transactor             transact.Transactor
+	FeeComponentRepository domain.FeeComponentRepository
+	HarborRepository       domain.HarborRepository
+	ClassRepository        domain.ClassRepository
+}
+
+func NewFeeComponentUsecase(
+	transactor transact.Transactor,
+	fee_component_repository domain.FeeComponentRepository,
+	harbor_repository domain.HarborRepository,
+	class_repository domain.ClassRepository,
+) *FeeComponentUsecase {
+	return &FeeComponentUsecase{
+		Transactor:             transactor,
+		FeeComponentRepository: fee_component_repository,
+		HarborRepository:       harbor_repository,
+		ClassRepository:        class_repository,
+	}
+}
+
+func (uc *FeeComponentUsecase) CreateFeeComponent(ctx context.Context, e *domain.FeeComponent) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if err := uc.checkFeeComponent(ctx, tx, e); err != nil {
+			return err
+		}
+
+		component := &domain.FeeComponent{
+			Name:              e.Name,
+			Kind:              e.Kind,
+			Amount:            e.Amount,
+			Percent:           e.Percent,
+			HarborID:          e.HarborID,
+			DepartureHarborID: e.DepartureHarborID,
+			ArrivalHarborID:   e.ArrivalHarborID,
+			ClassID:           e.ClassID,
+			IsActive:          e.IsActive,
+		}
+		if err := uc.FeeComponentRepository.Insert(ctx, tx, component); err != nil {
+			return fmt.Errorf("failed to create fee component: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *FeeComponentUsecase) ListFeeComponents(ctx context.Context, limit, offset int, sort, search string) ([]*domain.FeeComponent, int, error) {
+	var err error
+	var total int64
+	var components []*domain.FeeComponent
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		total, err = uc.FeeComponentRepository.Count(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to count fee components: %w", err)
+		}
+		components, err = uc.FeeComponentRepository.FindAll(ctx, tx, limit, offset, sort, search)
+		if err != nil {
+			return fmt.Errorf("failed to get all fee components: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to list fee components: %w", err)
+	}
+
+	return components, int(total), nil
+}
+
+func (uc *FeeComponentUsecase) GetFeeComponentByID(ctx context.Context, id uint) (*domain.FeeComponent, error) {
+	var err error
+	var component *domain.FeeComponent
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		component, err = uc.FeeComponentRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get fee component: %w", err)
+		}
+		if component == nil {
+			return errs.ErrNotFound
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get fee component by ID: %w", err)
+	}
+	return component, nil
+}
+
+// UpdateFeeComponent changes the fee for tickets sold from now on. Tickets
+// already sold keep the fees they were charged.
+func (uc *FeeComponentUsecase) UpdateFeeComponent(ctx context.Context, e *domain.FeeComponent) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		component, err := uc.FeeComponentRepository.FindByID(ctx, tx, e.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find fee component: %w", err)
+		}
+		if component == nil {
+			return errs.ErrNotFound
+		}
+		if err := uc.checkFeeComponent(ctx, tx, e); err != nil {
+			return err
+		}
+
+		component.Name = e.Name
+		component.Kind = e.Kind
+		component.Amount = e.Amount
+		component.Percent = e.Percent
+		component.HarborID = e.HarborID
+		component.DepartureHarborID = e.DepartureHarborID
+		component.ArrivalHarborID = e.ArrivalHarborID
+		component.ClassID = e.ClassID
+		component.IsActive = e.IsActive
+
+		if err := uc.FeeComponentRepository.Update(ctx, tx, component); err != nil {
+			return fmt.Errorf("failed to update fee component: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *FeeComponentUsecase) DeleteFeeComponent(ctx context.Context, id uint) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		component, err := uc.FeeComponentRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get fee component: %w", err)
+		}
+		if component == nil {
+			return errs.ErrNotFound
+		}
+
+		if err := uc.FeeComponentRepository.Delete(ctx, tx, component); err != nil {
+			return fmt.Errorf("failed to delete fee component: %w", err)
+		}
+		return nil
+	})
+}
+
+// checkFeeComponent validates the kind, the charge and the restrictions. A
+// component charges either an amount or a percent, never both, and a route
+// needs both harbors.
+func (uc *FeeComponentUsecase) checkFeeComponent(ctx context.Context, conn gotann.Connection, component *domain.FeeComponent) error {
+	component.Name = strings.TrimSpace(component.Name)
+	if component.Name == "" {
+		return fmt.Errorf("fee name is empty: %w", errs.ErrBadRequest)
+	}
+	switch component.Kind {
+	case enum.FeePortService.String(), enum.FeeInsurance.String(), enum.FeeTax.String():
+	default:
+		return fmt.Errorf("unknown fee kind %s: %w", component.Kind, errs.ErrBadRequest)
+	}
+	if component.Amount < 0 || component.Percent < 0 || component.Percent > 100 {
+		return fmt.Errorf("invalid fee amount or percent: %w", errs.ErrBadRequest)
+	}
+	if (component.Amount > 0) == (component.Percent > 0) {
+		return fmt.Errorf("fee needs either an amount or a percent: %w", errs.ErrBadRequest)
+	}
+	if (component.DepartureHarborID == nil) != (component.ArrivalHarborID == nil) {
+		return fmt.Errorf("route needs both departure and arrival harbor: %w", errs.ErrBadRequest)
+	}
+
+	for _, harborID := range []*uint{component.HarborID, component.DepartureHarborID, component.ArrivalHarborID} {
+		if harborID == nil {
+			continue
+		}
+		harbor, err := uc.HarborRepository.FindByID(ctx, conn, *harborID)
+		if err != nil {
+			return fmt.Errorf("failed to get harbor: %w", err)
+		}
+		if harbor == nil {
+			return fmt.Errorf("harbor %d: %w", *harborID, errs.ErrNotFound)
+		}
+	}
+	if component.ClassID != nil {
+		class, err := uc.ClassRepository.FindByID(ctx, conn, *component.ClassID)
+		if err != nil {
+			return fmt.Errorf("failed to get class: %w", err)
+		}
+		if class == nil {
+			return fmt.Errorf("class %d: %w", *component.ClassID, errs.ErrNotFound)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func feeComponentUsecase(t *testing.T) (*FeeComponentUsecase, *mocks.MockFeeComponentRepository, *mocks.MockHarborRepository, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockFeeComponentRepository(ctrl)
+	harborRepo := mocks.NewMockHarborRepository(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	uc := NewFeeComponentUsecase(transactor, repo, harborRepo, mocks.NewMockClassRepository(ctrl))
+	return uc, repo, harborRepo, transactor
+}
+
+func TestFeeComponentUsecase_CreateFeeComponent(t *testing.T) {
+	t.Parallel()
+	uc, repo, harborRepo, transactor := feeComponentUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	merak := uint(1)
+	tests := []struct {
+		name      string
+		component *domain.FeeComponent
+		mock      func()
+		err       error
+	}{
+		{
+			name:      "success",
+			component: &domain.FeeComponent{Name: " PPN ", Kind: "TAX", Percent: 11},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				repo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, component *domain.FeeComponent) error {
+						require.Equal(t, "PPN", component.Name)
+						return nil
+					},
+				)
+			},
+		},
+		{
+			name:      "unknown kind",
+			component: &domain.FeeComponent{Name: "Parkir", Kind: "PARKING", Amount: 2000},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name:      "amount and percent",
+			component: &domain.FeeComponent{Name: "PPN", Kind: "TAX", Amount: 2000, Percent: 11},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name:      "route with one harbor",
+			component: &domain.FeeComponent{Name: "Jasa Raharja", Kind: "INSURANCE", Amount: 2000, DepartureHarborID: &merak},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name:      "harbor not found",
+			component: &domain.FeeComponent{Name: "Jasa Pelabuhan", Kind: "PORT_SERVICE", Amount: 5000, HarborID: &merak},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				harborRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), merak).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+		{
+			name:      "repo error",
+			component: &domain.FeeComponent{Name: "Jasa Pelabuhan", Kind: "PORT_SERVICE", Amount: 5000},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				repo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.CreateFeeComponent(context.Background(), tc.component)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFeeComponentUsecase_DeleteFeeComponent(t *testing.T) {
+	t.Parallel()
+	uc, repo, _, transactor := feeComponentUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	tests := []struct {
+		name string
+		mock func()
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.FeeComponent{ID: 1}, nil)
+				repo.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "not found",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.DeleteFeeComponent(context.Background(), 1)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"testing"
+
+	"eticket-api/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketFees(t *testing.T) {
+	t.Parallel()
+	merak, bakauheni, other, economy := uint(1), uint(2), uint(9), uint(3)
+	bookingID := uint(7)
+	schedule := &domain.Schedule{DepartureHarborID: merak, ArrivalHarborID: bakauheni}
+	ticket := &domain.Ticket{ClassID: economy, Price: 60500, BookingID: &bookingID}
+	tests := []struct {
+		name       string
+		components []*domain.FeeComponent
+		want       []float64
+	}{
+		{
+			name:       "fixed amount",
+			components: []*domain.FeeComponent{{ID: 1, Name: "Jasa Pelabuhan", Kind: "PORT_SERVICE", Amount: 5000}},
+			want:       []float64{5000},
+		},
+		{
+			name:       "percent rounded to rupiah",
+			components: []*domain.FeeComponent{{ID: 1, Name: "PPN", Kind: "TAX", Percent: 11}},
+			want:       []float64{6655},
+		},
+		{
+			name: "departure harbor",
+			components: []*domain.FeeComponent{
+				{ID: 1, Name: "Jasa Pelabuhan", Kind: "PORT_SERVICE", Amount: 5000, HarborID: &merak},
+				{ID: 2, Name: "Jasa Pelabuhan", Kind: "PORT_SERVICE", Amount: 4000, HarborID: &bakauheni},
+			},
+			want: []float64{5000},
+		},
+		{
+			name: "route and class",
+			components: []*domain.FeeComponent{
+				{ID: 1, Name: "Jasa Raharja", Kind: "INSURANCE", Amount: 2000, DepartureHarborID: &merak, ArrivalHarborID: &bakauheni, ClassID: &economy},
+				{ID: 2, Name: "Jasa Raharja", Kind: "INSURANCE", Amount: 3000, DepartureHarborID: &bakauheni, ArrivalHarborID: &merak},
+				{ID: 3, Name: "Asuransi Bisnis", Kind: "INSURANCE", Amount: 1000, ClassID: &other},
+			},
+			want: []float64{2000},
+		},
+		{
+			name:       "zero fee skipped",
+			components: []*domain.FeeComponent{{ID: 1, Name: "PPN", Kind: "TAX", Percent: 0.0001}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fees := ticketFees(tc.components, schedule, ticket)
+			require.Len(t, fees, len(tc.want))
+			for i, fee := range fees {
+				require.Equal(t, tc.want[i], fee.Amount)
+				require.Equal(t, &bookingID, fee.BookingID)
+				require.NotNil(t, fee.FeeComponentID)
+			}
+		})
+	}
+}
+
+func TestGroupFees(t *testing.T) {
+	t.Parallel()
+	fees := []domain.TicketFee{
+		{Name: "Jasa Pelabuhan", Kind: "PORT_SERVICE", Amount: 5000},
+		{Name: "PPN", Kind: "TAX", Amount: 6655},
+		{Name: "Jasa Pelabuhan", Kind: "PORT_SERVICE", Amount: 5000},
+	}
+	grouped := groupFees(fees)
+	require.Equal(t, []domain.TicketFee{
+		{Name: "Jasa Pelabuhan", Kind: "PORT_SERVICE", Amount: 10000},
+		{Name: "PPN", Kind: "TAX", Amount: 6655},
+	}, grouped)
+	require.Equal(t, 16655.0, feeTotal(fees))
+}
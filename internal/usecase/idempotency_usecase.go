@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Stored responses are replayed for a day; after that the key can be reused
+const idempotencyKeyTTL = 24 * time.Hour
+
+type IdempotencyUsecase struct {
+	Transactor               transact.Transactor
+	IdempotencyKeyRepository domain.IdempotencyKeyRepository
+}
+
+func NewIdempotencyUsecase(
+	transactor transact.Transactor,
+	idempotency_key_repository domain.IdempotencyKeyRepository,
+) *IdempotencyUsecase {
+	return &IdempotencyUsecase{
+		Transactor:               transactor,
+		IdempotencyKeyRepository: idempotency_key_repository,
+	}
+}
+
+// Begin reserves a key for a request. When the key already holds a completed
+// response for the same request, that record is returned with replay set and
+// the request must not run again. A key reused for a different request gives
+// ErrKeyReused, and a key whose first request is still running ErrInProgress.
+func (uc *IdempotencyUsecase) Begin(ctx context.Context, key, scope, fingerprint string) (*domain.IdempotencyKey, bool, error) {
+	var replay bool
+	var record *domain.IdempotencyKey
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		existing, err := uc.IdempotencyKeyRepository.FindByKey(ctx, tx, key, scope)
+		if err != nil {
+			return fmt.Errorf("failed to get idempotency key: %w", err)
+		}
+		now := time.Now()
+		if existing != nil && existing.ExpiresAt.After(now) {
+			if existing.Fingerprint != fingerprint {
+				return errs.ErrKeyReused
+			}
+			if existing.Status != enum.IdempotencyCompleted.String() {
+				return errs.ErrInProgress
+			}
+			record, replay = existing, true
+			return nil
+		}
+		if existing != nil {
+			if err := uc.IdempotencyKeyRepository.Delete(ctx, tx, existing); err != nil {
+				return fmt.Errorf("failed to delete expired idempotency key: %w", err)
+			}
+		}
+
+		record = &domain.IdempotencyKey{
+			Key:         key,
+			Scope:       scope,
+			Fingerprint: fingerprint,
+			Status:      enum.IdempotencyProcessing.String(),
+			ExpiresAt:   now.Add(idempotencyKeyTTL),
+		}
+		if err := uc.IdempotencyKeyRepository.Insert(ctx, tx, record); err != nil {
+			// Another request with the same key got here first
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrInProgress
+			}
+			return fmt.Errorf("failed to create idempotency key: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, false, fmt.Errorf("failed to begin idempotent request: %w", err)
+	}
+	return record, replay, nil
+}
+
+// Complete stores the response of a request started with Begin. Server errors
+// are not stored, the key is released so the client can retry.
+func (uc *IdempotencyUsecase) Complete(ctx context.Context, record *domain.IdempotencyKey, status int, body []byte) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if status >= http.StatusInternalServerError {
+			if err := uc.IdempotencyKeyRepository.Delete(ctx, tx, record); err != nil {
+				return fmt.Errorf("failed to release idempotency key: %w", err)
+			}
+			return nil
+		}
+		record.Status = enum.IdempotencyCompleted.String()
+		record.ResponseStatus = status
+		record.ResponseBody = body
+		if err := uc.IdempotencyKeyRepository.Update(ctx, tx, record); err != nil {
+			return fmt.Errorf("failed to store idempotent response: %w", err)
+		}
+		return nil
+	})
+}
+
+// DeleteExpired removes keys whose stored response is no longer replayed.
+func (uc *IdempotencyUsecase) DeleteExpired(ctx context.Context) (int64, error) {
+	var deleted int64
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		deleted, err = uc.IdempotencyKeyRepository.DeleteExpired(ctx, tx, time.Now())
+		return err
+	}); err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func idempotencyUsecase(t *testing.T) (*IdempotencyUsecase, *mocks.MockIdempotencyKeyRepository, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	keyRepo := mocks.NewMockIdempotencyKeyRepository(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	uc := NewIdempotencyUsecase(transactor, keyRepo)
+	return uc, keyRepo, transactor
+}
+
+func TestIdempotencyUsecase_Begin(t *testing.T) {
+	t.Parallel()
+	uc, keyRepo, transactor := idempotencyUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	stored := func(status string, expiresAt time.Time) *domain.IdempotencyKey {
+		return &domain.IdempotencyKey{
+			ID:             1,
+			Key:            "k1",
+			Scope:          "POST /payment",
+			Fingerprint:    "abc",
+			Status:         status,
+			ResponseStatus: http.StatusOK,
+			ResponseBody:   []byte(`{"status":true}`),
+			ExpiresAt:      expiresAt,
+		}
+	}
+	tests := []struct {
+		name        string
+		fingerprint string
+		mock        func()
+		replay      bool
+		err         error
+	}{
+		{
+			name:        "new key",
+			fingerprint: "abc",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				keyRepo.EXPECT().FindByKey(gomock.Any(), gomock.Any(), "k1", "POST /payment").Return(nil, nil)
+				keyRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, key *domain.IdempotencyKey) error {
+						require.Equal(t, enum.IdempotencyProcessing.String(), key.Status)
+						return nil
+					},
+				)
+			},
+		},
+		{
+			name:        "replay",
+			fingerprint: "abc",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				keyRepo.EXPECT().FindByKey(gomock.Any(), gomock.Any(), "k1", "POST /payment").
+					Return(stored(enum.IdempotencyCompleted.String(), time.Now().Add(time.Hour)), nil)
+			},
+			replay: true,
+		},
+		{
+			name:        "different request",
+			fingerprint: "def",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				keyRepo.EXPECT().FindByKey(gomock.Any(), gomock.Any(), "k1", "POST /payment").
+					Return(stored(enum.IdempotencyCompleted.String(), time.Now().Add(time.Hour)), nil)
+			},
+			err: errs.ErrKeyReused,
+		},
+		{
+			name:        "still processing",
+			fingerprint: "abc",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				keyRepo.EXPECT().FindByKey(gomock.Any(), gomock.Any(), "k1", "POST /payment").
+					Return(stored(enum.IdempotencyProcessing.String(), time.Now().Add(time.Hour)), nil)
+			},
+			err: errs.ErrInProgress,
+		},
+		{
+			name:        "expired key is reused",
+			fingerprint: "def",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				keyRepo.EXPECT().FindByKey(gomock.Any(), gomock.Any(), "k1", "POST /payment").
+					Return(stored(enum.IdempotencyCompleted.String(), time.Now().Add(-time.Hour)), nil)
+				keyRepo.EXPECT().Delete(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				keyRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:        "concurrent insert",
+			fingerprint: "abc",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				keyRepo.EXPECT().FindByKey(gomock.Any(), gomock.Any(), "k1", "POST /payment").Return(nil, nil)
+				keyRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("duplicate key value violates unique constraint"))
+			},
+			err: errs.ErrInProgress,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			record, replay, err := uc.Begin(context.Background(), "k1", "POST /payment", tc.fingerprint)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.replay, replay)
+			require.Equal(t, tc.fingerprint, record.Fingerprint)
+		})
+	}
+}
+
+func TestIdempotencyUsecase_Complete(t *testing.T) {
+	t.Parallel()
+	uc, keyRepo, transactor := idempotencyUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+
+	transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).Times(2)
+	record := &domain.IdempotencyKey{ID: 1, Status: enum.IdempotencyProcessing.String()}
+	keyRepo.EXPECT().Update(gomock.Any(), gomock.Any(), record).Return(nil)
+	require.NoError(t, uc.Complete(context.Background(), record, http.StatusConflict, []byte(`{}`)))
+	require.Equal(t, enum.IdempotencyCompleted.String(), record.Status)
+	require.Equal(t, http.StatusConflict, record.ResponseStatus)
+
+	// Server errors release the key so the client can retry
+	failed := &domain.IdempotencyKey{ID: 2, Status: enum.IdempotencyProcessing.String()}
+	keyRepo.EXPECT().Delete(gomock.Any(), gomock.Any(), failed).Return(nil)
+	require.NoError(t, uc.Complete(context.Background(), failed, http.StatusInternalServerError, nil))
+}
@@ -0,0 +1,336 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/templates"
+	"eticket-api/internal/common/token"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/common/utils"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	bookingAccessCodeLength      = 6
+	bookingAccessCodeTTL         = 10 * time.Minute
+	bookingAccessCodeCooldown    = time.Minute
+	bookingAccessCodeMaxAttempts = 5
+)
+
+// ManageBookingUsecase serves the customer portal. Customers prove they own a
+// booking with its order ID and personal data, or with a one-time code sent to
+// the booking's email, and get a token scoped to that booking.
+type ManageBookingUsecase struct {
+	Transactor                  transact.Transactor
+	BookingRepository           domain.BookingRepository
+	TicketRepository            domain.TicketRepository
+	BookingAccessCodeRepository domain.BookingAccessCodeRepository
+	OutboxRepository            domain.OutboxRepository
+	Outbox                      *OutboxUsecase
+	Booking                     *BookingUsecase
+	TokenUtil                   token.TokenUtil
+}
+
+func NewManageBookingUsecase(
+	transactor transact.Transactor,
+	booking_repository domain.BookingRepository,
+	ticket_repository domain.TicketRepository,
+	booking_access_code_repository domain.BookingAccessCodeRepository,
+	outbox_repository domain.OutboxRepository,
+	outbox *OutboxUsecase,
+	booking *BookingUsecase,
+	token_util token.TokenUtil,
+) *ManageBookingUsecase {
+	return &ManageBookingUsecase{
+		Transactor:                  transactor,
+		BookingRepository:           booking_repository,
+		TicketRepository:            ticket_repository,
+		BookingAccessCodeRepository: booking_access_code_repository,
+		OutboxRepository:            outbox_repository,
+		Outbox:                      outbox,
+		Booking:                     booking,
+		TokenUtil:                   token_util,
+	}
+}
+
+// Login issues a booking token when the email and ID number match the
+// booking. A wrong order ID and wrong details give the same ErrUnauthorized.
+func (uc *ManageBookingUsecase) Login(ctx context.Context, orderID, email, idNumber string) (*domain.Booking, string, error) {
+	var err error
+	var booking *domain.Booking
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		booking, err = uc.BookingRepository.FindByOrderID(ctx, tx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking == nil || !sameEmail(booking.Email, email) || booking.IDNumber != strings.TrimSpace(idNumber) {
+			return errs.ErrUnauthorized
+		}
+		return nil
+	}); err != nil {
+		return nil, "", fmt.Errorf("failed to log in to booking: %w", err)
+	}
+
+	accessToken, err := uc.TokenUtil.GenerateBookingToken(booking)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate booking token: %w", err)
+	}
+	return booking, accessToken, nil
+}
+
+// RequestAccessCode emails a one-time code to the booking's address. Nothing
+// tells the caller whether the order ID and email matched a booking.
+func (uc *ManageBookingUsecase) RequestAccessCode(ctx context.Context, orderID, email string) error {
+	var notice *domain.Outbox
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		booking, err := uc.BookingRepository.FindByOrderID(ctx, tx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking == nil || !sameEmail(booking.Email, email) {
+			return nil
+		}
+
+		latest, err := uc.BookingAccessCodeRepository.FindLatestByBookingID(ctx, tx, booking.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get access code: %w", err)
+		}
+		now := time.Now()
+		if latest != nil && latest.UsedAt == nil && latest.CreatedAt.Add(bookingAccessCodeCooldown).After(now) {
+			return fmt.Errorf("access code was sent less than a minute ago: %w", errs.ErrConflict)
+		}
+
+		code, err := utils.GenerateNumericCode(bookingAccessCodeLength)
+		if err != nil {
+			return fmt.Errorf("failed to generate access code: %w", err)
+		}
+		if err := uc.BookingAccessCodeRepository.Insert(ctx, tx, &domain.BookingAccessCode{
+			BookingID: booking.ID,
+			CodeHash:  utils.HashToken(code),
+			ExpiresAt: now.Add(bookingAccessCodeTTL),
+		}); err != nil {
+			return fmt.Errorf("failed to create access code: %w", err)
+		}
+
+		notice, err = enqueueEmail(ctx, tx, uc.OutboxRepository, booking.OrderID, booking.Email,
+			"Kode Akses Pesanan "+booking.OrderID, templates.BookingAccessCodeEmail(booking, code, bookingAccessCodeTTL))
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to request access code: %w", err)
+	}
+
+	_ = uc.Outbox.Dispatch(ctx, notice)
+	return nil
+}
+
+// VerifyAccessCode trades the latest code of a booking for a booking token.
+// A code works once, expires after ten minutes and is burned after five
+// wrong guesses.
+func (uc *ManageBookingUsecase) VerifyAccessCode(ctx context.Context, orderID, code string) (*domain.Booking, string, error) {
+	var err error
+	var valid bool
+	var booking *domain.Booking
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		booking, err = uc.BookingRepository.FindByOrderID(ctx, tx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking == nil {
+			return nil
+		}
+		latest, err := uc.BookingAccessCodeRepository.FindLatestByBookingID(ctx, tx, booking.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get access code: %w", err)
+		}
+		now := time.Now()
+		if latest == nil || latest.UsedAt != nil || !latest.ExpiresAt.After(now) || latest.Attempts >= bookingAccessCodeMaxAttempts {
+			return nil
+		}
+
+		// Wrong guesses are counted, so the transaction commits either way
+		valid = subtle.ConstantTimeCompare([]byte(latest.CodeHash), []byte(utils.HashToken(strings.TrimSpace(code)))) == 1
+		if valid {
+			latest.UsedAt = &now
+		} else {
+			latest.Attempts++
+		}
+		if err := uc.BookingAccessCodeRepository.Update(ctx, tx, latest); err != nil {
+			return fmt.Errorf("failed to update access code: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, "", fmt.Errorf("failed to verify access code: %w", err)
+	}
+	if !valid {
+		return nil, "", fmt.Errorf("failed to verify access code: %w", errs.ErrUnauthorized)
+	}
+
+	accessToken, err := uc.TokenUtil.GenerateBookingToken(booking)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate booking token: %w", err)
+	}
+	return booking, accessToken, nil
+}
+
+func (uc *ManageBookingUsecase) GetBooking(ctx context.Context, bookingID uint) (*domain.Booking, error) {
+	var err error
+	var booking *domain.Booking
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		booking, err = uc.BookingRepository.FindByID(ctx, tx, bookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking == nil {
+			return errs.ErrNotFound
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+	return booking, nil
+}
+
+// ResendTicket sends the e-ticket email of a paid booking again, to the
+// booking's current email.
+func (uc *ManageBookingUsecase) ResendTicket(ctx context.Context, bookingID uint) error {
+	var notice *domain.Outbox
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		booking, err := uc.BookingRepository.FindByID(ctx, tx, bookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking == nil {
+			return errs.ErrNotFound
+		}
+		if booking.Status != enum.BookingPaid.String() {
+			return fmt.Errorf("booking %s is not paid: %w", booking.OrderID, errs.ErrConflict)
+		}
+
+		tickets, err := uc.TicketRepository.FindByBookingID(ctx, tx, booking.ID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve tickets: %w", err)
+		}
+		attachments, err := confirmationAttachments(uc.TokenUtil, booking, tickets)
+		if err != nil {
+			return fmt.Errorf("failed to render booking documents: %w", err)
+		}
+		notice, err = enqueueEmail(ctx, tx, uc.OutboxRepository, booking.OrderID, booking.Email,
+			"Your Booking is Confirmed", templates.BookingSuccessEmail(booking, tickets), attachments...)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to resend ticket: %w", err)
+	}
+
+	// A failed send is retried by the outbox job
+	_ = uc.Outbox.Dispatch(ctx, notice)
+	return nil
+}
+
+// GetTicketPass returns a ticket of the booking with the signed payload of its
+// QR code.
+func (uc *ManageBookingUsecase) GetTicketPass(ctx context.Context, bookingID, ticketID uint) (*domain.Ticket, string, error) {
+	var err error
+	var ticket *domain.Ticket
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		ticket, err = uc.TicketRepository.FindByID(ctx, tx, ticketID)
+		if err != nil {
+			return fmt.Errorf("failed to get ticket: %w", err)
+		}
+		// Tickets of other bookings look the same as missing ones
+		if ticket == nil || ticket.BookingID == nil || *ticket.BookingID != bookingID {
+			return errs.ErrNotFound
+		}
+		return nil
+	}); err != nil {
+		return nil, "", fmt.Errorf("failed to get ticket pass: %w", err)
+	}
+
+	payload, err := signTicket(uc.TokenUtil, ticket)
+	if err != nil {
+		return nil, "", err
+	}
+	return ticket, payload, nil
+}
+
+// GetDocument renders the e-tickets or the invoice of the booking as a PDF.
+func (uc *ManageBookingUsecase) GetDocument(ctx context.Context, bookingID uint, document string) (*domain.EmailAttachment, error) {
+	var booking *domain.Booking
+	var tickets []*domain.Ticket
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		booking, err = uc.BookingRepository.FindByID(ctx, tx, bookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking == nil {
+			return errs.ErrNotFound
+		}
+		tickets, err = uc.TicketRepository.FindByBookingID(ctx, tx, booking.ID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve tickets: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get booking document: %w", err)
+	}
+	return bookingDocument(uc.TokenUtil, booking, tickets, document)
+}
+
+// UpdateContact changes where booking emails and calls go. Tickets and
+// passenger data are left alone.
+func (uc *ManageBookingUsecase) UpdateContact(ctx context.Context, bookingID uint, email, phoneNumber string) (*domain.Booking, error) {
+	var booking *domain.Booking
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		current, err := uc.BookingRepository.FindByID(ctx, tx, bookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if current == nil {
+			return errs.ErrNotFound
+		}
+
+		current.Email = strings.TrimSpace(email)
+		current.PhoneNumber = strings.TrimSpace(phoneNumber)
+		current.Tickets = nil
+		current.Changes = nil
+		current.Fees = nil
+		current.Addons = nil
+		current.Schedule = domain.Schedule{}
+		if err := uc.BookingRepository.Update(ctx, tx, current); err != nil {
+			return fmt.Errorf("failed to update booking contact: %w", err)
+		}
+
+		booking, err = uc.BookingRepository.FindByID(ctx, tx, bookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update contact details: %w", err)
+	}
+	return booking, nil
+}
+
+// CancelBooking cancels the booking under its cancellation policy, the same
+// way the refund endpoint does.
+func (uc *ManageBookingUsecase) CancelBooking(ctx context.Context, bookingID uint) (*domain.Booking, []*domain.Refund, error) {
+	booking, err := uc.GetBooking(ctx, bookingID)
+	if err != nil {
+		return nil, nil, err
+	}
+	refunds, err := uc.Booking.RefundBooking(ctx, booking.OrderID, booking.Email, booking.IDNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	return booking, refunds, nil
+}
+
+func sameEmail(stored, given string) bool {
+	return strings.EqualFold(strings.TrimSpace(stored), strings.TrimSpace(given))
+}
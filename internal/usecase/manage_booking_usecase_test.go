@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/utils"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func manageBookingUsecase(t *testing.T) (*ManageBookingUsecase, *mocks.MockBookingRepository, *mocks.MockBookingAccessCodeRepository, *mocks.MockOutboxRepository, *mocks.MockTokenUtil, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	bookingRepo := mocks.NewMockBookingRepository(ctrl)
+	ticketRepo := mocks.NewMockTicketRepository(ctrl)
+	accessCodeRepo := mocks.NewMockBookingAccessCodeRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	bookingChangeRepo := mocks.NewMockBookingChangeRepository(ctrl)
+	tripayClient := mocks.NewMockTripayClient(ctrl)
+	mailer := mocks.NewMockMailer(ctrl)
+	tokenUtil := mocks.NewMockTokenUtil(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	outbox := NewOutboxUsecase(transactor, outboxRepo, bookingRepo, bookingChangeRepo, tripayClient, mailer, nil)
+	uc := NewManageBookingUsecase(transactor, bookingRepo, ticketRepo, accessCodeRepo, outboxRepo, outbox, nil, tokenUtil)
+	return uc, bookingRepo, accessCodeRepo, outboxRepo, tokenUtil, transactor
+}
+
+func TestManageBookingUsecase_Login(t *testing.T) {
+	t.Parallel()
+	uc, bookingRepo, _, _, tokenUtil, transactor := manageBookingUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	booking := &domain.Booking{ID: 1, OrderID: "ORD1", Email: "Budi@Mail.com", IDNumber: "3201"}
+	tests := []struct {
+		name     string
+		email    string
+		idNumber string
+		mock     func()
+		err      error
+	}{
+		{
+			name:     "success",
+			email:    " budi@mail.com ",
+			idNumber: "3201",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD1").Return(booking, nil)
+				tokenUtil.EXPECT().GenerateBookingToken(booking).Return("token", nil)
+			},
+		},
+		{
+			name:     "wrong ID number",
+			email:    "budi@mail.com",
+			idNumber: "9999",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD1").Return(booking, nil)
+			},
+			err: errs.ErrUnauthorized,
+		},
+		{
+			name:     "unknown order",
+			email:    "budi@mail.com",
+			idNumber: "3201",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD1").Return(nil, nil)
+			},
+			err: errs.ErrUnauthorized,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			_, accessToken, err := uc.Login(context.Background(), "ORD1", tc.email, tc.idNumber)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "token", accessToken)
+		})
+	}
+}
+
+func TestManageBookingUsecase_RequestAccessCode(t *testing.T) {
+	t.Parallel()
+	uc, bookingRepo, accessCodeRepo, outboxRepo, _, transactor := manageBookingUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	booking := &domain.Booking{ID: 1, OrderID: "ORD1", Email: "budi@mail.com"}
+	tests := []struct {
+		name  string
+		email string
+		mock  func()
+		err   error
+	}{
+		{
+			name:  "code sent",
+			email: "budi@mail.com",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).Times(2)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD1").Return(booking, nil)
+				accessCodeRepo.EXPECT().FindLatestByBookingID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+				accessCodeRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, code *domain.BookingAccessCode) error {
+						require.Len(t, code.CodeHash, 64)
+						require.True(t, code.ExpiresAt.After(time.Now()))
+						return nil
+					},
+				)
+				outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+			},
+		},
+		{
+			name:  "email does not match",
+			email: "other@mail.com",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD1").Return(booking, nil)
+			},
+		},
+		{
+			name:  "requested too soon",
+			email: "budi@mail.com",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD1").Return(booking, nil)
+				accessCodeRepo.EXPECT().FindLatestByBookingID(gomock.Any(), gomock.Any(), uint(1)).
+					Return(&domain.BookingAccessCode{ID: 1, BookingID: 1, CreatedAt: time.Now()}, nil)
+			},
+			err: errs.ErrConflict,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.RequestAccessCode(context.Background(), "ORD1", tc.email)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestManageBookingUsecase_VerifyAccessCode(t *testing.T) {
+	t.Parallel()
+	uc, bookingRepo, accessCodeRepo, _, tokenUtil, transactor := manageBookingUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	booking := &domain.Booking{ID: 1, OrderID: "ORD1", Email: "budi@mail.com"}
+	accessCode := func(expiresAt time.Time) *domain.BookingAccessCode {
+		return &domain.BookingAccessCode{ID: 1, BookingID: 1, CodeHash: utils.HashToken("123456"), ExpiresAt: expiresAt}
+	}
+	tests := []struct {
+		name string
+		code string
+		mock func()
+		err  error
+	}{
+		{
+			name: "valid code",
+			code: "123456",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD1").Return(booking, nil)
+				accessCodeRepo.EXPECT().FindLatestByBookingID(gomock.Any(), gomock.Any(), uint(1)).Return(accessCode(time.Now().Add(time.Minute)), nil)
+				accessCodeRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, code *domain.BookingAccessCode) error {
+						require.NotNil(t, code.UsedAt)
+						return nil
+					},
+				)
+				tokenUtil.EXPECT().GenerateBookingToken(booking).Return("token", nil)
+			},
+		},
+		{
+			name: "wrong code counts an attempt",
+			code: "654321",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD1").Return(booking, nil)
+				accessCodeRepo.EXPECT().FindLatestByBookingID(gomock.Any(), gomock.Any(), uint(1)).Return(accessCode(time.Now().Add(time.Minute)), nil)
+				accessCodeRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, code *domain.BookingAccessCode) error {
+						require.Equal(t, 1, code.Attempts)
+						require.Nil(t, code.UsedAt)
+						return nil
+					},
+				)
+			},
+			err: errs.ErrUnauthorized,
+		},
+		{
+			name: "expired code",
+			code: "123456",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD1").Return(booking, nil)
+				accessCodeRepo.EXPECT().FindLatestByBookingID(gomock.Any(), gomock.Any(), uint(1)).Return(accessCode(time.Now().Add(-time.Minute)), nil)
+			},
+			err: errs.ErrUnauthorized,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			_, accessToken, err := uc.VerifyAccessCode(context.Background(), "ORD1", tc.code)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "token", accessToken)
+		})
+	}
+}
+
+func TestManageBookingUsecase_ResendTicket(t *testing.T) {
+	t.Parallel()
+	uc, bookingRepo, _, _, _, transactor := manageBookingUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+
+	transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+	bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).
+		Return(&domain.Booking{ID: 1, OrderID: "ORD1", Status: enum.BookingUnpaid.String()}, nil)
+	require.ErrorIs(t, uc.ResendTicket(context.Background(), 1), errs.ErrConflict)
+}
+
+func TestManageBookingUsecase_UpdateContact(t *testing.T) {
+	t.Parallel()
+	uc, bookingRepo, _, _, _, transactor := manageBookingUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	current := &domain.Booking{
+		ID:      1,
+		Email:   "old@b.c",
+		Tickets: []domain.Ticket{{ID: 1}},
+		Fees:    []domain.TicketFee{{TicketID: 1, Amount: 5000}},
+		Addons:  []domain.BookingAddon{{ID: 1, Quantity: 1}},
+	}
+
+	transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+	bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(current, nil)
+	bookingRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, conn gotann.Connection, booking *domain.Booking) error {
+			// Only the booking row is saved, never its fees and add-ons again
+			require.Equal(t, "new@b.c", booking.Email)
+			require.Equal(t, "0812", booking.PhoneNumber)
+			require.Nil(t, booking.Tickets)
+			require.Nil(t, booking.Fees)
+			require.Nil(t, booking.Addons)
+			return nil
+		},
+	)
+	bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Booking{ID: 1, Email: "new@b.c"}, nil)
+
+	booking, err := uc.UpdateContact(context.Background(), 1, " new@b.c ", "0812")
+	require.NoError(t, err)
+	require.Equal(t, "new@b.c", booking.Email)
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/model"
+	"sort"
+	"time"
+)
+
+// buildManifest lists the tickets and add-ons of paid bookings on a schedule,
+// in the order the tickets were issued, with totals per class.
+func buildManifest(schedule *domain.Schedule, tickets []*domain.Ticket, addons []*domain.BookingAddon, now time.Time) *model.Manifest {
+	manifest := &model.Manifest{
+		ScheduleID:        schedule.ID,
+		ShipName:          schedule.Ship.ShipName,
+		DepartureHarbor:   schedule.DepartureHarbor.HarborName,
+		ArrivalHarbor:     schedule.ArrivalHarbor.HarborName,
+		DepartureDatetime: schedule.DepartureDatetime,
+		ArrivalDatetime:   schedule.ArrivalDatetime,
+		GeneratedAt:       now,
+		Passengers:        []model.ManifestPassenger{},
+		Vehicles:          []model.ManifestVehicle{},
+		Addons:            []model.ManifestAddon{},
+		Totals:            []model.ManifestClassTotal{},
+	}
+
+	paid := make([]*domain.Ticket, 0, len(tickets))
+	for _, ticket := range tickets {
+		if ticket.Booking.Status == enum.BookingPaid.String() {
+			paid = append(paid, ticket)
+		}
+	}
+	sort.Slice(paid, func(i, j int) bool { return paid[i].ID < paid[j].ID })
+
+	orderIDs := make(map[uint]string)
+	totals := make(map[uint]*model.ManifestClassTotal)
+	var classIDs []uint
+	for _, ticket := range paid {
+		orderIDs[ticket.Booking.ID] = ticket.Booking.OrderID
+		total, ok := totals[ticket.ClassID]
+		if !ok {
+			total = &model.ManifestClassTotal{ClassName: ticket.Class.ClassName, Type: ticket.Type}
+			totals[ticket.ClassID] = total
+			classIDs = append(classIDs, ticket.ClassID)
+		}
+		total.Tickets++
+		if ticket.IsCheckedIn {
+			total.CheckedIn++
+			manifest.CheckedInCount++
+		}
+
+		if ticket.Type == "vehicle" {
+			vehicle := model.ManifestVehicle{
+				No:            len(manifest.Vehicles) + 1,
+				TicketCode:    ticket.TicketCode,
+				OrderID:       ticket.Booking.OrderID,
+				LicensePlate:  ticket.LicensePlate,
+				VehicleType:   ticket.VehicleType,
+				VehicleBrand:  ticket.VehicleBrand,
+				VehicleLength: ticket.VehicleLength,
+				ClassName:     ticket.Class.ClassName,
+				CheckedIn:     ticket.IsCheckedIn,
+			}
+			if ticket.Driver != nil {
+				vehicle.DriverName = ticket.Driver.PassengerName
+				vehicle.DriverTicketCode = ticket.Driver.TicketCode
+			}
+			manifest.Vehicles = append(manifest.Vehicles, vehicle)
+			continue
+		}
+		manifest.Passengers = append(manifest.Passengers, model.ManifestPassenger{
+			No:           len(manifest.Passengers) + 1,
+			TicketCode:   ticket.TicketCode,
+			OrderID:      ticket.Booking.OrderID,
+			Name:         ticket.PassengerName,
+			Gender:       ticket.PassengerGender,
+			Age:          ticket.PassengerAge,
+			IDType:       ticket.IDType,
+			IDNumber:     ticket.IDNumber,
+			Address:      ticket.Address,
+			ClassName:    ticket.Class.ClassName,
+			FareCategory: ticket.FareCategory,
+			SeatNumber:   ticket.SeatNumber,
+			CheckedIn:    ticket.IsCheckedIn,
+		})
+	}
+	manifest.PassengerCount = len(manifest.Passengers)
+	manifest.VehicleCount = len(manifest.Vehicles)
+
+	sort.Slice(classIDs, func(i, j int) bool { return classIDs[i] < classIDs[j] })
+	for _, classID := range classIDs {
+		manifest.Totals = append(manifest.Totals, *totals[classID])
+	}
+
+	for _, addon := range addons {
+		line := model.ManifestAddon{
+			OrderID:  orderIDs[addon.BookingID],
+			Name:     addon.Name,
+			Kind:     addon.Kind,
+			Quantity: addon.Quantity,
+		}
+		if addon.Ticket != nil {
+			line.TicketCode = addon.Ticket.TicketCode
+			line.PassengerName = addon.Ticket.PassengerName
+			if addon.Ticket.SeatNumber != nil {
+				line.SeatNumber = *addon.Ticket.SeatNumber
+			}
+		}
+		manifest.Addons = append(manifest.Addons, line)
+	}
+	return manifest
+}
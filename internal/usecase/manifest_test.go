@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"eticket-api/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildManifest(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 7, 1, 8, 0, 0, 0, time.UTC)
+	schedule := &domain.Schedule{
+		ID:              1,
+		Ship:            domain.Ship{ShipName: "KMP Legundi"},
+		DepartureHarbor: domain.Harbor{HarborName: "Merak"},
+		ArrivalHarbor:   domain.Harbor{HarborName: "Bakauheni"},
+	}
+	paid := domain.Booking{ID: 7, OrderID: "ORD-7", Status: "PAID"}
+	unpaid := domain.Booking{ID: 8, OrderID: "ORD-8", Status: "UNPAID"}
+	seat := "A1"
+	plate := "B 1234 XY"
+	driver := &domain.Ticket{ID: 10, ClassID: 1, Type: "passenger", TicketCode: "T-10", PassengerName: "Budi", PassengerAge: 40, SeatNumber: &seat, IsCheckedIn: true, Booking: paid, Class: domain.Class{ClassName: "Ekonomi"}}
+	tickets := []*domain.Ticket{
+		{ID: 12, ClassID: 2, Type: "vehicle", TicketCode: "T-12", LicensePlate: &plate, Driver: driver, Booking: paid, Class: domain.Class{ClassName: "Golongan IV"}},
+		driver,
+		{ID: 11, ClassID: 1, Type: "passenger", TicketCode: "T-11", PassengerName: "Sari", PassengerAge: 2, FareCategory: "INFANT", Booking: paid, Class: domain.Class{ClassName: "Ekonomi"}},
+		{ID: 13, ClassID: 1, Type: "passenger", TicketCode: "T-13", PassengerName: "Andi", Booking: unpaid, Class: domain.Class{ClassName: "Ekonomi"}},
+	}
+	addons := []*domain.BookingAddon{
+		{BookingID: 7, Name: "Nasi Box", Kind: "MEAL", Quantity: 1, Ticket: driver},
+		{BookingID: 7, Name: "Bagasi 20kg", Kind: "LUGGAGE", Quantity: 2},
+	}
+
+	manifest := buildManifest(schedule, tickets, addons, now)
+
+	require.Equal(t, "KMP Legundi", manifest.ShipName)
+	require.False(t, manifest.Final)
+	require.Equal(t, 2, manifest.PassengerCount)
+	require.Equal(t, 1, manifest.VehicleCount)
+	require.Equal(t, 1, manifest.CheckedInCount)
+	require.Equal(t, "Budi", manifest.Passengers[0].Name)
+	require.Equal(t, 1, manifest.Passengers[0].No)
+	require.Equal(t, "Sari", manifest.Passengers[1].Name)
+	require.Equal(t, "INFANT", manifest.Passengers[1].FareCategory)
+	require.Equal(t, "Budi", manifest.Vehicles[0].DriverName)
+	require.Equal(t, "T-10", manifest.Vehicles[0].DriverTicketCode)
+	require.Len(t, manifest.Totals, 2)
+	require.Equal(t, "Ekonomi", manifest.Totals[0].ClassName)
+	require.Equal(t, 2, manifest.Totals[0].Tickets)
+	require.Equal(t, 1, manifest.Totals[0].CheckedIn)
+	require.Equal(t, 1, manifest.Totals[1].Tickets)
+	require.Len(t, manifest.Addons, 2)
+	require.Equal(t, "ORD-7", manifest.Addons[0].OrderID)
+	require.Equal(t, "A1", manifest.Addons[0].SeatNumber)
+	require.Empty(t, manifest.Addons[1].TicketCode)
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/model"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"time"
+)
+
+const manifestFreezeBatch = 20
+
+// ManifestFreeze records what the freeze sweep did for one departure.
+type ManifestFreeze struct {
+	ScheduleID uint
+	Passengers int
+	Vehicles   int
+	Err        error
+}
+
+type ManifestUsecase struct {
+	Transactor             transact.Transactor
+	ManifestRepository     domain.ManifestRepository
+	ScheduleRepository     domain.ScheduleRepository
+	TicketRepository       domain.TicketRepository
+	BookingAddonRepository domain.BookingAddonRepository
+}
+
+func NewManifestUsecase(
+	transactor transact.Transactor,
+	manifest_repository domain.ManifestRepository,
+	schedule_repository domain.ScheduleRepository,
+	ticket_repository domain.TicketRepository,
+	booking_addon_repository domain.BookingAddonRepository,
+) *ManifestUsecase {
+	return &ManifestUsecase{
+		Transactor:             transactor,
+		ManifestRepository:     manifest_repository,
+		ScheduleRepository:     schedule_repository,
+		TicketRepository:       ticket_repository,
+		BookingAddonRepository: booking_addon_repository,
+	}
+}
+
+// GetManifest returns the final manifest of a departure once it was frozen,
+// and the manifest as it stands now before that.
+func (uc *ManifestUsecase) GetManifest(ctx context.Context, scheduleID uint) (*model.Manifest, error) {
+	var manifest *model.Manifest
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		final, err := uc.ManifestRepository.FindByScheduleID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get final manifest: %w", err)
+		}
+		if final != nil {
+			manifest = new(model.Manifest)
+			if err := json.Unmarshal(final.Snapshot, manifest); err != nil {
+				return fmt.Errorf("failed to read final manifest: %w", err)
+			}
+			return nil
+		}
+
+		manifest, err = uc.liveManifest(ctx, tx, scheduleID, time.Now())
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get manifest: %w", err)
+	}
+	return manifest, nil
+}
+
+// FreezeDepartedManifests keeps the manifest of every departure that has left
+// and has none yet. Each departure is frozen on its own, so one failure does
+// not hold back the others.
+func (uc *ManifestUsecase) FreezeDepartedManifests(ctx context.Context) ([]*ManifestFreeze, error) {
+	now := time.Now()
+	var scheduleIDs []uint
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		scheduleIDs, err = uc.ManifestRepository.FindUnfrozenScheduleIDs(ctx, tx, now, manifestFreezeBatch)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to find departed schedules: %w", err)
+	}
+
+	freezes := make([]*ManifestFreeze, 0, len(scheduleIDs))
+	for _, scheduleID := range scheduleIDs {
+		freezes = append(freezes, uc.freezeManifest(ctx, scheduleID, now))
+	}
+	return freezes, nil
+}
+
+func (uc *ManifestUsecase) freezeManifest(ctx context.Context, scheduleID uint, now time.Time) *ManifestFreeze {
+	freeze := &ManifestFreeze{ScheduleID: scheduleID}
+	freeze.Err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		manifest, err := uc.liveManifest(ctx, tx, scheduleID, now)
+		if err != nil {
+			return err
+		}
+		manifest.Final = true
+		snapshot, err := json.Marshal(manifest)
+		if err != nil {
+			return fmt.Errorf("failed to encode manifest: %w", err)
+		}
+
+		// Another instance freezing the same departure wins, its copy is as good
+		if err := uc.ManifestRepository.Insert(ctx, tx, &domain.Manifest{
+			ScheduleID:     scheduleID,
+			PassengerCount: manifest.PassengerCount,
+			VehicleCount:   manifest.VehicleCount,
+			Snapshot:       snapshot,
+			FrozenAt:       now,
+		}); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return nil
+			}
+			return fmt.Errorf("failed to save final manifest: %w", err)
+		}
+		freeze.Passengers = manifest.PassengerCount
+		freeze.Vehicles = manifest.VehicleCount
+		return nil
+	})
+	return freeze
+}
+
+func (uc *ManifestUsecase) liveManifest(ctx context.Context, conn gotann.Connection, scheduleID uint, now time.Time) (*model.Manifest, error) {
+	schedule, err := uc.ScheduleRepository.FindByID(ctx, conn, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	if schedule == nil {
+		return nil, errs.ErrNotFound
+	}
+	tickets, err := uc.TicketRepository.FindByScheduleID(ctx, conn, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tickets: %w", err)
+	}
+	addons, err := uc.BookingAddonRepository.FindByScheduleID(ctx, conn, scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking add-ons: %w", err)
+	}
+	return buildManifest(schedule, tickets, addons, now), nil
+}
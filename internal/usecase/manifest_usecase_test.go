@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/internal/model"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func manifestUsecase(t *testing.T) (*ManifestUsecase, *mocks.MockManifestRepository, *mocks.MockScheduleRepository, *mocks.MockTicketRepository, *mocks.MockBookingAddonRepository, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	manifestRepo := mocks.NewMockManifestRepository(ctrl)
+	scheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	ticketRepo := mocks.NewMockTicketRepository(ctrl)
+	addonRepo := mocks.NewMockBookingAddonRepository(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	uc := NewManifestUsecase(transactor, manifestRepo, scheduleRepo, ticketRepo, addonRepo)
+	return uc, manifestRepo, scheduleRepo, ticketRepo, addonRepo, transactor
+}
+
+func TestManifestUsecase_GetManifest(t *testing.T) {
+	t.Parallel()
+	uc, manifestRepo, scheduleRepo, ticketRepo, addonRepo, transactor := manifestUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	snapshot, err := json.Marshal(&model.Manifest{ScheduleID: 1, Final: true, PassengerCount: 3})
+	require.NoError(t, err)
+	tests := []struct {
+		name  string
+		mock  func()
+		final bool
+		err   error
+	}{
+		{
+			name: "live before departure",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				manifestRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Schedule{ID: 1}, nil)
+				ticketRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+				addonRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+		},
+		{
+			name: "frozen after departure",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				manifestRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Manifest{ScheduleID: 1, Snapshot: snapshot}, nil)
+			},
+			final: true,
+		},
+		{
+			name: "schedule not found",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				manifestRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			manifest, err := uc.GetManifest(context.Background(), 1)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.final, manifest.Final)
+		})
+	}
+}
+
+func TestManifestUsecase_FreezeDepartedManifests(t *testing.T) {
+	t.Parallel()
+	uc, manifestRepo, scheduleRepo, ticketRepo, addonRepo, transactor := manifestUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	paid := domain.Booking{ID: 7, OrderID: "ORD-7", Status: "PAID"}
+
+	transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).Times(4)
+	manifestRepo.EXPECT().FindUnfrozenScheduleIDs(gomock.Any(), gomock.Any(), gomock.Any(), manifestFreezeBatch).Return([]uint{1, 2, 3}, nil)
+
+	// Frozen
+	scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Schedule{ID: 1}, nil)
+	ticketRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return([]*domain.Ticket{{ID: 1, Type: "passenger", Booking: paid}}, nil)
+	addonRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+	manifestRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, conn gotann.Connection, manifest *domain.Manifest) error {
+			require.Equal(t, 1, manifest.PassengerCount)
+			var snapshot model.Manifest
+			require.NoError(t, json.Unmarshal(manifest.Snapshot, &snapshot))
+			require.True(t, snapshot.Final)
+			return nil
+		},
+	)
+
+	// Frozen by another instance meanwhile
+	scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(2)).Return(&domain.Schedule{ID: 2}, nil)
+	ticketRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(2)).Return(nil, nil)
+	addonRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(2)).Return(nil, nil)
+	manifestRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("duplicate key value violates unique constraint"))
+
+	// Failed, retried next run
+	scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(3)).Return(nil, errInternalServErr)
+
+	freezes, err := uc.FreezeDepartedManifests(context.Background())
+	require.NoError(t, err)
+	require.Len(t, freezes, 3)
+	require.NoError(t, freezes[0].Err)
+	require.Equal(t, 1, freezes[0].Passengers)
+	require.NoError(t, freezes[1].Err)
+	require.ErrorIs(t, freezes[2].Err, errInternalServErr)
+}
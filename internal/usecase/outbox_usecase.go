@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/mailer"
+	"eticket-api/internal/common/sms"
+	"eticket-api/internal/common/templates"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"time"
+)
+
+const (
+	outboxLease       = 2 * time.Minute // an attempt that takes longer is retried by the next run
+	outboxBatch       = 50
+	outboxMaxAttempts = 8
+	outboxBaseBackoff = 30 * time.Second
+	outboxMaxBackoff  = 30 * time.Minute
+)
+
+// OutboxUsecase runs the side effects written to the outbox after the
+// transaction that wrote them committed. Events are delivered at least once:
+// failures are retried with exponential backoff until MaxAttempts, then the
+// event is marked FAILED.
+type OutboxUsecase struct {
+	Transactor              transact.Transactor
+	OutboxRepository        domain.OutboxRepository
+	BookingRepository       domain.BookingRepository
+	BookingChangeRepository domain.BookingChangeRepository
+	TripayClient            domain.TripayClient
+	Mailer                  mailer.Mailer
+	SMS                     sms.Sender // nil when no SMS channel is configured
+}
+
+func NewOutboxUsecase(
+	transactor transact.Transactor,
+	outbox_repository domain.OutboxRepository,
+	booking_repository domain.BookingRepository,
+	booking_change_repository domain.BookingChangeRepository,
+	tripay_client domain.TripayClient,
+	mailer mailer.Mailer,
+	sms sms.Sender,
+) *OutboxUsecase {
+	return &OutboxUsecase{
+		Transactor:              transactor,
+		OutboxRepository:        outbox_repository,
+		BookingRepository:       booking_repository,
+		BookingChangeRepository: booking_change_repository,
+		TripayClient:            tripay_client,
+		Mailer:                  mailer,
+		SMS:                     sms,
+	}
+}
+
+// Dispatch runs events right after the transaction that wrote them committed.
+// Events another dispatcher already took are skipped. A failed event stays in
+// the outbox for DispatchPending; the first error is returned for callers that
+// wait on the result.
+func (uc *OutboxUsecase) Dispatch(ctx context.Context, events ...*domain.Outbox) error {
+	var first error
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+		var claimed int64
+		if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+			var err error
+			claimed, err = uc.OutboxRepository.Claim(ctx, tx, event.ID, time.Now().Add(outboxLease))
+			return err
+		}); err != nil {
+			if first == nil {
+				first = fmt.Errorf("failed to claim outbox event %d: %w", event.ID, err)
+			}
+			continue
+		}
+		if claimed == 0 {
+			continue
+		}
+		event.Attempts++
+		if err := uc.run(ctx, event); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// DispatchPending runs every due event in batches. Failures are recorded on
+// the event and do not stop the run.
+func (uc *OutboxUsecase) DispatchPending(ctx context.Context) error {
+	for {
+		var events []*domain.Outbox
+		if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+			due, err := uc.OutboxRepository.FindDue(ctx, tx, outboxBatch)
+			if err != nil {
+				return fmt.Errorf("failed to find due outbox events: %w", err)
+			}
+			for _, event := range due {
+				claimed, err := uc.OutboxRepository.Claim(ctx, tx, event.ID, time.Now().Add(outboxLease))
+				if err != nil {
+					return fmt.Errorf("failed to claim outbox event %d: %w", event.ID, err)
+				}
+				if claimed == 1 {
+					event.Attempts++
+					events = append(events, event)
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			_ = uc.run(ctx, event)
+		}
+		if len(events) < outboxBatch {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+}
+
+// run executes a claimed event outside any transaction and records the
+// outcome. Follow-up events, like the invoice of a created payment, are
+// dispatched right away.
+func (uc *OutboxUsecase) run(ctx context.Context, event *domain.Outbox) error {
+	var followUps []*domain.Outbox
+	result, err := uc.execute(event)
+	if err == nil {
+		err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+			followUps, err = uc.complete(ctx, tx, event, result)
+			return err
+		})
+	}
+	if err != nil {
+		if recordErr := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+			return uc.fail(ctx, tx, event, err)
+		}); recordErr != nil {
+			return fmt.Errorf("failed to record outbox event %d failure: %w", event.ID, recordErr)
+		}
+		return err
+	}
+	return uc.Dispatch(ctx, followUps...)
+}
+
+// execute runs the side effect of an event and returns its result as JSON.
+func (uc *OutboxUsecase) execute(event *domain.Outbox) (string, error) {
+	switch event.EventType {
+	case enum.OutboxCreatePayment.String():
+		var payload domain.PaymentOutboxPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return "", fmt.Errorf("decode payment payload: %w", err)
+		}
+		payment, err := uc.TripayClient.CreatePayment(&payload.Request)
+		if err != nil {
+			return "", fmt.Errorf("create Tripay payment failed: %w", err)
+		}
+		result, err := json.Marshal(payment)
+		if err != nil {
+			return "", fmt.Errorf("encode payment result: %w", err)
+		}
+		return string(result), nil
+
+	case enum.OutboxSendEmail.String():
+		var payload domain.EmailOutboxPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return "", fmt.Errorf("decode email payload: %w", err)
+		}
+		attachments := make([]mailer.Attachment, len(payload.Attachments))
+		for i, attachment := range payload.Attachments {
+			attachments[i] = mailer.Attachment{
+				Filename:    attachment.Filename,
+				ContentType: attachment.ContentType,
+				Content:     attachment.Content,
+			}
+		}
+		if err := uc.Mailer.Send(payload.To, payload.Subject, payload.Body, attachments...); err != nil {
+			return "", fmt.Errorf("send email failed: %w", err)
+		}
+		return "{}", nil
+
+	case enum.OutboxSendSMS.String():
+		var payload domain.SMSOutboxPayload
+		if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+			return "", fmt.Errorf("decode sms payload: %w", err)
+		}
+		if uc.SMS == nil {
+			event.Attempts = event.MaxAttempts // retrying will not help
+			return "", fmt.Errorf("no sms channel configured: %w", errs.ErrBadRequest)
+		}
+		if err := uc.SMS.Send(payload.To, payload.Message); err != nil {
+			return "", fmt.Errorf("send sms failed: %w", err)
+		}
+		return "{}", nil
+
+	default:
+		event.Attempts = event.MaxAttempts // retrying will not help
+		return "", fmt.Errorf("unknown outbox event type %s: %w", event.EventType, errs.ErrBadRequest)
+	}
+}
+
+// complete marks an event done and stores what its side effect produced.
+func (uc *OutboxUsecase) complete(ctx context.Context, conn gotann.Connection, event *domain.Outbox, result string) ([]*domain.Outbox, error) {
+	var followUps []*domain.Outbox
+	if event.EventType == enum.OutboxCreatePayment.String() {
+		var err error
+		followUps, err = uc.recordPayment(ctx, conn, event, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	event.Status = enum.OutboxDone.String()
+	event.Result = &result
+	event.LastError = nil
+	event.ProcessedAt = &now
+	if err := uc.OutboxRepository.Update(ctx, conn, event); err != nil {
+		return nil, fmt.Errorf("failed to update outbox event: %w", err)
+	}
+	return followUps, nil
+}
+
+// recordPayment stores the Tripay reference on the booking or booking change
+// the payment was created for and queues the invoice email when asked to.
+func (uc *OutboxUsecase) recordPayment(ctx context.Context, conn gotann.Connection, event *domain.Outbox, result string) ([]*domain.Outbox, error) {
+	var payload domain.PaymentOutboxPayload
+	if err := json.Unmarshal([]byte(event.Payload), &payload); err != nil {
+		return nil, fmt.Errorf("decode payment payload: %w", err)
+	}
+	payment := new(domain.Transaction)
+	if err := json.Unmarshal([]byte(result), payment); err != nil {
+		return nil, fmt.Errorf("decode payment result: %w", err)
+	}
+
+	if payload.BookingChangeID != nil {
+		change, err := uc.BookingChangeRepository.FindByID(ctx, conn, *payload.BookingChangeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get booking change: %w", err)
+		}
+		if change == nil {
+			return nil, fmt.Errorf("booking change %d: %w", *payload.BookingChangeID, errs.ErrNotFound)
+		}
+		change.ReferenceNumber = &payment.Reference
+		if err := uc.BookingChangeRepository.Update(ctx, conn, change); err != nil {
+			return nil, fmt.Errorf("failed to update booking change: %w", err)
+		}
+		return nil, nil
+	}
+
+	booking, err := uc.BookingRepository.FindByID(ctx, conn, payload.BookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+	if booking == nil {
+		return nil, fmt.Errorf("booking %d: %w", payload.BookingID, errs.ErrNotFound)
+	}
+	booking.ReferenceNumber = &payment.Reference
+	if err := uc.BookingRepository.Update(ctx, conn, booking); err != nil {
+		return nil, fmt.Errorf("failed to update booking with reference number: %w", err)
+	}
+
+	// A booking that expired while its payment was being created gets no invoice
+	if !payload.SendInvoice || booking.Status != enum.BookingUnpaid.String() {
+		return nil, nil
+	}
+	invoice, err := enqueueEmail(ctx, conn, uc.OutboxRepository, booking.OrderID, booking.Email, "Your Booking is Confirmed", templates.BookingInvoiceEmail(booking, payment))
+	if err != nil {
+		return nil, err
+	}
+	return []*domain.Outbox{invoice}, nil
+}
+
+// fail records a failed attempt and schedules the next one, or gives up once
+// the event used all its attempts.
+func (uc *OutboxUsecase) fail(ctx context.Context, conn gotann.Connection, event *domain.Outbox, cause error) error {
+	message := cause.Error()
+	event.LastError = &message
+	event.Result = nil
+	event.ProcessedAt = nil
+	event.Status = enum.OutboxPending.String()
+	if event.Attempts >= event.MaxAttempts {
+		event.Status = enum.OutboxFailed.String()
+	} else {
+		event.NextAttemptAt = time.Now().Add(outboxBackoff(event.Attempts))
+	}
+	if err := uc.OutboxRepository.Update(ctx, conn, event); err != nil {
+		return fmt.Errorf("failed to update outbox event: %w", err)
+	}
+	return nil
+}
+
+// outboxBackoff doubles the wait after every failed attempt.
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	return backoff
+}
+
+// enqueueOutbox writes an event in the caller's transaction. It runs only if
+// that transaction commits.
+func enqueueOutbox(ctx context.Context, conn gotann.Connection, outbox domain.OutboxRepository, eventType enum.OutboxEventType, aggregateID string, payload interface{}, maxAttempts int) (*domain.Outbox, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s payload: %w", eventType, err)
+	}
+	event := &domain.Outbox{
+		EventType:     eventType.String(),
+		AggregateID:   aggregateID,
+		Payload:       string(data),
+		Status:        enum.OutboxPending.String(),
+		MaxAttempts:   maxAttempts,
+		NextAttemptAt: time.Now(),
+	}
+	if err := outbox.Insert(ctx, conn, event); err != nil {
+		return nil, fmt.Errorf("failed to write %s to outbox: %w", eventType, err)
+	}
+	return event, nil
+}
+
+func enqueueEmail(ctx context.Context, conn gotann.Connection, outbox domain.OutboxRepository, aggregateID, to, subject, body string, attachments ...domain.EmailAttachment) (*domain.Outbox, error) {
+	return enqueueOutbox(ctx, conn, outbox, enum.OutboxSendEmail, aggregateID, &domain.EmailOutboxPayload{
+		To:          to,
+		Subject:     subject,
+		Body:        body,
+		Attachments: attachments,
+	}, outboxMaxAttempts)
+}
+
+func enqueueSMS(ctx context.Context, conn gotann.Connection, outbox domain.OutboxRepository, aggregateID, to, message string) (*domain.Outbox, error) {
+	return enqueueOutbox(ctx, conn, outbox, enum.OutboxSendSMS, aggregateID, &domain.SMSOutboxPayload{
+		To:      to,
+		Message: message,
+	}, outboxMaxAttempts)
+}
+
+func enqueuePayment(ctx context.Context, conn gotann.Connection, outbox domain.OutboxRepository, payload *domain.PaymentOutboxPayload, maxAttempts int) (*domain.Outbox, error) {
+	return enqueueOutbox(ctx, conn, outbox, enum.OutboxCreatePayment, payload.Request.MerchantRef, payload, maxAttempts)
+}
+
+// paymentResult returns the Tripay transaction a dispatched payment event
+// created, or nil when it has not run successfully yet.
+func paymentResult(event *domain.Outbox) *domain.Transaction {
+	if event == nil || event.Status != enum.OutboxDone.String() || event.Result == nil {
+		return nil
+	}
+	payment := new(domain.Transaction)
+	if err := json.Unmarshal([]byte(*event.Result), payment); err != nil {
+		return nil
+	}
+	return payment
+}
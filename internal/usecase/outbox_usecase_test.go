@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func outboxUsecase(t *testing.T) (*OutboxUsecase, *mocks.MockOutboxRepository, *mocks.MockMailer, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	bookingRepo := mocks.NewMockBookingRepository(ctrl)
+	bookingChangeRepo := mocks.NewMockBookingChangeRepository(ctrl)
+	tripayClient := mocks.NewMockTripayClient(ctrl)
+	mailer := mocks.NewMockMailer(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	uc := NewOutboxUsecase(transactor, outboxRepo, bookingRepo, bookingChangeRepo, tripayClient, mailer, nil)
+	return uc, outboxRepo, mailer, transactor
+}
+
+func TestOutboxUsecase_Dispatch(t *testing.T) {
+	t.Parallel()
+	uc, outboxRepo, mailer, transactor := outboxUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	email := func(attempts int) *domain.Outbox {
+		return &domain.Outbox{
+			ID:          1,
+			EventType:   enum.OutboxSendEmail.String(),
+			Payload:     `{"to":"a@b.c","subject":"Hi","body":"<p>Hi</p>"}`,
+			Status:      enum.OutboxPending.String(),
+			Attempts:    attempts,
+			MaxAttempts: 3,
+		}
+	}
+	tests := []struct {
+		name     string
+		event    *domain.Outbox
+		mock     func()
+		status   string
+		attempts int
+		err      bool
+	}{
+		{
+			name:  "sent",
+			event: email(0),
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).Times(2)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), uint(1), gomock.Any()).Return(int64(1), nil)
+				mailer.EXPECT().Send("a@b.c", "Hi", "<p>Hi</p>").Return(nil)
+				outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			status:   enum.OutboxDone.String(),
+			attempts: 1,
+		},
+		{
+			name:  "send failed is retried later",
+			event: email(0),
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).Times(2)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), uint(1), gomock.Any()).Return(int64(1), nil)
+				mailer.EXPECT().Send("a@b.c", "Hi", "<p>Hi</p>").Return(errors.New("smtp down"))
+				outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			status:   enum.OutboxPending.String(),
+			attempts: 1,
+			err:      true,
+		},
+		{
+			name:  "last attempt failed",
+			event: email(2),
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).Times(2)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), uint(1), gomock.Any()).Return(int64(1), nil)
+				mailer.EXPECT().Send("a@b.c", "Hi", "<p>Hi</p>").Return(errors.New("smtp down"))
+				outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			status:   enum.OutboxFailed.String(),
+			attempts: 3,
+			err:      true,
+		},
+		{
+			name: "sms without a channel is not retried",
+			event: &domain.Outbox{
+				ID:          1,
+				EventType:   enum.OutboxSendSMS.String(),
+				Payload:     `{"to":"0812345678","message":"Hi"}`,
+				Status:      enum.OutboxPending.String(),
+				MaxAttempts: 3,
+			},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).Times(2)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), uint(1), gomock.Any()).Return(int64(1), nil)
+				outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			status:   enum.OutboxFailed.String(),
+			attempts: 3,
+			err:      true,
+		},
+		{
+			name:  "claimed elsewhere",
+			event: email(0),
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), uint(1), gomock.Any()).Return(int64(0), nil)
+			},
+			status:   enum.OutboxPending.String(),
+			attempts: 0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.Dispatch(context.Background(), tc.event)
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.status, tc.event.Status)
+			require.Equal(t, tc.attempts, tc.event.Attempts)
+			if tc.err && tc.status == enum.OutboxPending.String() {
+				require.True(t, tc.event.NextAttemptAt.After(time.Now()))
+				require.NotNil(t, tc.event.LastError)
+			}
+		})
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, outboxBaseBackoff, outboxBackoff(1))
+	require.Equal(t, 2*outboxBaseBackoff, outboxBackoff(2))
+	require.Equal(t, 8*outboxBaseBackoff, outboxBackoff(4))
+	require.Equal(t, outboxMaxBackoff, outboxBackoff(20))
+}
@@ -2,27 +2,66 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"eticket-api/internal/client"
 	enum "eticket-api/internal/common/enums"
 	errs "eticket-api/internal/common/errors"
-	"eticket-api/internal/common/mailer"
 	"eticket-api/internal/common/templates"
+	"eticket-api/internal/common/token"
 	"eticket-api/internal/common/transact"
 	"eticket-api/internal/domain"
 	"eticket-api/internal/model"
 	"eticket-api/pkg/gotann"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 )
 
+const (
+	latePaymentRefundPolicy = "Late payment"
+
+	bookingPaymentWindow = 30 * time.Minute
+	// Late Tripay callbacks get this long to arrive before the expiry job
+	// looks at a booking
+	bookingExpiryGrace = 5 * time.Minute
+	bookingExpiryBatch = 50
+)
+
+// Outcomes of the unpaid booking expiry sweep
+const (
+	BookingExpiryExpired  = "EXPIRED"  // payment never came, quota restored
+	BookingExpiryPaid     = "PAID"     // Tripay has it paid, the callback was missed
+	BookingExpiryExtended = "EXTENDED" // Tripay still accepts payment until a later time
+	BookingExpirySettled  = "SETTLED"  // a callback settled the booking during the sweep
+	BookingExpirySkipped  = "SKIPPED"  // Tripay could not be asked, retried next run
+)
+
+// BookingExpiry records what the expiry sweep decided for one booking.
+type BookingExpiry struct {
+	OrderID      string
+	Reference    string
+	TripayStatus string
+	Action       string
+	Err          error
+}
+
 type PaymentUsecase struct {
-	Transactor        transact.Transactor // Assuming transact package is imported
-	TripayClient      domain.TripayClient
-	BookingRepository domain.BookingRepository
-	TicketRepository  domain.TicketRepository
-	QuotaRepository   domain.QuotaRepository
-	Mailer            mailer.Mailer
+	Transactor                    transact.Transactor // Assuming transact package is imported
+	TripayClient                  domain.TripayClient
+	BookingRepository             domain.BookingRepository
+	TicketRepository              domain.TicketRepository
+	QuotaRepository               domain.QuotaRepository
+	ScheduleSeatRepository        domain.ScheduleSeatRepository
+	BookingChangeRepository       domain.BookingChangeRepository
+	AddonStockRepository          domain.AddonStockRepository
+	BookingAddonRepository        domain.BookingAddonRepository
+	RefundRepository              domain.RefundRepository
+	PromotionRepository           domain.PromotionRepository
+	PromotionRedemptionRepository domain.PromotionRedemptionRepository
+	OutboxRepository              domain.OutboxRepository
+	Outbox                        *OutboxUsecase
+	TokenUtil                     token.TokenUtil
 }
 
 func NewPaymentUsecase(
@@ -31,15 +70,33 @@ func NewPaymentUsecase(
 	booking_repository domain.BookingRepository,
 	ticket_repository domain.TicketRepository,
 	quota_repository domain.QuotaRepository,
-	mailer mailer.Mailer,
+	schedule_seat_repository domain.ScheduleSeatRepository,
+	booking_change_repository domain.BookingChangeRepository,
+	addon_stock_repository domain.AddonStockRepository,
+	booking_addon_repository domain.BookingAddonRepository,
+	refund_repository domain.RefundRepository,
+	promotion_repository domain.PromotionRepository,
+	promotion_redemption_repository domain.PromotionRedemptionRepository,
+	outbox_repository domain.OutboxRepository,
+	outbox *OutboxUsecase,
+	token_util token.TokenUtil,
 ) *PaymentUsecase {
 	return &PaymentUsecase{
-		Transactor:        transactor,
-		TripayClient:      tripay_client,
-		BookingRepository: booking_repository,
-		TicketRepository:  ticket_repository,
-		QuotaRepository:   quota_repository,
-		Mailer:            mailer,
+		Transactor:                    transactor,
+		TripayClient:                  tripay_client,
+		BookingRepository:             booking_repository,
+		TicketRepository:              ticket_repository,
+		QuotaRepository:               quota_repository,
+		ScheduleSeatRepository:        schedule_seat_repository,
+		BookingChangeRepository:       booking_change_repository,
+		AddonStockRepository:          addon_stock_repository,
+		BookingAddonRepository:        booking_addon_repository,
+		RefundRepository:              refund_repository,
+		PromotionRepository:           promotion_repository,
+		PromotionRedemptionRepository: promotion_redemption_repository,
+		OutboxRepository:              outbox_repository,
+		Outbox:                        outbox,
+		TokenUtil:                     token_util,
 	}
 }
 
@@ -57,7 +114,7 @@ func (uc *PaymentUsecase) GetTransactionDetail(ctx context.Context, reference st
 
 func (uc *PaymentUsecase) CreatePayment(ctx context.Context, request *model.WritePaymentRequest) (*domain.Transaction, error) {
 	var err error
-	var payment *domain.Transaction
+	var event *domain.Outbox
 	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
 		booking, err := uc.BookingRepository.FindByOrderID(ctx, tx, request.OrderID)
 		if err != nil {
@@ -76,15 +133,27 @@ func (uc *PaymentUsecase) CreatePayment(ctx context.Context, request *model.Writ
 		}
 
 		var amounts float64
-		for _, ticket := range tickets {
-			amounts += ticket.Price
-		}
-
+		var fees []domain.TicketFee
 		orderItems := make([]domain.OrderItem, len(tickets))
 		for i, ticket := range tickets {
+			amounts += ticket.Price + feeTotal(ticket.Fees)
 			orderItems[i] = client.TicketToItem(ticket)
+			fees = append(fees, ticket.Fees...)
+		}
+		for _, fee := range groupFees(fees) {
+			orderItems = append(orderItems, client.FeeToItem(fee))
+		}
+		for _, addon := range groupAddons(booking.Addons) {
+			orderItems = append(orderItems, client.AddonToItem(addon))
+		}
+		amounts += addonTotal(booking.Addons)
+		if booking.Discount > 0 && booking.PromoCode != nil {
+			discount := math.Min(booking.Discount, amounts)
+			orderItems = append(orderItems, client.DiscountToItem(*booking.PromoCode, discount))
+			amounts -= discount
 		}
 
+		deadline := time.Now().Add(bookingPaymentWindow)
 		payload := &domain.TransactionRequest{
 			Method:        request.PaymentMethod,
 			Amount:        int(amounts), // Convert to integer cents
@@ -95,39 +164,63 @@ func (uc *PaymentUsecase) CreatePayment(ctx context.Context, request *model.Writ
 			OrderItems:    orderItems,
 			CallbackUrl:   "https://example.com/callback",
 			ReturnUrl:     "https://example.com/callback",
-			ExpiredTime:   int(time.Now().Add(30 * time.Minute).Unix()),
-		}
-
-		payment, err = uc.TripayClient.CreatePayment(payload)
-		if err != nil {
-			// Tangani error Tripay timeout/down
-			if strings.Contains(err.Error(), "timeout") {
-				return errs.ErrExternalTimeout
-			}
-			if strings.Contains(err.Error(), "connection error") {
-				return errs.ErrExternalDown
-			}
-			return fmt.Errorf("create Tripay payment failed: %w", err)
+			ExpiredTime:   int(deadline.Unix()),
 		}
 
-		booking.ReferenceNumber = &payment.Reference
-		if err := uc.BookingRepository.Update(ctx, tx, booking); err != nil {
-			if errs.IsUniqueConstraintError(err) {
-				return errs.ErrConflict
+		// A new payment gives the customer a new deadline
+		if booking.Status == enum.BookingUnpaid.String() {
+			booking.PaymentDeadline = &deadline
+			if err := uc.BookingRepository.Update(ctx, tx, booking); err != nil {
+				return fmt.Errorf("failed to update payment deadline: %w", err)
 			}
-			return fmt.Errorf("failed to update booking with reference number: %w", err)
 		}
 
-		return nil
+		// The customer is waiting on this one and retries it themselves
+		event, err = enqueuePayment(ctx, tx, uc.OutboxRepository, &domain.PaymentOutboxPayload{
+			BookingID: booking.ID,
+			Request:   *payload,
+		}, 1)
+		return err
 	}); err != nil {
 		return nil, fmt.Errorf("failed to start transaction: %w", err)
 	}
 
+	if err := uc.Outbox.Dispatch(ctx, event); err != nil {
+		// Tangani error Tripay timeout/down
+		if strings.Contains(err.Error(), "timeout") {
+			return nil, errs.ErrExternalTimeout
+		}
+		if strings.Contains(err.Error(), "connection error") {
+			return nil, errs.ErrExternalDown
+		}
+		if errs.IsUniqueConstraintError(err) {
+			return nil, errs.ErrConflict
+		}
+		return nil, err
+	}
+
+	payment := paymentResult(event)
+	if payment == nil {
+		return nil, fmt.Errorf("payment for order %s was not created", request.OrderID)
+	}
 	return payment, nil
 }
 
 func (uc *PaymentUsecase) HandleCallback(ctx context.Context, request *domain.Callback) error {
-	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+	var email *domain.Outbox
+	err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		// Fare differences of booking changes are paid with their own merchant ref
+		change, err := uc.BookingChangeRepository.FindByMerchantRef(ctx, tx, request.MerchantRef)
+		if err != nil {
+			return fmt.Errorf("failed to get booking change: %w", err)
+		}
+		if change != nil {
+			return uc.HandleChangePayment(ctx, tx, change, request.Status)
+		}
+
+		if err := uc.BookingRepository.LockByOrderID(ctx, tx, request.MerchantRef); err != nil {
+			return fmt.Errorf("failed to lock booking: %w", err)
+		}
 		booking, err := uc.BookingRepository.FindByOrderID(ctx, tx, request.MerchantRef)
 		if err != nil {
 			return fmt.Errorf("failed to get booking: %w", err)
@@ -150,17 +243,32 @@ func (uc *PaymentUsecase) HandleCallback(ctx context.Context, request *domain.Ca
 			return nil
 		}
 
+		// The operator cancelled the departure and gave back its quota, the
+		// booking stays cancelled whatever Tripay reports afterwards; so does
+		// a booking refunded already
+		if booking.Status == enum.BookingCancelledByOperator.String() || booking.Status == enum.BookingRefund.String() {
+			return nil
+		}
+
 		// Handle different payment statuses
 		switch request.Status {
 		case "PAID":
-			// Payment successful
-			if err := uc.HandleSuccessfulPayment(ctx, tx, booking, tickets); err != nil {
+			// A payment landing after the booking expired or failed finds its
+			// quota and seats given back, they are taken again first
+			if booking.Status != enum.BookingUnpaid.String() {
+				if err := uc.retakeBooking(ctx, tx, booking, tickets); err != nil {
+					return err
+				}
+			}
+			email, err = uc.HandleSuccessfulPayment(ctx, tx, booking, tickets)
+			if err != nil {
 				return fmt.Errorf("handle successful payment failed: %w", err)
 			}
 
 		case "UNPAID", "FAILED", "EXPIRED", "REFUND", "CANCELLED":
 			// Payment unsuccessful
-			if err := uc.HandleUnsuccessfulPayment(ctx, tx, booking, tickets, request.Status); err != nil {
+			email, err = uc.HandleUnsuccessfulPayment(ctx, tx, booking, tickets, request.Status)
+			if err != nil {
 				return fmt.Errorf("handle unsuccessful payment failed: %w", err)
 			}
 
@@ -170,27 +278,216 @@ func (uc *PaymentUsecase) HandleCallback(ctx context.Context, request *domain.Ca
 
 		return nil
 	})
+	if errors.Is(err, errs.ErrQuotaExceeded) || errors.Is(err, errs.ErrSeatUnavailable) {
+		// Sold to someone else meanwhile, the late payment goes back
+		email, err = uc.refundLatePayment(ctx, request.MerchantRef)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Tripay only needs the callback acknowledged, the outbox job retries the email
+	_ = uc.Outbox.Dispatch(ctx, email)
+	return nil
+}
+
+// retakeBooking sells the quota, seats and add-ons of an expired or failed
+// booking again. It fails with ErrQuotaExceeded or ErrSeatUnavailable when
+// they are gone, leaving the caller to roll back.
+func (uc *PaymentUsecase) retakeBooking(ctx context.Context, tx gotann.Connection, booking *domain.Booking, tickets []*domain.Ticket) error {
+	if err := sellTickets(ctx, tx, uc.QuotaRepository, tickets); err != nil {
+		return err
+	}
+	moved, err := retakeSeats(ctx, tx, uc.ScheduleSeatRepository, tickets)
+	if err != nil {
+		return err
+	}
+	if len(moved) > 0 {
+		if err := uc.TicketRepository.UpdateBulk(ctx, tx, moved); err != nil {
+			return fmt.Errorf("failed to update seats: %w", err)
+		}
+	}
+	return sellBookingAddons(ctx, tx, uc.AddonStockRepository, booking.Addons)
+}
+
+// refundLatePayment records a full refund of a payment that came in for an
+// expired or failed booking whose quota or seats were sold meanwhile, and
+// tells the customer.
+func (uc *PaymentUsecase) refundLatePayment(ctx context.Context, orderID string) (*domain.Outbox, error) {
+	var email *domain.Outbox
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if err := uc.BookingRepository.LockByOrderID(ctx, tx, orderID); err != nil {
+			return fmt.Errorf("failed to lock booking: %w", err)
+		}
+		booking, err := uc.BookingRepository.FindByOrderID(ctx, tx, orderID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking == nil {
+			return errs.ErrNotFound
+		}
+		if booking.Status != enum.BookingExpired.String() && booking.Status != "FAILED" {
+			// Settled by another callback meanwhile
+			return nil
+		}
+
+		tickets := make([]*domain.Ticket, len(booking.Tickets))
+		refunds := make([]*domain.Refund, len(booking.Tickets))
+		for i := range booking.Tickets {
+			ticket := &booking.Tickets[i]
+			tickets[i] = ticket
+			refunds[i] = &domain.Refund{
+				BookingID:     booking.ID,
+				TicketID:      ticket.ID,
+				PolicyName:    latePaymentRefundPolicy,
+				TicketPrice:   ticket.Price,
+				RefundPercent: 100,
+				Amount:        ticket.Price,
+				Ticket:        *ticket,
+			}
+		}
+		discountRefunds(refunds, tickets, booking.Discount)
+		refundCharges(refunds, booking.Fees, booking.Addons)
+		if err := uc.RefundRepository.InsertBulk(ctx, tx, refunds); err != nil {
+			return fmt.Errorf("failed to record refunds: %w", err)
+		}
+
+		booking.Status = enum.BookingRefund.String()
+		if err := uc.BookingRepository.Update(ctx, tx, booking); err != nil {
+			return fmt.Errorf("failed to update booking status: %w", err)
+		}
+
+		htmlBody := templates.BookingFailedEmail(booking, "Payment arrived after the booking expired and will be refunded")
+		email, err = enqueueEmail(ctx, tx, uc.OutboxRepository, booking.OrderID, booking.Email, "Payment Refunded - Booking Expired", htmlBody)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return email, nil
+}
+
+// HandleChangePayment settles the fare difference of a booking change. The
+// tickets were moved when the change was made but still hold the old
+// departure: a paid change gives it back, an unpaid one moves the tickets back
+// onto it and gives back the new departure instead.
+func (uc *PaymentUsecase) HandleChangePayment(ctx context.Context, tx gotann.Connection, change *domain.BookingChange, status string) error {
+	if change.Status != enum.BookingChangeAwaitingPayment.String() {
+		// Already settled, ignore duplicate callback
+		return nil
+	}
+
+	switch status {
+	case "PAID":
+		change.Status = enum.BookingChangeCompleted.String()
+	case "UNPAID":
+		return nil
+	case "FAILED", "EXPIRED", "REFUND", "CANCELLED":
+		change.Status = enum.BookingChangePaymentFailed.String()
+	default:
+		return fmt.Errorf("unknown payment status: %s", status)
+	}
+
+	previous, err := decodeChangePrevious(change)
+	if err != nil {
+		return err
+	}
+	if previous != nil {
+		booking, err := uc.BookingRepository.FindByID(ctx, tx, change.BookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking == nil {
+			return errs.ErrNotFound
+		}
+		tickets, addons := previous.moved(booking)
+		if change.Status == enum.BookingChangeCompleted.String() {
+			err = vacateLeg(ctx, tx, uc.QuotaRepository, uc.ScheduleSeatRepository, uc.AddonStockRepository, change.FromScheduleID, previous.before(change.FromScheduleID, tickets), addons)
+		} else {
+			err = uc.revertChange(ctx, tx, change, previous, booking, tickets, addons)
+		}
+		if err != nil {
+			return err
+		}
+		change.Previous = nil
+	}
+
+	if err := uc.BookingChangeRepository.Update(ctx, tx, change); err != nil {
+		return fmt.Errorf("failed to update booking change status: %w", err)
+	}
+	return nil
+}
+
+// revertChange moves the tickets and add-ons of a change whose charge failed
+// back onto the departure they came from, at the fares, fees and seats they
+// had, and gives back what they took on the new one.
+func (uc *PaymentUsecase) revertChange(ctx context.Context, tx gotann.Connection, change *domain.BookingChange, previous *changePrevious, booking *domain.Booking, tickets []*domain.Ticket, addons []*domain.BookingAddon) error {
+	if err := vacateLeg(ctx, tx, uc.QuotaRepository, uc.ScheduleSeatRepository, uc.AddonStockRepository, change.ToScheduleID, tickets, addons); err != nil {
+		return err
+	}
+
+	restored := previous.before(change.FromScheduleID, tickets)
+	for _, ticket := range restored {
+		ticket.Fees = nil
+	}
+	if err := uc.TicketRepository.UpdateBulk(ctx, tx, restored); err != nil {
+		return fmt.Errorf("failed to move tickets back: %w", err)
+	}
+	for _, ticket := range previous.Tickets {
+		if err := uc.TicketRepository.ReplaceFees(ctx, tx, ticket.ID, ticket.Fees); err != nil {
+			return fmt.Errorf("failed to restore ticket fees: %w", err)
+		}
+	}
+	if len(addons) > 0 {
+		for _, addon := range addons {
+			addon.ScheduleID = change.FromScheduleID
+		}
+		if err := uc.BookingAddonRepository.UpdateBulk(ctx, tx, addons); err != nil {
+			return fmt.Errorf("failed to move add-ons back: %w", err)
+		}
+	}
+
+	booking.ScheduleID = previous.ScheduleID
+	booking.Schedule = domain.Schedule{}
+	booking.Tickets = nil
+	booking.Changes = nil
+	booking.Fees = nil
+	booking.Addons = nil
+	if err := uc.BookingRepository.Update(ctx, tx, booking); err != nil {
+		return fmt.Errorf("failed to update booking: %w", err)
+	}
+	return nil
 }
 
-func (uc *PaymentUsecase) HandleSuccessfulPayment(ctx context.Context, tx gotann.Connection, booking *domain.Booking, tickets []*domain.Ticket) error {
+func (uc *PaymentUsecase) HandleSuccessfulPayment(ctx context.Context, tx gotann.Connection, booking *domain.Booking, tickets []*domain.Ticket) (*domain.Outbox, error) {
 	// Update booking status to PAID
 	booking.Status = enum.BookingPaid.String()
 
 	if err := uc.BookingRepository.Update(ctx, tx, booking); err != nil {
-		return fmt.Errorf("failed to update booking status: %w", err)
+		return nil, fmt.Errorf("failed to update booking status: %w", err)
 	}
 
-	// Send confirmation email
+	// Queue confirmation email, it is sent once the status change commits
 	subject := "Your Booking is Confirmed"
 	htmlBody := templates.BookingSuccessEmail(booking, tickets)
 
-	// Use SendAsync for better performance
-	uc.Mailer.SendAsync(booking.Email, subject, htmlBody)
+	// The PDFs can still be downloaded, the confirmation does not wait on them
+	attachments, err := confirmationAttachments(uc.TokenUtil, booking, tickets)
+	if err != nil {
+		attachments = nil
+	}
 
-	return nil
+	return enqueueEmail(ctx, tx, uc.OutboxRepository, booking.OrderID, booking.Email, subject, htmlBody, attachments...)
 }
 
-func (uc *PaymentUsecase) HandleUnsuccessfulPayment(ctx context.Context, tx gotann.Connection, booking *domain.Booking, tickets []*domain.Ticket, status string) error {
+func (uc *PaymentUsecase) HandleUnsuccessfulPayment(ctx context.Context, tx gotann.Connection, booking *domain.Booking, tickets []*domain.Ticket, status string) (*domain.Outbox, error) {
+	// Only a booking that still holds sold quota gives it back; repeated
+	// callbacks for an already expired or refunded booking must not.
+	holdsQuota := booking.Status == enum.BookingUnpaid.String() || booking.Status == enum.BookingPaid.String()
+	if status == "UNPAID" && !holdsQuota {
+		// Reopening it would let the expiry sweep give the quota back twice
+		return nil, nil
+	}
+
 	// Update booking status based on payment status
 	switch status {
 	case "UNPAID":
@@ -205,39 +502,26 @@ func (uc *PaymentUsecase) HandleUnsuccessfulPayment(ctx context.Context, tx gota
 
 	// ✅ UPDATE BOOKING STATUS TO DATABASE
 	if err := uc.BookingRepository.Update(ctx, tx, booking); err != nil {
-		return fmt.Errorf("failed to update booking status: %w", err)
-	}
-
-	// Restore quota for each ticket's class
-	restored := make(map[uint]bool)
-	for _, ticket := range tickets {
-		if ticket == nil {
-			continue
-		}
-		// Avoid double-restoring quota for the same class
-		if restored[ticket.ClassID] {
-			continue
-		}
-		quota, err := uc.QuotaRepository.FindByScheduleIDAndClassID(ctx, tx, booking.ScheduleID, ticket.ClassID)
-		if err == nil && quota != nil {
-			// Count tickets for this class
-			count := 0
-			for _, t := range tickets {
-				if t != nil && t.ClassID == ticket.ClassID {
-					count++
-				}
-			}
-			quota.Quota += count
-			if err := uc.QuotaRepository.Update(ctx, tx, quota); err != nil {
-				fmt.Printf("Warning: failed to restore quota for class %d: %v\n", ticket.ClassID, err)
-			}
-			restored[ticket.ClassID] = true
-		} else if err != nil {
-			fmt.Printf("Warning: failed to find quota for class %d: %v\n", ticket.ClassID, err)
+		return nil, fmt.Errorf("failed to update booking status: %w", err)
+	}
+
+	// An UNPAID callback means the customer can still pay, keep the quota
+	if holdsQuota && status != "UNPAID" {
+		if err := restoreTickets(ctx, tx, uc.QuotaRepository, tickets); err != nil {
+			return nil, fmt.Errorf("failed to restore quota: %w", err)
+		}
+		if err := releaseTicketSeats(ctx, tx, uc.ScheduleSeatRepository, tickets); err != nil {
+			return nil, err
+		}
+		if err := restoreBookingAddons(ctx, tx, uc.AddonStockRepository, booking.Addons); err != nil {
+			return nil, err
+		}
+		if err := releaseBookingPromotion(ctx, tx, uc.PromotionRepository, uc.PromotionRedemptionRepository, booking.ID); err != nil {
+			return nil, err
 		}
 	}
 
-	// Send notification email
+	// Queue notification email
 	subject := "Payment Failed - Booking Not Confirmed"
 	var htmlBody string
 
@@ -252,7 +536,97 @@ func (uc *PaymentUsecase) HandleUnsuccessfulPayment(ctx context.Context, tx gota
 		htmlBody = templates.BookingFailedEmail(booking, "Payment was not successful")
 	}
 
-	uc.Mailer.SendAsync(booking.Email, subject, htmlBody)
+	return enqueueEmail(ctx, tx, uc.OutboxRepository, booking.OrderID, booking.Email, subject, htmlBody)
+}
 
-	return nil
+// ExpireUnpaidBookings settles UNPAID bookings whose payment deadline passed
+// without a Tripay callback. Tripay is asked for the payment status first, so
+// a missed PAID callback confirms the booking instead of expiring it. Expired
+// bookings give their quota and seats back and the customer is emailed.
+func (uc *PaymentUsecase) ExpireUnpaidBookings(ctx context.Context) ([]*BookingExpiry, error) {
+	now := time.Now()
+	var bookings []*domain.Booking
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		bookings, err = uc.BookingRepository.FindExpiredUnpaid(ctx, tx, now.Add(-bookingExpiryGrace), now.Add(-bookingPaymentWindow-bookingExpiryGrace), bookingExpiryBatch)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to find expired unpaid bookings: %w", err)
+	}
+
+	expiries := make([]*BookingExpiry, 0, len(bookings))
+	for _, booking := range bookings {
+		expiries = append(expiries, uc.expireBooking(ctx, booking, now))
+	}
+	return expiries, nil
+}
+
+func (uc *PaymentUsecase) expireBooking(ctx context.Context, booking *domain.Booking, now time.Time) *BookingExpiry {
+	expiry := &BookingExpiry{OrderID: booking.OrderID}
+
+	// Without a reference the payment was never created, there is nothing to ask
+	var payment *domain.Transaction
+	if booking.ReferenceNumber != nil {
+		expiry.Reference = *booking.ReferenceNumber
+		var err error
+		payment, err = uc.TripayClient.GetTransactionDetail(*booking.ReferenceNumber)
+		if err != nil {
+			expiry.Action = BookingExpirySkipped
+			expiry.Err = fmt.Errorf("failed to get transaction detail: %w", err)
+			return expiry
+		}
+		expiry.TripayStatus = payment.Status
+	}
+
+	var email *domain.Outbox
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if err := uc.BookingRepository.LockByOrderID(ctx, tx, booking.OrderID); err != nil {
+			return fmt.Errorf("failed to lock booking: %w", err)
+		}
+		current, err := uc.BookingRepository.FindByID(ctx, tx, booking.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if current == nil || current.Status != enum.BookingUnpaid.String() {
+			expiry.Action = BookingExpirySettled
+			return nil
+		}
+
+		tickets, err := uc.TicketRepository.FindByBookingID(ctx, tx, current.ID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve tickets: %w", err)
+		}
+
+		switch {
+		case payment != nil && payment.Status == "PAID":
+			expiry.Action = BookingExpiryPaid
+			email, err = uc.HandleSuccessfulPayment(ctx, tx, current, tickets)
+			return err
+
+		case payment != nil && payment.Status == "UNPAID" && payment.ExpiredTime > now.Unix():
+			expiry.Action = BookingExpiryExtended
+			deadline := time.Unix(payment.ExpiredTime, 0)
+			current.PaymentDeadline = &deadline
+			if err := uc.BookingRepository.Update(ctx, tx, current); err != nil {
+				return fmt.Errorf("failed to update payment deadline: %w", err)
+			}
+			return nil
+
+		default:
+			expiry.Action = BookingExpiryExpired
+			// A payment still waiting in the outbox must not be created now
+			if _, err := uc.OutboxRepository.CancelPending(ctx, tx, enum.OutboxCreatePayment.String(), current.OrderID); err != nil {
+				return fmt.Errorf("failed to cancel pending payment: %w", err)
+			}
+			email, err = uc.HandleUnsuccessfulPayment(ctx, tx, current, tickets, "EXPIRED")
+			return err
+		}
+	}); err != nil {
+		expiry.Action = BookingExpirySkipped
+		expiry.Err = fmt.Errorf("failed to settle booking %s: %w", booking.OrderID, err)
+		return expiry
+	}
+
+	_ = uc.Outbox.Dispatch(ctx, email)
+	return expiry
 }
@@ -2,32 +2,59 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
 	"eticket-api/internal/domain"
 	"eticket-api/internal/mocks"
 	"eticket-api/internal/model"
+	"eticket-api/pkg/gotann"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
-func paymentUsecase(t *testing.T) (*PaymentUsecase, *mocks.MockTripayClient, *mocks.MockBookingRepository, *mocks.MockTicketRepository, *mocks.MockQuotaRepository, *mocks.MockMailer, *mocks.MockTransactor) {
+func paymentUsecase(t *testing.T) (*PaymentUsecase, *mocks.MockTripayClient, *mocks.MockBookingRepository, *mocks.MockTicketRepository, *mocks.MockQuotaRepository, *mocks.MockScheduleSeatRepository, *mocks.MockOutboxRepository, *mocks.MockTransactor) {
 	t.Helper()
 	ctrl := gomock.NewController(t)
 	tripayClient := mocks.NewMockTripayClient(ctrl)
 	bookingRepo := mocks.NewMockBookingRepository(ctrl)
 	ticketRepo := mocks.NewMockTicketRepository(ctrl)
 	quotaRepo := mocks.NewMockQuotaRepository(ctrl)
-	mailer := mocks.NewMockMailer(ctrl)
+	scheduleSeatRepo := mocks.NewMockScheduleSeatRepository(ctrl)
+	bookingChangeRepo := mocks.NewMockBookingChangeRepository(ctrl)
+	redemptionRepo := mocks.NewMockPromotionRedemptionRepository(ctrl)
+	redemptionRepo.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
 	transactor := mocks.NewMockTransactor(ctrl)
-	uc := NewPaymentUsecase(transactor, tripayClient, bookingRepo, ticketRepo, quotaRepo, mailer)
-	return uc, tripayClient, bookingRepo, ticketRepo, quotaRepo, mailer, transactor
+	tokenUtil := mocks.NewMockTokenUtil(ctrl)
+	tokenUtil.EXPECT().GenerateTicketToken(gomock.Any(), gomock.Any()).Return("qr", nil).AnyTimes()
+	outbox := NewOutboxUsecase(transactor, outboxRepo, bookingRepo, bookingChangeRepo, tripayClient, mocks.NewMockMailer(ctrl), nil)
+	uc := NewPaymentUsecase(transactor, tripayClient, bookingRepo, ticketRepo, quotaRepo, scheduleSeatRepo, bookingChangeRepo, mocks.NewMockAddonStockRepository(ctrl), mocks.NewMockBookingAddonRepository(ctrl), mocks.NewMockRefundRepository(ctrl), mocks.NewMockPromotionRepository(ctrl), redemptionRepo, outboxRepo, outbox, tokenUtil)
+	return uc, tripayClient, bookingRepo, ticketRepo, quotaRepo, scheduleSeatRepo, outboxRepo, transactor
 }
 
 func TestPaymentUsecase_CreatePayment(t *testing.T) {
 	t.Parallel()
-	uc, _, _, _, _, _, transactor := paymentUsecase(t)
+	uc, tripayClient, bookingRepo, ticketRepo, _, _, outboxRepo, transactor := paymentUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	booking := &domain.Booking{ID: 1, OrderID: "ORD-1", Email: "a@b.c"}
+	enqueue := func() {
+		bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD-1").Return(booking, nil)
+		ticketRepo.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), uint(1)).Return([]*domain.Ticket{{ID: 1, Price: 100000}}, nil)
+		outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, conn gotann.Connection, event *domain.Outbox) error {
+				require.Equal(t, enum.OutboxCreatePayment.String(), event.EventType)
+				require.Equal(t, 1, event.MaxAttempts)
+				event.ID = 3
+				return nil
+			},
+		)
+		outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), uint(3), gomock.Any()).Return(int64(1), nil)
+	}
 	tests := []struct {
 		name string
 		mock func()
@@ -37,11 +64,37 @@ func TestPaymentUsecase_CreatePayment(t *testing.T) {
 		{
 			name: "success",
 			mock: func() {
-				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(nil)
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).Times(3)
+				enqueue()
+				tripayClient.EXPECT().CreatePayment(gomock.Any()).Return(&domain.Transaction{Reference: "T1"}, nil)
+				bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(booking, nil)
+				bookingRepo.EXPECT().Update(gomock.Any(), gomock.Any(), booking).Return(nil)
+				outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, event *domain.Outbox) error {
+						require.Equal(t, enum.OutboxDone.String(), event.Status)
+						return nil
+					},
+				)
 			},
-			res: &domain.Transaction{},
+			res: &domain.Transaction{Reference: "T1"},
 			err: nil,
 		},
+		{
+			name: "tripay timeout",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).Times(3)
+				enqueue()
+				tripayClient.EXPECT().CreatePayment(gomock.Any()).Return(nil, errors.New("request timeout"))
+				outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, event *domain.Outbox) error {
+						require.Equal(t, enum.OutboxFailed.String(), event.Status)
+						return nil
+					},
+				)
+			},
+			res: nil,
+			err: errs.ErrExternalTimeout,
+		},
 		{
 			name: "repo error",
 			mock: func() {
@@ -54,11 +107,12 @@ func TestPaymentUsecase_CreatePayment(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock()
-			_, err := uc.CreatePayment(context.Background(), &model.WritePaymentRequest{})
+			res, err := uc.CreatePayment(context.Background(), &model.WritePaymentRequest{OrderID: "ORD-1"})
 			if tc.err != nil {
 				require.ErrorIs(t, err, tc.err)
 			} else {
 				require.NoError(t, err)
+				require.Equal(t, tc.res.Reference, res.Reference)
 			}
 		})
 	}
@@ -66,7 +120,7 @@ func TestPaymentUsecase_CreatePayment(t *testing.T) {
 
 func TestPaymentUsecase_HandleCallback(t *testing.T) {
 	t.Parallel()
-	uc, _, _, _, _, _, transactor := paymentUsecase(t)
+	uc, _, _, _, _, _, _, transactor := paymentUsecase(t)
 	tests := []struct {
 		name string
 		mock func()
@@ -99,3 +153,246 @@ func TestPaymentUsecase_HandleCallback(t *testing.T) {
 		})
 	}
 }
+
+func TestPaymentUsecase_HandleCallback_LatePayment(t *testing.T) {
+	t.Parallel()
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	seat := "1A"
+	expired := func() *domain.Booking {
+		bookingID := uint(1)
+		return &domain.Booking{
+			ID:      bookingID,
+			OrderID: "ORD-1",
+			Email:   "a@b.c",
+			Status:  enum.BookingExpired.String(),
+			Tickets: []domain.Ticket{{ID: 1, BookingID: &bookingID, ScheduleID: 7, ClassID: 2, Price: 100000}},
+		}
+	}
+	tests := []struct {
+		name   string
+		mock   func(quotaRepo *mocks.MockQuotaRepository, seatRepo *mocks.MockScheduleSeatRepository, refundRepo *mocks.MockRefundRepository)
+		status string
+	}{
+		{
+			name: "sold again",
+			mock: func(quotaRepo *mocks.MockQuotaRepository, seatRepo *mocks.MockScheduleSeatRepository, refundRepo *mocks.MockRefundRepository) {
+				quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(7), uint(2), 1).Return(true, nil)
+				quotaRepo.EXPECT().Confirm(gomock.Any(), gomock.Any(), uint(7), uint(2), 1).Return(true, nil)
+				seatRepo.EXPECT().FindAvailable(gomock.Any(), gomock.Any(), uint(7), uint(2), []string{"1A"}, 1).Return([]*domain.ScheduleSeat{{SeatNumber: "1A"}}, nil)
+				seatRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			status: enum.BookingPaid.String(),
+		},
+		{
+			name: "gone meanwhile",
+			mock: func(quotaRepo *mocks.MockQuotaRepository, seatRepo *mocks.MockScheduleSeatRepository, refundRepo *mocks.MockRefundRepository) {
+				quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(7), uint(2), 1).Return(false, nil)
+				refundRepo.EXPECT().InsertBulk(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, refunds []*domain.Refund) error {
+						require.Len(t, refunds, 1)
+						require.Equal(t, latePaymentRefundPolicy, refunds[0].PolicyName)
+						require.Equal(t, 100000.0, refunds[0].Amount)
+						return nil
+					},
+				)
+			},
+			status: enum.BookingRefund.String(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			uc, _, bookingRepo, ticketRepo, quotaRepo, seatRepo, outboxRepo, transactor := paymentUsecase(t)
+			changeRepo := uc.BookingChangeRepository.(*mocks.MockBookingChangeRepository)
+			refundRepo := uc.RefundRepository.(*mocks.MockRefundRepository)
+			transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).AnyTimes()
+			changeRepo.EXPECT().FindByMerchantRef(gomock.Any(), gomock.Any(), "ORD-1").Return(nil, nil)
+			bookingRepo.EXPECT().LockByOrderID(gomock.Any(), gomock.Any(), "ORD-1").Return(nil).AnyTimes()
+			booking := expired()
+			bookingRepo.EXPECT().FindByOrderID(gomock.Any(), gomock.Any(), "ORD-1").Return(booking, nil).AnyTimes()
+			ticketRepo.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), uint(1)).Return([]*domain.Ticket{{ID: 1, ScheduleID: 7, ClassID: 2, Price: 100000, SeatNumber: &seat}}, nil)
+			bookingRepo.EXPECT().Update(gomock.Any(), gomock.Any(), booking).Return(nil)
+			outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+			tc.mock(quotaRepo, seatRepo, refundRepo)
+
+			require.NoError(t, uc.HandleCallback(context.Background(), &domain.Callback{MerchantRef: "ORD-1", Status: "PAID"}))
+			require.Equal(t, tc.status, booking.Status)
+		})
+	}
+}
+
+func TestPaymentUsecase_HandleChangePayment(t *testing.T) {
+	t.Parallel()
+	uc, _, bookingRepo, ticketRepo, quotaRepo, seatRepo, _, _ := paymentUsecase(t)
+	changeRepo := uc.BookingChangeRepository.(*mocks.MockBookingChangeRepository)
+	oldSeat, newSeat := "1A", "2A"
+	// One adult moved from schedule 1 to 2, awaiting the fare difference
+	change := func() *domain.BookingChange {
+		previous, err := changePrevious{
+			ScheduleID: 1,
+			Tickets:    []movedTicket{{ID: 1, FareCategory: "ADULT", Price: 100000, SeatNumber: &oldSeat, Fees: []domain.TicketFee{{TicketID: 1, Name: "Port", Amount: 5000}}}},
+		}.encode()
+		require.NoError(t, err)
+		return &domain.BookingChange{ID: 1, BookingID: 1, FromScheduleID: 1, ToScheduleID: 2, Status: enum.BookingChangeAwaitingPayment.String(), Previous: previous}
+	}
+	moved := func() *domain.Booking {
+		return &domain.Booking{ID: 1, ScheduleID: 2, Tickets: []domain.Ticket{{ID: 1, ScheduleID: 2, ClassID: 1, FareCategory: "ADULT", Price: 120000, SeatNumber: &newSeat}}}
+	}
+	tests := []struct {
+		name   string
+		status string
+		mock   func()
+		result string
+	}{
+		{
+			name:   "paid gives back the old departure",
+			status: "PAID",
+			mock: func() {
+				bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(moved(), nil)
+				seatRepo.EXPECT().ReleaseByScheduleIDAndTicketIDs(gomock.Any(), gomock.Any(), uint(1), []uint{1}).Return(nil)
+				quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(1), uint(1), 1).Return(nil)
+			},
+			result: enum.BookingChangeCompleted.String(),
+		},
+		{
+			name:   "failed moves the tickets back",
+			status: "EXPIRED",
+			mock: func() {
+				bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(moved(), nil)
+				seatRepo.EXPECT().ReleaseByScheduleIDAndTicketIDs(gomock.Any(), gomock.Any(), uint(2), []uint{1}).Return(nil)
+				quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(2), uint(1), 1).Return(nil)
+				ticketRepo.EXPECT().UpdateBulk(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, tickets []*domain.Ticket) error {
+						require.Len(t, tickets, 1)
+						require.Equal(t, uint(1), tickets[0].ScheduleID)
+						require.Equal(t, 100000.0, tickets[0].Price)
+						require.Equal(t, &oldSeat, tickets[0].SeatNumber)
+						return nil
+					},
+				)
+				ticketRepo.EXPECT().ReplaceFees(gomock.Any(), gomock.Any(), uint(1), gomock.Len(1)).Return(nil)
+				bookingRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, booking *domain.Booking) error {
+						require.Equal(t, uint(1), booking.ScheduleID)
+						require.Empty(t, booking.Tickets)
+						return nil
+					},
+				)
+			},
+			result: enum.BookingChangePaymentFailed.String(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			changeRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, conn gotann.Connection, change *domain.BookingChange) error {
+					require.Equal(t, tc.result, change.Status)
+					require.Nil(t, change.Previous)
+					return nil
+				},
+			)
+			require.NoError(t, uc.HandleChangePayment(context.Background(), nil, change(), tc.status))
+		})
+	}
+}
+
+func TestPaymentUsecase_ExpireUnpaidBookings(t *testing.T) {
+	t.Parallel()
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	reference := "T1"
+	unpaid := func() *domain.Booking {
+		bookingID := uint(1)
+		return &domain.Booking{
+			ID:              bookingID,
+			OrderID:         "ORD-1",
+			Email:           "a@b.c",
+			ReferenceNumber: &reference,
+			Status:          enum.BookingUnpaid.String(),
+			Tickets:         []domain.Ticket{{ID: 1, BookingID: &bookingID, ScheduleID: 7, ClassID: 2}},
+		}
+	}
+	tickets := []*domain.Ticket{{ID: 1, ScheduleID: 7, ClassID: 2}}
+	tests := []struct {
+		name   string
+		mock   func(tripayClient *mocks.MockTripayClient, bookingRepo *mocks.MockBookingRepository, ticketRepo *mocks.MockTicketRepository, quotaRepo *mocks.MockQuotaRepository, seatRepo *mocks.MockScheduleSeatRepository, outboxRepo *mocks.MockOutboxRepository)
+		action string
+		status string
+	}{
+		{
+			name: "expired",
+			mock: func(tripayClient *mocks.MockTripayClient, bookingRepo *mocks.MockBookingRepository, ticketRepo *mocks.MockTicketRepository, quotaRepo *mocks.MockQuotaRepository, seatRepo *mocks.MockScheduleSeatRepository, outboxRepo *mocks.MockOutboxRepository) {
+				tripayClient.EXPECT().GetTransactionDetail("T1").Return(&domain.Transaction{Status: "EXPIRED"}, nil)
+				outboxRepo.EXPECT().CancelPending(gomock.Any(), gomock.Any(), enum.OutboxCreatePayment.String(), "ORD-1").Return(int64(0), nil)
+				quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(7), uint(2), 1).Return(nil)
+				seatRepo.EXPECT().ReleaseByTicketIDs(gomock.Any(), gomock.Any(), []uint{1}).Return(nil)
+				outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+			},
+			action: BookingExpiryExpired,
+			status: enum.BookingExpired.String(),
+		},
+		{
+			name: "paid without callback",
+			mock: func(tripayClient *mocks.MockTripayClient, bookingRepo *mocks.MockBookingRepository, ticketRepo *mocks.MockTicketRepository, quotaRepo *mocks.MockQuotaRepository, seatRepo *mocks.MockScheduleSeatRepository, outboxRepo *mocks.MockOutboxRepository) {
+				tripayClient.EXPECT().GetTransactionDetail("T1").Return(&domain.Transaction{Status: "PAID"}, nil)
+				outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+			},
+			action: BookingExpiryPaid,
+			status: enum.BookingPaid.String(),
+		},
+		{
+			name: "payment still open",
+			mock: func(tripayClient *mocks.MockTripayClient, bookingRepo *mocks.MockBookingRepository, ticketRepo *mocks.MockTicketRepository, quotaRepo *mocks.MockQuotaRepository, seatRepo *mocks.MockScheduleSeatRepository, outboxRepo *mocks.MockOutboxRepository) {
+				tripayClient.EXPECT().GetTransactionDetail("T1").Return(&domain.Transaction{
+					Status:      "UNPAID",
+					ExpiredTime: time.Now().Add(time.Hour).Unix(),
+				}, nil)
+			},
+			action: BookingExpiryExtended,
+			status: enum.BookingUnpaid.String(),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			uc, tripayClient, bookingRepo, ticketRepo, quotaRepo, seatRepo, outboxRepo, transactor := paymentUsecase(t)
+			transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx).AnyTimes()
+			booking := unpaid()
+			bookingRepo.EXPECT().FindExpiredUnpaid(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), bookingExpiryBatch).Return([]*domain.Booking{unpaid()}, nil)
+			bookingRepo.EXPECT().LockByOrderID(gomock.Any(), gomock.Any(), "ORD-1").Return(nil)
+			bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(booking, nil)
+			ticketRepo.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), uint(1)).Return(tickets, nil)
+			bookingRepo.EXPECT().Update(gomock.Any(), gomock.Any(), booking).Return(nil)
+			tc.mock(tripayClient, bookingRepo, ticketRepo, quotaRepo, seatRepo, outboxRepo)
+
+			expiries, err := uc.ExpireUnpaidBookings(context.Background())
+			require.NoError(t, err)
+			require.Len(t, expiries, 1)
+			require.NoError(t, expiries[0].Err)
+			require.Equal(t, tc.action, expiries[0].Action)
+			require.Equal(t, tc.status, booking.Status)
+		})
+	}
+}
+
+func TestPaymentUsecase_ExpireUnpaidBookings_TripayDown(t *testing.T) {
+	t.Parallel()
+	uc, tripayClient, bookingRepo, _, _, _, _, transactor := paymentUsecase(t)
+	transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) },
+	)
+	reference := "T1"
+	bookingRepo.EXPECT().FindExpiredUnpaid(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), bookingExpiryBatch).Return([]*domain.Booking{
+		{ID: 1, OrderID: "ORD-1", ReferenceNumber: &reference, Status: enum.BookingUnpaid.String()},
+	}, nil)
+	tripayClient.EXPECT().GetTransactionDetail("T1").Return(nil, errors.New("connection error"))
+
+	expiries, err := uc.ExpireUnpaidBookings(context.Background())
+	require.NoError(t, err)
+	require.Len(t, expiries, 1)
+	require.Equal(t, BookingExpirySkipped, expiries[0].Action)
+	require.Error(t, expiries[0].Err)
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"math"
+	"time"
+)
+
+// promotionDiscount computes what a promotion takes off a claim at now. Only
+// items on the promotion's route, class and schedule count towards the
+// discount, while the minimum spend is checked against the whole claim. The
+// discount never exceeds the price of the items it covers.
+func promotionDiscount(promotion *domain.Promotion, items []domain.ClaimItem, schedules map[uint]*domain.Schedule, now time.Time) (float64, error) {
+	if !promotion.IsActive || now.Before(promotion.ValidFrom) || !now.Before(promotion.ValidUntil) {
+		return 0, fmt.Errorf("promo code %s is not valid now: %w", promotion.Code, errs.ErrPromoUnavailable)
+	}
+
+	var total, covered float64
+	for _, item := range items {
+		total += item.Subtotal
+		if promotionCovers(promotion, schedules[item.ScheduleID], item.ClassID) {
+			covered += item.Subtotal
+		}
+	}
+	if covered == 0 {
+		return 0, fmt.Errorf("promo code %s does not apply to this trip: %w", promotion.Code, errs.ErrPromoUnavailable)
+	}
+	if total < promotion.MinSpend {
+		return 0, fmt.Errorf("promo code %s needs a minimum spend of %.0f: %w", promotion.Code, promotion.MinSpend, errs.ErrPromoUnavailable)
+	}
+
+	var discount float64
+	switch promotion.DiscountType {
+	case enum.DiscountPercentage.String():
+		discount = math.Round(covered * promotion.DiscountValue / 100)
+		if promotion.MaxDiscount != nil && discount > *promotion.MaxDiscount {
+			discount = *promotion.MaxDiscount
+		}
+	case enum.DiscountFixed.String():
+		discount = promotion.DiscountValue
+	default:
+		return 0, fmt.Errorf("unknown discount type %s", promotion.DiscountType)
+	}
+	return math.Min(discount, covered), nil
+}
+
+// ticketDiscount computes what a promotion takes off priced tickets, at the
+// time it was redeemed. Child, senior and infant fares make tickets cheaper
+// than the fare a claim is locked at, so the discount of a booking comes from
+// its tickets rather than from the claim.
+func ticketDiscount(promotion *domain.Promotion, tickets []*domain.Ticket, schedules map[uint]*domain.Schedule, redeemedAt time.Time) (float64, error) {
+	subtotals := make(map[legClass]float64)
+	for _, ticket := range tickets {
+		subtotals[legClass{ScheduleID: ticket.ScheduleID, ClassID: ticket.ClassID}] += ticket.Price
+	}
+	items := make([]domain.ClaimItem, 0, len(subtotals))
+	for key, subtotal := range subtotals {
+		items = append(items, domain.ClaimItem{ScheduleID: key.ScheduleID, ClassID: key.ClassID, Subtotal: subtotal})
+	}
+	return promotionDiscount(promotion, items, schedules, redeemedAt)
+}
+
+// promotionCovers reports whether an item of classID on schedule falls within
+// the promotion's restrictions.
+func promotionCovers(promotion *domain.Promotion, schedule *domain.Schedule, classID uint) bool {
+	if promotion.ClassID != nil && *promotion.ClassID != classID {
+		return false
+	}
+	if schedule == nil {
+		return promotion.ScheduleID == nil && promotion.DepartureHarborID == nil && promotion.ArrivalHarborID == nil
+	}
+	if promotion.ScheduleID != nil && *promotion.ScheduleID != schedule.ID {
+		return false
+	}
+	if promotion.DepartureHarborID != nil && *promotion.DepartureHarborID != schedule.DepartureHarborID {
+		return false
+	}
+	if promotion.ArrivalHarborID != nil && *promotion.ArrivalHarborID != schedule.ArrivalHarborID {
+		return false
+	}
+	return true
+}
+
+// releaseRedemption gives a promo code use back: the redemption is deleted
+// and the promotion counts one use less. A nil redemption is a no-op.
+func releaseRedemption(ctx context.Context, conn gotann.Connection, promotions domain.PromotionRepository, redemptions domain.PromotionRedemptionRepository, redemption *domain.PromotionRedemption) error {
+	if redemption == nil {
+		return nil
+	}
+	if err := redemptions.Delete(ctx, conn, redemption); err != nil {
+		return fmt.Errorf("failed to delete promo redemption: %w", err)
+	}
+	if err := promotions.Release(ctx, conn, redemption.PromotionID); err != nil {
+		return fmt.Errorf("failed to release promotion: %w", err)
+	}
+	return nil
+}
+
+// releaseBookingPromotion gives back the promo code use of a booking that was
+// never paid or that the operator cancelled.
+func releaseBookingPromotion(ctx context.Context, conn gotann.Connection, promotions domain.PromotionRepository, redemptions domain.PromotionRedemptionRepository, bookingID uint) error {
+	redemption, err := redemptions.FindByBookingID(ctx, conn, bookingID)
+	if err != nil {
+		return fmt.Errorf("failed to get promo redemption: %w", err)
+	}
+	return releaseRedemption(ctx, conn, promotions, redemptions, redemption)
+}
@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromotionDiscount(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	schedules := map[uint]*domain.Schedule{
+		1: {ID: 1, DepartureHarborID: 10, ArrivalHarborID: 20},
+		2: {ID: 2, DepartureHarborID: 20, ArrivalHarborID: 10},
+	}
+	items := []domain.ClaimItem{
+		{ScheduleID: 1, ClassID: 1, Quantity: 2, Subtotal: 200000},
+		{ScheduleID: 2, ClassID: 2, Quantity: 1, Subtotal: 50000},
+	}
+	promotion := func(discountType string, value float64) *domain.Promotion {
+		return &domain.Promotion{
+			Code:          "HEBAT",
+			DiscountType:  discountType,
+			DiscountValue: value,
+			ValidFrom:     now.Add(-time.Hour),
+			ValidUntil:    now.Add(time.Hour),
+			IsActive:      true,
+		}
+	}
+	maxDiscount := 30000.0
+	class, departure, arrival := uint(2), uint(10), uint(20)
+	tests := []struct {
+		name      string
+		promotion func() *domain.Promotion
+		discount  float64
+		err       error
+	}{
+		{
+			name:      "percentage of the whole claim",
+			promotion: func() *domain.Promotion { return promotion(enum.DiscountPercentage.String(), 10) },
+			discount:  25000,
+		},
+		{
+			name: "percentage capped",
+			promotion: func() *domain.Promotion {
+				p := promotion(enum.DiscountPercentage.String(), 20)
+				p.MaxDiscount = &maxDiscount
+				return p
+			},
+			discount: 30000,
+		},
+		{
+			name: "fixed on the class only",
+			promotion: func() *domain.Promotion {
+				p := promotion(enum.DiscountFixed.String(), 75000)
+				p.ClassID = &class
+				return p
+			},
+			discount: 50000,
+		},
+		{
+			name: "percentage on the route only",
+			promotion: func() *domain.Promotion {
+				p := promotion(enum.DiscountPercentage.String(), 10)
+				p.DepartureHarborID, p.ArrivalHarborID = &departure, &arrival
+				return p
+			},
+			discount: 20000,
+		},
+		{
+			name: "restriction misses the claim",
+			promotion: func() *domain.Promotion {
+				p := promotion(enum.DiscountFixed.String(), 10000)
+				other := uint(9)
+				p.ScheduleID = &other
+				return p
+			},
+			err: errs.ErrPromoUnavailable,
+		},
+		{
+			name: "below minimum spend",
+			promotion: func() *domain.Promotion {
+				p := promotion(enum.DiscountFixed.String(), 10000)
+				p.MinSpend = 300000
+				return p
+			},
+			err: errs.ErrPromoUnavailable,
+		},
+		{
+			name: "expired",
+			promotion: func() *domain.Promotion {
+				p := promotion(enum.DiscountFixed.String(), 10000)
+				p.ValidUntil = now
+				return p
+			},
+			err: errs.ErrPromoUnavailable,
+		},
+		{
+			name: "inactive",
+			promotion: func() *domain.Promotion {
+				p := promotion(enum.DiscountFixed.String(), 10000)
+				p.IsActive = false
+				return p
+			},
+			err: errs.ErrPromoUnavailable,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			discount, err := promotionDiscount(tc.promotion(), items, schedules, now)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.discount, discount)
+		})
+	}
+}
+
+func TestTicketDiscount(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	schedules := map[uint]*domain.Schedule{1: {ID: 1, DepartureHarborID: 10, ArrivalHarborID: 20}}
+	// An adult and a child on a leg locked at 100000 a head
+	tickets := []*domain.Ticket{
+		{ScheduleID: 1, ClassID: 1, Price: 100000},
+		{ScheduleID: 1, ClassID: 1, Price: 60000},
+	}
+	promotion := &domain.Promotion{
+		Code:          "HEBAT",
+		DiscountType:  enum.DiscountPercentage.String(),
+		DiscountValue: 10,
+		ValidFrom:     now.Add(-time.Hour),
+		ValidUntil:    now.Add(time.Hour),
+		IsActive:      true,
+	}
+
+	discount, err := ticketDiscount(promotion, tickets, schedules, now)
+	require.NoError(t, err)
+	require.Equal(t, 16000.0, discount)
+
+	// The child fare leaves the booking under a minimum the claim met
+	promotion.MinSpend = 200000
+	_, err = ticketDiscount(promotion, tickets, schedules, now)
+	require.ErrorIs(t, err, errs.ErrPromoUnavailable)
+}
+
+func TestReleaseBookingPromotion(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	promotions := mocks.NewMockPromotionRepository(ctrl)
+	redemptions := mocks.NewMockPromotionRedemptionRepository(ctrl)
+	redemption := &domain.PromotionRedemption{ID: 3, PromotionID: 5}
+
+	redemptions.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), uint(1)).Return(redemption, nil)
+	redemptions.EXPECT().Delete(gomock.Any(), gomock.Any(), redemption).Return(nil)
+	promotions.EXPECT().Release(gomock.Any(), gomock.Any(), uint(5)).Return(nil)
+	require.NoError(t, releaseBookingPromotion(context.Background(), nil, promotions, redemptions, 1))
+
+	// A booking without a promo code has nothing to give back
+	redemptions.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), uint(2)).Return(nil, nil)
+	require.NoError(t, releaseBookingPromotion(context.Background(), nil, promotions, redemptions, 2))
+}
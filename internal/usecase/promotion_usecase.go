@@ -0,0 +1,225 @@
+package usecase
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"strings"
+)
+
+type PromotionUsecase struct {
+	Transactor          transact.Transactor
+	PromotionRepository domain.PromotionRepository
+	HarborRepository    domain.HarborRepository
+	ClassRepository     domain.ClassRepository
+	ScheduleRepository  domain.ScheduleRepository
+}
+
+func NewPromotionUsecase(
+	transactor transact.Transactor,
+	promotion_repository domain.PromotionRepository,
+	harbor_repository domain.HarborRepository,
+	class_repository domain.ClassRepository,
+	schedule_repository domain.ScheduleRepository,
+) *PromotionUsecase {
+	return &PromotionUsecase{
+		Transactor:          transactor,
+		PromotionRepository: promotion_repository,
+		HarborRepository:    harbor_repository,
+		ClassRepository:     class_repository,
+		ScheduleRepository:  schedule_repository,
+	}
+}
+
+func (uc *PromotionUsecase) CreatePromotion(ctx context.Context, e *domain.Promotion) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if err := uc.checkPromotion(ctx, tx, e); err != nil {
+			return err
+		}
+
+		promotion := &domain.Promotion{
+			Code:              e.Code,
+			Name:              e.Name,
+			DiscountType:      e.DiscountType,
+			DiscountValue:     e.DiscountValue,
+			MaxDiscount:       e.MaxDiscount,
+			MinSpend:          e.MinSpend,
+			ValidFrom:         e.ValidFrom,
+			ValidUntil:        e.ValidUntil,
+			UsageLimit:        e.UsageLimit,
+			PerCustomerLimit:  e.PerCustomerLimit,
+			DepartureHarborID: e.DepartureHarborID,
+			ArrivalHarborID:   e.ArrivalHarborID,
+			ClassID:           e.ClassID,
+			ScheduleID:        e.ScheduleID,
+			IsActive:          e.IsActive,
+		}
+		if err := uc.PromotionRepository.Insert(ctx, tx, promotion); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("failed to create promotion: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *PromotionUsecase) ListPromotions(ctx context.Context, limit, offset int, sort, search string) ([]*domain.Promotion, int, error) {
+	var err error
+	var total int64
+	var promotions []*domain.Promotion
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		total, err = uc.PromotionRepository.Count(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to count promotions: %w", err)
+		}
+		promotions, err = uc.PromotionRepository.FindAll(ctx, tx, limit, offset, sort, search)
+		if err != nil {
+			return fmt.Errorf("failed to get all promotions: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to list promotions: %w", err)
+	}
+
+	return promotions, int(total), nil
+}
+
+func (uc *PromotionUsecase) GetPromotionByID(ctx context.Context, id uint) (*domain.Promotion, error) {
+	var err error
+	var promotion *domain.Promotion
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		promotion, err = uc.PromotionRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get promotion: %w", err)
+		}
+		if promotion == nil {
+			return errs.ErrNotFound
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get promotion by ID: %w", err)
+	}
+	return promotion, nil
+}
+
+// UpdatePromotion replaces the promotion's terms. Bookings already made keep
+// the discount they were given, and the usage count is left as it is.
+func (uc *PromotionUsecase) UpdatePromotion(ctx context.Context, e *domain.Promotion) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		promotion, err := uc.PromotionRepository.FindByID(ctx, tx, e.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find promotion: %w", err)
+		}
+		if promotion == nil {
+			return errs.ErrNotFound
+		}
+		if err := uc.checkPromotion(ctx, tx, e); err != nil {
+			return err
+		}
+
+		promotion.Code = e.Code
+		promotion.Name = e.Name
+		promotion.DiscountType = e.DiscountType
+		promotion.DiscountValue = e.DiscountValue
+		promotion.MaxDiscount = e.MaxDiscount
+		promotion.MinSpend = e.MinSpend
+		promotion.ValidFrom = e.ValidFrom
+		promotion.ValidUntil = e.ValidUntil
+		promotion.UsageLimit = e.UsageLimit
+		promotion.PerCustomerLimit = e.PerCustomerLimit
+		promotion.DepartureHarborID = e.DepartureHarborID
+		promotion.ArrivalHarborID = e.ArrivalHarborID
+		promotion.ClassID = e.ClassID
+		promotion.ScheduleID = e.ScheduleID
+		promotion.IsActive = e.IsActive
+
+		if err := uc.PromotionRepository.Update(ctx, tx, promotion); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("failed to update promotion: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *PromotionUsecase) DeletePromotion(ctx context.Context, id uint) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		promotion, err := uc.PromotionRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get promotion: %w", err)
+		}
+		if promotion == nil {
+			return errs.ErrNotFound
+		}
+
+		if err := uc.PromotionRepository.Delete(ctx, tx, promotion); err != nil {
+			return fmt.Errorf("failed to delete promotion: %w", err)
+		}
+		return nil
+	})
+}
+
+// checkPromotion normalises the code and validates the discount, validity
+// window and restrictions. A route needs both harbors.
+func (uc *PromotionUsecase) checkPromotion(ctx context.Context, conn gotann.Connection, promotion *domain.Promotion) error {
+	promotion.Code = strings.ToUpper(strings.TrimSpace(promotion.Code))
+	if promotion.Code == "" {
+		return fmt.Errorf("promo code is empty: %w", errs.ErrBadRequest)
+	}
+	switch promotion.DiscountType {
+	case enum.DiscountPercentage.String():
+		if promotion.DiscountValue <= 0 || promotion.DiscountValue > 100 {
+			return fmt.Errorf("percentage must be between 0 and 100: %w", errs.ErrBadRequest)
+		}
+	case enum.DiscountFixed.String():
+		if promotion.DiscountValue <= 0 {
+			return fmt.Errorf("discount must be positive: %w", errs.ErrBadRequest)
+		}
+	default:
+		return fmt.Errorf("unknown discount type %s: %w", promotion.DiscountType, errs.ErrBadRequest)
+	}
+	if !promotion.ValidUntil.After(promotion.ValidFrom) {
+		return fmt.Errorf("valid until must be after valid from: %w", errs.ErrBadRequest)
+	}
+	if (promotion.DepartureHarborID == nil) != (promotion.ArrivalHarborID == nil) {
+		return fmt.Errorf("route needs both departure and arrival harbor: %w", errs.ErrBadRequest)
+	}
+
+	for _, harborID := range []*uint{promotion.DepartureHarborID, promotion.ArrivalHarborID} {
+		if harborID == nil {
+			continue
+		}
+		harbor, err := uc.HarborRepository.FindByID(ctx, conn, *harborID)
+		if err != nil {
+			return fmt.Errorf("failed to get harbor: %w", err)
+		}
+		if harbor == nil {
+			return fmt.Errorf("harbor %d: %w", *harborID, errs.ErrNotFound)
+		}
+	}
+	if promotion.ClassID != nil {
+		class, err := uc.ClassRepository.FindByID(ctx, conn, *promotion.ClassID)
+		if err != nil {
+			return fmt.Errorf("failed to get class: %w", err)
+		}
+		if class == nil {
+			return fmt.Errorf("class %d: %w", *promotion.ClassID, errs.ErrNotFound)
+		}
+	}
+	if promotion.ScheduleID != nil {
+		schedule, err := uc.ScheduleRepository.FindByID(ctx, conn, *promotion.ScheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if schedule == nil {
+			return fmt.Errorf("schedule %d: %w", *promotion.ScheduleID, errs.ErrNotFound)
+		}
+	}
+	return nil
+}
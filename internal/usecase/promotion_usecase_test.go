@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func promotionUsecase(t *testing.T) (*PromotionUsecase, *mocks.MockPromotionRepository, *mocks.MockHarborRepository, *mocks.MockScheduleRepository, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	promotionRepo := mocks.NewMockPromotionRepository(ctrl)
+	harborRepo := mocks.NewMockHarborRepository(ctrl)
+	classRepo := mocks.NewMockClassRepository(ctrl)
+	scheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	uc := NewPromotionUsecase(transactor, promotionRepo, harborRepo, classRepo, scheduleRepo)
+	return uc, promotionRepo, harborRepo, scheduleRepo, transactor
+}
+
+func TestPromotionUsecase_CreatePromotion(t *testing.T) {
+	t.Parallel()
+	uc, promotionRepo, harborRepo, scheduleRepo, transactor := promotionUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	departure, arrival, schedule := uint(1), uint(2), uint(3)
+	promotion := func(discountType string, value float64) *domain.Promotion {
+		return &domain.Promotion{
+			Code:          " hebat10 ",
+			Name:          "Hebat",
+			DiscountType:  discountType,
+			DiscountValue: value,
+			ValidFrom:     from,
+			ValidUntil:    from.Add(30 * 24 * time.Hour),
+			IsActive:      true,
+		}
+	}
+	tests := []struct {
+		name      string
+		promotion func() *domain.Promotion
+		mock      func()
+		err       error
+	}{
+		{
+			name: "success",
+			promotion: func() *domain.Promotion {
+				p := promotion(enum.DiscountPercentage.String(), 10)
+				p.DepartureHarborID, p.ArrivalHarborID, p.ScheduleID = &departure, &arrival, &schedule
+				return p
+			},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				harborRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Harbor{ID: 1}, nil)
+				harborRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(2)).Return(&domain.Harbor{ID: 2}, nil)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(3)).Return(&domain.Schedule{ID: 3}, nil)
+				promotionRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, promotion *domain.Promotion) error {
+						require.Equal(t, "HEBAT10", promotion.Code)
+						return nil
+					},
+				)
+			},
+		},
+		{
+			name:      "percentage over 100",
+			promotion: func() *domain.Promotion { return promotion(enum.DiscountPercentage.String(), 120) },
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name: "ends before it starts",
+			promotion: func() *domain.Promotion {
+				p := promotion(enum.DiscountFixed.String(), 10000)
+				p.ValidUntil = from.Add(-time.Hour)
+				return p
+			},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name: "unknown schedule",
+			promotion: func() *domain.Promotion {
+				p := promotion(enum.DiscountFixed.String(), 10000)
+				p.ScheduleID = &schedule
+				return p
+			},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(3)).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+		{
+			name:      "duplicate code",
+			promotion: func() *domain.Promotion { return promotion(enum.DiscountFixed.String(), 10000) },
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				promotionRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("duplicate key value violates unique constraint"))
+			},
+			err: errs.ErrConflict,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.CreatePromotion(context.Background(), tc.promotion())
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
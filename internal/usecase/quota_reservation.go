@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"sort"
+)
+
+// legClass identifies one class on one leg (schedule) of a claim or booking.
+type legClass struct {
+	ScheduleID uint
+	ClassID    uint
+}
+
+type classQuantity struct {
+	ScheduleID uint
+	ClassID    uint
+	Quantity   int
+}
+
+// itemScheduleID returns the leg of a claim item. Items created before claim
+// sessions could span several legs carry no schedule of their own.
+func itemScheduleID(scheduleID uint, item domain.ClaimItem) uint {
+	if item.ScheduleID != 0 {
+		return item.ScheduleID
+	}
+	return scheduleID
+}
+
+// groupClaimItems folds claim items into one total per leg and class, ordered
+// so concurrent reservations always touch quota rows in the same order.
+// Vehicle classes counted in lane meters hold their units, not their
+// quantity.
+func groupClaimItems(scheduleID uint, items []domain.ClaimItem) []classQuantity {
+	totals := make(map[legClass]int)
+	for _, item := range items {
+		units := item.Quantity
+		if item.QuotaUnits > 0 {
+			units = item.QuotaUnits
+		}
+		totals[legClass{ScheduleID: itemScheduleID(scheduleID, item), ClassID: item.ClassID}] += units
+	}
+	return sortedQuantities(totals)
+}
+
+// groupTickets counts tickets per leg and class, in the same order as
+// groupClaimItems. Infants on a lap hold no quota and are left out.
+func groupTickets(tickets []*domain.Ticket) []classQuantity {
+	totals := make(map[legClass]int)
+	for _, ticket := range tickets {
+		if ticket == nil || onLap(ticket) {
+			continue
+		}
+		totals[legClass{ScheduleID: ticket.ScheduleID, ClassID: ticket.ClassID}] += ticketUnits(ticket)
+	}
+	return sortedQuantities(totals)
+}
+
+func sortedQuantities(totals map[legClass]int) []classQuantity {
+	quantities := make([]classQuantity, 0, len(totals))
+	for key, quantity := range totals {
+		quantities = append(quantities, classQuantity{ScheduleID: key.ScheduleID, ClassID: key.ClassID, Quantity: quantity})
+	}
+	sort.Slice(quantities, func(i, j int) bool {
+		if quantities[i].ScheduleID != quantities[j].ScheduleID {
+			return quantities[i].ScheduleID < quantities[j].ScheduleID
+		}
+		return quantities[i].ClassID < quantities[j].ClassID
+	})
+	return quantities
+}
+
+// reserveClaimItems holds quota for every item on every leg or fails with
+// ErrQuotaExceeded. Holds taken before the failing class are undone by the
+// surrounding rollback, so a multi-leg lock is all or nothing. Items without
+// a leg of their own belong to scheduleID.
+func reserveClaimItems(ctx context.Context, conn gotann.Connection, quotas domain.QuotaRepository, scheduleID uint, items []domain.ClaimItem) error {
+	for _, q := range groupClaimItems(scheduleID, items) {
+		ok, err := quotas.Reserve(ctx, conn, q.ScheduleID, q.ClassID, q.Quantity)
+		if err != nil {
+			return fmt.Errorf("reserve quota for schedule %d class %d: %w", q.ScheduleID, q.ClassID, err)
+		}
+		if !ok {
+			return fmt.Errorf("schedule %d class %d: %w", q.ScheduleID, q.ClassID, errs.ErrQuotaExceeded)
+		}
+	}
+	return nil
+}
+
+func releaseClaimItems(ctx context.Context, conn gotann.Connection, quotas domain.QuotaRepository, scheduleID uint, items []domain.ClaimItem) error {
+	for _, q := range groupClaimItems(scheduleID, items) {
+		if err := quotas.Release(ctx, conn, q.ScheduleID, q.ClassID, q.Quantity); err != nil {
+			return fmt.Errorf("release quota for schedule %d class %d: %w", q.ScheduleID, q.ClassID, err)
+		}
+	}
+	return nil
+}
+
+func confirmClaimItems(ctx context.Context, conn gotann.Connection, quotas domain.QuotaRepository, scheduleID uint, items []domain.ClaimItem) error {
+	for _, q := range groupClaimItems(scheduleID, items) {
+		ok, err := quotas.Confirm(ctx, conn, q.ScheduleID, q.ClassID, q.Quantity)
+		if err != nil {
+			return fmt.Errorf("confirm quota for schedule %d class %d: %w", q.ScheduleID, q.ClassID, err)
+		}
+		if !ok {
+			return fmt.Errorf("schedule %d class %d: %w", q.ScheduleID, q.ClassID, errs.ErrQuotaExceeded)
+		}
+	}
+	return nil
+}
+
+// restoreTickets returns the quota consumed by already issued tickets, on
+// whichever leg each ticket was issued for.
+func restoreTickets(ctx context.Context, conn gotann.Connection, quotas domain.QuotaRepository, tickets []*domain.Ticket) error {
+	for _, q := range groupTickets(tickets) {
+		if err := quotas.Restore(ctx, conn, q.ScheduleID, q.ClassID, q.Quantity); err != nil {
+			return fmt.Errorf("restore quota for schedule %d class %d: %w", q.ScheduleID, q.ClassID, err)
+		}
+	}
+	return nil
+}
+
+// sellTickets takes quota for tickets moved onto a leg outside the claim flow,
+// reserving and confirming in one go. It fails with ErrQuotaExceeded when any
+// class on the leg is short.
+func sellTickets(ctx context.Context, conn gotann.Connection, quotas domain.QuotaRepository, tickets []*domain.Ticket) error {
+	for _, q := range groupTickets(tickets) {
+		ok, err := quotas.Reserve(ctx, conn, q.ScheduleID, q.ClassID, q.Quantity)
+		if err != nil {
+			return fmt.Errorf("reserve quota for schedule %d class %d: %w", q.ScheduleID, q.ClassID, err)
+		}
+		if !ok {
+			return fmt.Errorf("schedule %d class %d: %w", q.ScheduleID, q.ClassID, errs.ErrQuotaExceeded)
+		}
+		ok, err = quotas.Confirm(ctx, conn, q.ScheduleID, q.ClassID, q.Quantity)
+		if err != nil {
+			return fmt.Errorf("confirm quota for schedule %d class %d: %w", q.ScheduleID, q.ClassID, err)
+		}
+		if !ok {
+			return fmt.Errorf("schedule %d class %d: %w", q.ScheduleID, q.ClassID, errs.ErrQuotaExceeded)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReserveClaimItems(t *testing.T) {
+	t.Parallel()
+	items := []domain.ClaimItem{
+		{ClassID: 2, Quantity: 1},
+		{ClassID: 1, Quantity: 2},
+		{ClassID: 2, Quantity: 3},
+	}
+	tests := []struct {
+		name string
+		mock func(quotaRepo *mocks.MockQuotaRepository)
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func(quotaRepo *mocks.MockQuotaRepository) {
+				gomock.InOrder(
+					quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(7), uint(1), 2).Return(true, nil),
+					quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(7), uint(2), 4).Return(true, nil),
+				)
+			},
+			err: nil,
+		},
+		{
+			name: "quota exceeded",
+			mock: func(quotaRepo *mocks.MockQuotaRepository) {
+				gomock.InOrder(
+					quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(7), uint(1), 2).Return(true, nil),
+					quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(7), uint(2), 4).Return(false, nil),
+				)
+			},
+			err: errs.ErrQuotaExceeded,
+		},
+		{
+			name: "repo error",
+			mock: func(quotaRepo *mocks.MockQuotaRepository) {
+				quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(7), uint(1), 2).Return(false, errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			quotaRepo := mocks.NewMockQuotaRepository(ctrl)
+			tc.mock(quotaRepo)
+			err := reserveClaimItems(context.Background(), nil, quotaRepo, 7, items)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRestoreTickets(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	quotaRepo := mocks.NewMockQuotaRepository(ctrl)
+	tickets := []*domain.Ticket{
+		{ScheduleID: 10, ClassID: 1},
+		{ScheduleID: 9, ClassID: 3},
+		nil,
+		{ScheduleID: 9, ClassID: 3},
+		{ScheduleID: 9, ClassID: 1},
+		{ScheduleID: 9, ClassID: 1, FareCategory: "INFANT"},           // On a lap, holds no quota
+		{ScheduleID: 10, ClassID: 5, Type: "vehicle", QuotaUnits: 12}, // Lane meters
+	}
+
+	gomock.InOrder(
+		quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(9), uint(1), 1).Return(nil),
+		quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(9), uint(3), 2).Return(nil),
+		quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(10), uint(1), 1).Return(nil),
+		quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(10), uint(5), 12).Return(nil),
+	)
+	require.NoError(t, restoreTickets(context.Background(), nil, quotaRepo, tickets))
+}
+
+func TestReserveClaimItems_MultiLeg(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	quotaRepo := mocks.NewMockQuotaRepository(ctrl)
+	items := []domain.ClaimItem{
+		{ScheduleID: 8, ClassID: 1, Quantity: 2},
+		{ClassID: 1, Quantity: 2},
+	}
+
+	// The return leg runs out, the outbound hold is rolled back with the transaction
+	gomock.InOrder(
+		quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(7), uint(1), 2).Return(true, nil),
+		quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(8), uint(1), 2).Return(false, nil),
+	)
+	err := reserveClaimItems(context.Background(), nil, quotaRepo, 7, items)
+	require.ErrorIs(t, err, errs.ErrQuotaExceeded)
+}
+
+func TestReserveClaimItems_LaneMeters(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	quotaRepo := mocks.NewMockQuotaRepository(ctrl)
+	items := []domain.ClaimItem{
+		{ClassID: 1, Quantity: 2},
+		{ClassID: 4, Quantity: 2, QuotaUnits: 24}, // Two trucks of 12 lane meters
+	}
+
+	gomock.InOrder(
+		quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(7), uint(1), 2).Return(true, nil),
+		quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(7), uint(4), 24).Return(true, nil),
+	)
+	require.NoError(t, reserveClaimItems(context.Background(), nil, quotaRepo, 7, items))
+}
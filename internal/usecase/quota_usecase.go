@@ -28,12 +28,17 @@ func NewQuotaUsecase(
 
 func (uc *QuotaUsecase) CreateQuota(ctx context.Context, e *domain.Quota) error {
 	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if err := checkFares(e.Fares); err != nil {
+			return err
+		}
+
 		quota := &domain.Quota{
 			ScheduleID: e.ScheduleID,
 			ClassID:    e.ClassID,
 			Quota:      e.Capacity,
 			Capacity:   e.Capacity,
 			Price:      e.Price,
+			Fares:      e.Fares,
 		}
 		if err := uc.QuotaRepository.Insert(ctx, tx, quota); err != nil {
 			if errs.IsUniqueConstraintError(err) {
@@ -49,12 +54,16 @@ func (uc *QuotaUsecase) CreateQuotaBulk(ctx context.Context, es []*domain.Quota)
 	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
 		quotas := make([]*domain.Quota, len(es))
 		for i, e := range es {
+			if err := checkFares(e.Fares); err != nil {
+				return err
+			}
 			quotas[i] = &domain.Quota{
 				ScheduleID: e.ScheduleID,
 				ClassID:    e.ClassID,
 				Price:      e.Price,
 				Quota:      e.Capacity,
 				Capacity:   e.Capacity,
+				Fares:      e.Fares,
 			}
 		}
 
@@ -110,6 +119,10 @@ func (uc *QuotaUsecase) GetQuotaByID(ctx context.Context, id uint) (*domain.Quot
 	return quota, nil
 }
 
+// UpdateQuota replaces the quota's fares along with the rest. Tickets already
+// sold keep the price they were sold at, and the units sold and held by open
+// claim sessions stay taken: the remaining quota is what the new capacity
+// leaves after the sold ones, and it may not drop below what is held.
 func (uc *QuotaUsecase) UpdateQuota(ctx context.Context, e *domain.Quota) error {
 	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
 		quota, err := uc.QuotaRepository.FindByID(ctx, tx, e.ID)
@@ -119,16 +132,27 @@ func (uc *QuotaUsecase) UpdateQuota(ctx context.Context, e *domain.Quota) error
 		if quota == nil {
 			return errs.ErrNotFound
 		}
+		if err := checkFares(e.Fares); err != nil {
+			return err
+		}
+		sold := quota.Capacity - quota.Quota
+		if e.Capacity < sold+quota.Held {
+			return fmt.Errorf("capacity %d is below the %d units sold and %d held: %w", e.Capacity, sold, quota.Held, errs.ErrBadRequest)
+		}
 
 		quota.ScheduleID = e.ScheduleID
 		quota.ClassID = e.ClassID
-		quota.Quota = e.Capacity
+		quota.Quota = e.Capacity - sold
 		quota.Capacity = e.Capacity
 		quota.Price = e.Price
+		quota.Fares = nil
 
 		if err := uc.QuotaRepository.Update(ctx, tx, quota); err != nil {
 			return fmt.Errorf("failed to update quota: %w", err)
 		}
+		if err := uc.QuotaRepository.ReplaceFares(ctx, tx, quota.ID, e.Fares); err != nil {
+			return fmt.Errorf("failed to update fares: %w", err)
+		}
 		return nil
 	})
 }
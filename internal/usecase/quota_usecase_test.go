@@ -4,8 +4,10 @@ import (
 	"context"
 	"testing"
 
+	errs "eticket-api/internal/common/errors"
 	"eticket-api/internal/domain"
 	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
@@ -91,3 +93,57 @@ func TestQuotaUsecase_CreateQuotaBulk(t *testing.T) {
 		})
 	}
 }
+
+func TestQuotaUsecase_UpdateQuota(t *testing.T) {
+	t.Parallel()
+	uc, repo, transactor := quotaUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	// 30 of 100 sold, 10 of the remaining 70 held by claim sessions
+	current := func() *domain.Quota {
+		return &domain.Quota{ID: 1, ScheduleID: 7, ClassID: 2, Capacity: 100, Quota: 70, Held: 10}
+	}
+	tests := []struct {
+		name     string
+		capacity int
+		mock     func()
+		err      error
+	}{
+		{
+			name:     "sold and held units stay taken",
+			capacity: 80,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(current(), nil)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, quota *domain.Quota) error {
+						require.Equal(t, 80, quota.Capacity)
+						require.Equal(t, 50, quota.Quota)
+						require.Equal(t, 10, quota.Held)
+						return nil
+					},
+				)
+				repo.EXPECT().ReplaceFares(gomock.Any(), gomock.Any(), uint(1), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:     "below sold and held",
+			capacity: 39,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(current(), nil)
+			},
+			err: errs.ErrBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.UpdateQuota(context.Background(), &domain.Quota{ID: 1, ScheduleID: 7, ClassID: 2, Capacity: tc.capacity})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"eticket-api/internal/domain"
+	"math"
+	"sort"
+	"time"
+)
+
+// defaultCancellationPolicy applies when no policy covers a ticket: the full
+// price is refunded until departure and nothing after.
+var defaultCancellationPolicy = domain.CancellationPolicy{
+	Name:  "Default",
+	Rules: []domain.CancellationRule{{MinHoursBefore: 0, RefundPercent: 100}},
+}
+
+// ticketRefund computes the refund of one ticket cancelled at now. Rules are
+// tried from the longest notice down and the first one met applies, so a
+// cancellation after departure or one that meets no rule refunds nothing, as
+// does a ticket that was already checked in.
+func ticketRefund(policy *domain.CancellationPolicy, ticket *domain.Ticket, departure, now time.Time) *domain.Refund {
+	hours := departure.Sub(now).Hours()
+	refund := &domain.Refund{
+		TicketID:             ticket.ID,
+		PolicyName:           policy.Name,
+		HoursBeforeDeparture: math.Round(hours*100) / 100,
+		TicketPrice:          ticket.Price,
+		Ticket:               *ticket,
+	}
+	if policy.ID != 0 {
+		refund.PolicyID = &policy.ID
+	}
+	if ticket.BookingID != nil {
+		refund.BookingID = *ticket.BookingID
+	}
+	if ticket.IsCheckedIn || hours < 0 {
+		return refund
+	}
+
+	rules := make([]domain.CancellationRule, len(policy.Rules))
+	copy(rules, policy.Rules)
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].MinHoursBefore > rules[j].MinHoursBefore
+	})
+	for _, rule := range rules {
+		if hours >= float64(rule.MinHoursBefore) {
+			refund.RefundPercent = rule.RefundPercent
+			refund.Amount = math.Round(ticket.Price * rule.RefundPercent / 100)
+			break
+		}
+	}
+	return refund
+}
+
+// discountRefunds takes a booking's promo discount off its refunds. Each
+// refund keeps the share of its ticket in what was actually paid, so the
+// refunds of a booking never add up to more than its payment.
+func discountRefunds(refunds []*domain.Refund, tickets []*domain.Ticket, discount float64) {
+	var gross float64
+	for _, ticket := range tickets {
+		gross += ticket.Price
+	}
+	if discount <= 0 || gross <= 0 {
+		return
+	}
+	paid := math.Max(gross-discount, 0) / gross
+	for _, refund := range refunds {
+		refund.Amount = math.Round(refund.Amount * paid)
+	}
+}
+
+// refundCharges adds the fees and add-ons of the refunded tickets to their
+// refunds, at the percentage the policy gave each ticket. An add-on for the
+// whole booking goes with the first refund on its leg. Callers pass only the
+// add-ons whose stock they give back.
+func refundCharges(refunds []*domain.Refund, fees []domain.TicketFee, addons []domain.BookingAddon) {
+	if len(refunds) == 0 {
+		return
+	}
+	index := make(map[uint]*domain.Refund, len(refunds))
+	legs := make(map[uint]*domain.Refund)
+	for _, refund := range refunds {
+		index[refund.TicketID] = refund
+		if _, ok := legs[refund.Ticket.ScheduleID]; !ok {
+			legs[refund.Ticket.ScheduleID] = refund
+		}
+	}
+
+	for _, fee := range fees {
+		if refund, ok := index[fee.TicketID]; ok {
+			refund.Amount += math.Round(fee.Amount * refund.RefundPercent / 100)
+		}
+	}
+	for _, addon := range addons {
+		refund := refunds[0]
+		if r, ok := legs[addon.ScheduleID]; ok {
+			refund = r
+		}
+		if addon.TicketID != nil {
+			if r, ok := index[*addon.TicketID]; ok {
+				refund = r
+			}
+		}
+		refund.Amount += math.Round(addon.Price * float64(addon.Quantity) * refund.RefundPercent / 100)
+	}
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"eticket-api/internal/domain"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTicketRefund(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := &domain.CancellationPolicy{
+		ID:   3,
+		Name: "Standard",
+		Rules: []domain.CancellationRule{
+			{MinHoursBefore: 6, RefundPercent: 50},
+			{MinHoursBefore: 48, RefundPercent: 100},
+		},
+	}
+	tests := []struct {
+		name      string
+		policy    *domain.CancellationPolicy
+		ticket    domain.Ticket
+		departure time.Time
+		percent   float64
+		amount    float64
+	}{
+		{
+			name:      "full refund before 48h",
+			policy:    policy,
+			ticket:    domain.Ticket{ID: 1, Price: 150000},
+			departure: now.Add(72 * time.Hour),
+			percent:   100,
+			amount:    150000,
+		},
+		{
+			name:      "half refund before 6h",
+			policy:    policy,
+			ticket:    domain.Ticket{ID: 1, Price: 150000},
+			departure: now.Add(10 * time.Hour),
+			percent:   50,
+			amount:    75000,
+		},
+		{
+			name:      "no refund within 6h",
+			policy:    policy,
+			ticket:    domain.Ticket{ID: 1, Price: 150000},
+			departure: now.Add(2 * time.Hour),
+			percent:   0,
+			amount:    0,
+		},
+		{
+			name:      "no refund after departure",
+			policy:    &defaultCancellationPolicy,
+			ticket:    domain.Ticket{ID: 1, Price: 150000},
+			departure: now.Add(-time.Hour),
+			percent:   0,
+			amount:    0,
+		},
+		{
+			name:      "default policy before departure",
+			policy:    &defaultCancellationPolicy,
+			ticket:    domain.Ticket{ID: 1, Price: 150000},
+			departure: now.Add(time.Hour),
+			percent:   100,
+			amount:    150000,
+		},
+		{
+			name:      "checked in",
+			policy:    policy,
+			ticket:    domain.Ticket{ID: 1, Price: 150000, IsCheckedIn: true},
+			departure: now.Add(72 * time.Hour),
+			percent:   0,
+			amount:    0,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			refund := ticketRefund(tc.policy, &tc.ticket, tc.departure, now)
+			require.Equal(t, tc.percent, refund.RefundPercent)
+			require.Equal(t, tc.amount, refund.Amount)
+			require.Equal(t, tc.ticket.Price, refund.TicketPrice)
+		})
+	}
+}
+
+func TestDiscountRefunds(t *testing.T) {
+	t.Parallel()
+	tickets := []*domain.Ticket{{ID: 1, Price: 150000}, {ID: 2, Price: 50000}}
+	tests := []struct {
+		name     string
+		discount float64
+		amounts  []float64
+	}{
+		{name: "no discount", discount: 0, amounts: []float64{150000, 25000}},
+		{name: "quarter off", discount: 50000, amounts: []float64{112500, 18750}},
+		{name: "discount over price", discount: 300000, amounts: []float64{0, 0}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			refunds := []*domain.Refund{{TicketID: 1, Amount: 150000}, {TicketID: 2, Amount: 25000}}
+			discountRefunds(refunds, tickets, tc.discount)
+			for i, refund := range refunds {
+				require.Equal(t, tc.amounts[i], refund.Amount)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"eticket-api/config"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/templates"
+	"eticket-api/internal/common/token"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	reminderBatch = 50
+	reminderPath  = "/manage-booking?order_id=%s"
+)
+
+// departureReminders are sent this long before a departure, longest first.
+// Each one covers the time until the next, so a booking paid close to
+// departure only gets the last reminder it is still early enough for.
+var departureReminders = []struct {
+	Kind   enum.NotificationKind
+	Before time.Duration
+}{
+	{Kind: enum.NotificationReminder24h, Before: 24 * time.Hour},
+	{Kind: enum.NotificationReminder3h, Before: 3 * time.Hour},
+}
+
+// ReminderSend records what the reminder sweep did for the leg of a booking.
+// Skipped reminders were sent by another instance, or the leg has no tickets
+// left to board.
+type ReminderSend struct {
+	BookingID  uint
+	ScheduleID uint
+	Kind       string
+	Skipped    bool
+	Err        error
+}
+
+type ReminderUsecase struct {
+	Transactor                transact.Transactor
+	NotificationLogRepository domain.NotificationLogRepository
+	BookingRepository         domain.BookingRepository
+	TicketRepository          domain.TicketRepository
+	OutboxRepository          domain.OutboxRepository
+	Outbox                    *OutboxUsecase
+	TokenUtil                 token.TokenUtil
+	FrontendURL               string
+}
+
+func NewReminderUsecase(
+	transactor transact.Transactor,
+	notification_log_repository domain.NotificationLogRepository,
+	booking_repository domain.BookingRepository,
+	ticket_repository domain.TicketRepository,
+	outbox_repository domain.OutboxRepository,
+	outbox *OutboxUsecase,
+	token_util token.TokenUtil,
+	cfg *config.Config,
+) *ReminderUsecase {
+	return &ReminderUsecase{
+		Transactor:                transactor,
+		NotificationLogRepository: notification_log_repository,
+		BookingRepository:         booking_repository,
+		TicketRepository:          ticket_repository,
+		OutboxRepository:          outbox_repository,
+		Outbox:                    outbox,
+		TokenUtil:                 token_util,
+		FrontendURL:               strings.TrimRight(cfg.Frontend.URL, "/"),
+	}
+}
+
+// SendDepartureReminders emails a reminder to every leg of a paid booking
+// that is due one. Each reminder is sent on its own, so one failure does not
+// hold back the others.
+func (uc *ReminderUsecase) SendDepartureReminders(ctx context.Context) ([]*ReminderSend, error) {
+	now := time.Now()
+	var sends []*ReminderSend
+	for i, reminder := range departureReminders {
+		departsAfter := now
+		if i+1 < len(departureReminders) {
+			departsAfter = now.Add(departureReminders[i+1].Before)
+		}
+
+		var dues []*domain.DueNotification
+		if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+			var err error
+			dues, err = uc.NotificationLogRepository.FindDueReminders(ctx, tx, reminder.Kind.String(), departsAfter, now.Add(reminder.Before), reminderBatch)
+			return err
+		}); err != nil {
+			return sends, fmt.Errorf("failed to find due reminders: %w", err)
+		}
+
+		for _, due := range dues {
+			sends = append(sends, uc.sendReminder(ctx, due, reminder.Kind.String(), reminder.Before, now))
+		}
+	}
+	return sends, nil
+}
+
+// sendReminder queues the reminder email in the transaction that logs it.
+// When another instance logged it first, this one is skipped.
+func (uc *ReminderUsecase) sendReminder(ctx context.Context, due *domain.DueNotification, kind string, before time.Duration, now time.Time) *ReminderSend {
+	send := &ReminderSend{BookingID: due.BookingID, ScheduleID: due.ScheduleID, Kind: kind}
+	var email *domain.Outbox
+	send.Err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		booking, err := uc.BookingRepository.FindByID(ctx, tx, due.BookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get booking: %w", err)
+		}
+		if booking == nil {
+			return errs.ErrNotFound
+		}
+		logged, err := uc.NotificationLogRepository.Insert(ctx, tx, &domain.NotificationLog{
+			BookingID:  due.BookingID,
+			ScheduleID: due.ScheduleID,
+			Kind:       kind,
+			Recipient:  booking.Email,
+			SentAt:     now,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to log reminder: %w", err)
+		}
+		if logged == 0 {
+			send.Skipped = true
+			return nil
+		}
+
+		all, err := uc.TicketRepository.FindByBookingID(ctx, tx, due.BookingID)
+		if err != nil {
+			return fmt.Errorf("failed to get tickets: %w", err)
+		}
+		var tickets []*domain.Ticket
+		for _, ticket := range all {
+			if ticket.ScheduleID == due.ScheduleID && storedBoardingStatus(ticket) != enum.BoardingCancelled.String() {
+				tickets = append(tickets, ticket)
+			}
+		}
+		// Nothing left to remind of, the log keeps the leg from coming up again
+		if len(tickets) == 0 {
+			send.Skipped = true
+			return nil
+		}
+		schedule := &tickets[0].Schedule
+
+		eticket, err := bookingDocument(uc.TokenUtil, booking, tickets, DocumentETicket)
+		if err != nil {
+			return err
+		}
+		subject := fmt.Sprintf("Pengingat Keberangkatan - %s", booking.OrderID)
+		htmlBody := templates.DepartureReminderEmail(booking, schedule, tickets, before,
+			schedule.DepartureDatetime.Add(-checkInOpensBefore), uc.FrontendURL+fmt.Sprintf(reminderPath, booking.OrderID))
+		email, err = enqueueEmail(ctx, tx, uc.OutboxRepository, booking.OrderID, booking.Email, subject, htmlBody, *eticket)
+		return err
+	})
+	if send.Err == nil && email != nil {
+		_ = uc.Outbox.Dispatch(ctx, email)
+	}
+	return send
+}
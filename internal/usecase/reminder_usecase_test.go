@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"eticket-api/config"
+	"testing"
+	"time"
+
+	enum "eticket-api/internal/common/enums"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func reminderUsecase(t *testing.T) (*ReminderUsecase, *mocks.MockNotificationLogRepository, *mocks.MockBookingRepository, *mocks.MockTicketRepository, *mocks.MockOutboxRepository, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	notificationLogRepo := mocks.NewMockNotificationLogRepository(ctrl)
+	bookingRepo := mocks.NewMockBookingRepository(ctrl)
+	ticketRepo := mocks.NewMockTicketRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	tokenUtil := mocks.NewMockTokenUtil(ctrl)
+	tokenUtil.EXPECT().GenerateTicketToken(gomock.Any(), gomock.Any()).Return("pass", nil).AnyTimes()
+	transactor := mocks.NewMockTransactor(ctrl)
+	outbox := NewOutboxUsecase(transactor, outboxRepo, bookingRepo, mocks.NewMockBookingChangeRepository(ctrl), mocks.NewMockTripayClient(ctrl), mocks.NewMockMailer(ctrl), nil)
+	uc := NewReminderUsecase(transactor, notificationLogRepo, bookingRepo, ticketRepo, outboxRepo, outbox, tokenUtil, &config.Config{Frontend: config.Frontend{URL: "https://example.com"}})
+	return uc, notificationLogRepo, bookingRepo, ticketRepo, outboxRepo, transactor
+}
+
+func TestReminderUsecase_SendDepartureReminders(t *testing.T) {
+	t.Parallel()
+	uc, notificationLogRepo, bookingRepo, ticketRepo, outboxRepo, transactor := reminderUsecase(t)
+	transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+			return fn(nil)
+		},
+	).AnyTimes()
+
+	schedule := domain.Schedule{ID: 1, DepartureDatetime: time.Now().Add(20 * time.Hour)}
+	booking := &domain.Booking{ID: 5, OrderID: "ORD-5", Email: "a@example.com", Status: enum.BookingPaid.String()}
+
+	// The 24 hour reminder covers departures between 3 and 24 hours away
+	notificationLogRepo.EXPECT().FindDueReminders(gomock.Any(), gomock.Any(), enum.NotificationReminder24h.String(), gomock.Any(), gomock.Any(), reminderBatch).DoAndReturn(
+		func(ctx context.Context, conn gotann.Connection, kind string, departsAfter, departsBefore time.Time, limit int) ([]*domain.DueNotification, error) {
+			require.Equal(t, 21*time.Hour, departsBefore.Sub(departsAfter))
+			return []*domain.DueNotification{{BookingID: 5, ScheduleID: 1}, {BookingID: 6, ScheduleID: 1}}, nil
+		},
+	)
+	notificationLogRepo.EXPECT().FindDueReminders(gomock.Any(), gomock.Any(), enum.NotificationReminder3h.String(), gomock.Any(), gomock.Any(), reminderBatch).Return(nil, nil)
+
+	bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(5)).Return(booking, nil)
+	notificationLogRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, conn gotann.Connection, log *domain.NotificationLog) (int64, error) {
+			require.Equal(t, uint(5), log.BookingID)
+			require.Equal(t, enum.NotificationReminder24h.String(), log.Kind)
+			return 1, nil
+		},
+	)
+	ticketRepo.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), uint(5)).Return([]*domain.Ticket{
+		{ID: 1, ScheduleID: 1, TicketCode: "T-1", Schedule: schedule},
+		{ID: 2, ScheduleID: 2, TicketCode: "T-2"},
+	}, nil)
+	outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, conn gotann.Connection, event *domain.Outbox) error {
+			require.Equal(t, enum.OutboxSendEmail.String(), event.EventType)
+			event.ID = 11
+			return nil
+		},
+	)
+	outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), uint(11), gomock.Any()).Return(int64(0), nil)
+
+	// Another instance already sent the reminder of booking 6
+	bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(6)).Return(&domain.Booking{ID: 6, Email: "b@example.com"}, nil)
+	notificationLogRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+
+	sends, err := uc.SendDepartureReminders(context.Background())
+	require.NoError(t, err)
+	require.Len(t, sends, 2)
+	require.NoError(t, sends[0].Err)
+	require.False(t, sends[0].Skipped)
+	require.NoError(t, sends[1].Err)
+	require.True(t, sends[1].Skipped)
+}
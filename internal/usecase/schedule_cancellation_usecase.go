@@ -0,0 +1,764 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/templates"
+	"eticket-api/internal/common/token"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/model"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	cancellationBatch       = 50
+	cancellationMaxAttempts = 5
+	// Departures on the route tried when rebooking onto the next one with room
+	rebookCandidates = 10
+
+	operatorCancellationPolicy = "Operator cancellation"
+)
+
+// CancellationSettle records what the cancellation job did with one booking.
+// Skipped bookings were settled by another instance first.
+type CancellationSettle struct {
+	CancellationID uint
+	BookingID      uint
+	Status         string
+	Skipped        bool
+	Err            error
+}
+
+type ScheduleCancellationUsecase struct {
+	Transactor                     transact.Transactor
+	ScheduleCancellationRepository domain.ScheduleCancellationRepository
+	ScheduleRepository             domain.ScheduleRepository
+	BookingRepository              domain.BookingRepository
+	TicketRepository               domain.TicketRepository
+	QuotaRepository                domain.QuotaRepository
+	ScheduleSeatRepository         domain.ScheduleSeatRepository
+	SeatLayoutRepository           domain.SeatLayoutRepository
+	BookingChangeRepository        domain.BookingChangeRepository
+	RefundRepository               domain.RefundRepository
+	AddonStockRepository           domain.AddonStockRepository
+	BookingAddonRepository         domain.BookingAddonRepository
+	BoardingEventRepository        domain.BoardingEventRepository
+	PromotionRepository            domain.PromotionRepository
+	PromotionRedemptionRepository  domain.PromotionRedemptionRepository
+	OutboxRepository               domain.OutboxRepository
+	Outbox                         *OutboxUsecase
+	TokenUtil                      token.TokenUtil
+}
+
+func NewScheduleCancellationUsecase(
+	transactor transact.Transactor,
+	schedule_cancellation_repository domain.ScheduleCancellationRepository,
+	schedule_repository domain.ScheduleRepository,
+	booking_repository domain.BookingRepository,
+	ticket_repository domain.TicketRepository,
+	quota_repository domain.QuotaRepository,
+	schedule_seat_repository domain.ScheduleSeatRepository,
+	seat_layout_repository domain.SeatLayoutRepository,
+	booking_change_repository domain.BookingChangeRepository,
+	refund_repository domain.RefundRepository,
+	addon_stock_repository domain.AddonStockRepository,
+	booking_addon_repository domain.BookingAddonRepository,
+	boarding_event_repository domain.BoardingEventRepository,
+	promotion_repository domain.PromotionRepository,
+	promotion_redemption_repository domain.PromotionRedemptionRepository,
+	outbox_repository domain.OutboxRepository,
+	outbox *OutboxUsecase,
+	token_util token.TokenUtil,
+) *ScheduleCancellationUsecase {
+	return &ScheduleCancellationUsecase{
+		Transactor:                     transactor,
+		ScheduleCancellationRepository: schedule_cancellation_repository,
+		ScheduleRepository:             schedule_repository,
+		BookingRepository:              booking_repository,
+		TicketRepository:               ticket_repository,
+		QuotaRepository:                quota_repository,
+		ScheduleSeatRepository:         schedule_seat_repository,
+		SeatLayoutRepository:           seat_layout_repository,
+		BookingChangeRepository:        booking_change_repository,
+		RefundRepository:               refund_repository,
+		AddonStockRepository:           addon_stock_repository,
+		BookingAddonRepository:         booking_addon_repository,
+		BoardingEventRepository:        boarding_event_repository,
+		PromotionRepository:            promotion_repository,
+		PromotionRedemptionRepository:  promotion_redemption_repository,
+		OutboxRepository:               outbox_repository,
+		Outbox:                         outbox,
+		TokenUtil:                      token_util,
+	}
+}
+
+// CancelSchedule cancels a departure that has not left yet and queues every
+// paid and unpaid booking on it for the cancellation job. Paid bookings are
+// refunded in full, or with resolution REBOOK moved onto toScheduleID, or the
+// next departure on the route with room when it is nil.
+func (uc *ScheduleCancellationUsecase) CancelSchedule(ctx context.Context, scheduleID uint, reason, resolution string, toScheduleID *uint, userID uint) (*model.ScheduleCancellation, error) {
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		schedule, err := uc.ScheduleRepository.FindByID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if schedule == nil {
+			return errs.ErrNotFound
+		}
+		existing, err := uc.ScheduleCancellationRepository.FindByScheduleID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get cancellation: %w", err)
+		}
+		if existing != nil {
+			return fmt.Errorf("schedule %d was already cancelled: %w", scheduleID, errs.ErrConflict)
+		}
+		if schedule.Status == enum.ScheduleFinished.String() || !schedule.DepartureDatetime.After(time.Now()) {
+			return fmt.Errorf("schedule %d already departed: %w", scheduleID, errs.ErrConflict)
+		}
+		if toScheduleID != nil {
+			if resolution != enum.CancellationRebook.String() {
+				return fmt.Errorf("only a rebooking moves bookings to another departure: %w", errs.ErrBadRequest)
+			}
+			to, err := uc.ScheduleRepository.FindByID(ctx, tx, *toScheduleID)
+			if err != nil {
+				return fmt.Errorf("failed to get schedule: %w", err)
+			}
+			if to == nil {
+				return fmt.Errorf("schedule %d: %w", *toScheduleID, errs.ErrNotFound)
+			}
+			if err := rebookable(schedule, to, time.Now()); err != nil {
+				return err
+			}
+		}
+
+		schedule.Status = enum.ScheduleCancelled.String()
+		if err := uc.ScheduleRepository.Update(ctx, tx, schedule); err != nil {
+			return fmt.Errorf("failed to update schedule: %w", err)
+		}
+
+		bookingIDs, err := uc.ScheduleCancellationRepository.FindBookingIDs(ctx, tx, scheduleID, []string{
+			enum.BookingPaid.String(),
+			enum.BookingUnpaid.String(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to find bookings: %w", err)
+		}
+		cancellation := &domain.ScheduleCancellation{
+			ScheduleID:   scheduleID,
+			Reason:       reason,
+			Resolution:   resolution,
+			ToScheduleID: toScheduleID,
+			Status:       enum.ScheduleCancellationRunning.String(),
+			Total:        len(bookingIDs),
+		}
+		if userID != 0 {
+			cancellation.UserID = &userID
+		}
+		if err := uc.ScheduleCancellationRepository.Insert(ctx, tx, cancellation); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return fmt.Errorf("schedule %d was already cancelled: %w", scheduleID, errs.ErrConflict)
+			}
+			return fmt.Errorf("failed to create cancellation: %w", err)
+		}
+
+		items := make([]*domain.ScheduleCancellationItem, len(bookingIDs))
+		for i, bookingID := range bookingIDs {
+			items[i] = &domain.ScheduleCancellationItem{
+				CancellationID: cancellation.ID,
+				BookingID:      bookingID,
+				Status:         enum.CancellationItemPending.String(),
+			}
+		}
+		if err := uc.ScheduleCancellationRepository.InsertItems(ctx, tx, items); err != nil {
+			return fmt.Errorf("failed to queue bookings: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to cancel schedule: %w", err)
+	}
+	return uc.GetCancellation(ctx, scheduleID)
+}
+
+// GetCancellation reports how far the cancellation of a departure got, with
+// the bookings it gave up on.
+func (uc *ScheduleCancellationUsecase) GetCancellation(ctx context.Context, scheduleID uint) (*model.ScheduleCancellation, error) {
+	var report *model.ScheduleCancellation
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		cancellation, err := uc.ScheduleCancellationRepository.FindByScheduleID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get cancellation: %w", err)
+		}
+		if cancellation == nil {
+			return errs.ErrNotFound
+		}
+		counts, err := uc.ScheduleCancellationRepository.CountItemsByStatus(ctx, tx, cancellation.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count bookings: %w", err)
+		}
+		refunded, err := uc.ScheduleCancellationRepository.SumRefunded(ctx, tx, cancellation.ID)
+		if err != nil {
+			return fmt.Errorf("failed to sum refunds: %w", err)
+		}
+		failed, err := uc.ScheduleCancellationRepository.FindItemsByStatus(ctx, tx, cancellation.ID, enum.CancellationItemFailed.String())
+		if err != nil {
+			return fmt.Errorf("failed to get failed bookings: %w", err)
+		}
+
+		report = &model.ScheduleCancellation{
+			ID:             cancellation.ID,
+			ScheduleID:     cancellation.ScheduleID,
+			Reason:         cancellation.Reason,
+			Resolution:     cancellation.Resolution,
+			ToScheduleID:   cancellation.ToScheduleID,
+			Status:         cancellation.Status,
+			Total:          cancellation.Total,
+			Pending:        counts[enum.CancellationItemPending.String()],
+			Refunded:       counts[enum.CancellationItemRefunded.String()],
+			Rebooked:       counts[enum.CancellationItemRebooked.String()],
+			Cancelled:      counts[enum.CancellationItemCancelled.String()],
+			Failed:         counts[enum.CancellationItemFailed.String()],
+			RefundedAmount: refunded,
+			Failures:       make([]model.ScheduleCancellationFailure, 0, len(failed)),
+			StartedAt:      cancellation.CreatedAt,
+			CompletedAt:    cancellation.CompletedAt,
+		}
+		for _, item := range failed {
+			failure := model.ScheduleCancellationFailure{
+				BookingID: item.BookingID,
+				OrderID:   item.Booking.OrderID,
+				Attempts:  item.Attempts,
+			}
+			if item.Error != nil {
+				failure.Error = *item.Error
+			}
+			report.Failures = append(report.Failures, failure)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get cancellation: %w", err)
+	}
+	return report, nil
+}
+
+// RetryFailed puts the bookings a cancellation gave up on back in the queue,
+// e.g. once staff fixed what stopped them.
+func (uc *ScheduleCancellationUsecase) RetryFailed(ctx context.Context, scheduleID uint) (*model.ScheduleCancellation, error) {
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		cancellation, err := uc.ScheduleCancellationRepository.FindByScheduleID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get cancellation: %w", err)
+		}
+		if cancellation == nil {
+			return errs.ErrNotFound
+		}
+		retried, err := uc.ScheduleCancellationRepository.RetryFailedItems(ctx, tx, cancellation.ID)
+		if err != nil {
+			return fmt.Errorf("failed to requeue bookings: %w", err)
+		}
+		if retried > 0 && cancellation.Status != enum.ScheduleCancellationRunning.String() {
+			cancellation.Status = enum.ScheduleCancellationRunning.String()
+			cancellation.CompletedAt = nil
+			if err := uc.ScheduleCancellationRepository.Update(ctx, tx, cancellation); err != nil {
+				return fmt.Errorf("failed to update cancellation: %w", err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to retry cancellation: %w", err)
+	}
+	return uc.GetCancellation(ctx, scheduleID)
+}
+
+// ProcessCancellations settles the next batch of queued bookings of every
+// running cancellation, each in its own transaction so one failure does not
+// hold back the others. A cancellation with nothing left queued is completed.
+func (uc *ScheduleCancellationUsecase) ProcessCancellations(ctx context.Context) ([]*CancellationSettle, error) {
+	var running []*domain.ScheduleCancellation
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		running, err = uc.ScheduleCancellationRepository.FindRunning(ctx, tx)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to find running cancellations: %w", err)
+	}
+
+	var settles []*CancellationSettle
+	for _, cancellation := range running {
+		var items []*domain.ScheduleCancellationItem
+		if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+			var err error
+			items, err = uc.ScheduleCancellationRepository.FindPendingItems(ctx, tx, cancellation.ID, cancellationBatch)
+			return err
+		}); err != nil {
+			return settles, fmt.Errorf("failed to find queued bookings: %w", err)
+		}
+
+		if len(items) == 0 {
+			if err := uc.complete(ctx, cancellation); err != nil {
+				return settles, err
+			}
+			continue
+		}
+		for _, item := range items {
+			settles = append(settles, uc.settle(ctx, cancellation, item))
+		}
+	}
+	return settles, nil
+}
+
+func (uc *ScheduleCancellationUsecase) complete(ctx context.Context, cancellation *domain.ScheduleCancellation) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		counts, err := uc.ScheduleCancellationRepository.CountItemsByStatus(ctx, tx, cancellation.ID)
+		if err != nil {
+			return fmt.Errorf("failed to count bookings: %w", err)
+		}
+		if counts[enum.CancellationItemPending.String()] > 0 {
+			return nil
+		}
+		now := time.Now()
+		cancellation.Status = enum.ScheduleCancellationCompleted.String()
+		cancellation.CompletedAt = &now
+		if err := uc.ScheduleCancellationRepository.Update(ctx, tx, cancellation); err != nil {
+			return fmt.Errorf("failed to complete cancellation %d: %w", cancellation.ID, err)
+		}
+		return nil
+	})
+}
+
+// settle settles one booking and marks its item in the same transaction. A
+// failure is recorded on the item, which is retried on the next run until it
+// runs out of attempts.
+func (uc *ScheduleCancellationUsecase) settle(ctx context.Context, cancellation *domain.ScheduleCancellation, item *domain.ScheduleCancellationItem) *CancellationSettle {
+	settle := &CancellationSettle{CancellationID: cancellation.ID, BookingID: item.BookingID}
+	now := time.Now()
+
+	attempt := *item
+	attempt.Attempts++
+	var email *domain.Outbox
+	err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		email, err = uc.settleBooking(ctx, tx, cancellation, &attempt, now)
+		if err != nil {
+			return err
+		}
+		attempt.Error = nil
+		attempt.ProcessedAt = &now
+		updated, err := uc.ScheduleCancellationRepository.UpdatePendingItem(ctx, tx, &attempt)
+		if err != nil {
+			return fmt.Errorf("failed to update cancellation item: %w", err)
+		}
+		if updated == 0 {
+			settle.Skipped = true
+			return fmt.Errorf("booking %d was settled by another run: %w", item.BookingID, errs.ErrConflict)
+		}
+		return nil
+	})
+	switch {
+	case settle.Skipped:
+		return settle
+	case err == nil:
+		settle.Status = attempt.Status
+		_ = uc.Outbox.Dispatch(ctx, email)
+		return settle
+	}
+
+	settle.Err = err
+	failed := *item
+	failed.Attempts++
+	message := err.Error()
+	failed.Error = &message
+	if failed.Attempts >= cancellationMaxAttempts {
+		failed.Status = enum.CancellationItemFailed.String()
+		failed.ProcessedAt = &now
+	}
+	settle.Status = failed.Status
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		_, err := uc.ScheduleCancellationRepository.UpdatePendingItem(ctx, tx, &failed)
+		return err
+	}); err != nil {
+		settle.Err = fmt.Errorf("failed to record failure %q: %w", message, err)
+	}
+	return settle
+}
+
+// settleBooking gives back what a booking holds on the cancelled departure and
+// emails the customer. An unpaid booking is cancelled whole. Bookings that no
+// longer hold anything on it, such as ones that expired since, count as
+// cancelled without an email.
+func (uc *ScheduleCancellationUsecase) settleBooking(ctx context.Context, conn gotann.Connection, cancellation *domain.ScheduleCancellation, item *domain.ScheduleCancellationItem, now time.Time) (*domain.Outbox, error) {
+	booking, err := uc.BookingRepository.FindByID(ctx, conn, item.BookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+	if booking == nil {
+		return nil, fmt.Errorf("booking %d: %w", item.BookingID, errs.ErrNotFound)
+	}
+
+	var tickets []*domain.Ticket
+	checkedIn := false
+	for i := range booking.Tickets {
+		ticket := &booking.Tickets[i]
+		if ticket.ScheduleID == cancellation.ScheduleID && storedBoardingStatus(ticket) != enum.BoardingCancelled.String() {
+			tickets = append(tickets, ticket)
+			checkedIn = checkedIn || ticket.IsCheckedIn
+		}
+	}
+	var addons []*domain.BookingAddon
+	for i := range booking.Addons {
+		if booking.Addons[i].ScheduleID == cancellation.ScheduleID {
+			addons = append(addons, &booking.Addons[i])
+		}
+	}
+
+	switch {
+	case len(tickets) == 0 || (booking.Status != enum.BookingPaid.String() && booking.Status != enum.BookingUnpaid.String()):
+		item.Status = enum.CancellationItemCancelled.String()
+		return nil, nil
+
+	case booking.Status == enum.BookingUnpaid.String():
+		item.Status = enum.CancellationItemCancelled.String()
+		return uc.cancelUnpaid(ctx, conn, cancellation, booking)
+
+	case cancellation.Resolution == enum.CancellationRebook.String() && !checkedIn:
+		to, seats, err := uc.rebookTarget(ctx, conn, cancellation, tickets, addons, now)
+		if err != nil {
+			return nil, err
+		}
+		if to != nil {
+			item.Status = enum.CancellationItemRebooked.String()
+			item.ToScheduleID = &to.ID
+			return uc.rebook(ctx, conn, cancellation, booking, tickets, addons, to, seats)
+		}
+	}
+
+	// Nowhere to rebook onto, or a refund was asked for
+	refunds, err := uc.refund(ctx, conn, cancellation, booking, tickets, addons, now)
+	if err != nil {
+		return nil, err
+	}
+	item.Status = enum.CancellationItemRefunded.String()
+	item.RefundAmount = 0
+	for _, refund := range refunds {
+		item.RefundAmount += refund.Amount
+	}
+	return enqueueEmail(ctx, conn, uc.OutboxRepository, booking.OrderID, booking.Email, cancellationSubject(booking),
+		templates.ScheduleCancellationEmail(booking, &cancellation.Schedule, cancellation.Reason, refunds, nil))
+}
+
+// cancelUnpaid cancels an unpaid booking on every leg and gives back the
+// quota, seats and add-ons it holds.
+func (uc *ScheduleCancellationUsecase) cancelUnpaid(ctx context.Context, conn gotann.Connection, cancellation *domain.ScheduleCancellation, booking *domain.Booking) (*domain.Outbox, error) {
+	tickets := make([]*domain.Ticket, len(booking.Tickets))
+	for i := range booking.Tickets {
+		tickets[i] = &booking.Tickets[i]
+	}
+	if err := restoreTickets(ctx, conn, uc.QuotaRepository, tickets); err != nil {
+		return nil, fmt.Errorf("failed to restore quota: %w", err)
+	}
+	if err := releaseTicketSeats(ctx, conn, uc.ScheduleSeatRepository, tickets); err != nil {
+		return nil, err
+	}
+	if err := restoreBookingAddons(ctx, conn, uc.AddonStockRepository, booking.Addons); err != nil {
+		return nil, err
+	}
+	if err := releaseBookingPromotion(ctx, conn, uc.PromotionRepository, uc.PromotionRedemptionRepository, booking.ID); err != nil {
+		return nil, err
+	}
+
+	booking.Status = enum.BookingCancelledByOperator.String()
+	if err := uc.saveBooking(ctx, conn, booking); err != nil {
+		return nil, err
+	}
+	return enqueueEmail(ctx, conn, uc.OutboxRepository, booking.OrderID, booking.Email, cancellationSubject(booking),
+		templates.ScheduleCancellationEmail(booking, &cancellation.Schedule, cancellation.Reason, nil, nil))
+}
+
+// refund refunds the tickets of a paid booking on the cancelled departure in
+// full: the fare after the booking's discount, the fees and the add-ons of the
+// leg. The tickets are cancelled for boarding. When nothing else is left on
+// the booking it is cancelled by the operator and its credit is paid out too.
+func (uc *ScheduleCancellationUsecase) refund(ctx context.Context, conn gotann.Connection, cancellation *domain.ScheduleCancellation, booking *domain.Booking, tickets []*domain.Ticket, addons []*domain.BookingAddon, now time.Time) ([]*domain.Refund, error) {
+	all := make([]*domain.Ticket, len(booking.Tickets))
+	whole := true
+	for i := range booking.Tickets {
+		all[i] = &booking.Tickets[i]
+		if all[i].ScheduleID != cancellation.ScheduleID && storedBoardingStatus(all[i]) != enum.BoardingCancelled.String() {
+			whole = false
+		}
+	}
+
+	hours := math.Round(cancellation.Schedule.DepartureDatetime.Sub(now).Hours()*100) / 100
+	refunds := make([]*domain.Refund, len(tickets))
+	for i, ticket := range tickets {
+		refunds[i] = &domain.Refund{
+			BookingID:            booking.ID,
+			TicketID:             ticket.ID,
+			PolicyName:           operatorCancellationPolicy,
+			HoursBeforeDeparture: hours,
+			TicketPrice:          ticket.Price,
+			RefundPercent:        100,
+			Amount:               ticket.Price,
+			Ticket:               *ticket,
+		}
+	}
+	discountRefunds(refunds, all, booking.Discount)
+	refundCharges(refunds, booking.Fees, bookingAddonValues(addons))
+	if whole && booking.Credit > 0 {
+		refunds[0].Amount += booking.Credit
+		booking.Credit = 0
+	}
+	if err := uc.RefundRepository.InsertBulk(ctx, conn, refunds); err != nil {
+		return nil, fmt.Errorf("failed to record refunds: %w", err)
+	}
+
+	actor := &BoardingActor{}
+	if cancellation.UserID != nil {
+		actor.UserID = *cancellation.UserID
+	}
+	for _, ticket := range tickets {
+		if _, err := moveTicket(ctx, conn, uc.TicketRepository, uc.BoardingEventRepository, ticket, enum.BoardingCancelled.String(), actor, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := restoreTickets(ctx, conn, uc.QuotaRepository, tickets); err != nil {
+		return nil, fmt.Errorf("failed to restore quota: %w", err)
+	}
+	if err := releaseTicketSeats(ctx, conn, uc.ScheduleSeatRepository, tickets); err != nil {
+		return nil, err
+	}
+	if err := restoreBookingAddons(ctx, conn, uc.AddonStockRepository, bookingAddonValues(addons)); err != nil {
+		return nil, err
+	}
+
+	if whole {
+		booking.Status = enum.BookingCancelledByOperator.String()
+		if err := releaseBookingPromotion(ctx, conn, uc.PromotionRepository, uc.PromotionRedemptionRepository, booking.ID); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.saveBooking(ctx, conn, booking); err != nil {
+		return nil, err
+	}
+	return refunds, nil
+}
+
+// rebookTarget finds the departure to move the tickets of a booking onto: the
+// one staff picked, or the next on the route with quota, seats and add-on
+// stock for all of them. It returns nil when there is none.
+func (uc *ScheduleCancellationUsecase) rebookTarget(ctx context.Context, conn gotann.Connection, cancellation *domain.ScheduleCancellation, tickets []*domain.Ticket, addons []*domain.BookingAddon, now time.Time) (*domain.Schedule, []*domain.ScheduleSeat, error) {
+	var candidates []*domain.Schedule
+	if cancellation.ToScheduleID != nil {
+		to, err := uc.ScheduleRepository.FindByID(ctx, conn, *cancellation.ToScheduleID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if to != nil {
+			candidates = append(candidates, to)
+		}
+	} else {
+		var err error
+		candidates, err = uc.ScheduleRepository.FindUpcomingOnRoute(ctx, conn, cancellation.Schedule.DepartureHarborID, cancellation.Schedule.ArrivalHarborID, now, rebookCandidates)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to find departures on the route: %w", err)
+		}
+	}
+
+	for _, to := range candidates {
+		if rebookable(&cancellation.Schedule, to, now) != nil {
+			continue
+		}
+		room, err := uc.hasRoom(ctx, conn, to.ID, tickets, addons)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !room {
+			continue
+		}
+		seated, err := ensureScheduleSeats(ctx, conn, uc.SeatLayoutRepository, uc.ScheduleSeatRepository, to)
+		if err != nil {
+			return nil, nil, err
+		}
+		seats, err := takeSeats(ctx, conn, uc.ScheduleSeatRepository, to.ID, seated, tickets)
+		if errors.Is(err, errs.ErrSeatUnavailable) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		return to, seats, nil
+	}
+	return nil, nil, nil
+}
+
+// hasRoom reports whether a departure has the quota and add-on stock left for
+// the tickets and add-ons of a booking.
+func (uc *ScheduleCancellationUsecase) hasRoom(ctx context.Context, conn gotann.Connection, scheduleID uint, tickets []*domain.Ticket, addons []*domain.BookingAddon) (bool, error) {
+	quotas, err := uc.QuotaRepository.FindByScheduleID(ctx, conn, scheduleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get quotas: %w", err)
+	}
+	quotaByClass := make(map[uint]*domain.Quota, len(quotas))
+	for _, quota := range quotas {
+		quotaByClass[quota.ClassID] = quota
+	}
+	for _, q := range groupTickets(tickets) {
+		quota, ok := quotaByClass[q.ClassID]
+		if !ok || quota.Held+q.Quantity > quota.Quota {
+			return false, nil
+		}
+	}
+	if len(addons) == 0 {
+		return true, nil
+	}
+
+	stocks, err := uc.AddonStockRepository.FindByScheduleID(ctx, conn, scheduleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get add-on stock: %w", err)
+	}
+	stockByAddon := make(map[uint]*domain.AddonStock, len(stocks))
+	for _, stock := range stocks {
+		stockByAddon[stock.AddonID] = stock
+	}
+	for _, q := range groupBookingAddons(bookingAddonValues(addons)) {
+		stock, ok := stockByAddon[q.AddonID]
+		if !ok || stock.Held+q.Quantity > stock.Stock {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// rebook moves the tickets and add-ons of a paid booking on the cancelled
+// departure onto another at the fares already paid, records it as a booking
+// change and emails the customer the new e-tickets.
+func (uc *ScheduleCancellationUsecase) rebook(ctx context.Context, conn gotann.Connection, cancellation *domain.ScheduleCancellation, booking *domain.Booking, tickets []*domain.Ticket, addons []*domain.BookingAddon, to *domain.Schedule, seats []*domain.ScheduleSeat) (*domain.Outbox, error) {
+	moved := bookingAddonValues(addons)
+	if err := releaseTicketSeats(ctx, conn, uc.ScheduleSeatRepository, tickets); err != nil {
+		return nil, err
+	}
+	if err := restoreTickets(ctx, conn, uc.QuotaRepository, tickets); err != nil {
+		return nil, fmt.Errorf("failed to restore quota: %w", err)
+	}
+	if err := restoreBookingAddons(ctx, conn, uc.AddonStockRepository, moved); err != nil {
+		return nil, err
+	}
+
+	details := []string{fmt.Sprintf("Departure cancelled by the operator: %s", cancellation.Reason)}
+	for i, ticket := range tickets {
+		oldSeat := "-"
+		if ticket.SeatNumber != nil {
+			oldSeat = *ticket.SeatNumber
+		}
+		ticket.SeatNumber = nil
+		newSeat := "-"
+		if seats[i] != nil {
+			ticket.SeatNumber = &seats[i].SeatNumber
+			newSeat = seats[i].SeatNumber
+		}
+		ticket.ScheduleID = to.ID
+		details = append(details, fmt.Sprintf("%s: seat %s -> %s", ticket.TicketCode, oldSeat, newSeat))
+	}
+	if err := sellTickets(ctx, conn, uc.QuotaRepository, tickets); err != nil {
+		return nil, err
+	}
+	if err := uc.TicketRepository.UpdateBulk(ctx, conn, tickets); err != nil {
+		return nil, fmt.Errorf("failed to move tickets: %w", err)
+	}
+	if err := sellSeats(ctx, conn, uc.ScheduleSeatRepository, tickets, seats); err != nil {
+		return nil, err
+	}
+	if len(addons) > 0 {
+		for i, addon := range addons {
+			addon.ScheduleID = to.ID
+			moved[i].ScheduleID = to.ID
+		}
+		if err := sellBookingAddons(ctx, conn, uc.AddonStockRepository, moved); err != nil {
+			return nil, err
+		}
+		if err := uc.BookingAddonRepository.UpdateBulk(ctx, conn, addons); err != nil {
+			return nil, fmt.Errorf("failed to move add-ons: %w", err)
+		}
+		for _, addon := range groupAddons(moved) {
+			details = append(details, fmt.Sprintf("%s x%d: moved", addon.Name, addon.Quantity))
+		}
+	}
+
+	change := &domain.BookingChange{
+		BookingID:      booking.ID,
+		Type:           enum.BookingChangeOperatorRebook.String(),
+		FromScheduleID: cancellation.ScheduleID,
+		ToScheduleID:   to.ID,
+		Settlement:     enum.FareSettlementNone.String(),
+		Status:         enum.BookingChangeCompleted.String(),
+		Detail:         strings.Join(details, "\n"),
+	}
+	if err := uc.BookingChangeRepository.Insert(ctx, conn, change); err != nil {
+		return nil, fmt.Errorf("failed to record booking change: %w", err)
+	}
+	if booking.ScheduleID == cancellation.ScheduleID {
+		booking.ScheduleID = to.ID
+	}
+	if err := uc.saveBooking(ctx, conn, booking); err != nil {
+		return nil, err
+	}
+
+	for _, ticket := range tickets {
+		ticket.Schedule = *to
+	}
+	eticket, err := bookingDocument(uc.TokenUtil, booking, tickets, DocumentETicket)
+	if err != nil {
+		return nil, err
+	}
+	return enqueueEmail(ctx, conn, uc.OutboxRepository, booking.OrderID, booking.Email, cancellationSubject(booking),
+		templates.ScheduleCancellationEmail(booking, &cancellation.Schedule, cancellation.Reason, nil, to), *eticket)
+}
+
+// saveBooking writes the booking row alone, not the tickets, fees and add-ons
+// it was loaded with.
+func (uc *ScheduleCancellationUsecase) saveBooking(ctx context.Context, conn gotann.Connection, booking *domain.Booking) error {
+	row := *booking
+	row.Tickets = nil
+	row.Schedule = domain.Schedule{}
+	row.Changes = nil
+	row.Fees = nil
+	row.Addons = nil
+	if err := uc.BookingRepository.Update(ctx, conn, &row); err != nil {
+		return fmt.Errorf("failed to update booking: %w", err)
+	}
+	return nil
+}
+
+// rebookable checks that passengers of a cancelled departure can be moved
+// onto another: a later, still scheduled departure on the same route.
+func rebookable(from, to *domain.Schedule, now time.Time) error {
+	switch {
+	case to.ID == from.ID:
+		return fmt.Errorf("schedule %d is the one being cancelled: %w", to.ID, errs.ErrBadRequest)
+	case to.Status != enum.ScheduleActive.String():
+		return fmt.Errorf("schedule %d is %s: %w", to.ID, to.Status, errs.ErrBadRequest)
+	case !to.DepartureDatetime.After(now):
+		return fmt.Errorf("schedule %d already departed: %w", to.ID, errs.ErrBadRequest)
+	case to.DepartureHarborID != from.DepartureHarborID || to.ArrivalHarborID != from.ArrivalHarborID:
+		return fmt.Errorf("schedule %d is on another route: %w", to.ID, errs.ErrBadRequest)
+	}
+	return nil
+}
+
+func bookingAddonValues(addons []*domain.BookingAddon) []domain.BookingAddon {
+	values := make([]domain.BookingAddon, len(addons))
+	for i, addon := range addons {
+		values[i] = *addon
+	}
+	return values
+}
+
+func cancellationSubject(booking *domain.Booking) string {
+	return fmt.Sprintf("Keberangkatan Dibatalkan - %s", booking.OrderID)
+}
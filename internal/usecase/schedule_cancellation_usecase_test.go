@@ -0,0 +1,344 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type scheduleCancellationMocks struct {
+	cancellationRepo *mocks.MockScheduleCancellationRepository
+	scheduleRepo     *mocks.MockScheduleRepository
+	bookingRepo      *mocks.MockBookingRepository
+	ticketRepo       *mocks.MockTicketRepository
+	quotaRepo        *mocks.MockQuotaRepository
+	seatRepo         *mocks.MockScheduleSeatRepository
+	refundRepo       *mocks.MockRefundRepository
+	addonStockRepo   *mocks.MockAddonStockRepository
+	eventRepo        *mocks.MockBoardingEventRepository
+	outboxRepo       *mocks.MockOutboxRepository
+}
+
+func scheduleCancellationUsecase(t *testing.T) (*ScheduleCancellationUsecase, *scheduleCancellationMocks) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	m := &scheduleCancellationMocks{
+		cancellationRepo: mocks.NewMockScheduleCancellationRepository(ctrl),
+		scheduleRepo:     mocks.NewMockScheduleRepository(ctrl),
+		bookingRepo:      mocks.NewMockBookingRepository(ctrl),
+		ticketRepo:       mocks.NewMockTicketRepository(ctrl),
+		quotaRepo:        mocks.NewMockQuotaRepository(ctrl),
+		seatRepo:         mocks.NewMockScheduleSeatRepository(ctrl),
+		refundRepo:       mocks.NewMockRefundRepository(ctrl),
+		addonStockRepo:   mocks.NewMockAddonStockRepository(ctrl),
+		eventRepo:        mocks.NewMockBoardingEventRepository(ctrl),
+		outboxRepo:       mocks.NewMockOutboxRepository(ctrl),
+	}
+	transactor := mocks.NewMockTransactor(ctrl)
+	transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+			return fn(nil)
+		},
+	).AnyTimes()
+	bookingChangeRepo := mocks.NewMockBookingChangeRepository(ctrl)
+	redemptionRepo := mocks.NewMockPromotionRedemptionRepository(ctrl)
+	redemptionRepo.EXPECT().FindByBookingID(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	outbox := NewOutboxUsecase(transactor, m.outboxRepo, m.bookingRepo, bookingChangeRepo, mocks.NewMockTripayClient(ctrl), mocks.NewMockMailer(ctrl), nil)
+	uc := NewScheduleCancellationUsecase(transactor, m.cancellationRepo, m.scheduleRepo, m.bookingRepo, m.ticketRepo, m.quotaRepo,
+		m.seatRepo, mocks.NewMockSeatLayoutRepository(ctrl), bookingChangeRepo, m.refundRepo, m.addonStockRepo,
+		mocks.NewMockBookingAddonRepository(ctrl), m.eventRepo, mocks.NewMockPromotionRepository(ctrl), redemptionRepo, m.outboxRepo, outbox, mocks.NewMockTokenUtil(ctrl))
+	return uc, m
+}
+
+func TestScheduleCancellationUsecase_CancelSchedule(t *testing.T) {
+	t.Parallel()
+	departure := time.Now().Add(48 * time.Hour)
+	other := uint(2)
+
+	tests := []struct {
+		name         string
+		resolution   string
+		toScheduleID *uint
+		setup        func(m *scheduleCancellationMocks)
+		wantErr      error
+	}{
+		{
+			name:       "schedule not found",
+			resolution: enum.CancellationRefund.String(),
+			setup: func(m *scheduleCancellationMocks) {
+				m.scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			wantErr: errs.ErrNotFound,
+		},
+		{
+			name:       "already cancelled",
+			resolution: enum.CancellationRefund.String(),
+			setup: func(m *scheduleCancellationMocks) {
+				m.scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Schedule{ID: 1, DepartureDatetime: departure}, nil)
+				m.cancellationRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.ScheduleCancellation{ID: 3}, nil)
+			},
+			wantErr: errs.ErrConflict,
+		},
+		{
+			name:       "already departed",
+			resolution: enum.CancellationRefund.String(),
+			setup: func(m *scheduleCancellationMocks) {
+				m.scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Schedule{ID: 1, DepartureDatetime: time.Now().Add(-time.Hour)}, nil)
+				m.cancellationRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			wantErr: errs.ErrConflict,
+		},
+		{
+			name:         "rebook onto another route",
+			resolution:   enum.CancellationRebook.String(),
+			toScheduleID: &other,
+			setup: func(m *scheduleCancellationMocks) {
+				m.scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Schedule{ID: 1, DepartureHarborID: 1, ArrivalHarborID: 2, DepartureDatetime: departure}, nil)
+				m.cancellationRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+				m.scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(2)).Return(&domain.Schedule{ID: 2, DepartureHarborID: 2, ArrivalHarborID: 1, DepartureDatetime: departure, Status: enum.ScheduleActive.String()}, nil)
+			},
+			wantErr: errs.ErrBadRequest,
+		},
+		{
+			name:       "bookings queued",
+			resolution: enum.CancellationRefund.String(),
+			setup: func(m *scheduleCancellationMocks) {
+				m.scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Schedule{ID: 1, DepartureDatetime: departure, Status: enum.ScheduleActive.String()}, nil)
+				m.cancellationRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+				m.scheduleRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, schedule *domain.Schedule) error {
+						require.Equal(t, enum.ScheduleCancelled.String(), schedule.Status)
+						return nil
+					},
+				)
+				m.cancellationRepo.EXPECT().FindBookingIDs(gomock.Any(), gomock.Any(), uint(1), []string{enum.BookingPaid.String(), enum.BookingUnpaid.String()}).Return([]uint{5, 6}, nil)
+				m.cancellationRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, cancellation *domain.ScheduleCancellation) error {
+						require.Equal(t, 2, cancellation.Total)
+						require.Equal(t, enum.ScheduleCancellationRunning.String(), cancellation.Status)
+						require.Equal(t, uint(9), *cancellation.UserID)
+						cancellation.ID = 3
+						return nil
+					},
+				)
+				m.cancellationRepo.EXPECT().InsertItems(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, items []*domain.ScheduleCancellationItem) error {
+						require.Len(t, items, 2)
+						require.Equal(t, uint(3), items[1].CancellationID)
+						require.Equal(t, uint(6), items[1].BookingID)
+						require.Equal(t, enum.CancellationItemPending.String(), items[1].Status)
+						return nil
+					},
+				)
+
+				// The report right after queueing
+				m.cancellationRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.ScheduleCancellation{ID: 3, ScheduleID: 1, Total: 2}, nil)
+				m.cancellationRepo.EXPECT().CountItemsByStatus(gomock.Any(), gomock.Any(), uint(3)).Return(map[string]int64{enum.CancellationItemPending.String(): 2}, nil)
+				m.cancellationRepo.EXPECT().SumRefunded(gomock.Any(), gomock.Any(), uint(3)).Return(0.0, nil)
+				m.cancellationRepo.EXPECT().FindItemsByStatus(gomock.Any(), gomock.Any(), uint(3), enum.CancellationItemFailed.String()).Return(nil, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			uc, m := scheduleCancellationUsecase(t)
+			tt.setup(m)
+
+			report, err := uc.CancelSchedule(context.Background(), 1, "Cuaca buruk", tt.resolution, tt.toScheduleID, 9)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, int64(2), report.Pending)
+			require.Empty(t, report.Failures)
+		})
+	}
+}
+
+func TestScheduleCancellationUsecase_ProcessCancellations(t *testing.T) {
+	t.Parallel()
+	departure := time.Now().Add(48 * time.Hour)
+	userID := uint(9)
+	cancellation := &domain.ScheduleCancellation{
+		ID:         3,
+		ScheduleID: 1,
+		Reason:     "Cuaca buruk",
+		Resolution: enum.CancellationRefund.String(),
+		Status:     enum.ScheduleCancellationRunning.String(),
+		UserID:     &userID,
+		Schedule:   domain.Schedule{ID: 1, DepartureDatetime: departure},
+	}
+	bookingID := uint(5)
+	ticketID := uint(10)
+
+	tests := []struct {
+		name       string
+		item       *domain.ScheduleCancellationItem
+		setup      func(m *scheduleCancellationMocks)
+		wantStatus string
+		wantErr    bool
+	}{
+		{
+			name: "paid booking refunded in full",
+			item: &domain.ScheduleCancellationItem{ID: 1, CancellationID: 3, BookingID: 5, Status: enum.CancellationItemPending.String()},
+			setup: func(m *scheduleCancellationMocks) {
+				m.bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(5)).Return(&domain.Booking{
+					ID:       5,
+					OrderID:  "ORD-5",
+					Status:   enum.BookingPaid.String(),
+					Discount: 20000,
+					Tickets: []domain.Ticket{
+						{ID: 10, BookingID: &bookingID, ScheduleID: 1, ClassID: 1, TicketCode: "T-10", Price: 100000},
+						{ID: 11, BookingID: &bookingID, ScheduleID: 1, ClassID: 1, TicketCode: "T-11", Price: 100000},
+					},
+					Fees:   []domain.TicketFee{{TicketID: 10, Amount: 5000}, {TicketID: 11, Amount: 5000}},
+					Addons: []domain.BookingAddon{{ScheduleID: 1, AddonID: 2, TicketID: &ticketID, Price: 15000, Quantity: 2}},
+				}, nil)
+				m.refundRepo.EXPECT().InsertBulk(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, refunds []*domain.Refund) error {
+						require.Len(t, refunds, 2)
+						// 10% promo off the fare, fees and add-ons back in full
+						require.Equal(t, 90000.0+5000+30000, refunds[0].Amount)
+						require.Equal(t, 90000.0+5000, refunds[1].Amount)
+						require.Equal(t, 100.0, refunds[1].RefundPercent)
+						require.Equal(t, operatorCancellationPolicy, refunds[1].PolicyName)
+						return nil
+					},
+				)
+				m.ticketRepo.EXPECT().UpdateBoarding(gomock.Any(), gomock.Any(), gomock.Any(), enum.BoardingIssued.String()).Return(int64(1), nil).Times(2)
+				m.eventRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, event *domain.BoardingEvent) error {
+						require.Equal(t, enum.BoardingCancelled.String(), event.ToStatus)
+						require.Equal(t, userID, *event.UserID)
+						return nil
+					},
+				).Times(2)
+				m.quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(1), uint(1), 2).Return(nil)
+				m.seatRepo.EXPECT().ReleaseByTicketIDs(gomock.Any(), gomock.Any(), []uint{10, 11}).Return(nil)
+				m.addonStockRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(1), uint(2), 2).Return(nil)
+				m.bookingRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, booking *domain.Booking) error {
+						require.Equal(t, enum.BookingCancelledByOperator.String(), booking.Status)
+						require.Nil(t, booking.Tickets)
+						return nil
+					},
+				)
+				m.outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, event *domain.Outbox) error {
+						event.ID = 11
+						return nil
+					},
+				)
+				m.cancellationRepo.EXPECT().UpdatePendingItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, item *domain.ScheduleCancellationItem) (int64, error) {
+						require.Equal(t, enum.CancellationItemRefunded.String(), item.Status)
+						require.Equal(t, 220000.0, item.RefundAmount)
+						require.Equal(t, 1, item.Attempts)
+						return 1, nil
+					},
+				)
+				m.outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), uint(11), gomock.Any()).Return(int64(0), nil)
+			},
+			wantStatus: enum.CancellationItemRefunded.String(),
+		},
+		{
+			name: "unpaid booking cancelled",
+			item: &domain.ScheduleCancellationItem{ID: 2, CancellationID: 3, BookingID: 6, Status: enum.CancellationItemPending.String()},
+			setup: func(m *scheduleCancellationMocks) {
+				m.bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(6)).Return(&domain.Booking{
+					ID:      6,
+					Status:  enum.BookingUnpaid.String(),
+					Tickets: []domain.Ticket{{ID: 12, ScheduleID: 1, ClassID: 1}, {ID: 13, ScheduleID: 4, ClassID: 1}},
+				}, nil)
+				m.quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(1), uint(1), 1).Return(nil)
+				m.quotaRepo.EXPECT().Restore(gomock.Any(), gomock.Any(), uint(4), uint(1), 1).Return(nil)
+				m.seatRepo.EXPECT().ReleaseByTicketIDs(gomock.Any(), gomock.Any(), []uint{12, 13}).Return(nil)
+				m.bookingRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, booking *domain.Booking) error {
+						require.Equal(t, enum.BookingCancelledByOperator.String(), booking.Status)
+						return nil
+					},
+				)
+				m.outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.cancellationRepo.EXPECT().UpdatePendingItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				m.outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+			},
+			wantStatus: enum.CancellationItemCancelled.String(),
+		},
+		{
+			name: "settled by another instance",
+			item: &domain.ScheduleCancellationItem{ID: 2, CancellationID: 3, BookingID: 6, Status: enum.CancellationItemPending.String()},
+			setup: func(m *scheduleCancellationMocks) {
+				m.bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(6)).Return(&domain.Booking{ID: 6, Status: enum.BookingExpired.String()}, nil)
+				m.cancellationRepo.EXPECT().UpdatePendingItem(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+			},
+		},
+		{
+			name: "last attempt fails",
+			item: &domain.ScheduleCancellationItem{ID: 2, CancellationID: 3, BookingID: 6, Status: enum.CancellationItemPending.String(), Attempts: cancellationMaxAttempts - 1},
+			setup: func(m *scheduleCancellationMocks) {
+				m.bookingRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(6)).Return(nil, errInternalServErr)
+				m.cancellationRepo.EXPECT().UpdatePendingItem(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, item *domain.ScheduleCancellationItem) (int64, error) {
+						require.Equal(t, enum.CancellationItemFailed.String(), item.Status)
+						require.Equal(t, cancellationMaxAttempts, item.Attempts)
+						require.Contains(t, *item.Error, errInternalServErr.Error())
+						return 1, nil
+					},
+				)
+			},
+			wantStatus: enum.CancellationItemFailed.String(),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			uc, m := scheduleCancellationUsecase(t)
+			running := *cancellation
+			m.cancellationRepo.EXPECT().FindRunning(gomock.Any(), gomock.Any()).Return([]*domain.ScheduleCancellation{&running}, nil)
+			m.cancellationRepo.EXPECT().FindPendingItems(gomock.Any(), gomock.Any(), uint(3), cancellationBatch).Return([]*domain.ScheduleCancellationItem{tt.item}, nil)
+			tt.setup(m)
+
+			settles, err := uc.ProcessCancellations(context.Background())
+			require.NoError(t, err)
+			require.Len(t, settles, 1)
+			require.Equal(t, tt.wantErr, settles[0].Err != nil)
+			require.Equal(t, tt.wantStatus, settles[0].Status)
+		})
+	}
+}
+
+func TestScheduleCancellationUsecase_ProcessCancellations_Completes(t *testing.T) {
+	t.Parallel()
+	uc, m := scheduleCancellationUsecase(t)
+	m.cancellationRepo.EXPECT().FindRunning(gomock.Any(), gomock.Any()).Return([]*domain.ScheduleCancellation{{ID: 3, Status: enum.ScheduleCancellationRunning.String()}}, nil)
+	m.cancellationRepo.EXPECT().FindPendingItems(gomock.Any(), gomock.Any(), uint(3), cancellationBatch).Return(nil, nil)
+	m.cancellationRepo.EXPECT().CountItemsByStatus(gomock.Any(), gomock.Any(), uint(3)).Return(map[string]int64{enum.CancellationItemRefunded.String(): 4}, nil)
+	m.cancellationRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, conn gotann.Connection, cancellation *domain.ScheduleCancellation) error {
+			require.Equal(t, enum.ScheduleCancellationCompleted.String(), cancellation.Status)
+			require.NotNil(t, cancellation.CompletedAt)
+			return nil
+		},
+	)
+
+	settles, err := uc.ProcessCancellations(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, settles)
+}
@@ -0,0 +1,227 @@
+package usecase
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/templates"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/model"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// onTimeToleranceMinutes is how late a departure may leave and still count
+// as on time.
+const onTimeToleranceMinutes = 15
+
+type ScheduleDelayUsecase struct {
+	Transactor              transact.Transactor
+	ScheduleDelayRepository domain.ScheduleDelayRepository
+	ScheduleRepository      domain.ScheduleRepository
+	OutboxRepository        domain.OutboxRepository
+	Outbox                  *OutboxUsecase
+}
+
+func NewScheduleDelayUsecase(
+	transactor transact.Transactor,
+	schedule_delay_repository domain.ScheduleDelayRepository,
+	schedule_repository domain.ScheduleRepository,
+	outbox_repository domain.OutboxRepository,
+	outbox *OutboxUsecase,
+) *ScheduleDelayUsecase {
+	return &ScheduleDelayUsecase{
+		Transactor:              transactor,
+		ScheduleDelayRepository: schedule_delay_repository,
+		ScheduleRepository:      schedule_repository,
+		OutboxRepository:        outbox_repository,
+		Outbox:                  outbox,
+	}
+}
+
+// DelaySchedule moves a departure to a later time and records it in the
+// delay history. The arrival moves by the same amount unless newArrival is
+// given. Every paid booking still to board is emailed, and sent an SMS too
+// when notifySMS is set; the messages go out through the outbox job so a full
+// ship does not hold up the request.
+func (uc *ScheduleDelayUsecase) DelaySchedule(ctx context.Context, scheduleID uint, newDeparture time.Time, newArrival *time.Time, reason, note string, notifySMS bool, userID uint) (*domain.ScheduleDelay, error) {
+	if notifySMS && uc.Outbox.SMS == nil {
+		return nil, fmt.Errorf("no sms channel is configured: %w", errs.ErrBadRequest)
+	}
+
+	delay := new(domain.ScheduleDelay)
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		schedule, err := uc.ScheduleRepository.FindByID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if schedule == nil {
+			return errs.ErrNotFound
+		}
+		if schedule.Status != enum.ScheduleActive.String() {
+			return fmt.Errorf("schedule %d is %s: %w", scheduleID, schedule.Status, errs.ErrConflict)
+		}
+		if !newDeparture.After(schedule.DepartureDatetime) {
+			return fmt.Errorf("a delay must leave later than %s: %w", schedule.DepartureDatetime.Format(time.RFC3339), errs.ErrBadRequest)
+		}
+		arrival := schedule.ArrivalDatetime.Add(newDeparture.Sub(schedule.DepartureDatetime))
+		if newArrival != nil {
+			arrival = *newArrival
+		}
+		if !arrival.After(newDeparture) {
+			return fmt.Errorf("arrival must be after departure: %w", errs.ErrBadRequest)
+		}
+
+		previousDeparture := schedule.DepartureDatetime
+		*delay = domain.ScheduleDelay{
+			ScheduleID:        scheduleID,
+			Reason:            reason,
+			Note:              note,
+			PreviousDeparture: previousDeparture,
+			NewDeparture:      newDeparture,
+			PreviousArrival:   schedule.ArrivalDatetime,
+			NewArrival:        arrival,
+		}
+		if userID != 0 {
+			delay.UserID = &userID
+		}
+		schedule.DepartureDatetime = newDeparture
+		schedule.ArrivalDatetime = arrival
+		moved, err := uc.ScheduleRepository.Reschedule(ctx, tx, schedule, previousDeparture)
+		if err != nil {
+			return fmt.Errorf("failed to reschedule: %w", err)
+		}
+		if moved == 0 {
+			return fmt.Errorf("schedule %d was rescheduled meanwhile: %w", scheduleID, errs.ErrConflict)
+		}
+
+		bookings, err := uc.ScheduleDelayRepository.FindBookingsToNotify(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to find bookings: %w", err)
+		}
+		delay.Notified = len(bookings)
+		if err := uc.ScheduleDelayRepository.Insert(ctx, tx, delay); err != nil {
+			return fmt.Errorf("failed to record delay: %w", err)
+		}
+
+		subject := fmt.Sprintf("Keberangkatan Diundur - %s %s", schedule.DepartureHarbor.HarborName, newDeparture.Format("02 Jan 15:04"))
+		for _, booking := range bookings {
+			if _, err := enqueueEmail(ctx, tx, uc.OutboxRepository, booking.OrderID, booking.Email, subject, templates.ScheduleDelayEmail(booking, schedule, delay)); err != nil {
+				return err
+			}
+			if notifySMS && booking.PhoneNumber != "" {
+				if _, err := enqueueSMS(ctx, tx, uc.OutboxRepository, booking.OrderID, booking.PhoneNumber, templates.ScheduleDelaySMS(booking, schedule, delay)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to delay schedule: %w", err)
+	}
+	return delay, nil
+}
+
+// ListDelays returns the delay history of a schedule, oldest first.
+func (uc *ScheduleDelayUsecase) ListDelays(ctx context.Context, scheduleID uint) ([]*domain.ScheduleDelay, error) {
+	var delays []*domain.ScheduleDelay
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		schedule, err := uc.ScheduleRepository.FindByID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if schedule == nil {
+			return errs.ErrNotFound
+		}
+		delays, err = uc.ScheduleDelayRepository.FindByScheduleID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get delays: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list delays: %w", err)
+	}
+	return delays, nil
+}
+
+// OnTimePerformance reports how the departures leaving from from up to to
+// kept to their timetable, and what delayed them.
+func (uc *ScheduleDelayUsecase) OnTimePerformance(ctx context.Context, from, to time.Time) (*model.OnTimePerformance, error) {
+	if !to.After(from) {
+		return nil, fmt.Errorf("period must end after it starts: %w", errs.ErrBadRequest)
+	}
+
+	var departures int64
+	var delays []*domain.ScheduleDelay
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		var err error
+		departures, err = uc.ScheduleRepository.CountDepartingBetween(ctx, tx, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to count departures: %w", err)
+		}
+		delays, err = uc.ScheduleDelayRepository.FindDepartingBetween(ctx, tx, from, to)
+		if err != nil {
+			return fmt.Errorf("failed to get delays: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to report on-time performance: %w", err)
+	}
+	return onTimePerformance(from, to, departures, delays), nil
+}
+
+// onTimePerformance adds up the delays of each departure. The departures
+// not in delays left on time.
+func onTimePerformance(from, to time.Time, departures int64, delays []*domain.ScheduleDelay) *model.OnTimePerformance {
+	report := &model.OnTimePerformance{
+		From:             from,
+		To:               to,
+		ToleranceMinutes: onTimeToleranceMinutes,
+		Departures:       departures,
+		Reasons:          []model.DelayReasonSummary{},
+	}
+
+	late := map[uint]int{}
+	reasons := map[string]*model.DelayReasonSummary{}
+	for _, delay := range delays {
+		minutes := delay.Minutes()
+		late[delay.ScheduleID] += minutes
+		summary, ok := reasons[delay.Reason]
+		if !ok {
+			summary = &model.DelayReasonSummary{Reason: delay.Reason}
+			reasons[delay.Reason] = summary
+		}
+		summary.Delays++
+		summary.Minutes += int64(minutes)
+	}
+
+	var total int
+	for _, minutes := range late {
+		if minutes > onTimeToleranceMinutes {
+			report.Delayed++
+			total += minutes
+		}
+		report.MaxDelayMinutes = max(report.MaxDelayMinutes, minutes)
+	}
+	report.OnTime = departures - report.Delayed
+	if departures > 0 {
+		report.OnTimeRate = float64(report.OnTime) * 100 / float64(departures)
+	}
+	if report.Delayed > 0 {
+		report.AverageDelayMinutes = float64(total) / float64(report.Delayed)
+	}
+
+	for _, summary := range reasons {
+		report.Reasons = append(report.Reasons, *summary)
+	}
+	sort.Slice(report.Reasons, func(i, j int) bool {
+		if report.Reasons[i].Minutes != report.Reasons[j].Minutes {
+			return report.Reasons[i].Minutes > report.Reasons[j].Minutes
+		}
+		return report.Reasons[i].Reason < report.Reasons[j].Reason
+	})
+	return report
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func scheduleDelayUsecase(t *testing.T, withSMS bool) (*ScheduleDelayUsecase, *mocks.MockScheduleDelayRepository, *mocks.MockScheduleRepository, *mocks.MockOutboxRepository) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	delayRepo := mocks.NewMockScheduleDelayRepository(ctrl)
+	scheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+			return fn(nil)
+		},
+	).AnyTimes()
+	outbox := NewOutboxUsecase(transactor, outboxRepo, mocks.NewMockBookingRepository(ctrl), mocks.NewMockBookingChangeRepository(ctrl), mocks.NewMockTripayClient(ctrl), mocks.NewMockMailer(ctrl), nil)
+	if withSMS {
+		outbox.SMS = mocks.NewMockSender(ctrl)
+	}
+	uc := NewScheduleDelayUsecase(transactor, delayRepo, scheduleRepo, outboxRepo, outbox)
+	return uc, delayRepo, scheduleRepo, outboxRepo
+}
+
+func TestScheduleDelayUsecase_DelaySchedule(t *testing.T) {
+	t.Parallel()
+	departure := time.Date(2026, 11, 2, 8, 0, 0, 0, time.UTC)
+	arrival := departure.Add(4 * time.Hour)
+	schedule := func(status string) *domain.Schedule {
+		return &domain.Schedule{
+			ID:                1,
+			DepartureDatetime: departure,
+			ArrivalDatetime:   arrival,
+			Status:            status,
+			DepartureHarbor:   domain.Harbor{HarborName: "Merak"},
+			ArrivalHarbor:     domain.Harbor{HarborName: "Bakauheni"},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		withSMS      bool
+		notifySMS    bool
+		newDeparture time.Time
+		setup        func(delayRepo *mocks.MockScheduleDelayRepository, scheduleRepo *mocks.MockScheduleRepository, outboxRepo *mocks.MockOutboxRepository)
+		wantErr      error
+	}{
+		{
+			name:         "sms asked without a channel",
+			notifySMS:    true,
+			newDeparture: departure.Add(time.Hour),
+			setup: func(delayRepo *mocks.MockScheduleDelayRepository, scheduleRepo *mocks.MockScheduleRepository, outboxRepo *mocks.MockOutboxRepository) {
+			},
+			wantErr: errs.ErrBadRequest,
+		},
+		{
+			name:         "schedule not found",
+			newDeparture: departure.Add(time.Hour),
+			setup: func(delayRepo *mocks.MockScheduleDelayRepository, scheduleRepo *mocks.MockScheduleRepository, outboxRepo *mocks.MockOutboxRepository) {
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			wantErr: errs.ErrNotFound,
+		},
+		{
+			name:         "cancelled schedule",
+			newDeparture: departure.Add(time.Hour),
+			setup: func(delayRepo *mocks.MockScheduleDelayRepository, scheduleRepo *mocks.MockScheduleRepository, outboxRepo *mocks.MockOutboxRepository) {
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(schedule(enum.ScheduleCancelled.String()), nil)
+			},
+			wantErr: errs.ErrConflict,
+		},
+		{
+			name:         "earlier departure",
+			newDeparture: departure.Add(-time.Hour),
+			setup: func(delayRepo *mocks.MockScheduleDelayRepository, scheduleRepo *mocks.MockScheduleRepository, outboxRepo *mocks.MockOutboxRepository) {
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(schedule(enum.ScheduleActive.String()), nil)
+			},
+			wantErr: errs.ErrBadRequest,
+		},
+		{
+			name:         "rescheduled meanwhile",
+			newDeparture: departure.Add(time.Hour),
+			setup: func(delayRepo *mocks.MockScheduleDelayRepository, scheduleRepo *mocks.MockScheduleRepository, outboxRepo *mocks.MockOutboxRepository) {
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(schedule(enum.ScheduleActive.String()), nil)
+				scheduleRepo.EXPECT().Reschedule(gomock.Any(), gomock.Any(), gomock.Any(), departure).Return(int64(0), nil)
+			},
+			wantErr: errs.ErrConflict,
+		},
+		{
+			name:         "passengers notified",
+			withSMS:      true,
+			notifySMS:    true,
+			newDeparture: departure.Add(90 * time.Minute),
+			setup: func(delayRepo *mocks.MockScheduleDelayRepository, scheduleRepo *mocks.MockScheduleRepository, outboxRepo *mocks.MockOutboxRepository) {
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(schedule(enum.ScheduleActive.String()), nil)
+				scheduleRepo.EXPECT().Reschedule(gomock.Any(), gomock.Any(), gomock.Any(), departure).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, schedule *domain.Schedule, previous time.Time) (int64, error) {
+						require.Equal(t, departure.Add(90*time.Minute), schedule.DepartureDatetime)
+						require.Equal(t, arrival.Add(90*time.Minute), schedule.ArrivalDatetime)
+						return 1, nil
+					},
+				)
+				delayRepo.EXPECT().FindBookingsToNotify(gomock.Any(), gomock.Any(), uint(1)).Return([]*domain.Booking{
+					{ID: 5, OrderID: "ORD-5", Email: "a@b.c", PhoneNumber: "0812345678"},
+					{ID: 6, OrderID: "ORD-6", Email: "d@e.f"},
+				}, nil)
+				delayRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, delay *domain.ScheduleDelay) error {
+						require.Equal(t, departure, delay.PreviousDeparture)
+						require.Equal(t, 90, delay.Minutes())
+						require.Equal(t, 2, delay.Notified)
+						require.Equal(t, uint(9), *delay.UserID)
+						return nil
+					},
+				)
+
+				// An email to each booking, an SMS only where there is a phone number
+				outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, event *domain.Outbox) error {
+						if event.EventType == enum.OutboxSendEmail.String() {
+							return nil
+						}
+						require.Equal(t, enum.OutboxSendSMS.String(), event.EventType)
+						require.Equal(t, "ORD-5", event.AggregateID)
+						var payload domain.SMSOutboxPayload
+						require.NoError(t, json.Unmarshal([]byte(event.Payload), &payload))
+						require.Equal(t, "0812345678", payload.To)
+						require.Contains(t, payload.Message, "Cuaca buruk")
+						return nil
+					},
+				).Times(3)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			uc, delayRepo, scheduleRepo, outboxRepo := scheduleDelayUsecase(t, tt.withSMS)
+			tt.setup(delayRepo, scheduleRepo, outboxRepo)
+
+			delay, err := uc.DelaySchedule(context.Background(), 1, tt.newDeparture, nil, enum.DelayWeather.String(), "", tt.notifySMS, 9)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.newDeparture, delay.NewDeparture)
+		})
+	}
+}
+
+func TestOnTimePerformance(t *testing.T) {
+	t.Parallel()
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	at := func(minutes int) time.Time { return from.Add(time.Duration(minutes) * time.Minute) }
+
+	report := onTimePerformance(from, to, 10, []*domain.ScheduleDelay{
+		// Two delays of one departure add up past the tolerance
+		{ScheduleID: 1, Reason: enum.DelayWeather.String(), PreviousDeparture: at(0), NewDeparture: at(10)},
+		{ScheduleID: 1, Reason: enum.DelayWeather.String(), PreviousDeparture: at(10), NewDeparture: at(30)},
+		// Within the tolerance
+		{ScheduleID: 2, Reason: enum.DelayPortCongestion.String(), PreviousDeparture: at(0), NewDeparture: at(15)},
+		{ScheduleID: 3, Reason: enum.DelayTechnical.String(), PreviousDeparture: at(0), NewDeparture: at(120)},
+	})
+
+	require.Equal(t, int64(2), report.Delayed)
+	require.Equal(t, int64(8), report.OnTime)
+	require.Equal(t, 80.0, report.OnTimeRate)
+	require.Equal(t, 75.0, report.AverageDelayMinutes)
+	require.Equal(t, 120, report.MaxDelayMinutes)
+	require.Len(t, report.Reasons, 3)
+	require.Equal(t, enum.DelayTechnical.String(), report.Reasons[0].Reason)
+	require.Equal(t, int64(2), report.Reasons[1].Delays)
+	require.Equal(t, int64(30), report.Reasons[1].Minutes)
+
+	empty := onTimePerformance(from, to, 0, nil)
+	require.Equal(t, 0.0, empty.OnTimeRate)
+	require.Empty(t, empty.Reasons)
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	enum "eticket-api/internal/common/enums"
 	errs "eticket-api/internal/common/errors"
 	"eticket-api/internal/common/transact"
 	"eticket-api/internal/domain"
@@ -10,29 +11,26 @@ import (
 )
 
 type ScheduleUsecase struct {
-	Transactor             transact.Transactor
-	ClaimSessionRepository domain.ClaimSessionRepository
-	ClassRepository        domain.ClassRepository
-	ShipRepository         domain.ShipRepository
-	ScheduleRepository     domain.ScheduleRepository
-	TicketRepository       domain.TicketRepository
+	Transactor         transact.Transactor
+	ClassRepository    domain.ClassRepository
+	ShipRepository     domain.ShipRepository
+	ScheduleRepository domain.ScheduleRepository
+	TicketRepository   domain.TicketRepository
 }
 
 func NewScheduleUsecase(
 	transactor transact.Transactor,
-	claim_session_repository domain.ClaimSessionRepository,
 	class_repository domain.ClassRepository,
 	ship_repository domain.ShipRepository,
 	schedule_repository domain.ScheduleRepository,
 	ticket_repository domain.TicketRepository,
 ) *ScheduleUsecase {
 	return &ScheduleUsecase{
-		Transactor:             transactor,
-		ClaimSessionRepository: claim_session_repository,
-		ClassRepository:        class_repository,
-		ShipRepository:         ship_repository,
-		ScheduleRepository:     schedule_repository,
-		TicketRepository:       ticket_repository,
+		Transactor:         transactor,
+		ClassRepository:    class_repository,
+		ShipRepository:     ship_repository,
+		ScheduleRepository: schedule_repository,
+		TicketRepository:   ticket_repository,
 	}
 }
 
@@ -107,25 +105,9 @@ func (uc *ScheduleUsecase) GetScheduleByID(ctx context.Context, id uint) (*domai
 			return errs.ErrNotFound
 		}
 
-		// 2. Ambil semua claim session aktif (sudah preload claim items)
-		claimSessions, err := uc.ClaimSessionRepository.FindActiveByScheduleID(ctx, tx, schedule.ID)
-		if err != nil {
-			return fmt.Errorf("failed to get claim sessions: %w", err)
-		}
-
-		// 3. Hitung total claimed per class
-		claimedByClass := make(map[uint]int)
-		for _, session := range claimSessions {
-			for _, item := range session.ClaimItems {
-				claimedByClass[item.ClassID] += item.Quantity
-			}
-		}
-
-		// 4. Hitung available quota untuk setiap class
+		// 2. Hitung available quota: sisa quota dikurangi yang sedang di-hold
 		for _, quota := range schedule.Quotas {
-			claimed := claimedByClass[quota.ClassID]
-			quota.Quota = max(quota.Quota-claimed, 0)
-			fmt.Printf("Schedule ID %d has %d active claim sessions, [Quota:%s:%d]\n", schedule.ID, len(claimSessions), quota.Class.ClassName, quota.Quota)
+			quota.Quota = max(quota.Quota-quota.Held, 0)
 		}
 
 		return nil
@@ -144,6 +126,14 @@ func (uc *ScheduleUsecase) UpdateSchedule(ctx context.Context, e *domain.Schedul
 		if schedule == nil {
 			return errs.ErrNotFound
 		}
+		// Cancelling goes through CancelSchedule, which settles the bookings on it
+		if e.Status == enum.ScheduleCancelled.String() && schedule.Status != enum.ScheduleCancelled.String() {
+			return fmt.Errorf("schedule %d must be cancelled through its cancel endpoint: %w", e.ID, errs.ErrBadRequest)
+		}
+		// Delaying goes through DelaySchedule, which tells the passengers
+		if e.DepartureDatetime.After(schedule.DepartureDatetime) && schedule.Status == enum.ScheduleActive.String() {
+			return fmt.Errorf("schedule %d must be delayed through its delay endpoint: %w", e.ID, errs.ErrBadRequest)
+		}
 
 		schedule.ShipID = e.ShipID
 		schedule.DepartureHarborID = e.DepartureHarborID
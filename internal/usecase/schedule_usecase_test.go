@@ -11,22 +11,21 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func scheduleUsecase(t *testing.T) (*ScheduleUsecase, *mocks.MockClaimSessionRepository, *mocks.MockClassRepository, *mocks.MockShipRepository, *mocks.MockScheduleRepository, *mocks.MockTicketRepository, *mocks.MockTransactor) {
+func scheduleUsecase(t *testing.T) (*ScheduleUsecase, *mocks.MockClassRepository, *mocks.MockShipRepository, *mocks.MockScheduleRepository, *mocks.MockTicketRepository, *mocks.MockTransactor) {
 	t.Helper()
 	ctrl := gomock.NewController(t)
-	claimSessionRepo := mocks.NewMockClaimSessionRepository(ctrl)
 	classRepo := mocks.NewMockClassRepository(ctrl)
 	shipRepo := mocks.NewMockShipRepository(ctrl)
 	scheduleRepo := mocks.NewMockScheduleRepository(ctrl)
 	ticketRepo := mocks.NewMockTicketRepository(ctrl)
 	transactor := mocks.NewMockTransactor(ctrl)
-	uc := NewScheduleUsecase(transactor, claimSessionRepo, classRepo, shipRepo, scheduleRepo, ticketRepo)
-	return uc, claimSessionRepo, classRepo, shipRepo, scheduleRepo, ticketRepo, transactor
+	uc := NewScheduleUsecase(transactor, classRepo, shipRepo, scheduleRepo, ticketRepo)
+	return uc, classRepo, shipRepo, scheduleRepo, ticketRepo, transactor
 }
 
 func TestScheduleUsecase_CreateSchedule(t *testing.T) {
 	t.Parallel()
-	uc, _, _, _, _, _, transactor := scheduleUsecase(t)
+	uc, _, _, _, _, transactor := scheduleUsecase(t)
 	tests := []struct {
 		name string
 		mock func()
@@ -62,7 +61,7 @@ func TestScheduleUsecase_CreateSchedule(t *testing.T) {
 
 func TestScheduleUsecase_GetScheduleByID(t *testing.T) {
 	t.Parallel()
-	uc, _, _, _, _, _, transactor := scheduleUsecase(t)
+	uc, _, _, _, _, transactor := scheduleUsecase(t)
 	tests := []struct {
 		name string
 		mock func()
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"sort"
+)
+
+type seatRequest struct {
+	ScheduleID  uint
+	ClassID     uint
+	Quantity    int
+	SeatNumbers []string
+}
+
+// ensureScheduleSeats copies the ship's seat layouts into the schedule's seat
+// inventory for every class that has a layout but no inventory yet. It returns
+// the classes that are sold by seat; other classes are sold by quota only.
+func ensureScheduleSeats(ctx context.Context, conn gotann.Connection, layouts domain.SeatLayoutRepository, seats domain.ScheduleSeatRepository, schedule *domain.Schedule) (map[uint]bool, error) {
+	counts, err := seats.CountByScheduleIDGroupByClass(ctx, conn, schedule.ID)
+	if err != nil {
+		return nil, fmt.Errorf("count schedule seats: %w", err)
+	}
+	shipLayouts, err := layouts.FindByShipID(ctx, conn, schedule.ShipID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch seat layouts: %w", err)
+	}
+
+	seated := make(map[uint]bool, len(shipLayouts))
+	var missing []*domain.ScheduleSeat
+	for _, layout := range shipLayouts {
+		if len(layout.Seats) == 0 {
+			continue
+		}
+		seated[layout.ClassID] = true
+		if counts[layout.ClassID] > 0 {
+			continue
+		}
+		for _, seat := range layout.Seats {
+			status := enum.SeatAvailable.String()
+			if seat.IsBlocked {
+				status = enum.SeatBlocked.String()
+			}
+			missing = append(missing, &domain.ScheduleSeat{
+				ScheduleID:   schedule.ID,
+				ClassID:      layout.ClassID,
+				SeatNumber:   seat.SeatNumber,
+				RowNumber:    seat.RowNumber,
+				ColumnNumber: seat.ColumnNumber,
+				Status:       status,
+			})
+		}
+	}
+	// Classes that already have inventory keep it even if the layout is gone
+	for classID, total := range counts {
+		if total > 0 {
+			seated[classID] = true
+		}
+	}
+
+	if len(missing) > 0 {
+		if err := seats.InsertBulk(ctx, conn, missing); err != nil {
+			return nil, fmt.Errorf("create schedule seats: %w", err)
+		}
+	}
+	return seated, nil
+}
+
+// groupSeatRequests merges requests for the same leg and class, ordered so
+// concurrent holds lock seat rows in the same order.
+func groupSeatRequests(requests []seatRequest) []seatRequest {
+	byClass := make(map[legClass]*seatRequest)
+	for _, r := range requests {
+		key := legClass{ScheduleID: r.ScheduleID, ClassID: r.ClassID}
+		group, ok := byClass[key]
+		if !ok {
+			group = &seatRequest{ScheduleID: r.ScheduleID, ClassID: r.ClassID}
+			byClass[key] = group
+		}
+		group.Quantity += r.Quantity
+		group.SeatNumbers = append(group.SeatNumbers, r.SeatNumbers...)
+	}
+	grouped := make([]seatRequest, 0, len(byClass))
+	for _, group := range byClass {
+		grouped = append(grouped, *group)
+	}
+	sort.Slice(grouped, func(i, j int) bool {
+		if grouped[i].ScheduleID != grouped[j].ScheduleID {
+			return grouped[i].ScheduleID < grouped[j].ScheduleID
+		}
+		return grouped[i].ClassID < grouped[j].ClassID
+	})
+	return grouped
+}
+
+// holdSeats holds the requested seats for a claim session and auto-assigns the
+// rest of each class's quantity, leg by leg. Classes without a seat map are
+// skipped.
+func holdSeats(ctx context.Context, conn gotann.Connection, seats domain.ScheduleSeatRepository, sessionID uint, seated map[legClass]bool, requests []seatRequest) error {
+	for _, r := range groupSeatRequests(requests) {
+		if !seated[legClass{ScheduleID: r.ScheduleID, ClassID: r.ClassID}] {
+			if len(r.SeatNumbers) > 0 {
+				return fmt.Errorf("schedule %d class %d has no seat map: %w", r.ScheduleID, r.ClassID, errs.ErrBadRequest)
+			}
+			continue
+		}
+		if len(r.SeatNumbers) > r.Quantity {
+			return fmt.Errorf("schedule %d class %d: more seats selected than tickets: %w", r.ScheduleID, r.ClassID, errs.ErrBadRequest)
+		}
+		unique := make(map[string]bool, len(r.SeatNumbers))
+		for _, number := range r.SeatNumbers {
+			if unique[number] {
+				return fmt.Errorf("schedule %d class %d: seat %s selected twice: %w", r.ScheduleID, r.ClassID, number, errs.ErrBadRequest)
+			}
+			unique[number] = true
+		}
+
+		if len(r.SeatNumbers) > 0 {
+			held, err := seats.Hold(ctx, conn, r.ScheduleID, r.ClassID, sessionID, r.SeatNumbers)
+			if err != nil {
+				return fmt.Errorf("hold seats for schedule %d class %d: %w", r.ScheduleID, r.ClassID, err)
+			}
+			if held != int64(len(r.SeatNumbers)) {
+				return fmt.Errorf("schedule %d class %d: %w", r.ScheduleID, r.ClassID, errs.ErrSeatUnavailable)
+			}
+		}
+
+		if rest := r.Quantity - len(r.SeatNumbers); rest > 0 {
+			held, err := seats.HoldAny(ctx, conn, r.ScheduleID, r.ClassID, sessionID, rest)
+			if err != nil {
+				return fmt.Errorf("assign seats for schedule %d class %d: %w", r.ScheduleID, r.ClassID, err)
+			}
+			if held != int64(rest) {
+				return fmt.Errorf("schedule %d class %d: %w", r.ScheduleID, r.ClassID, errs.ErrSeatUnavailable)
+			}
+		}
+	}
+	return nil
+}
+
+// heldSeats hands out the seats a claim session holds to its tickets.
+type heldSeats struct {
+	byClass map[legClass][]*domain.ScheduleSeat
+	taken   map[uint]bool
+}
+
+func newHeldSeats(seats []*domain.ScheduleSeat) *heldSeats {
+	h := &heldSeats{
+		byClass: make(map[legClass][]*domain.ScheduleSeat),
+		taken:   make(map[uint]bool, len(seats)),
+	}
+	for _, seat := range seats {
+		key := legClass{ScheduleID: seat.ScheduleID, ClassID: seat.ClassID}
+		h.byClass[key] = append(h.byClass[key], seat)
+	}
+	return h
+}
+
+// take returns the requested seat when the session holds it, otherwise the
+// next unassigned seat of the class on that leg, or nil when there is none.
+func (h *heldSeats) take(scheduleID, classID uint, requested *string) *domain.ScheduleSeat {
+	var next *domain.ScheduleSeat
+	for _, seat := range h.byClass[legClass{ScheduleID: scheduleID, ClassID: classID}] {
+		if h.taken[seat.ID] {
+			continue
+		}
+		if requested != nil && seat.SeatNumber == *requested {
+			next = seat
+			break
+		}
+		if next == nil {
+			next = seat
+		}
+	}
+	if next != nil {
+		h.taken[next.ID] = true
+	}
+	return next
+}
+
+// sellSeats marks a seat sold to its ticket. Both slices are index aligned; a
+// nil seat means the ticket's class is not sold by seat.
+func sellSeats(ctx context.Context, conn gotann.Connection, seats domain.ScheduleSeatRepository, tickets []*domain.Ticket, assigned []*domain.ScheduleSeat) error {
+	for i, seat := range assigned {
+		if seat == nil {
+			continue
+		}
+		seat.Status = enum.SeatSold.String()
+		seat.ClaimSessionID = nil
+		seat.TicketID = &tickets[i].ID
+		if err := seats.Update(ctx, conn, seat); err != nil {
+			return fmt.Errorf("sell seat %s: %w", seat.SeatNumber, err)
+		}
+	}
+	return nil
+}
+
+// releaseTicketSeats puts the seats of cancelled or refunded tickets back on sale.
+func releaseTicketSeats(ctx context.Context, conn gotann.Connection, seats domain.ScheduleSeatRepository, tickets []*domain.Ticket) error {
+	ids := make([]uint, 0, len(tickets))
+	for _, ticket := range tickets {
+		if ticket != nil {
+			ids = append(ids, ticket.ID)
+		}
+	}
+	if err := seats.ReleaseByTicketIDs(ctx, conn, ids); err != nil {
+		return fmt.Errorf("release ticket seats: %w", err)
+	}
+	return nil
+}
+
+// retakeSeats sells seats again to tickets whose seats were given back: the
+// seat each had while it is still free, another of its class otherwise. It
+// returns the tickets that got another seat and fails with ErrSeatUnavailable
+// when a class is full.
+func retakeSeats(ctx context.Context, conn gotann.Connection, seats domain.ScheduleSeatRepository, tickets []*domain.Ticket) ([]*domain.Ticket, error) {
+	var moved []*domain.Ticket
+	for _, ticket := range tickets {
+		if ticket.SeatNumber == nil || onLap(ticket) {
+			continue
+		}
+		available, err := seats.FindAvailable(ctx, conn, ticket.ScheduleID, ticket.ClassID, []string{*ticket.SeatNumber}, 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find available seats: %w", err)
+		}
+		if len(available) == 0 {
+			available, err = seats.FindAvailable(ctx, conn, ticket.ScheduleID, ticket.ClassID, nil, 1)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find available seats: %w", err)
+			}
+			if len(available) == 0 {
+				return nil, fmt.Errorf("schedule %d class %d: %w", ticket.ScheduleID, ticket.ClassID, errs.ErrSeatUnavailable)
+			}
+			ticket.SeatNumber = &available[0].SeatNumber
+			moved = append(moved, ticket)
+		}
+		if err := sellSeats(ctx, conn, seats, []*domain.Ticket{ticket}, available); err != nil {
+			return nil, err
+		}
+	}
+	return moved, nil
+}
+
+// takeSeats finds a free seat on the schedule for every ticket of a seated
+// class, index aligned with tickets. Unseated classes get nil.
+func takeSeats(ctx context.Context, conn gotann.Connection, seats domain.ScheduleSeatRepository, scheduleID uint, seated map[uint]bool, tickets []*domain.Ticket) ([]*domain.ScheduleSeat, error) {
+	byClass := make(map[uint][]int)
+	for i, ticket := range tickets {
+		if seated[ticket.ClassID] && !onLap(ticket) {
+			byClass[ticket.ClassID] = append(byClass[ticket.ClassID], i)
+		}
+	}
+
+	taken := make([]*domain.ScheduleSeat, len(tickets))
+	for classID, indexes := range byClass {
+		available, err := seats.FindAvailable(ctx, conn, scheduleID, classID, nil, len(indexes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to find available seats: %w", err)
+		}
+		if len(available) < len(indexes) {
+			return nil, fmt.Errorf("schedule %d class %d: %w", scheduleID, classID, errs.ErrSeatUnavailable)
+		}
+		for i, index := range indexes {
+			taken[index] = available[i]
+		}
+	}
+	return taken, nil
+}
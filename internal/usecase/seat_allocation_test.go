@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoldSeats(t *testing.T) {
+	t.Parallel()
+	seated := map[legClass]bool{{ScheduleID: 7, ClassID: 1}: true}
+	tests := []struct {
+		name     string
+		requests []seatRequest
+		mock     func(seatRepo *mocks.MockScheduleSeatRepository)
+		err      error
+	}{
+		{
+			name: "selected and auto-assigned",
+			requests: []seatRequest{
+				{ScheduleID: 7, ClassID: 1, Quantity: 3, SeatNumbers: []string{"2A"}},
+				{ScheduleID: 7, ClassID: 2, Quantity: 2},
+			},
+			mock: func(seatRepo *mocks.MockScheduleSeatRepository) {
+				gomock.InOrder(
+					seatRepo.EXPECT().Hold(gomock.Any(), gomock.Any(), uint(7), uint(1), uint(5), []string{"2A"}).Return(int64(1), nil),
+					seatRepo.EXPECT().HoldAny(gomock.Any(), gomock.Any(), uint(7), uint(1), uint(5), 2).Return(int64(2), nil),
+				)
+			},
+			err: nil,
+		},
+		{
+			name:     "selected seat taken",
+			requests: []seatRequest{{ScheduleID: 7, ClassID: 1, Quantity: 1, SeatNumbers: []string{"2A"}}},
+			mock: func(seatRepo *mocks.MockScheduleSeatRepository) {
+				seatRepo.EXPECT().Hold(gomock.Any(), gomock.Any(), uint(7), uint(1), uint(5), []string{"2A"}).Return(int64(0), nil)
+			},
+			err: errs.ErrSeatUnavailable,
+		},
+		{
+			name:     "not enough free seats",
+			requests: []seatRequest{{ScheduleID: 7, ClassID: 1, Quantity: 2}},
+			mock: func(seatRepo *mocks.MockScheduleSeatRepository) {
+				seatRepo.EXPECT().HoldAny(gomock.Any(), gomock.Any(), uint(7), uint(1), uint(5), 2).Return(int64(1), nil)
+			},
+			err: errs.ErrSeatUnavailable,
+		},
+		{
+			name:     "more seats than tickets",
+			requests: []seatRequest{{ScheduleID: 7, ClassID: 1, Quantity: 1, SeatNumbers: []string{"2A", "2B"}}},
+			mock:     func(seatRepo *mocks.MockScheduleSeatRepository) {},
+			err:      errs.ErrBadRequest,
+		},
+		{
+			name:     "seat selected twice",
+			requests: []seatRequest{{ScheduleID: 7, ClassID: 1, Quantity: 1, SeatNumbers: []string{"2A"}}, {ScheduleID: 7, ClassID: 1, Quantity: 1, SeatNumbers: []string{"2A"}}},
+			mock:     func(seatRepo *mocks.MockScheduleSeatRepository) {},
+			err:      errs.ErrBadRequest,
+		},
+		{
+			name:     "selection for class without seat map",
+			requests: []seatRequest{{ScheduleID: 7, ClassID: 2, Quantity: 1, SeatNumbers: []string{"2A"}}},
+			mock:     func(seatRepo *mocks.MockScheduleSeatRepository) {},
+			err:      errs.ErrBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			seatRepo := mocks.NewMockScheduleSeatRepository(ctrl)
+			tc.mock(seatRepo)
+			err := holdSeats(context.Background(), nil, seatRepo, 5, seated, tc.requests)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestHeldSeats_Take(t *testing.T) {
+	t.Parallel()
+	held := newHeldSeats([]*domain.ScheduleSeat{
+		{ID: 1, ScheduleID: 7, ClassID: 1, SeatNumber: "1A"},
+		{ID: 2, ScheduleID: 7, ClassID: 1, SeatNumber: "1B"},
+		{ID: 3, ScheduleID: 7, ClassID: 2, SeatNumber: "1A"},
+		{ID: 4, ScheduleID: 8, ClassID: 1, SeatNumber: "1A"},
+	})
+	requested := "1B"
+	unknown := "9Z"
+
+	require.Equal(t, uint(2), held.take(7, 1, &requested).ID)
+	require.Equal(t, uint(1), held.take(7, 1, &unknown).ID)
+	require.Nil(t, held.take(7, 1, nil))
+	require.Equal(t, uint(3), held.take(7, 2, nil).ID)
+	require.Equal(t, uint(4), held.take(8, 1, nil).ID)
+	require.Nil(t, held.take(7, 4, nil))
+}
+
+func TestEnsureScheduleSeats(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	layoutRepo := mocks.NewMockSeatLayoutRepository(ctrl)
+	seatRepo := mocks.NewMockScheduleSeatRepository(ctrl)
+	schedule := &domain.Schedule{ID: 7, ShipID: 3}
+
+	seatRepo.EXPECT().CountByScheduleIDGroupByClass(gomock.Any(), gomock.Any(), uint(7)).Return(map[uint]int64{2: 10}, nil)
+	layoutRepo.EXPECT().FindByShipID(gomock.Any(), gomock.Any(), uint(3)).Return([]*domain.SeatLayout{
+		{ClassID: 1, Seats: []domain.LayoutSeat{{SeatNumber: "1A"}, {SeatNumber: "1B", IsBlocked: true}}},
+		{ClassID: 2, Seats: []domain.LayoutSeat{{SeatNumber: "1A"}}},
+	}, nil)
+	seatRepo.EXPECT().InsertBulk(gomock.Any(), gomock.Any(), gomock.Len(2)).DoAndReturn(
+		func(_ context.Context, _ interface{}, seats []*domain.ScheduleSeat) error {
+			require.Equal(t, "AVAILABLE", seats[0].Status)
+			require.Equal(t, "BLOCKED", seats[1].Status)
+			return nil
+		})
+
+	seated, err := ensureScheduleSeats(context.Background(), nil, layoutRepo, seatRepo, schedule)
+	require.NoError(t, err)
+	require.Equal(t, map[uint]bool{1: true, 2: true}, seated)
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+)
+
+type SeatLayoutUsecase struct {
+	Transactor             transact.Transactor
+	SeatLayoutRepository   domain.SeatLayoutRepository
+	ScheduleSeatRepository domain.ScheduleSeatRepository
+	ScheduleRepository     domain.ScheduleRepository
+	ShipRepository         domain.ShipRepository
+	ClassRepository        domain.ClassRepository
+}
+
+func NewSeatLayoutUsecase(
+	transactor transact.Transactor,
+	seat_layout_repository domain.SeatLayoutRepository,
+	schedule_seat_repository domain.ScheduleSeatRepository,
+	schedule_repository domain.ScheduleRepository,
+	ship_repository domain.ShipRepository,
+	class_repository domain.ClassRepository,
+) *SeatLayoutUsecase {
+	return &SeatLayoutUsecase{
+		Transactor:             transactor,
+		SeatLayoutRepository:   seat_layout_repository,
+		ScheduleSeatRepository: schedule_seat_repository,
+		ScheduleRepository:     schedule_repository,
+		ShipRepository:         ship_repository,
+		ClassRepository:        class_repository,
+	}
+}
+
+func (uc *SeatLayoutUsecase) CreateSeatLayout(ctx context.Context, e *domain.SeatLayout) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if err := uc.checkShipAndClass(ctx, tx, e.ShipID, e.ClassID); err != nil {
+			return err
+		}
+
+		layout := &domain.SeatLayout{
+			ShipID:  e.ShipID,
+			ClassID: e.ClassID,
+			Rows:    e.Rows,
+			Columns: e.Columns,
+			Seats:   e.Seats,
+		}
+		if err := uc.SeatLayoutRepository.Insert(ctx, tx, layout); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("failed to create seat layout: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *SeatLayoutUsecase) ListSeatLayouts(ctx context.Context, limit, offset int, sort, search string) ([]*domain.SeatLayout, int, error) {
+	var err error
+	var total int64
+	var layouts []*domain.SeatLayout
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		total, err = uc.SeatLayoutRepository.Count(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to count seat layouts: %w", err)
+		}
+		layouts, err = uc.SeatLayoutRepository.FindAll(ctx, tx, limit, offset, sort, search)
+		if err != nil {
+			return fmt.Errorf("failed to get all seat layouts: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to list seat layouts: %w", err)
+	}
+
+	return layouts, int(total), nil
+}
+
+func (uc *SeatLayoutUsecase) GetSeatLayoutByID(ctx context.Context, id uint) (*domain.SeatLayout, error) {
+	var err error
+	var layout *domain.SeatLayout
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		layout, err = uc.SeatLayoutRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get seat layout: %w", err)
+		}
+		if layout == nil {
+			return errs.ErrNotFound
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get seat layout by ID: %w", err)
+	}
+	return layout, nil
+}
+
+// UpdateSeatLayout replaces the layout's seats. Schedules that already have a
+// seat inventory keep the seats they were created with.
+func (uc *SeatLayoutUsecase) UpdateSeatLayout(ctx context.Context, e *domain.SeatLayout) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		layout, err := uc.SeatLayoutRepository.FindByID(ctx, tx, e.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find seat layout: %w", err)
+		}
+		if layout == nil {
+			return errs.ErrNotFound
+		}
+		if err := uc.checkShipAndClass(ctx, tx, e.ShipID, e.ClassID); err != nil {
+			return err
+		}
+
+		layout.ShipID = e.ShipID
+		layout.ClassID = e.ClassID
+		layout.Rows = e.Rows
+		layout.Columns = e.Columns
+		layout.Seats = e.Seats
+		layout.Ship = domain.Ship{}
+		layout.Class = domain.Class{}
+
+		if err := uc.SeatLayoutRepository.Update(ctx, tx, layout); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("failed to update seat layout: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *SeatLayoutUsecase) DeleteSeatLayout(ctx context.Context, id uint) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		layout, err := uc.SeatLayoutRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get seat layout: %w", err)
+		}
+		if layout == nil {
+			return errs.ErrNotFound
+		}
+
+		if err := uc.SeatLayoutRepository.Delete(ctx, tx, layout); err != nil {
+			return fmt.Errorf("failed to delete seat layout: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetScheduleSeatMap returns the live seat inventory of a schedule, creating
+// it from the ship's layouts on first access.
+func (uc *SeatLayoutUsecase) GetScheduleSeatMap(ctx context.Context, scheduleID uint) ([]*domain.ScheduleSeat, error) {
+	var err error
+	var seats []*domain.ScheduleSeat
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		schedule, err := uc.ScheduleRepository.FindByID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if schedule == nil {
+			return errs.ErrNotFound
+		}
+		if _, err := ensureScheduleSeats(ctx, tx, uc.SeatLayoutRepository, uc.ScheduleSeatRepository, schedule); err != nil {
+			return err
+		}
+		seats, err = uc.ScheduleSeatRepository.FindByScheduleID(ctx, tx, scheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule seats: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get schedule seat map: %w", err)
+	}
+	return seats, nil
+}
+
+func (uc *SeatLayoutUsecase) checkShipAndClass(ctx context.Context, conn gotann.Connection, shipID, classID uint) error {
+	ship, err := uc.ShipRepository.FindByID(ctx, conn, shipID)
+	if err != nil {
+		return fmt.Errorf("failed to get ship: %w", err)
+	}
+	if ship == nil {
+		return fmt.Errorf("ship %d: %w", shipID, errs.ErrNotFound)
+	}
+	class, err := uc.ClassRepository.FindByID(ctx, conn, classID)
+	if err != nil {
+		return fmt.Errorf("failed to get class: %w", err)
+	}
+	if class == nil {
+		return fmt.Errorf("class %d: %w", classID, errs.ErrNotFound)
+	}
+	return nil
+}
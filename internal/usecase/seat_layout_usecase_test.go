@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func seatLayoutUsecase(t *testing.T) (*SeatLayoutUsecase, *mocks.MockSeatLayoutRepository, *mocks.MockScheduleSeatRepository, *mocks.MockScheduleRepository, *mocks.MockShipRepository, *mocks.MockClassRepository, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	layoutRepo := mocks.NewMockSeatLayoutRepository(ctrl)
+	seatRepo := mocks.NewMockScheduleSeatRepository(ctrl)
+	scheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	shipRepo := mocks.NewMockShipRepository(ctrl)
+	classRepo := mocks.NewMockClassRepository(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	uc := NewSeatLayoutUsecase(transactor, layoutRepo, seatRepo, scheduleRepo, shipRepo, classRepo)
+	return uc, layoutRepo, seatRepo, scheduleRepo, shipRepo, classRepo, transactor
+}
+
+func TestSeatLayoutUsecase_CreateSeatLayout(t *testing.T) {
+	t.Parallel()
+	uc, layoutRepo, _, _, shipRepo, classRepo, transactor := seatLayoutUsecase(t)
+	tests := []struct {
+		name string
+		mock func()
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+						return fn(nil)
+					},
+				)
+				shipRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Ship{ID: 1}, nil)
+				classRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(2)).Return(&domain.Class{ID: 2}, nil)
+				layoutRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+			err: nil,
+		},
+		{
+			name: "class not found",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+						return fn(nil)
+					},
+				)
+				shipRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Ship{ID: 1}, nil)
+				classRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(2)).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+		{
+			name: "repo error",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.CreateSeatLayout(context.Background(), &domain.SeatLayout{ShipID: 1, ClassID: 2})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSeatLayoutUsecase_GetScheduleSeatMap(t *testing.T) {
+	t.Parallel()
+	uc, layoutRepo, seatRepo, scheduleRepo, _, _, transactor := seatLayoutUsecase(t)
+	tests := []struct {
+		name string
+		mock func()
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+						return fn(nil)
+					},
+				)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(7)).Return(&domain.Schedule{ID: 7, ShipID: 3}, nil)
+				seatRepo.EXPECT().CountByScheduleIDGroupByClass(gomock.Any(), gomock.Any(), uint(7)).Return(map[uint]int64{}, nil)
+				layoutRepo.EXPECT().FindByShipID(gomock.Any(), gomock.Any(), uint(3)).Return(nil, nil)
+				seatRepo.EXPECT().FindByScheduleID(gomock.Any(), gomock.Any(), uint(7)).Return([]*domain.ScheduleSeat{}, nil)
+			},
+			err: nil,
+		},
+		{
+			name: "schedule not found",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+						return fn(nil)
+					},
+				)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(7)).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			_, err := uc.GetScheduleSeatMap(context.Background(), 7)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
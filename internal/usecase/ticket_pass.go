@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/token"
+	"eticket-api/internal/domain"
+	"fmt"
+	"time"
+)
+
+const (
+	// Gates start letting passengers through this long before departure
+	checkInOpensBefore = 6 * time.Hour
+	// A ticket QR outlives the departure by this much when the schedule has no
+	// arrival time
+	ticketPassGrace = 24 * time.Hour
+)
+
+// signTicket signs the QR payload of a ticket. Only tickets of paid bookings
+// get one, and it stays valid until the crossing is over.
+func signTicket(tokens token.TokenUtil, ticket *domain.Ticket) (string, error) {
+	if ticket.Booking.Status != enum.BookingPaid.String() {
+		return "", fmt.Errorf("booking of ticket %s is not paid: %w", ticket.TicketCode, errs.ErrConflict)
+	}
+	payload, err := tokens.GenerateTicketToken(ticket, ticketPassExpiry(ticket))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign ticket: %w", err)
+	}
+	return payload, nil
+}
+
+// ticketPassExpiry is when the QR of a ticket stops being valid: once the
+// crossing is over.
+func ticketPassExpiry(ticket *domain.Ticket) time.Time {
+	return crossingEnd(&ticket.Schedule)
+}
+
+// crossingEnd is when a departure has arrived, or a grace period after it
+// left when the schedule has no arrival time.
+func crossingEnd(schedule *domain.Schedule) time.Time {
+	end := schedule.ArrivalDatetime
+	if !end.After(schedule.DepartureDatetime) {
+		end = schedule.DepartureDatetime.Add(ticketPassGrace)
+	}
+	return end
+}
+
+// checkTicketPass checks a scanned ticket at the gate: the QR must still be
+// for the ticket's departure, at the gate's departure if it has one, within
+// the check-in window and for a paid booking.
+func checkTicketPass(ticket *domain.Ticket, claims *token.TicketClaims, scheduleID *uint, now time.Time) error {
+	if claims.ScheduleID != ticket.ScheduleID {
+		return fmt.Errorf("ticket %s was moved to another departure, its QR was reissued: %w", ticket.TicketCode, errs.ErrBadRequest)
+	}
+	if scheduleID != nil && *scheduleID != ticket.ScheduleID {
+		return fmt.Errorf("ticket %s is for another departure: %w", ticket.TicketCode, errs.ErrBadRequest)
+	}
+	departure := ticket.Schedule.DepartureDatetime
+	if now.Before(departure.Add(-checkInOpensBefore)) {
+		return fmt.Errorf("check-in opens at %s: %w", departure.Add(-checkInOpensBefore).Format(time.RFC3339), errs.ErrBadRequest)
+	}
+	if now.After(departure) {
+		return fmt.Errorf("departure at %s has left: %w", departure.Format(time.RFC3339), errs.ErrBadRequest)
+	}
+	if ticket.Booking.Status != enum.BookingPaid.String() {
+		return fmt.Errorf("booking of ticket %s is %s: %w", ticket.TicketCode, ticket.Booking.Status, errs.ErrBadRequest)
+	}
+	return nil
+}
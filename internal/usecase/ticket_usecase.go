@@ -4,19 +4,25 @@ import (
 	"context"
 	enum "eticket-api/internal/common/enums"
 	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/token"
 	"eticket-api/internal/common/transact"
 	"eticket-api/internal/common/utils"
 	"eticket-api/internal/domain"
 	"eticket-api/pkg/gotann"
 	"fmt"
+	"time"
 )
 
 type TicketUsecase struct {
-	Transactor         transact.Transactor
-	TicketRepository   domain.TicketRepository
-	BookingRepository  domain.BookingRepository
-	ScheduleRepository domain.ScheduleRepository
-	QuotaRepository    domain.QuotaRepository
+	Transactor              transact.Transactor
+	TicketRepository        domain.TicketRepository
+	BookingRepository       domain.BookingRepository
+	ScheduleRepository      domain.ScheduleRepository
+	QuotaRepository         domain.QuotaRepository
+	SeatLayoutRepository    domain.SeatLayoutRepository
+	ScheduleSeatRepository  domain.ScheduleSeatRepository
+	TokenUtil               token.TokenUtil
+	BoardingEventRepository domain.BoardingEventRepository
 }
 
 func NewTicketUsecase(
@@ -25,13 +31,21 @@ func NewTicketUsecase(
 	booking_repository domain.BookingRepository,
 	schedule_repository domain.ScheduleRepository,
 	quota_reposiotry domain.QuotaRepository,
+	seat_layout_repository domain.SeatLayoutRepository,
+	schedule_seat_repository domain.ScheduleSeatRepository,
+	token_util token.TokenUtil,
+	boarding_event_repository domain.BoardingEventRepository,
 ) *TicketUsecase {
 	return &TicketUsecase{
-		Transactor:         transactor,
-		TicketRepository:   ticket_repository,
-		BookingRepository:  booking_repository,
-		ScheduleRepository: schedule_repository,
-		QuotaRepository:    quota_reposiotry,
+		Transactor:              transactor,
+		TicketRepository:        ticket_repository,
+		BookingRepository:       booking_repository,
+		ScheduleRepository:      schedule_repository,
+		QuotaRepository:         quota_reposiotry,
+		SeatLayoutRepository:    seat_layout_repository,
+		ScheduleSeatRepository:  schedule_seat_repository,
+		TokenUtil:               token_util,
+		BoardingEventRepository: boarding_event_repository,
 	}
 }
 func (uc *TicketUsecase) CreateTicket(ctx context.Context, e *domain.Ticket) error {
@@ -53,17 +67,61 @@ func (uc *TicketUsecase) CreateTicket(ctx context.Context, e *domain.Ticket) err
 			return errs.ErrNotFound
 		}
 
-		if e.SeatNumber == nil || *e.SeatNumber == "" {
-			seat := fmt.Sprintf("%s%d", quota.Class.ClassAlias, quota.Capacity-quota.Quota+1)
-			e.SeatNumber = &seat
+		seated, err := ensureScheduleSeats(ctx, tx, uc.SeatLayoutRepository, uc.ScheduleSeatRepository, schedule)
+		if err != nil {
+			return err
+		}
+
+		e.Type = quota.Class.Type
+		if e.Type == "vehicle" {
+			e.QuotaUnits = laneMeters(quota)
+		}
+		if err := uc.checkDriverTicket(ctx, tx, e); err != nil {
+			return err
+		}
+		e.FareCategory, e.Price, err = ticketFare(quota, e)
+		if err != nil {
+			return err
+		}
+		if onLap(e) && e.BookingID != nil {
+			booked, err := uc.TicketRepository.FindByBookingID(ctx, tx, *e.BookingID)
+			if err != nil {
+				return fmt.Errorf("failed to retrieve booking tickets: %w", err)
+			}
+			if err := checkLaps(append(booked, e)); err != nil {
+				return err
+			}
+		}
+
+		// Classes with a seat map take the requested seat or the first free one
+		// instead of a number derived from the remaining quota. Infants on a
+		// lap get neither.
+		var seat *domain.ScheduleSeat
+		if onLap(e) {
+			e.SeatNumber = nil
+		} else if seated[e.ClassID] {
+			var numbers []string
+			if e.SeatNumber != nil && *e.SeatNumber != "" {
+				numbers = []string{*e.SeatNumber}
+			}
+			available, err := uc.ScheduleSeatRepository.FindAvailable(ctx, tx, e.ScheduleID, e.ClassID, numbers, 1)
+			if err != nil {
+				return fmt.Errorf("failed to find available seat: %w", err)
+			}
+			if len(available) == 0 {
+				return errs.ErrSeatUnavailable
+			}
+			seat = available[0]
+			e.SeatNumber = &seat.SeatNumber
 		}
 
 		ticket := &domain.Ticket{
 			TicketCode:      utils.GenerateTicketReferenceID(), // Unique ticket code
 			ScheduleID:      e.ScheduleID,
 			ClassID:         e.ClassID,
-			Type:            quota.Class.Type,
-			Price:           quota.Price,
+			Type:            e.Type,
+			Price:           e.Price,
+			FareCategory:    e.FareCategory,
 			Address:         e.Address,
 			PassengerName:   e.PassengerName,
 			PassengerAge:    e.PassengerAge,
@@ -72,6 +130,11 @@ func (uc *TicketUsecase) CreateTicket(ctx context.Context, e *domain.Ticket) err
 			IDNumber:        e.IDNumber,
 			SeatNumber:      e.SeatNumber,
 			LicensePlate:    e.LicensePlate,
+			VehicleType:     e.VehicleType,
+			VehicleBrand:    e.VehicleBrand,
+			VehicleLength:   e.VehicleLength,
+			DriverTicketID:  e.DriverTicketID,
+			QuotaUnits:      e.QuotaUnits,
 			IsCheckedIn:     e.IsCheckedIn, // Default value
 		}
 
@@ -81,13 +144,12 @@ func (uc *TicketUsecase) CreateTicket(ctx context.Context, e *domain.Ticket) err
 			}
 			return fmt.Errorf("failed to create ticket: %w", err)
 		}
-
-		quota.Quota -= 1
-
-		if err := uc.QuotaRepository.Update(ctx, tx, quota); err != nil {
-			return fmt.Errorf("failed to update quota: %w", err)
+		if err := sellSeats(ctx, tx, uc.ScheduleSeatRepository, []*domain.Ticket{ticket}, []*domain.ScheduleSeat{seat}); err != nil {
+			return err
 		}
-		return nil
+
+		// Units held by open claim sessions are not for sale here
+		return sellTickets(ctx, tx, uc.QuotaRepository, []*domain.Ticket{ticket})
 	})
 }
 
@@ -172,7 +234,13 @@ func (uc *TicketUsecase) UpdateTicket(ctx context.Context, e *domain.Ticket) err
 		ticket.IDType = e.IDType
 		ticket.IDNumber = e.IDNumber
 		ticket.LicensePlate = e.LicensePlate
-		ticket.IsCheckedIn = e.IsCheckedIn
+		ticket.VehicleType = e.VehicleType
+		ticket.VehicleBrand = e.VehicleBrand
+		ticket.VehicleLength = e.VehicleLength
+		ticket.DriverTicketID = e.DriverTicketID
+		if err := uc.checkDriverTicket(ctx, tx, ticket); err != nil {
+			return err
+		}
 
 		if err := uc.TicketRepository.Update(ctx, tx, ticket); err != nil {
 			return fmt.Errorf("failed to update ticket: %w", err)
@@ -192,6 +260,9 @@ func (uc *TicketUsecase) DeleteTicket(ctx context.Context, id uint) error {
 			return errs.ErrNotFound
 		}
 
+		if err := releaseTicketSeats(ctx, tx, uc.ScheduleSeatRepository, []*domain.Ticket{ticket}); err != nil {
+			return err
+		}
 		if err := uc.TicketRepository.Delete(ctx, tx, ticket); err != nil {
 			return fmt.Errorf("failed to delete ticket: %w", err)
 		}
@@ -199,7 +270,9 @@ func (uc *TicketUsecase) DeleteTicket(ctx context.Context, id uint) error {
 	})
 }
 
-func (uc *TicketUsecase) CheckIn(ctx context.Context, id uint) error {
+// CheckIn checks in a ticket of a paid booking by its ID, for gates without
+// a scanner.
+func (uc *TicketUsecase) CheckIn(ctx context.Context, id uint, actor *BoardingActor) error {
 	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
 		ticket, err := uc.TicketRepository.FindByID(ctx, tx, id)
 		if err != nil {
@@ -208,28 +281,86 @@ func (uc *TicketUsecase) CheckIn(ctx context.Context, id uint) error {
 		if ticket == nil {
 			return errs.ErrNotFound
 		}
+		if ticket.Booking.Status != enum.BookingPaid.String() {
+			return fmt.Errorf("booking is not paid, cannot check in ticket: %w", errs.ErrBadRequest)
+		}
+
+		_, err = moveTicket(ctx, tx, uc.TicketRepository, uc.BoardingEventRepository, ticket, enum.BoardingCheckedIn.String(), actor, time.Now())
+		return err
+	})
+}
 
-		booking, err := uc.BookingRepository.FindByID(ctx, tx, *ticket.BookingID)
+// GetTicketPass returns a ticket with the signed payload of its QR code.
+func (uc *TicketUsecase) GetTicketPass(ctx context.Context, id uint) (*domain.Ticket, string, error) {
+	var err error
+	var ticket *domain.Ticket
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		ticket, err = uc.TicketRepository.FindByID(ctx, tx, id)
 		if err != nil {
-			return fmt.Errorf("failed to find booking: %w", err)
+			return fmt.Errorf("failed to get ticket: %w", err)
 		}
-		if booking == nil {
+		if ticket == nil {
 			return errs.ErrNotFound
 		}
-		if booking.Status != enum.BookingPaid.String() {
-			return fmt.Errorf("booking is not paid, cannot check in ticket")
-		}
+		return nil
+	}); err != nil {
+		return nil, "", fmt.Errorf("failed to get ticket pass: %w", err)
+	}
 
-		ticket.IsCheckedIn = true
+	payload, err := signTicket(uc.TokenUtil, ticket)
+	if err != nil {
+		return nil, "", err
+	}
+	return ticket, payload, nil
+}
 
-		if err := uc.TicketRepository.Update(ctx, tx, ticket); err != nil {
-			return fmt.Errorf("failed to update ticket: %w", err)
-		}
+// VerifyTicket checks in the ticket of a scanned QR code. Gates working a
+// single departure pass its scheduleID to turn away tickets for others.
+func (uc *TicketUsecase) VerifyTicket(ctx context.Context, payload string, scheduleID *uint, actor *BoardingActor) (*domain.Ticket, error) {
+	claims, err := uc.TokenUtil.ValidateTicketToken(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket QR, %v: %w", err, errs.ErrBadRequest)
+	}
 
-		if err := uc.BookingRepository.Update(ctx, tx, booking); err != nil {
-			return fmt.Errorf("failed to update booking: %w", err)
+	var ticket *domain.Ticket
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		ticket, err = uc.TicketRepository.FindByTicketCode(ctx, tx, claims.TicketCode)
+		if err != nil {
+			return fmt.Errorf("failed to get ticket: %w", err)
 		}
+		if ticket == nil {
+			return errs.ErrNotFound
+		}
+		now := time.Now()
+		if err := checkTicketPass(ticket, claims, scheduleID, now); err != nil {
+			return err
+		}
+		_, err = moveTicket(ctx, tx, uc.TicketRepository, uc.BoardingEventRepository, ticket, enum.BoardingCheckedIn.String(), actor, now)
+		return err
+	}); err != nil {
+		return nil, fmt.Errorf("failed to verify ticket: %w", err)
+	}
+	return ticket, nil
+}
 
+// checkDriverTicket makes sure the driver of a vehicle ticket is an adult
+// passenger on the same departure. Tickets without a driver are left alone.
+func (uc *TicketUsecase) checkDriverTicket(ctx context.Context, conn gotann.Connection, ticket *domain.Ticket) error {
+	if ticket.DriverTicketID == nil {
 		return nil
-	})
+	}
+	if ticket.Type != "vehicle" {
+		return fmt.Errorf("only vehicle tickets have a driver: %w", errs.ErrBadRequest)
+	}
+	driver, err := uc.TicketRepository.FindByID(ctx, conn, *ticket.DriverTicketID)
+	if err != nil {
+		return fmt.Errorf("failed to get driver ticket: %w", err)
+	}
+	if driver == nil {
+		return fmt.Errorf("driver ticket %d: %w", *ticket.DriverTicketID, errs.ErrNotFound)
+	}
+	if driver.Type != "passenger" || onLap(driver) || driver.ScheduleID != ticket.ScheduleID || driver.PassengerAge < minDriverAge {
+		return fmt.Errorf("ticket %d cannot drive this vehicle: %w", driver.ID, errs.ErrBadRequest)
+	}
+	return nil
 }
@@ -2,30 +2,39 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/token"
 	"eticket-api/internal/domain"
 	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 )
 
-func ticketUsecase(t *testing.T) (*TicketUsecase, *mocks.MockTicketRepository, *mocks.MockScheduleRepository, *mocks.MockQuotaRepository, *mocks.MockTransactor) {
+func ticketUsecase(t *testing.T) (*TicketUsecase, *mocks.MockTicketRepository, *mocks.MockScheduleRepository, *mocks.MockQuotaRepository, *mocks.MockTokenUtil, *mocks.MockBoardingEventRepository, *mocks.MockTransactor) {
 	t.Helper()
 	ctrl := gomock.NewController(t)
 	ticketRepo := mocks.NewMockTicketRepository(ctrl)
 	bookingRepo := mocks.NewMockBookingRepository(ctrl)
 	scheduleRepo := mocks.NewMockScheduleRepository(ctrl)
 	quotaRepo := mocks.NewMockQuotaRepository(ctrl)
+	seatLayoutRepo := mocks.NewMockSeatLayoutRepository(ctrl)
+	scheduleSeatRepo := mocks.NewMockScheduleSeatRepository(ctrl)
+	tokenUtil := mocks.NewMockTokenUtil(ctrl)
+	boardingEventRepo := mocks.NewMockBoardingEventRepository(ctrl)
 	transactor := mocks.NewMockTransactor(ctrl)
-	uc := NewTicketUsecase(transactor, ticketRepo, bookingRepo, scheduleRepo, quotaRepo)
-	return uc, ticketRepo, scheduleRepo, quotaRepo, transactor
+	uc := NewTicketUsecase(transactor, ticketRepo, bookingRepo, scheduleRepo, quotaRepo, seatLayoutRepo, scheduleSeatRepo, tokenUtil, boardingEventRepo)
+	return uc, ticketRepo, scheduleRepo, quotaRepo, tokenUtil, boardingEventRepo, transactor
 }
 
 func TestTicketUsecase_CreateTicket(t *testing.T) {
 	t.Parallel()
-	uc, _, _, _, transactor := ticketUsecase(t)
+	uc, _, _, _, _, _, transactor := ticketUsecase(t)
 	tests := []struct {
 		name string
 		mock func()
@@ -61,7 +70,7 @@ func TestTicketUsecase_CreateTicket(t *testing.T) {
 
 func TestTicketUsecase_CheckIn(t *testing.T) {
 	t.Parallel()
-	uc, _, _, _, transactor := ticketUsecase(t)
+	uc, _, _, _, _, _, transactor := ticketUsecase(t)
 	tests := []struct {
 		name string
 		mock func()
@@ -85,7 +94,135 @@ func TestTicketUsecase_CheckIn(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mock()
-			err := uc.CheckIn(context.Background(), 1)
+			err := uc.CheckIn(context.Background(), 1, &BoardingActor{})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTicketUsecase_VerifyTicket(t *testing.T) {
+	t.Parallel()
+	uc, ticketRepo, _, _, tokenUtil, boardingEventRepo, transactor := ticketUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	otherSchedule := uint(2)
+	checkedInAt := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	ticket := func(departsIn time.Duration, status string, checkedIn bool) *domain.Ticket {
+		ticket := &domain.Ticket{
+			ID:             1,
+			ScheduleID:     1,
+			TicketCode:     "T-1",
+			BoardingStatus: "ISSUED",
+			Schedule:       domain.Schedule{DepartureDatetime: time.Now().Add(departsIn)},
+			Booking:        domain.Booking{Status: status},
+		}
+		if checkedIn {
+			ticket.BoardingStatus = "CHECKED_IN"
+			ticket.IsCheckedIn = true
+			ticket.CheckedInAt = &checkedInAt
+		}
+		return ticket
+	}
+	claims := &token.TicketClaims{TicketCode: "T-1", ScheduleID: 1}
+	tests := []struct {
+		name       string
+		scheduleID *uint
+		mock       func()
+		err        error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				tokenUtil.EXPECT().ValidateTicketToken("qr").Return(claims, nil)
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(ticket(time.Hour, "PAID", false), nil)
+				ticketRepo.EXPECT().UpdateBoarding(gomock.Any(), gomock.Any(), gomock.Any(), "ISSUED").DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, ticket *domain.Ticket, from string) (int64, error) {
+						require.Equal(t, "CHECKED_IN", ticket.BoardingStatus)
+						require.True(t, ticket.IsCheckedIn)
+						require.NotNil(t, ticket.CheckedInAt)
+						return 1, nil
+					})
+				boardingEventRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, event *domain.BoardingEvent) error {
+						require.Equal(t, "ISSUED", event.FromStatus)
+						require.Equal(t, "CHECKED_IN", event.ToStatus)
+						require.Equal(t, "A", event.Gate)
+						require.Equal(t, uint(7), *event.UserID)
+						return nil
+					})
+			},
+		},
+		{
+			name: "invalid signature",
+			mock: func() {
+				tokenUtil.EXPECT().ValidateTicketToken("qr").Return(nil, errors.New("invalid token signature"))
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name: "unknown ticket",
+			mock: func() {
+				tokenUtil.EXPECT().ValidateTicketToken("qr").Return(claims, nil)
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+		{
+			name:       "other departure",
+			scheduleID: &otherSchedule,
+			mock: func() {
+				tokenUtil.EXPECT().ValidateTicketToken("qr").Return(claims, nil)
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(ticket(time.Hour, "PAID", false), nil)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name: "check-in not open",
+			mock: func() {
+				tokenUtil.EXPECT().ValidateTicketToken("qr").Return(claims, nil)
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(ticket(48*time.Hour, "PAID", false), nil)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name: "departed",
+			mock: func() {
+				tokenUtil.EXPECT().ValidateTicketToken("qr").Return(claims, nil)
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(ticket(-time.Hour, "PAID", false), nil)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name: "refunded booking",
+			mock: func() {
+				tokenUtil.EXPECT().ValidateTicketToken("qr").Return(claims, nil)
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(ticket(time.Hour, "REFUNDED", false), nil)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name: "already checked in",
+			mock: func() {
+				tokenUtil.EXPECT().ValidateTicketToken("qr").Return(claims, nil)
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				ticketRepo.EXPECT().FindByTicketCode(gomock.Any(), gomock.Any(), "T-1").Return(ticket(time.Hour, "PAID", true), nil)
+			},
+			err: errs.ErrConflict,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			_, err := uc.VerifyTicket(context.Background(), "qr", tc.scheduleID, &BoardingActor{UserID: 7, Gate: "A"})
 			if tc.err != nil {
 				require.ErrorIs(t, err, tc.err)
 			} else {
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/model"
+	"fmt"
+)
+
+// minDriverAge is the youngest a passenger can be to drive a vehicle on board.
+const minDriverAge = 17
+
+// laneMeters returns the deck length one vehicle of the quota's class takes
+// when its quota is counted in lane meters, or zero when it is counted in
+// units.
+func laneMeters(quota *domain.Quota) int {
+	if quota.Class.VehicleCategory == nil {
+		return 0
+	}
+	return quota.Class.VehicleCategory.LaneMeters
+}
+
+// ticketUnits returns the quota a ticket took.
+func ticketUnits(ticket *domain.Ticket) int {
+	if ticket.QuotaUnits > 0 {
+		return ticket.QuotaUnits
+	}
+	return 1
+}
+
+// checkDriverSeats makes sure every leg of a claim books at least as many
+// passengers as vehicles, since each vehicle needs its driver on board.
+func checkDriverSeats(items []domain.ClaimItem, quotaByLeg map[legClass]*domain.Quota) error {
+	passengers := make(map[uint]int)
+	vehicles := make(map[uint]int)
+	for _, item := range items {
+		quota := quotaByLeg[legClass{ScheduleID: item.ScheduleID, ClassID: item.ClassID}]
+		if quota == nil {
+			continue
+		}
+		switch quota.Class.Type {
+		case "passenger":
+			passengers[item.ScheduleID] += item.Quantity
+		case "vehicle":
+			vehicles[item.ScheduleID] += item.Quantity
+		}
+	}
+	for leg, count := range vehicles {
+		if count > passengers[leg] {
+			return fmt.Errorf("%d vehicles but %d passengers on schedule %d, every vehicle needs a driver: %w", count, passengers[leg], leg, errs.ErrBadRequest)
+		}
+	}
+	return nil
+}
+
+// checkVehicle validates the vehicle data of an entry against the golongan of
+// its class, when the class has one.
+func checkVehicle(quota *domain.Quota, data model.TESTClaimSessionTicketDataEntry) error {
+	if data.LicensePlate == nil || *data.LicensePlate == "" {
+		return fmt.Errorf("missing license plate for vehicle class %d: %w", quota.ClassID, errs.ErrBadRequest)
+	}
+	if data.VehicleType == nil || !knownVehicleType(*data.VehicleType) {
+		return fmt.Errorf("missing or unknown vehicle type for vehicle class %d: %w", quota.ClassID, errs.ErrBadRequest)
+	}
+	if data.VehicleBrand == nil || *data.VehicleBrand == "" {
+		return fmt.Errorf("missing vehicle brand for vehicle class %d: %w", quota.ClassID, errs.ErrBadRequest)
+	}
+	if data.VehicleLength == nil || *data.VehicleLength <= 0 {
+		return fmt.Errorf("missing vehicle length for vehicle class %d: %w", quota.ClassID, errs.ErrBadRequest)
+	}
+	if data.DriverIDNumber == "" {
+		return fmt.Errorf("missing driver for vehicle %s: %w", *data.LicensePlate, errs.ErrBadRequest)
+	}
+
+	category := quota.Class.VehicleCategory
+	if category == nil {
+		return nil
+	}
+	if *data.VehicleType != category.VehicleType {
+		return fmt.Errorf("vehicle %s is a %s, golongan %s is for %s: %w", *data.LicensePlate, *data.VehicleType, category.Golongan, category.VehicleType, errs.ErrBadRequest)
+	}
+	length := *data.VehicleLength
+	if length < category.MinLength || (category.MaxLength != nil && length > *category.MaxLength) {
+		return fmt.Errorf("vehicle %s of %.2f m does not fit golongan %s: %w", *data.LicensePlate, length, category.Golongan, errs.ErrBadRequest)
+	}
+	return nil
+}
+
+// driverLink ties a vehicle ticket to the passenger ticket of its driver.
+type driverLink struct {
+	Vehicle *domain.Ticket
+	Driver  *domain.Ticket
+}
+
+// matchDrivers finds the driver of every vehicle ticket among the passenger
+// tickets on the same leg by ID number. driverIDs runs parallel to tickets
+// and holds the driver's ID number for vehicles. A driver must be old enough
+// to drive and drives one vehicle only.
+func matchDrivers(tickets []*domain.Ticket, driverIDs []string) ([]driverLink, error) {
+	type legPassenger struct {
+		ScheduleID uint
+		IDNumber   string
+	}
+	passengers := make(map[legPassenger]*domain.Ticket)
+	for _, ticket := range tickets {
+		if ticket.Type != "passenger" || onLap(ticket) || ticket.IDNumber == nil || *ticket.IDNumber == "" {
+			continue
+		}
+		passengers[legPassenger{ScheduleID: ticket.ScheduleID, IDNumber: *ticket.IDNumber}] = ticket
+	}
+
+	var links []driverLink
+	driving := make(map[*domain.Ticket]bool)
+	for i, ticket := range tickets {
+		if ticket.Type != "vehicle" {
+			continue
+		}
+		driver := passengers[legPassenger{ScheduleID: ticket.ScheduleID, IDNumber: driverIDs[i]}]
+		if driver == nil {
+			return nil, fmt.Errorf("driver %s of vehicle %s is not a passenger on the same departure: %w", driverIDs[i], vehiclePlate(ticket), errs.ErrBadRequest)
+		}
+		if driver.PassengerAge < minDriverAge {
+			return nil, fmt.Errorf("driver %s of vehicle %s is under %d: %w", driverIDs[i], vehiclePlate(ticket), minDriverAge, errs.ErrBadRequest)
+		}
+		if driving[driver] {
+			return nil, fmt.Errorf("driver %s already drives another vehicle: %w", driverIDs[i], errs.ErrBadRequest)
+		}
+		driving[driver] = true
+		links = append(links, driverLink{Vehicle: ticket, Driver: driver})
+	}
+	return links, nil
+}
+
+func vehiclePlate(ticket *domain.Ticket) string {
+	if ticket.LicensePlate == nil {
+		return ""
+	}
+	return *ticket.LicensePlate
+}
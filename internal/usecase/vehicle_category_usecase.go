@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"strings"
+)
+
+type VehicleCategoryUsecase struct {
+	Transactor                transact.Transactor
+	VehicleCategoryRepository domain.VehicleCategoryRepository
+}
+
+func NewVehicleCategoryUsecase(
+	transactor transact.Transactor,
+	vehicle_category_repository domain.VehicleCategoryRepository,
+) *VehicleCategoryUsecase {
+	return &VehicleCategoryUsecase{
+		Transactor:                transactor,
+		VehicleCategoryRepository: vehicle_category_repository,
+	}
+}
+
+func (uc *VehicleCategoryUsecase) CreateVehicleCategory(ctx context.Context, e *domain.VehicleCategory) error {
+	if err := checkVehicleCategory(e); err != nil {
+		return err
+	}
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		category := &domain.VehicleCategory{
+			Golongan:    e.Golongan,
+			Name:        e.Name,
+			VehicleType: e.VehicleType,
+			MinLength:   e.MinLength,
+			MaxLength:   e.MaxLength,
+			LaneMeters:  e.LaneMeters,
+		}
+		if err := uc.VehicleCategoryRepository.Insert(ctx, tx, category); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("failed to create vehicle category: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *VehicleCategoryUsecase) ListVehicleCategories(ctx context.Context, limit, offset int, sort, search string) ([]*domain.VehicleCategory, int, error) {
+	var err error
+	var total int64
+	var categories []*domain.VehicleCategory
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		total, err = uc.VehicleCategoryRepository.Count(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to count vehicle categories: %w", err)
+		}
+		categories, err = uc.VehicleCategoryRepository.FindAll(ctx, tx, limit, offset, sort, search)
+		if err != nil {
+			return fmt.Errorf("failed to get all vehicle categories: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to list vehicle categories: %w", err)
+	}
+
+	return categories, int(total), nil
+}
+
+func (uc *VehicleCategoryUsecase) GetVehicleCategoryByID(ctx context.Context, id uint) (*domain.VehicleCategory, error) {
+	var err error
+	var category *domain.VehicleCategory
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		category, err = uc.VehicleCategoryRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get vehicle category: %w", err)
+		}
+		if category == nil {
+			return errs.ErrNotFound
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get vehicle category by ID: %w", err)
+	}
+	return category, nil
+}
+
+// UpdateVehicleCategory changes the golongan's rules. Lane meters apply to
+// claims made from now on; tickets already sold keep the quota they took.
+func (uc *VehicleCategoryUsecase) UpdateVehicleCategory(ctx context.Context, e *domain.VehicleCategory) error {
+	if err := checkVehicleCategory(e); err != nil {
+		return err
+	}
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		category, err := uc.VehicleCategoryRepository.FindByID(ctx, tx, e.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find vehicle category: %w", err)
+		}
+		if category == nil {
+			return errs.ErrNotFound
+		}
+
+		category.Golongan = e.Golongan
+		category.Name = e.Name
+		category.VehicleType = e.VehicleType
+		category.MinLength = e.MinLength
+		category.MaxLength = e.MaxLength
+		category.LaneMeters = e.LaneMeters
+
+		if err := uc.VehicleCategoryRepository.Update(ctx, tx, category); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("failed to update vehicle category: %w", err)
+		}
+		return nil
+	})
+}
+
+func (uc *VehicleCategoryUsecase) DeleteVehicleCategory(ctx context.Context, id uint) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		category, err := uc.VehicleCategoryRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get vehicle category: %w", err)
+		}
+		if category == nil {
+			return errs.ErrNotFound
+		}
+
+		if err := uc.VehicleCategoryRepository.Delete(ctx, tx, category); err != nil {
+			return fmt.Errorf("failed to delete vehicle category: %w", err)
+		}
+		return nil
+	})
+}
+
+// checkVehicleCategory normalises the golongan and validates the vehicle type
+// and length range.
+func checkVehicleCategory(category *domain.VehicleCategory) error {
+	category.Golongan = strings.ToUpper(strings.TrimSpace(category.Golongan))
+	if category.Golongan == "" {
+		return fmt.Errorf("golongan is empty: %w", errs.ErrBadRequest)
+	}
+	if !knownVehicleType(category.VehicleType) {
+		return fmt.Errorf("unknown vehicle type %s: %w", category.VehicleType, errs.ErrBadRequest)
+	}
+	if category.MinLength < 0 || (category.MaxLength != nil && *category.MaxLength < category.MinLength) {
+		return fmt.Errorf("invalid length range: %w", errs.ErrBadRequest)
+	}
+	if category.LaneMeters < 0 {
+		return fmt.Errorf("lane meters must not be negative: %w", errs.ErrBadRequest)
+	}
+	return nil
+}
+
+func knownVehicleType(vehicleType string) bool {
+	switch vehicleType {
+	case enum.VehicleBicycle.String(),
+		enum.VehicleMotorbike.String(),
+		enum.VehicleCar.String(),
+		enum.VehicleBus.String(),
+		enum.VehicleTruck.String():
+		return true
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func vehicleCategoryUsecase(t *testing.T) (*VehicleCategoryUsecase, *mocks.MockVehicleCategoryRepository, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockVehicleCategoryRepository(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	uc := NewVehicleCategoryUsecase(transactor, repo)
+	return uc, repo, transactor
+}
+
+func TestVehicleCategoryUsecase_CreateVehicleCategory(t *testing.T) {
+	t.Parallel()
+	uc, repo, transactor := vehicleCategoryUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	five, three := 5.0, 3.0
+	tests := []struct {
+		name     string
+		category *domain.VehicleCategory
+		mock     func()
+		err      error
+	}{
+		{
+			name:     "success",
+			category: &domain.VehicleCategory{Golongan: " iva ", Name: "Mobil penumpang", VehicleType: "CAR", MaxLength: &five, LaneMeters: 5},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				repo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, category *domain.VehicleCategory) error {
+						require.Equal(t, "IVA", category.Golongan)
+						return nil
+					},
+				)
+			},
+		},
+		{
+			name:     "unknown vehicle type",
+			category: &domain.VehicleCategory{Golongan: "IX", VehicleType: "TRAIN"},
+			mock:     func() {},
+			err:      errs.ErrBadRequest,
+		},
+		{
+			name:     "max below min length",
+			category: &domain.VehicleCategory{Golongan: "IVA", VehicleType: "CAR", MinLength: 4, MaxLength: &three},
+			mock:     func() {},
+			err:      errs.ErrBadRequest,
+		},
+		{
+			name:     "repo error",
+			category: &domain.VehicleCategory{Golongan: "II", VehicleType: "MOTORBIKE"},
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				repo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).Return(errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.CreateVehicleCategory(context.Background(), tc.category)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestVehicleCategoryUsecase_UpdateVehicleCategory(t *testing.T) {
+	t.Parallel()
+	uc, repo, transactor := vehicleCategoryUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error { return fn(nil) }
+	tests := []struct {
+		name string
+		mock func()
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.VehicleCategory{ID: 1}, nil)
+				repo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, category *domain.VehicleCategory) error {
+						require.Equal(t, 12, category.LaneMeters)
+						return nil
+					},
+				)
+			},
+		},
+		{
+			name: "not found",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				repo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.UpdateVehicleCategory(context.Background(), &domain.VehicleCategory{ID: 1, Golongan: "VII", VehicleType: "TRUCK", LaneMeters: 12})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
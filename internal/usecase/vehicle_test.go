@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"testing"
+
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/model"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckVehicle(t *testing.T) {
+	t.Parallel()
+	plate, car, truck, brand := "B 1234 XY", "CAR", "TRUCK", "Toyota"
+	four, five, twelve := 4.0, 5.0, 12.0
+	quota := &domain.Quota{
+		ClassID: 3,
+		Class: domain.Class{
+			Type:            "vehicle",
+			VehicleCategory: &domain.VehicleCategory{Golongan: "IVA", VehicleType: car, MaxLength: &five},
+		},
+	}
+	entry := func(vehicleType *string, length *float64, driver string) model.TESTClaimSessionTicketDataEntry {
+		return model.TESTClaimSessionTicketDataEntry{
+			LicensePlate:   &plate,
+			VehicleType:    vehicleType,
+			VehicleBrand:   &brand,
+			VehicleLength:  length,
+			DriverIDNumber: driver,
+		}
+	}
+	tests := []struct {
+		name  string
+		quota *domain.Quota
+		data  model.TESTClaimSessionTicketDataEntry
+		err   error
+	}{
+		{name: "fits golongan", quota: quota, data: entry(&car, &four, "3201")},
+		{name: "on the upper bound", quota: quota, data: entry(&car, &five, "3201")},
+		{name: "class without golongan", quota: &domain.Quota{Class: domain.Class{Type: "vehicle"}}, data: entry(&truck, &twelve, "3201")},
+		{name: "too long", quota: quota, data: entry(&car, &twelve, "3201"), err: errs.ErrBadRequest},
+		{name: "wrong vehicle type", quota: quota, data: entry(&truck, &four, "3201"), err: errs.ErrBadRequest},
+		{name: "missing length", quota: quota, data: entry(&car, nil, "3201"), err: errs.ErrBadRequest},
+		{name: "missing driver", quota: quota, data: entry(&car, &four, ""), err: errs.ErrBadRequest},
+		{name: "missing plate", quota: quota, data: model.TESTClaimSessionTicketDataEntry{VehicleType: &car}, err: errs.ErrBadRequest},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkVehicle(tc.quota, tc.data)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestMatchDrivers(t *testing.T) {
+	t.Parallel()
+	adult, child, infant := "3201", "3202", "3203"
+	passenger := func(scheduleID uint, idNumber *string, age int, category string) *domain.Ticket {
+		return &domain.Ticket{ScheduleID: scheduleID, Type: "passenger", IDNumber: idNumber, PassengerAge: age, FareCategory: category}
+	}
+	vehicle := func(scheduleID uint) *domain.Ticket {
+		return &domain.Ticket{ScheduleID: scheduleID, Type: "vehicle"}
+	}
+	tests := []struct {
+		name      string
+		tickets   []*domain.Ticket
+		driverIDs []string
+		driver    int // Index of the expected driver of the last ticket
+		err       error
+	}{
+		{
+			name:      "driver on the same leg",
+			tickets:   []*domain.Ticket{passenger(7, &adult, 30, "ADULT"), passenger(8, &adult, 30, "ADULT"), vehicle(8)},
+			driverIDs: []string{"", "", adult},
+			driver:    1,
+		},
+		{
+			name:      "driver only on another leg",
+			tickets:   []*domain.Ticket{passenger(7, &adult, 30, "ADULT"), vehicle(8)},
+			driverIDs: []string{"", adult},
+			err:       errs.ErrBadRequest,
+		},
+		{
+			name:      "driver too young",
+			tickets:   []*domain.Ticket{passenger(7, &child, 10, "CHILD"), vehicle(7)},
+			driverIDs: []string{"", child},
+			err:       errs.ErrBadRequest,
+		},
+		{
+			name:      "infant on a lap",
+			tickets:   []*domain.Ticket{passenger(7, &infant, 1, "INFANT"), vehicle(7)},
+			driverIDs: []string{"", infant},
+			err:       errs.ErrBadRequest,
+		},
+		{
+			name:      "one driver for two vehicles",
+			tickets:   []*domain.Ticket{passenger(7, &adult, 30, "ADULT"), vehicle(7), vehicle(7)},
+			driverIDs: []string{"", adult, adult},
+			err:       errs.ErrBadRequest,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			links, err := matchDrivers(tc.tickets, tc.driverIDs)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, links, 1)
+			require.Same(t, tc.tickets[len(tc.tickets)-1], links[0].Vehicle)
+			require.Same(t, tc.tickets[tc.driver], links[0].Driver)
+		})
+	}
+}
+
+func TestCheckDriverSeats(t *testing.T) {
+	t.Parallel()
+	quotaByLeg := map[legClass]*domain.Quota{
+		{ScheduleID: 7, ClassID: 1}: {Class: domain.Class{Type: "passenger"}},
+		{ScheduleID: 7, ClassID: 3}: {Class: domain.Class{Type: "vehicle"}},
+		{ScheduleID: 8, ClassID: 1}: {Class: domain.Class{Type: "passenger"}},
+		{ScheduleID: 8, ClassID: 3}: {Class: domain.Class{Type: "vehicle"}},
+	}
+	ok := []domain.ClaimItem{
+		{ScheduleID: 7, ClassID: 1, Quantity: 2},
+		{ScheduleID: 7, ClassID: 3, Quantity: 2},
+		{ScheduleID: 8, ClassID: 1, Quantity: 1},
+	}
+	require.NoError(t, checkDriverSeats(ok, quotaByLeg))
+
+	short := []domain.ClaimItem{
+		{ScheduleID: 7, ClassID: 1, Quantity: 2},
+		{ScheduleID: 8, ClassID: 3, Quantity: 1},
+	}
+	require.ErrorIs(t, checkDriverSeats(short, quotaByLeg), errs.ErrBadRequest)
+}
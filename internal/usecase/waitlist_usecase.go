@@ -0,0 +1,306 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"eticket-api/config"
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/common/templates"
+	"eticket-api/internal/common/transact"
+	"eticket-api/internal/domain"
+	"eticket-api/pkg/gotann"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	waitlistOfferTTL   = 30 * time.Minute
+	waitlistOfferBatch = 100
+	waitlistOfferPath  = "/claim/%s"
+)
+
+type WaitlistUsecase struct {
+	Transactor             transact.Transactor
+	WaitlistRepository     domain.WaitlistRepository
+	ClaimSessionRepository domain.ClaimSessionRepository
+	ScheduleRepository     domain.ScheduleRepository
+	QuotaRepository        domain.QuotaRepository
+	SeatLayoutRepository   domain.SeatLayoutRepository
+	ScheduleSeatRepository domain.ScheduleSeatRepository
+	OutboxRepository       domain.OutboxRepository
+	Outbox                 *OutboxUsecase
+	FrontendURL            string
+}
+
+func NewWaitlistUsecase(
+	transactor transact.Transactor,
+	waitlist_repository domain.WaitlistRepository,
+	claim_session_repository domain.ClaimSessionRepository,
+	schedule_repository domain.ScheduleRepository,
+	quota_repository domain.QuotaRepository,
+	seat_layout_repository domain.SeatLayoutRepository,
+	schedule_seat_repository domain.ScheduleSeatRepository,
+	outbox_repository domain.OutboxRepository,
+	outbox *OutboxUsecase,
+	cfg *config.Config,
+) *WaitlistUsecase {
+	return &WaitlistUsecase{
+		Transactor:             transactor,
+		WaitlistRepository:     waitlist_repository,
+		ClaimSessionRepository: claim_session_repository,
+		ScheduleRepository:     schedule_repository,
+		QuotaRepository:        quota_repository,
+		SeatLayoutRepository:   seat_layout_repository,
+		ScheduleSeatRepository: schedule_seat_repository,
+		OutboxRepository:       outbox_repository,
+		Outbox:                 outbox,
+		FrontendURL:            strings.TrimRight(cfg.Frontend.URL, "/"),
+	}
+}
+
+// JoinWaitlist queues a customer for a class of a schedule. A customer can
+// wait only once per schedule and class.
+func (uc *WaitlistUsecase) JoinWaitlist(ctx context.Context, e *domain.Waitlist) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		schedule, err := uc.ScheduleRepository.FindByID(ctx, tx, e.ScheduleID)
+		if err != nil {
+			return fmt.Errorf("failed to get schedule: %w", err)
+		}
+		if schedule == nil {
+			return fmt.Errorf("schedule %d: %w", e.ScheduleID, errs.ErrNotFound)
+		}
+		if !schedule.DepartureDatetime.After(time.Now()) {
+			return fmt.Errorf("schedule %d already departed: %w", e.ScheduleID, errs.ErrBadRequest)
+		}
+		if schedule.Status == enum.ScheduleCancelled.String() {
+			return fmt.Errorf("schedule %d was cancelled: %w", e.ScheduleID, errs.ErrBadRequest)
+		}
+		quota, err := uc.QuotaRepository.FindByScheduleIDAndClassID(ctx, tx, e.ScheduleID, e.ClassID)
+		if err != nil {
+			return fmt.Errorf("failed to get quota: %w", err)
+		}
+		if quota == nil {
+			return fmt.Errorf("class %d on schedule %d: %w", e.ClassID, e.ScheduleID, errs.ErrNotFound)
+		}
+		// A vehicle needs its driver in the same booking, which an offer for
+		// a single class cannot give
+		if quota.Class.Type == "vehicle" {
+			return fmt.Errorf("vehicle class %d cannot be waitlisted: %w", e.ClassID, errs.ErrBadRequest)
+		}
+		if e.Quantity > quota.Capacity {
+			return fmt.Errorf("party of %d exceeds class capacity: %w", e.Quantity, errs.ErrBadRequest)
+		}
+
+		waitlist := &domain.Waitlist{
+			ScheduleID:   e.ScheduleID,
+			ClassID:      e.ClassID,
+			CustomerName: e.CustomerName,
+			Email:        e.Email,
+			PhoneNumber:  e.PhoneNumber,
+			Quantity:     e.Quantity,
+			Status:       enum.WaitlistWaiting.String(),
+		}
+		if err := uc.WaitlistRepository.Insert(ctx, tx, waitlist); err != nil {
+			if errs.IsUniqueConstraintError(err) {
+				return errs.ErrConflict
+			}
+			return fmt.Errorf("failed to create waitlist entry: %w", err)
+		}
+		e.ID = waitlist.ID
+		e.Status = waitlist.Status
+		return nil
+	})
+}
+
+func (uc *WaitlistUsecase) ListWaitlists(ctx context.Context, limit, offset int, sort, search string) ([]*domain.Waitlist, int, error) {
+	var err error
+	var total int64
+	var waitlists []*domain.Waitlist
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		total, err = uc.WaitlistRepository.Count(ctx, tx)
+		if err != nil {
+			return fmt.Errorf("failed to count waitlist entries: %w", err)
+		}
+		waitlists, err = uc.WaitlistRepository.FindAll(ctx, tx, limit, offset, sort, search)
+		if err != nil {
+			return fmt.Errorf("failed to get all waitlist entries: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return nil, 0, fmt.Errorf("failed to list waitlist entries: %w", err)
+	}
+
+	return waitlists, int(total), nil
+}
+
+func (uc *WaitlistUsecase) GetWaitlistByID(ctx context.Context, id uint) (*domain.Waitlist, error) {
+	var err error
+	var waitlist *domain.Waitlist
+	if err = uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		waitlist, err = uc.WaitlistRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get waitlist entry: %w", err)
+		}
+		if waitlist == nil {
+			return errs.ErrNotFound
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to get waitlist entry by ID: %w", err)
+	}
+	return waitlist, nil
+}
+
+// CancelWaitlist takes a waiting entry out of the queue. Entries that were
+// already offered are closed through their claim session instead.
+func (uc *WaitlistUsecase) CancelWaitlist(ctx context.Context, id uint) error {
+	return uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		waitlist, err := uc.WaitlistRepository.FindByID(ctx, tx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get waitlist entry: %w", err)
+		}
+		if waitlist == nil {
+			return errs.ErrNotFound
+		}
+		if waitlist.Status != enum.WaitlistWaiting.String() {
+			return errs.ErrConflict
+		}
+
+		waitlist.Status = enum.WaitlistCancelled.String()
+		if err := uc.WaitlistRepository.Update(ctx, tx, waitlist); err != nil {
+			return fmt.Errorf("failed to cancel waitlist entry: %w", err)
+		}
+		return nil
+	})
+}
+
+// OfferFreedQuota settles earlier offers and then walks the queue oldest first,
+// offering each entry a claim session while its class has quota again. Quota
+// freed by expired sessions, refunds and failed payments all ends up here. A
+// class whose head of queue cannot be served is skipped for the rest of the
+// run so later, smaller parties do not jump ahead.
+func (uc *WaitlistUsecase) OfferFreedQuota(ctx context.Context) error {
+	var waiting []*domain.Waitlist
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		if err := uc.WaitlistRepository.SettleOffers(ctx, tx); err != nil {
+			return fmt.Errorf("failed to settle waitlist offers: %w", err)
+		}
+		var err error
+		waiting, err = uc.WaitlistRepository.FindWaiting(ctx, tx, waitlistOfferBatch)
+		if err != nil {
+			return fmt.Errorf("failed to find waiting entries: %w", err)
+		}
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to load waitlist: %w", err)
+	}
+
+	blocked := make(map[legClass]bool)
+	for _, entry := range waiting {
+		key := legClass{ScheduleID: entry.ScheduleID, ClassID: entry.ClassID}
+		if blocked[key] {
+			continue
+		}
+		if err := uc.offer(ctx, entry); err != nil {
+			switch {
+			case errors.Is(err, errs.ErrQuotaExceeded),
+				errors.Is(err, errs.ErrSeatUnavailable),
+				errors.Is(err, errs.ErrNotFound),
+				errors.Is(err, errs.ErrExpired):
+				blocked[key] = true
+			case errors.Is(err, errs.ErrConflict):
+			default:
+				return fmt.Errorf("failed to offer waitlist entry %d: %w", entry.ID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// offer holds quota and seats for one entry in a claim session of its own and
+// emails the customer a link to complete it. The email goes through the
+// outbox, so it is only sent once the offer committed.
+func (uc *WaitlistUsecase) offer(ctx context.Context, entry *domain.Waitlist) error {
+	var email *domain.Outbox
+	if err := uc.Transactor.Execute(ctx, func(tx gotann.Transaction) error {
+		schedule, err := uc.ScheduleRepository.FindByID(ctx, tx, entry.ScheduleID)
+		if err != nil {
+			return fmt.Errorf("retrieve schedule: %w", err)
+		}
+		if schedule == nil {
+			return fmt.Errorf("schedule %d: %w", entry.ScheduleID, errs.ErrNotFound)
+		}
+		if !schedule.DepartureDatetime.After(time.Now()) {
+			return fmt.Errorf("schedule %d already departed: %w", entry.ScheduleID, errs.ErrExpired)
+		}
+		if schedule.Status == enum.ScheduleCancelled.String() {
+			return fmt.Errorf("schedule %d was cancelled: %w", entry.ScheduleID, errs.ErrExpired)
+		}
+		quota, err := uc.QuotaRepository.FindByScheduleIDAndClassID(ctx, tx, entry.ScheduleID, entry.ClassID)
+		if err != nil {
+			return fmt.Errorf("fetch quota: %w", err)
+		}
+		if quota == nil {
+			return fmt.Errorf("class %d on schedule %d: %w", entry.ClassID, entry.ScheduleID, errs.ErrNotFound)
+		}
+		classes, err := ensureScheduleSeats(ctx, tx, uc.SeatLayoutRepository, uc.ScheduleSeatRepository, schedule)
+		if err != nil {
+			return err
+		}
+
+		claimItems := []domain.ClaimItem{{
+			ScheduleID: entry.ScheduleID,
+			ClassID:    entry.ClassID,
+			Quantity:   entry.Quantity,
+			QuotaUnits: entry.Quantity * laneMeters(quota),
+			Subtotal:   float64(entry.Quantity) * quota.Price,
+		}}
+		if err := reserveClaimItems(ctx, tx, uc.QuotaRepository, entry.ScheduleID, claimItems); err != nil {
+			return err
+		}
+
+		session := &domain.ClaimSession{
+			SessionID:  uuid.NewString(),
+			ScheduleID: entry.ScheduleID,
+			Status:     enum.ClaimSessionPending.String(),
+			ExpiresAt:  time.Now().Add(waitlistOfferTTL),
+			ClaimItems: claimItems,
+		}
+		if err := uc.ClaimSessionRepository.Insert(ctx, tx, session); err != nil {
+			return fmt.Errorf("create session: %w", err)
+		}
+
+		seated := map[legClass]bool{
+			{ScheduleID: entry.ScheduleID, ClassID: entry.ClassID}: classes[entry.ClassID],
+		}
+		if err := holdSeats(ctx, tx, uc.ScheduleSeatRepository, session.ID, seated, []seatRequest{{
+			ScheduleID: entry.ScheduleID,
+			ClassID:    entry.ClassID,
+			Quantity:   entry.Quantity,
+		}}); err != nil {
+			return err
+		}
+
+		offered, err := uc.WaitlistRepository.Offer(ctx, tx, entry.ID, session.ID)
+		if err != nil {
+			return fmt.Errorf("mark entry offered: %w", err)
+		}
+		if offered == 0 {
+			return errs.ErrConflict
+		}
+
+		subject := "Kursi Anda Tersedia"
+		htmlBody := templates.WaitlistOfferEmail(entry, schedule, &quota.Class, uc.FrontendURL+fmt.Sprintf(waitlistOfferPath, session.SessionID), session.ExpiresAt)
+		email, err = enqueueEmail(ctx, tx, uc.OutboxRepository, session.SessionID, entry.Email, subject, htmlBody)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	_ = uc.Outbox.Dispatch(ctx, email)
+	return nil
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"context"
+	"eticket-api/config"
+	"testing"
+	"time"
+
+	enum "eticket-api/internal/common/enums"
+	errs "eticket-api/internal/common/errors"
+	"eticket-api/internal/domain"
+	"eticket-api/internal/mocks"
+	"eticket-api/pkg/gotann"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func waitlistUsecase(t *testing.T) (*WaitlistUsecase, *mocks.MockWaitlistRepository, *mocks.MockClaimSessionRepository, *mocks.MockScheduleRepository, *mocks.MockQuotaRepository, *mocks.MockSeatLayoutRepository, *mocks.MockScheduleSeatRepository, *mocks.MockOutboxRepository, *mocks.MockMailer, *mocks.MockTransactor) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	waitlistRepo := mocks.NewMockWaitlistRepository(ctrl)
+	claimSessionRepo := mocks.NewMockClaimSessionRepository(ctrl)
+	scheduleRepo := mocks.NewMockScheduleRepository(ctrl)
+	quotaRepo := mocks.NewMockQuotaRepository(ctrl)
+	layoutRepo := mocks.NewMockSeatLayoutRepository(ctrl)
+	seatRepo := mocks.NewMockScheduleSeatRepository(ctrl)
+	outboxRepo := mocks.NewMockOutboxRepository(ctrl)
+	mailer := mocks.NewMockMailer(ctrl)
+	transactor := mocks.NewMockTransactor(ctrl)
+	outbox := NewOutboxUsecase(transactor, outboxRepo, mocks.NewMockBookingRepository(ctrl), mocks.NewMockBookingChangeRepository(ctrl), mocks.NewMockTripayClient(ctrl), mailer, nil)
+	uc := NewWaitlistUsecase(transactor, waitlistRepo, claimSessionRepo, scheduleRepo, quotaRepo, layoutRepo, seatRepo, outboxRepo, outbox, &config.Config{Frontend: config.Frontend{URL: "https://example.com"}})
+	return uc, waitlistRepo, claimSessionRepo, scheduleRepo, quotaRepo, layoutRepo, seatRepo, outboxRepo, mailer, transactor
+}
+
+func TestWaitlistUsecase_JoinWaitlist(t *testing.T) {
+	t.Parallel()
+	uc, waitlistRepo, _, scheduleRepo, quotaRepo, _, _, _, _, transactor := waitlistUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+		return fn(nil)
+	}
+	schedule := &domain.Schedule{ID: 1, DepartureDatetime: time.Now().Add(24 * time.Hour)}
+	tests := []struct {
+		name     string
+		quantity int
+		mock     func()
+		err      error
+	}{
+		{
+			name:     "success",
+			quantity: 2,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(schedule, nil)
+				quotaRepo.EXPECT().FindByScheduleIDAndClassID(gomock.Any(), gomock.Any(), uint(1), uint(2)).Return(&domain.Quota{Capacity: 10}, nil)
+				waitlistRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, conn gotann.Connection, w *domain.Waitlist) error {
+						require.Equal(t, enum.WaitlistWaiting.String(), w.Status)
+						return nil
+					},
+				)
+			},
+		},
+		{
+			name:     "class not sold on schedule",
+			quantity: 2,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(schedule, nil)
+				quotaRepo.EXPECT().FindByScheduleIDAndClassID(gomock.Any(), gomock.Any(), uint(1), uint(2)).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+		{
+			name:     "party larger than class",
+			quantity: 11,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(schedule, nil)
+				quotaRepo.EXPECT().FindByScheduleIDAndClassID(gomock.Any(), gomock.Any(), uint(1), uint(2)).Return(&domain.Quota{Capacity: 10}, nil)
+			},
+			err: errs.ErrBadRequest,
+		},
+		{
+			name:     "repo error",
+			quantity: 2,
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(errInternalServErr)
+			},
+			err: errInternalServErr,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.JoinWaitlist(context.Background(), &domain.Waitlist{ScheduleID: 1, ClassID: 2, Quantity: tc.quantity})
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWaitlistUsecase_CancelWaitlist(t *testing.T) {
+	t.Parallel()
+	uc, waitlistRepo, _, _, _, _, _, _, _, transactor := waitlistUsecase(t)
+	runTx := func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+		return fn(nil)
+	}
+	tests := []struct {
+		name string
+		mock func()
+		err  error
+	}{
+		{
+			name: "success",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				waitlistRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Waitlist{ID: 1, Status: enum.WaitlistWaiting.String()}, nil)
+				waitlistRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "already offered",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				waitlistRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(&domain.Waitlist{ID: 1, Status: enum.WaitlistOffered.String()}, nil)
+			},
+			err: errs.ErrConflict,
+		},
+		{
+			name: "not found",
+			mock: func() {
+				transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(runTx)
+				waitlistRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(nil, nil)
+			},
+			err: errs.ErrNotFound,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mock()
+			err := uc.CancelWaitlist(context.Background(), 1)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWaitlistUsecase_OfferFreedQuota(t *testing.T) {
+	t.Parallel()
+	uc, waitlistRepo, claimSessionRepo, scheduleRepo, quotaRepo, layoutRepo, seatRepo, outboxRepo, mailer, transactor := waitlistUsecase(t)
+	transactor.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(tx gotann.Transaction) error) error {
+			return fn(nil)
+		},
+	).AnyTimes()
+
+	schedule := &domain.Schedule{ID: 1, ShipID: 3, DepartureDatetime: time.Now().Add(24 * time.Hour)}
+	waitlistRepo.EXPECT().SettleOffers(gomock.Any(), gomock.Any()).Return(nil)
+	waitlistRepo.EXPECT().FindWaiting(gomock.Any(), gomock.Any(), waitlistOfferBatch).Return([]*domain.Waitlist{
+		{ID: 1, ScheduleID: 1, ClassID: 2, Quantity: 4, Email: "a@example.com"},
+		{ID: 2, ScheduleID: 1, ClassID: 2, Quantity: 1, Email: "b@example.com"},
+		{ID: 3, ScheduleID: 1, ClassID: 5, Quantity: 1, Email: "c@example.com"},
+	}, nil)
+	scheduleRepo.EXPECT().FindByID(gomock.Any(), gomock.Any(), uint(1)).Return(schedule, nil).Times(2)
+	seatRepo.EXPECT().CountByScheduleIDGroupByClass(gomock.Any(), gomock.Any(), uint(1)).Return(map[uint]int64{}, nil).Times(2)
+	layoutRepo.EXPECT().FindByShipID(gomock.Any(), gomock.Any(), uint(3)).Return(nil, nil).Times(2)
+
+	// The head of class 2 does not fit, so entry 2 must wait its turn
+	quotaRepo.EXPECT().FindByScheduleIDAndClassID(gomock.Any(), gomock.Any(), uint(1), uint(2)).Return(&domain.Quota{Price: 100}, nil)
+	quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(1), uint(2), 4).Return(false, nil)
+
+	quotaRepo.EXPECT().FindByScheduleIDAndClassID(gomock.Any(), gomock.Any(), uint(1), uint(5)).Return(&domain.Quota{Price: 100}, nil)
+	quotaRepo.EXPECT().Reserve(gomock.Any(), gomock.Any(), uint(1), uint(5), 1).Return(true, nil)
+	claimSessionRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, conn gotann.Connection, session *domain.ClaimSession) error {
+			require.Equal(t, enum.ClaimSessionPending.String(), session.Status)
+			require.Len(t, session.ClaimItems, 1)
+			session.ID = 9
+			return nil
+		},
+	)
+	waitlistRepo.EXPECT().Offer(gomock.Any(), gomock.Any(), uint(3), uint(9)).Return(int64(1), nil)
+	outboxRepo.EXPECT().Insert(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, conn gotann.Connection, event *domain.Outbox) error {
+			require.Equal(t, enum.OutboxSendEmail.String(), event.EventType)
+			event.ID = 11
+			return nil
+		},
+	)
+	outboxRepo.EXPECT().Claim(gomock.Any(), gomock.Any(), uint(11), gomock.Any()).Return(int64(1), nil)
+	mailer.EXPECT().Send("c@example.com", gomock.Any(), gomock.Any()).Return(nil)
+	outboxRepo.EXPECT().Update(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, uc.OfferFreedQuota(context.Background()))
+}
@@ -0,0 +1,66 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentBytes(t *testing.T) {
+	t.Parallel()
+	doc := New(A4Width, A4Height)
+	doc.Text(40, 60, 12, true, "E-Ticket (ORD-1)")
+	doc.Line(40, 70, 555, 70)
+	doc.AddPage()
+	doc.Text(40, 60, 10, false, `Café \ Ω`)
+	doc.Rect(40, 80, 10, 10)
+
+	out := doc.Bytes()
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+
+	// startxref points at the cross-reference table
+	match := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(out)
+	require.NotNil(t, match)
+	xref, err := strconv.Atoi(string(match[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n0 9\n0000000000 65535 f \n")))
+
+	// Every entry is 20 bytes and points at the start of its object
+	entries := out[xref+len("xref\n0 9\n0000000000 65535 f \n"):]
+	for i := 1; i < 9; i++ {
+		entry := string(entries[(i-1)*20 : i*20])
+		require.Regexp(t, `^\d{10} 00000 n \n$`, entry)
+		offset, err := strconv.Atoi(entry[:10])
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i))), "object %d at %d", i, offset)
+	}
+	require.True(t, bytes.HasPrefix(entries[8*20:], []byte("trailer\n<< /Size 9 /Root 1 0 R >>\n")))
+
+	// Stream lengths match their content
+	streams := regexp.MustCompile(`(?s)<< /Length (\d+) >>\nstream\n(.*?)endstream`).FindAllSubmatch(out, -1)
+	require.Len(t, streams, 2)
+	for _, stream := range streams {
+		length, err := strconv.Atoi(string(stream[1]))
+		require.NoError(t, err)
+		require.Equal(t, length, len(stream[2]))
+	}
+
+	require.Contains(t, string(out), "/Kids [5 0 R 7 0 R] /Count 2")
+	require.Contains(t, string(streams[0][2]), `(E-Ticket \(ORD-1\)) Tj`)
+	require.Contains(t, string(streams[1][2]), `(Caf\351 \\ ?) Tj`)
+	require.Contains(t, string(streams[1][2]), "40.00 751.89 10.00 10.00 re f")
+}
+
+func TestDocumentBytesBlank(t *testing.T) {
+	t.Parallel()
+	out := string(New(A4Width, A4Height).Bytes())
+	require.Contains(t, out, "/Kids [5 0 R] /Count 1")
+	require.Contains(t, out, "<< /Length 0 >>\nstream\nendstream")
+	require.Equal(t, 1, strings.Count(out, "/Type /Page "))
+}
@@ -0,0 +1,273 @@
+package qrcode
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Known vectors from ISO/IEC 18004 and the published format and version
+// information tables.
+
+func TestRSRemainder(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		data []byte
+		ecc  []byte
+	}{
+		{
+			// ISO/IEC 18004 Annex I, "01234567" as 1-M
+			name: "01234567",
+			data: []byte{0x10, 0x20, 0x0C, 0x56, 0x61, 0x80, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11},
+			ecc:  []byte{0xA5, 0x24, 0xD4, 0xC1, 0xED, 0x36, 0xC7, 0x87, 0x2C, 0x55},
+		},
+		{
+			name: "HELLO WORLD",
+			data: []byte{0x20, 0x5B, 0x0B, 0x78, 0xD1, 0x72, 0xDC, 0x4D, 0x43, 0x40, 0xEC, 0x11, 0xEC, 0x11, 0xEC, 0x11},
+			ecc:  []byte{0xC4, 0x23, 0x27, 0x77, 0xEB, 0xD7, 0xE7, 0xE2, 0x5D, 0x17},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.ecc, rsRemainder(tc.data, rsDivisor(len(tc.ecc))))
+		})
+	}
+}
+
+// levelMFormat is the format information of level M by mask.
+var levelMFormat = [8]int{
+	0b101010000010010,
+	0b101000100100101,
+	0b101111001111100,
+	0b101101101001011,
+	0b100010111111001,
+	0b100000011001110,
+	0b100111110010111,
+	0b100101010100000,
+}
+
+func TestFormatBits(t *testing.T) {
+	t.Parallel()
+	for mask, want := range levelMFormat {
+		require.Equal(t, want, formatBits(mask), "mask %d", mask)
+	}
+}
+
+func TestVersionBits(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, 0b000111110010010100, versionBits(7))
+	require.Equal(t, 0b001000010110111100, versionBits(8))
+	require.Equal(t, 0b101000110001101001, versionBits(40))
+}
+
+// ticketSymbol is "TKT-0001" as 1-M with mask 0, pinned so a change to the
+// placement or the mask choice shows up; decode checks it is well formed.
+var ticketSymbol = []string{
+	"#######....#..#######",
+	"#.....#.#...#.#.....#",
+	"#.###.#....##.#.###.#",
+	"#.###.#..#.##.#.###.#",
+	"#.###.#.#.###.#.###.#",
+	"#.....#..#.#..#.....#",
+	"#######.#.#.#.#######",
+	".....................",
+	"#.#.#.#..##.#...#..#.",
+	"..#.#..#...#.#..####.",
+	".##..###.#.#.##.##.##",
+	"........#.####.#...#.",
+	"#..####.####..#.#####",
+	"........#.....#.#....",
+	"#######...#.#...#.###",
+	"#.....#..##...##.#.#.",
+	"#.###.#.#...#.#.#....",
+	"#.###.#...##.#..#.##.",
+	"#.###.#.#..#.##.###.#",
+	"#.....#..#.###.#.#.#.",
+	"#######.##.#.##.##.##",
+}
+
+func TestEncodeGolden(t *testing.T) {
+	t.Parallel()
+	code, err := Encode([]byte("TKT-0001"))
+	require.NoError(t, err)
+	require.Equal(t, ticketSymbol, rows(code))
+	require.Equal(t, []byte("TKT-0001"), decode(t, code))
+}
+
+func TestEncodeDecodes(t *testing.T) {
+	t.Parallel()
+	for _, size := range []int{1, 14, 15, 100, 271, 1000, 2331} {
+		data := bytes.Repeat([]byte("eticket-"), size/8+1)[:size]
+		code, err := Encode(data)
+		require.NoError(t, err)
+		require.Equal(t, data, decode(t, code), "%d bytes in version %d", size, code.version)
+	}
+
+	_, err := Encode(make([]byte, 2332))
+	require.ErrorIs(t, err, ErrTooLong)
+}
+
+func rows(code *Code) []string {
+	rows := make([]string, code.Size())
+	for y := range rows {
+		var row strings.Builder
+		for x := 0; x < code.Size(); x++ {
+			if code.Dark(x, y) {
+				row.WriteByte('#')
+			} else {
+				row.WriteByte('.')
+			}
+		}
+		rows[y] = row.String()
+	}
+	return rows
+}
+
+// decode reads a symbol back the way a scanner would, without the encoder's
+// helpers: it finds the mask from the format bits, lifts it, reads the
+// codewords, checks every block against its error correction and returns the
+// byte mode segment.
+func decode(t *testing.T, code *Code) []byte {
+	t.Helper()
+	size := code.Size()
+
+	// The format bits around the top-left finder, bit 14 first
+	positions := [][2]int{{0, 8}, {1, 8}, {2, 8}, {3, 8}, {4, 8}, {5, 8}, {7, 8}, {8, 8}, {8, 7}, {8, 5}, {8, 4}, {8, 3}, {8, 2}, {8, 1}, {8, 0}}
+	format := 0
+	for _, p := range positions {
+		format <<= 1
+		if code.Dark(p[0], p[1]) {
+			format |= 1
+		}
+	}
+	mask := -1
+	for m, bits := range levelMFormat {
+		if bits == format {
+			mask = m
+		}
+	}
+	require.NotEqual(t, -1, mask, "format bits %015b", format)
+
+	// The copy split between the top-right and bottom-left finders
+	copied := 0
+	for i := 14; i >= 8; i-- {
+		copied <<= 1
+		if code.Dark(8, size-15+i) {
+			copied |= 1
+		}
+	}
+	for i := 7; i >= 0; i-- {
+		copied <<= 1
+		if code.Dark(size-1-i, 8) {
+			copied |= 1
+		}
+	}
+	require.Equal(t, format, copied, "format bits copy")
+
+	masks := [8]func(x, y int) bool{
+		func(x, y int) bool { return (x+y)%2 == 0 },
+		func(x, y int) bool { return y%2 == 0 },
+		func(x, y int) bool { return x%3 == 0 },
+		func(x, y int) bool { return (x+y)%3 == 0 },
+		func(x, y int) bool { return (y/2+x/3)%2 == 0 },
+		func(x, y int) bool { return (x*y)%2+(x*y)%3 == 0 },
+		func(x, y int) bool { return ((x*y)%2+(x*y)%3)%2 == 0 },
+		func(x, y int) bool { return ((x+y)%2+(x*y)%3)%2 == 0 },
+	}
+
+	var bits []bool
+	for right, upward := size-1, true; right > 0; right, upward = right-2, !upward {
+		if right == 6 {
+			right--
+		}
+		for i := 0; i < size; i++ {
+			y := i
+			if upward {
+				y = size - 1 - i
+			}
+			for _, x := range []int{right, right - 1} {
+				if code.function[y][x] {
+					continue
+				}
+				bits = append(bits, code.Dark(x, y) != masks[mask](x, y))
+			}
+		}
+	}
+	codewords := make([]byte, len(bits)/8)
+	for i := range codewords {
+		for j := 0; j < 8; j++ {
+			if bits[i*8+j] {
+				codewords[i] |= 1 << (7 - j)
+			}
+		}
+	}
+
+	l := layouts[code.version]
+	count := l.shortBlocks + l.longBlocks
+	blocks := make([][]byte, count)
+	next := 0
+	for i := 0; i < max(l.shortData, l.longData); i++ {
+		for b := range blocks {
+			if i < l.shortData || b >= l.shortBlocks {
+				blocks[b] = append(blocks[b], codewords[next])
+				next++
+			}
+		}
+	}
+	for i := 0; i < l.ecc; i++ {
+		for b := range blocks {
+			blocks[b] = append(blocks[b], codewords[next])
+			next++
+		}
+	}
+
+	// A block with correct error correction vanishes at the first ecc powers
+	// of the generator
+	exp, log := gfTables()
+	var data []byte
+	for b, block := range blocks {
+		for i := 0; i < l.ecc; i++ {
+			var syndrome byte
+			for _, c := range block {
+				if syndrome != 0 {
+					syndrome = exp[(int(log[syndrome])+i)%255]
+				}
+				syndrome ^= c
+			}
+			require.Zero(t, syndrome, "block %d syndrome %d", b, i)
+		}
+		data = append(data, block[:len(block)-l.ecc]...)
+	}
+
+	pos := 0
+	read := func(n int) int {
+		value := 0
+		for ; n > 0; n, pos = n-1, pos+1 {
+			value = value<<1 | int(data[pos/8]>>(7-pos%8)&1)
+		}
+		return value
+	}
+	require.Equal(t, 0x4, read(4), "byte mode")
+	length := read(countBits(code.version))
+	payload := make([]byte, length)
+	for i := range payload {
+		payload[i] = byte(read(8))
+	}
+	return payload
+}
+
+func gfTables() (exp [255]byte, log [256]byte) {
+	x := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(x)
+		log[x] = byte(i)
+		x <<= 1
+		if x&0x100 != 0 {
+			x ^= 0x11D
+		}
+	}
+	return exp, log
+}